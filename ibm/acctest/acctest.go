@@ -190,6 +190,14 @@ var (
 	IcdDbDeploymentId         string
 	IcdDbBackupId             string
 	IcdDbTaskId               string
+	IcdDbPostgresqlHost       string
+	IcdDbPostgresqlPort       string
+	IcdDbPostgresqlUser       string
+	IcdDbPostgresqlPassword   string
+	IcdDbMysqlHost            string
+	IcdDbMysqlPort            string
+	IcdDbMysqlUser            string
+	IcdDbMysqlPassword        string
 	KmsInstanceID             string
 	CrkID                     string
 	KmsAccountID              string
@@ -272,6 +280,7 @@ var (
 	Image_cos_url           string
 	Image_cos_url_encrypted string
 	Image_operating_system  string
+	Image_source_file       string
 )
 
 // Transit Gateway Power Virtual Server
@@ -1038,6 +1047,54 @@ func init() {
 		IcdDbTaskId = "crn:v1:bluemix:public:databases-for-redis:au-syd:a/40ddc34a953a8c02f10987b59085b60e:367b0a22-05bb-41e3-a1ed-ded1ff0889e5:task:882013a6-2751-4df7-a77a-98d258638704"
 		fmt.Println("[INFO] Set the environment variable ICD_DB_TASK_ID for testing ibm_cloud_databases else it is set to default value 'crn:v1:bluemix:public:databases-for-redis:au-syd:a/40ddc34a953a8c02f10987b59085b60e:367b0a22-05bb-41e3-a1ed-ded1ff0889e5:task:882013a6-2751-4df7-a77a-98d258638704'")
 	}
+	IcdDbPostgresqlHost = os.Getenv("ICD_DB_POSTGRESQL_HOST")
+	if IcdDbPostgresqlHost == "" {
+		IcdDbPostgresqlHost = "localhost"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_POSTGRESQL_HOST for testing ibm_database_postgresql resources else it is set to default value 'localhost'")
+	}
+
+	IcdDbPostgresqlPort = os.Getenv("ICD_DB_POSTGRESQL_PORT")
+	if IcdDbPostgresqlPort == "" {
+		IcdDbPostgresqlPort = "5432"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_POSTGRESQL_PORT for testing ibm_database_postgresql resources else it is set to default value '5432'")
+	}
+
+	IcdDbPostgresqlUser = os.Getenv("ICD_DB_POSTGRESQL_USER")
+	if IcdDbPostgresqlUser == "" {
+		IcdDbPostgresqlUser = "postgres"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_POSTGRESQL_USER for testing ibm_database_postgresql resources else it is set to default value 'postgres'")
+	}
+
+	IcdDbPostgresqlPassword = os.Getenv("ICD_DB_POSTGRESQL_PASSWORD")
+	if IcdDbPostgresqlPassword == "" {
+		IcdDbPostgresqlPassword = "postgres"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_POSTGRESQL_PASSWORD for testing ibm_database_postgresql resources else it is set to default value 'postgres'")
+	}
+
+	IcdDbMysqlHost = os.Getenv("ICD_DB_MYSQL_HOST")
+	if IcdDbMysqlHost == "" {
+		IcdDbMysqlHost = "localhost"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_MYSQL_HOST for testing ibm_database_mysql resources else it is set to default value 'localhost'")
+	}
+
+	IcdDbMysqlPort = os.Getenv("ICD_DB_MYSQL_PORT")
+	if IcdDbMysqlPort == "" {
+		IcdDbMysqlPort = "3306"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_MYSQL_PORT for testing ibm_database_mysql resources else it is set to default value '3306'")
+	}
+
+	IcdDbMysqlUser = os.Getenv("ICD_DB_MYSQL_USER")
+	if IcdDbMysqlUser == "" {
+		IcdDbMysqlUser = "root"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_MYSQL_USER for testing ibm_database_mysql resources else it is set to default value 'root'")
+	}
+
+	IcdDbMysqlPassword = os.Getenv("ICD_DB_MYSQL_PASSWORD")
+	if IcdDbMysqlPassword == "" {
+		IcdDbMysqlPassword = "mysql"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_MYSQL_PASSWORD for testing ibm_database_mysql resources else it is set to default value 'mysql'")
+	}
+
 	// Added for Power Colo Testing
 	Pi_image = os.Getenv("PI_IMAGE")
 	if Pi_image == "" {
@@ -1356,6 +1413,10 @@ func init() {
 		Image_cos_url_encrypted = "cos://us-south/cosbucket-vpc-image-gen2/rhel-guest-image-7.0-encrypted.qcow2"
 		fmt.Println("[WARN] Set the environment variable IMAGE_COS_URL_ENCRYPTED with a VALID COS Image SQL URL for testing ibm_is_image resources on staging/test")
 	}
+	Image_source_file = os.Getenv("IMAGE_SOURCE_FILE")
+	if Image_source_file == "" {
+		fmt.Println("[WARN] Set the environment variable IMAGE_SOURCE_FILE with the path of a local qcow2 image file for testing ibm_is_image resources with source_file")
+	}
 	Image_operating_system = os.Getenv("IMAGE_OPERATING_SYSTEM")
 	if Image_operating_system == "" {
 		Image_operating_system = "red-7-amd64"
@@ -2173,6 +2234,16 @@ func TestAccPreCheckImage(t *testing.T) {
 	}
 }
 
+func TestAccPreCheckImageSourceFile(t *testing.T) {
+	TestAccPreCheck(t)
+	if Image_source_file == "" {
+		t.Fatal("IMAGE_SOURCE_FILE must be set for acceptance tests")
+	}
+	if Image_operating_system == "" {
+		t.Fatal("IMAGE_OPERATING_SYSTEM must be set for acceptance tests")
+	}
+}
+
 func TestAccPreCheckEncryptedImage(t *testing.T) {
 	TestAccPreCheck(t)
 	if Image_cos_url_encrypted == "" {
@@ -0,0 +1,239 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	gohttp "net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// assumeProfileGrantType is the IAM grant type that exchanges an access
+// token for the access token of a trusted profile.
+const assumeProfileGrantType = "urn:ibm:params:oauth:grant-type:assume"
+
+// AssumeProfile is a trusted profile the provider assumes. It is identified
+// either by its ID, by its CRN or by its name and the ID of its account.
+type AssumeProfile struct {
+	ProfileID   string
+	ProfileCRN  string
+	ProfileName string
+	AccountID   string
+}
+
+func (p AssumeProfile) String() string {
+	switch {
+	case p.ProfileID != "":
+		return p.ProfileID
+	case p.ProfileCRN != "":
+		return p.ProfileCRN
+	default:
+		return fmt.Sprintf("%s in account %s", p.ProfileName, p.AccountID)
+	}
+}
+
+// Validate checks that the trusted profile is identified in exactly one way.
+func (p AssumeProfile) Validate() error {
+	identifiers := 0
+	for _, identifier := range []string{p.ProfileID, p.ProfileCRN, p.ProfileName} {
+		if identifier != "" {
+			identifiers++
+		}
+	}
+	if identifiers != 1 {
+		return fmt.Errorf("[ERROR] exactly one of profile_id, profile_crn or profile_name must be set for a trusted profile to assume")
+	}
+	if p.ProfileName != "" && p.AccountID == "" {
+		return fmt.Errorf("[ERROR] account_id must be set to assume the trusted profile %s by name", p.ProfileName)
+	}
+	if p.ProfileName == "" && p.AccountID != "" {
+		return fmt.Errorf("[ERROR] account_id can only be set to assume a trusted profile by name")
+	}
+	return nil
+}
+
+// tokenSource returns an IAM access token, without the "Bearer " prefix.
+type tokenSource interface {
+	GetToken() (string, error)
+}
+
+// staticToken is a tokenSource for an access token the provider can't refresh.
+type staticToken string
+
+func (t staticToken) GetToken() (string, error) {
+	return string(t), nil
+}
+
+// assumedProfileToken is a tokenSource for the access token of a trusted
+// profile. The token is requested with the token of the previous identity
+// in the chain and requested again before it expires.
+type assumedProfileToken struct {
+	source  tokenSource
+	profile AssumeProfile
+	url     string
+	client  *gohttp.Client
+
+	lock      sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+func (t *assumedProfileToken) GetToken() (string, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.token != "" && time.Now().Before(t.refreshAt) {
+		return t.token, nil
+	}
+	token, err := t.source.GetToken()
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":   {assumeProfileGrantType},
+		"access_token": {token},
+	}
+	switch {
+	case t.profile.ProfileID != "":
+		form.Set("profile_id", t.profile.ProfileID)
+	case t.profile.ProfileCRN != "":
+		form.Set("profile_crn", t.profile.ProfileCRN)
+	default:
+		form.Set("profile_name", t.profile.ProfileName)
+		form.Set("account", t.profile.AccountID)
+	}
+	request, err := gohttp.NewRequest(gohttp.MethodPost, strings.TrimSuffix(t.url, "/")+"/identity/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	issuedAt := time.Now()
+	response, err := t.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error assuming the trusted profile %s: %s", t.profile, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error assuming the trusted profile %s: %s", t.profile, err)
+	}
+	if response.StatusCode != gohttp.StatusOK {
+		return "", fmt.Errorf("[ERROR] Error assuming the trusted profile %s: %s %s", t.profile, response.Status, body)
+	}
+	var tokenResponse core.IamTokenServerResponse
+	if err := json.Unmarshal(body, &tokenResponse); err != nil || tokenResponse.AccessToken == "" {
+		return "", fmt.Errorf("[ERROR] Error assuming the trusted profile %s: invalid token response", t.profile)
+	}
+
+	log.Printf("[DEBUG] Assumed the trusted profile %s", t.profile)
+	t.token = tokenResponse.AccessToken
+	// The token is renewed once 80% of its lifetime has passed, like the
+	// tokens of the IAM authenticator.
+	t.refreshAt = issuedAt.Add(time.Duration(tokenResponse.ExpiresIn) * time.Second * 8 / 10)
+	return t.token, nil
+}
+
+// assumeProfileAuthenticator is a core.Authenticator that authenticates
+// requests as the last trusted profile of a chain of assumed profiles.
+type assumeProfileAuthenticator struct {
+	tokenSource
+}
+
+// assumeProfileAuthenticator returns an authenticator that exchanges the
+// token of base for the token of each trusted profile of c.AssumeProfiles
+// in turn.
+func (c *Config) assumeProfileAuthenticator(base core.Authenticator, iamURL string, client *gohttp.Client) *assumeProfileAuthenticator {
+	var source tokenSource
+	switch base := base.(type) {
+	case *core.BearerTokenAuthenticator:
+		source = staticToken(base.BearerToken)
+	case tokenSource:
+		source = base
+	}
+	for _, profile := range c.AssumeProfiles {
+		source = &assumedProfileToken{
+			source:  source,
+			profile: profile,
+			url:     iamURL,
+			client:  client,
+		}
+	}
+	return &assumeProfileAuthenticator{source}
+}
+
+func (a *assumeProfileAuthenticator) AuthenticationType() string {
+	return core.AUTHTYPE_IAM_ASSUME
+}
+
+func (a *assumeProfileAuthenticator) Authenticate(request *gohttp.Request) error {
+	token, err := a.GetToken()
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (a *assumeProfileAuthenticator) Validate() error {
+	return nil
+}
+
+// assumeProfileTransport authenticates the requests of clients that were
+// configured with a static bearer token, such as the bluemix-go, Key Protect
+// and classic infrastructure clients, with the current token of the last
+// trusted profile. These clients can't renew the token on their own, since
+// the token of a trusted profile comes without a refresh token.
+type assumeProfileTransport struct {
+	authenticator *assumeProfileAuthenticator
+	next          gohttp.RoundTripper
+}
+
+func (t *assumeProfileTransport) RoundTrip(request *gohttp.Request) (*gohttp.Response, error) {
+	// requests for IAM tokens use basic authentication and are left alone
+	if !strings.HasPrefix(request.Header.Get("Authorization"), "Bearer ") {
+		return t.next.RoundTrip(request)
+	}
+	request = request.Clone(request.Context())
+	if err := t.authenticator.Authenticate(request); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(request)
+}
+
+// transport returns next wrapped to authenticate its requests as the last
+// trusted profile, or next itself when no profiles are assumed.
+func (a *assumeProfileAuthenticator) transport(next gohttp.RoundTripper) gohttp.RoundTripper {
+	if a == nil {
+		return next
+	}
+	if next == nil {
+		next = gohttp.DefaultTransport
+	}
+	return &assumeProfileTransport{authenticator: a, next: next}
+}
+
+// client returns a copy of client whose requests are authenticated as the
+// last trusted profile.
+func (a *assumeProfileAuthenticator) client(client *gohttp.Client) *gohttp.Client {
+	if a == nil {
+		return client
+	}
+	if client == nil {
+		client = &gohttp.Client{}
+	}
+	assumed := *client
+	assumed.Transport = a.transport(client.Transport)
+	return &assumed
+}
@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"encoding/json"
+	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func TestAssumeProfileValidate(t *testing.T) {
+	for _, tc := range []struct {
+		profile AssumeProfile
+		valid   bool
+	}{
+		{AssumeProfile{ProfileID: "Profile-1"}, true},
+		{AssumeProfile{ProfileCRN: "crn:v1:bluemix:public:iam-identity::a/1::profile:Profile-1"}, true},
+		{AssumeProfile{ProfileName: "deployer", AccountID: "account-1"}, true},
+		{AssumeProfile{}, false},
+		{AssumeProfile{ProfileID: "Profile-1", ProfileName: "deployer", AccountID: "account-1"}, false},
+		{AssumeProfile{ProfileName: "deployer"}, false},
+		{AssumeProfile{ProfileID: "Profile-1", AccountID: "account-1"}, false},
+	} {
+		if err := tc.profile.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%+v: expected valid %t, got %v", tc.profile, tc.valid, err)
+		}
+	}
+}
+
+func TestAssumeProfileAuthenticator(t *testing.T) {
+	requests := 0
+	expiresIn := int64(3600)
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		requests++
+		if err := r.ParseForm(); err != nil || r.URL.Path != "/identity/token" || r.Form.Get("grant_type") != assumeProfileGrantType {
+			w.WriteHeader(gohttp.StatusBadRequest)
+			return
+		}
+		var token string
+		switch {
+		case r.Form.Get("access_token") == "base-token" && r.Form.Get("profile_id") == "Profile-1":
+			token = "profile-1-token"
+		case r.Form.Get("access_token") == "profile-1-token" && r.Form.Get("profile_name") == "deployer" && r.Form.Get("account") == "account-2":
+			token = "profile-2-token"
+		default:
+			w.WriteHeader(gohttp.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(core.IamTokenServerResponse{AccessToken: token, ExpiresIn: expiresIn})
+	}))
+	defer server.Close()
+
+	c := &Config{AssumeProfiles: []AssumeProfile{
+		{ProfileID: "Profile-1"},
+		{ProfileName: "deployer", AccountID: "account-2"},
+	}}
+	authenticator := c.assumeProfileAuthenticator(&core.BearerTokenAuthenticator{BearerToken: "base-token"}, server.URL, server.Client())
+
+	request, _ := gohttp.NewRequest(gohttp.MethodGet, "https://example.com", nil)
+	if err := authenticator.Authenticate(request); err != nil {
+		t.Fatal(err)
+	}
+	if header := request.Header.Get("Authorization"); header != "Bearer profile-2-token" {
+		t.Fatalf("unexpected Authorization header %q", header)
+	}
+	if requests != 2 {
+		t.Fatalf("expected a request for each trusted profile, got %d", requests)
+	}
+
+	// the token is cached until it needs to be refreshed
+	if _, err := authenticator.GetToken(); err != nil || requests != 2 {
+		t.Fatalf("expected the cached token, got %d requests and %v", requests, err)
+	}
+
+	c.AssumeProfiles[1].AccountID = "account-1"
+	authenticator = c.assumeProfileAuthenticator(&core.BearerTokenAuthenticator{BearerToken: "base-token"}, server.URL, server.Client())
+	if _, err := authenticator.GetToken(); err == nil {
+		t.Fatal("expected an error for a trusted profile that can't be assumed")
+	}
+
+	// expired tokens are requested again for each trusted profile
+	expiresIn = 0
+	c.AssumeProfiles[1].AccountID = "account-2"
+	authenticator = c.assumeProfileAuthenticator(&core.BearerTokenAuthenticator{BearerToken: "base-token"}, server.URL, server.Client())
+	requests = 0
+	for i := 0; i < 2; i++ {
+		if token, err := authenticator.GetToken(); err != nil || token != "profile-2-token" {
+			t.Fatalf("unexpected token %q: %v", token, err)
+		}
+	}
+	if requests != 4 {
+		t.Fatalf("expected the expired tokens to be refreshed, got %d requests", requests)
+	}
+}
+
+func TestAssumeProfileTransport(t *testing.T) {
+	var authorization []string
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	tokens := 0
+	c := &Config{AssumeProfiles: []AssumeProfile{{ProfileID: "Profile-1"}}}
+	iam := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		tokens++
+		json.NewEncoder(w).Encode(core.IamTokenServerResponse{AccessToken: fmt.Sprintf("profile-1-token-%d", tokens), ExpiresIn: 0})
+	}))
+	defer iam.Close()
+	authenticator := c.assumeProfileAuthenticator(&core.BearerTokenAuthenticator{BearerToken: "base-token"}, iam.URL, iam.Client())
+	client := authenticator.client(server.Client())
+
+	for _, header := range []string{"Bearer stale-token", "Bearer stale-token", "Basic Yng6Yng="} {
+		request, _ := gohttp.NewRequest(gohttp.MethodGet, server.URL, nil)
+		request.Header.Set("Authorization", header)
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if request.Header.Get("Authorization") != header {
+			t.Fatal("the request of the client was modified")
+		}
+	}
+	// expired tokens are renewed and requests for IAM tokens keep their basic authentication
+	expected := []string{"Bearer profile-1-token-1", "Bearer profile-1-token-2", "Basic Yng6Yng="}
+	if fmt.Sprint(authorization) != fmt.Sprint(expected) {
+		t.Fatalf("expected the Authorization headers %v, got %v", expected, authorization)
+	}
+
+	var none *assumeProfileAuthenticator
+	if none.client(server.Client()) != server.Client() || none.transport(gohttp.DefaultTransport) != gohttp.DefaultTransport {
+		t.Fatal("expected the client to be unchanged without trusted profiles")
+	}
+}
@@ -5,11 +5,8 @@ package conns
 
 import (
 	"crypto/tls"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"log"
 	"net"
 	gohttp "net/http"
@@ -90,6 +87,7 @@ import (
 	"github.com/IBM/vpc-go-sdk/common"
 	vpc "github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/apache/openwhisk-client-go/whisk"
+	httptransport "github.com/go-openapi/runtime/client"
 	jwt "github.com/golang-jwt/jwt"
 	slsession "github.com/softlayer/softlayer-go/session"
 
@@ -180,6 +178,12 @@ type Config struct {
 	// Constant Retry Delay for API calls
 	RetryDelay time.Duration
 
+	// RetryPolicy rate limits and retries the requests to all services, the settings that
+	// are zero fall back to RetryCount and RetryDelay
+	RetryPolicy RetryPolicy
+	// ServiceRetryPolicies override RetryPolicy for individual services
+	ServiceRetryPolicies map[string]RetryPolicy
+
 	// FunctionNameSpace ...
 	FunctionNameSpace string
 
@@ -198,10 +202,39 @@ type Config struct {
 	// IAM Refresh Token
 	IAMRefreshToken string
 
+	// Trusted profiles that are assumed in turn, the last of which the
+	// clients authenticate as
+	AssumeProfiles []AssumeProfile
+
 	// Zone
 	Zone          string
 	Visibility    string
 	EndpointsFile string
+
+	// Tags that are attached to every resource that supports tagging
+	DefaultTags       []string
+	DefaultAccessTags []string
+
+	// Resources that must not be deleted or replaced
+	ProtectedResources []ProtectedResources
+
+	// HTTPRecorderFile is the cassette that the HTTP requests of all clients are
+	// recorded to, or replayed from when HTTPRecorderMode is replay
+	HTTPRecorderFile string
+	HTTPRecorderMode string
+
+	// rate limiters shared by the clients of each service
+	rateLimiters *rateLimiters
+	recorder     *cassette
+	endpoints    *EndpointsFile
+}
+
+// ProtectedResources selects resources that the provider refuses to delete or replace.
+// A resource is selected when its type matches one of the ResourceTypes patterns and it
+// carries all of the Tags.
+type ProtectedResources struct {
+	ResourceTypes []string
+	Tags          []string
 }
 
 // Session stores the information required for communication with the SoftLayer and Bluemix API
@@ -315,11 +348,21 @@ type ClientSession interface {
 	MqcloudV1() (*mqcloudv1.MqcloudV1, error)
 	VmwareV1() (*vmwarev1.VmwareV1, error)
 	LogsV0() (*logsv0.LogsV0, error)
+	DefaultTags() []string
+	DefaultAccessTags() []string
+	ProtectedResources() []ProtectedResources
 }
 
 type clientSession struct {
 	session *Session
 
+	defaultTags        []string
+	defaultAccessTags  []string
+	protectedResources []ProtectedResources
+
+	// renews the token of the last trusted profile when profiles are assumed
+	assumeAuthenticator *assumeProfileAuthenticator
+
 	appidErr error
 	appidAPI *appid.AppIDManagementV4
 
@@ -704,7 +747,34 @@ func (sess clientSession) BluemixAcccountv1API() (accountv1.AccountServiceAPI, e
 
 // BluemixSession to provide the Bluemix Session
 func (sess clientSession) BluemixSession() (*bxsession.Session, error) {
-	return sess.session.BluemixSession, sess.bluemixSessionErr
+	if sess.assumeAuthenticator == nil || sess.bluemixSessionErr != nil {
+		return sess.session.BluemixSession, sess.bluemixSessionErr
+	}
+	// Resources read the access token of the session, so it is the current
+	// token of the last trusted profile rather than the one obtained when
+	// the provider was configured.
+	token, err := sess.assumeAuthenticator.GetToken()
+	if err != nil {
+		return nil, err
+	}
+	bluemixSession := &bxsession.Session{Config: sess.session.BluemixSession.Config.Copy()}
+	bluemixSession.Config.IAMAccessToken = "Bearer " + token
+	return bluemixSession, nil
+}
+
+// DefaultTags returns the user tags configured in the default_tags block of the provider
+func (sess clientSession) DefaultTags() []string {
+	return sess.defaultTags
+}
+
+// DefaultAccessTags returns the access tags configured in the default_tags block of the provider
+func (sess clientSession) DefaultAccessTags() []string {
+	return sess.defaultAccessTags
+}
+
+// ProtectedResources returns the protected_resources blocks of the provider
+func (sess clientSession) ProtectedResources() []ProtectedResources {
+	return sess.protectedResources
 }
 
 // BluemixUserDetails ...
@@ -871,7 +941,7 @@ func (sess clientSession) KeyManagementAPI() (*kp.Client, error) {
 			}
 		}
 
-		kpClient, err := kp.New(*clientConfig, DefaultTransport())
+		kpClient, err := kp.New(*clientConfig, sess.kmsAPI.HttpClient.Transport)
 		if err != nil {
 			sess.kpErr = fmt.Errorf("[ERROR] Error occured while configuring Key Protect Service: %q", err)
 		}
@@ -1289,13 +1359,22 @@ func (session clientSession) IBMCloudLogsRoutingV0() (*ibmcloudlogsroutingv0.IBM
 
 // ClientSession configures and returns a fully initialized ClientSession
 func (c *Config) ClientSession() (interface{}, error) {
+	if err := c.openRecorder(); err != nil {
+		return nil, err
+	}
+	if err := c.loadEndpointsFile(); err != nil {
+		return nil, err
+	}
 	sess, err := newSession(c)
 	if err != nil {
 		return nil, err
 	}
 	log.Printf("[INFO] Configured Region: %s\n", c.Region)
 	session := clientSession{
-		session: sess,
+		session:            sess,
+		defaultTags:        c.DefaultTags,
+		defaultAccessTags:  c.DefaultAccessTags,
+		protectedResources: c.ProtectedResources,
 	}
 
 	if sess.BluemixSession == nil {
@@ -1388,15 +1467,16 @@ func (c *Config) ClientSession() (interface{}, error) {
 		return session, nil
 	}
 
+	iamPolicy := c.retryPolicy("iam")
 	if sess.BluemixSession.Config.BluemixAPIKey != "" {
 		err = authenticateAPIKey(sess.BluemixSession)
 		if err != nil {
-			for count := c.RetryCount; count >= 0; count-- {
+			for attempt := 0; attempt < iamPolicy.MaxRetries; attempt++ {
 				if err == nil || !isRetryable(err) {
 					break
 				}
-				time.Sleep(c.RetryDelay)
-				log.Printf("Retrying IAM Authentication %d", count)
+				time.Sleep(iamPolicy.Backoff(attempt, nil))
+				log.Printf("Retrying IAM Authentication %d", attempt+1)
 				err = authenticateAPIKey(sess.BluemixSession)
 			}
 			if err != nil {
@@ -1411,12 +1491,12 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.IAMTrustedProfileID == "" && sess.BluemixSession.Config.IAMAccessToken != "" && sess.BluemixSession.Config.BluemixAPIKey == "" {
 		err := RefreshToken(sess.BluemixSession)
 		if err != nil {
-			for count := c.RetryCount; count >= 0; count-- {
+			for attempt := 0; attempt < iamPolicy.MaxRetries; attempt++ {
 				if err == nil || !isRetryable(err) {
 					break
 				}
-				time.Sleep(c.RetryDelay)
-				log.Printf("Retrying refresh token %d", count)
+				time.Sleep(iamPolicy.Backoff(attempt, nil))
+				log.Printf("Retrying refresh token %d", attempt+1)
 				err = RefreshToken(sess.BluemixSession)
 			}
 			if err != nil {
@@ -1425,7 +1505,68 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 
 	}
-	userConfig, err := fetchUserDetails(sess.BluemixSession, c.RetryCount, c.RetryDelay)
+
+	iamURL := iamidentity.DefaultServiceURL
+	if c.Visibility == "private" || c.Visibility == "public-and-private" {
+		if c.Region == "us-south" || c.Region == "us-east" {
+			iamURL = ContructEndpoint(fmt.Sprintf("private.%s.iam", c.Region), cloudEndpoint)
+		} else {
+			iamURL = ContructEndpoint("private.iam", cloudEndpoint)
+		}
+	}
+	iamURL = c.fileFallBack("IBMCLOUD_IAM_API_ENDPOINT", iamURL)
+
+	var authenticator core.Authenticator
+
+	iamClient := core.DefaultHTTPClient()
+	iamClient.Transport = c.transport("iam", iamClient.Transport)
+	if c.BluemixAPIKey != "" || sess.BluemixSession.Config.IAMRefreshToken != "" {
+		if c.BluemixAPIKey != "" {
+			authenticator = &core.IamAuthenticator{
+				ApiKey: c.BluemixAPIKey,
+				URL:    EnvFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamURL),
+				Client: iamClient,
+			}
+		} else {
+			// Construct the IamAuthenticator with the IAM refresh token.
+			authenticator = &core.IamAuthenticator{
+				RefreshToken: sess.BluemixSession.Config.IAMRefreshToken,
+				ClientId:     "bx",
+				ClientSecret: "bx",
+				URL:          EnvFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamURL),
+				Client:       iamClient,
+			}
+		}
+	} else if strings.HasPrefix(sess.BluemixSession.Config.IAMAccessToken, "Bearer") {
+		authenticator = &core.BearerTokenAuthenticator{
+			BearerToken: sess.BluemixSession.Config.IAMAccessToken[7:],
+		}
+	} else {
+		authenticator = &core.BearerTokenAuthenticator{
+			BearerToken: sess.BluemixSession.Config.IAMAccessToken,
+		}
+	}
+
+	var assumeAuthenticator *assumeProfileAuthenticator
+	if len(c.AssumeProfiles) > 0 {
+		// Every client authenticates as the last trusted profile of the chain
+		// and the bluemix-go clients must not re-authenticate as the base
+		// identity when their token expires. Their requests carry the current
+		// token of the trusted profile instead, which is renewed before it expires.
+		assumeAuthenticator = c.assumeProfileAuthenticator(authenticator, EnvFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamURL), iamClient)
+		token, err := assumeAuthenticator.GetToken()
+		if err != nil {
+			return nil, err
+		}
+		authenticator = assumeAuthenticator
+		session.assumeAuthenticator = assumeAuthenticator
+		sess.BluemixSession.Config.IAMAccessToken = "Bearer " + token
+		sess.BluemixSession.Config.IAMRefreshToken = ""
+		sess.BluemixSession.Config.BluemixAPIKey = ""
+		sess.BluemixSession.Config.HTTPClient = assumeAuthenticator.client(sess.BluemixSession.Config.HTTPClient)
+	}
+
+	userConfig, err := fetchUserDetails(sess.BluemixSession, iamPolicy.MaxRetries, iamPolicy.MinDelay)
 	if err != nil {
 		session.bmxUserFetchErr = fmt.Errorf("[ERROR] Error occured while fetching account user details: %q", err)
 	}
@@ -1435,27 +1576,12 @@ func (c *Config) ClientSession() (interface{}, error) {
 		log.Println("Configuring SoftLayer Session with token from IBM Cloud Session")
 		sess.SoftLayerSession.IAMToken = sess.BluemixSession.Config.IAMAccessToken
 		sess.SoftLayerSession.IAMRefreshToken = sess.BluemixSession.Config.IAMRefreshToken
+		sess.SoftLayerSession.HTTPClient = assumeAuthenticator.client(sess.SoftLayerSession.HTTPClient)
 	}
 
 	session.functionClient, session.functionConfigErr = FunctionClient(sess.BluemixSession.Config)
 
 	BluemixRegion = sess.BluemixSession.Config.Region
-	var fileMap map[string]interface{}
-	if f := EnvFallBack([]string{"IBMCLOUD_ENDPOINTS_FILE_PATH", "IC_ENDPOINTS_FILE_PATH"}, c.EndpointsFile); f != "" {
-		jsonFile, err := os.Open(f)
-		if err != nil {
-			log.Fatalf("Unable to open Endpoints File %s", err)
-		}
-		defer jsonFile.Close()
-		bytes, err := ioutil.ReadAll(jsonFile)
-		if err != nil {
-			log.Fatalf("Unable to read Endpoints File %s", err)
-		}
-		err = json.Unmarshal([]byte(bytes), &fileMap)
-		if err != nil {
-			log.Fatalf("Unable to unmarshal Endpoints File %s", err)
-		}
-	}
 	accv1API, err := accountv1.New(sess.BluemixSession)
 	if err != nil {
 		session.accountV1ConfigErr = fmt.Errorf("[ERROR] Error occured while configuring Bluemix Accountv1 Service: %q", err)
@@ -1496,11 +1622,9 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		kpurl = ContructEndpoint(fmt.Sprintf("private.%s.kms", c.Region), cloudEndpoint)
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		kpurl = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_KP_API_ENDPOINT", c.Region, kpurl)
-	}
+	kpurl = c.fileFallBack("IBMCLOUD_KP_API_ENDPOINT", kpurl)
 	var options kp.ClientConfig
-	if c.BluemixAPIKey != "" {
+	if sess.BluemixSession.Config.BluemixAPIKey != "" {
 		options = kp.ClientConfig{
 			BaseURL: EnvFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, kpurl),
 			APIKey:  sess.BluemixSession.Config.BluemixAPIKey, // pragma: allowlist secret
@@ -1515,34 +1639,20 @@ func (c *Config) ClientSession() (interface{}, error) {
 			Verbose: kp.VerboseFailOnly,
 		}
 	}
-	kpAPIclient, err := kp.New(options, DefaultTransport())
+	kpAPIclient, err := kp.New(options, assumeAuthenticator.transport(c.rateLimitedTransport("kms", DefaultTransport())))
 	if err != nil {
 		session.kpErr = fmt.Errorf("[ERROR] Error occured while configuring Key Protect Service: %q", err)
 	}
 	session.kpAPI = kpAPIclient
 
-	iamURL := iamidentity.DefaultServiceURL
-	if c.Visibility == "private" || c.Visibility == "public-and-private" {
-		if c.Region == "us-south" || c.Region == "us-east" {
-			iamURL = ContructEndpoint(fmt.Sprintf("private.%s.iam", c.Region), cloudEndpoint)
-		} else {
-			iamURL = ContructEndpoint("private.iam", cloudEndpoint)
-		}
-	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		iamURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_IAM_API_ENDPOINT", c.Region, iamURL)
-	}
-
 	// KEY MANAGEMENT Service
 	kmsurl := ContructEndpoint(fmt.Sprintf("%s.kms", c.Region), cloudEndpoint)
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		kmsurl = ContructEndpoint(fmt.Sprintf("private.%s.kms", c.Region), cloudEndpoint)
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		kmsurl = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_KP_API_ENDPOINT", c.Region, kmsurl)
-	}
+	kmsurl = c.fileFallBack("IBMCLOUD_KP_API_ENDPOINT", kmsurl)
 	var kmsOptions kp.ClientConfig
-	if c.BluemixAPIKey != "" {
+	if sess.BluemixSession.Config.BluemixAPIKey != "" {
 		kmsOptions = kp.ClientConfig{
 			BaseURL: EnvFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, kmsurl),
 			APIKey:  sess.BluemixSession.Config.BluemixAPIKey, // pragma: allowlist secret
@@ -1559,44 +1669,15 @@ func (c *Config) ClientSession() (interface{}, error) {
 			TokenURL: EnvFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamURL) + "/identity/token",
 		}
 	}
-	kmsAPIclient, err := kp.New(kmsOptions, DefaultTransport())
+	kmsAPIclient, err := kp.New(kmsOptions, assumeAuthenticator.transport(c.rateLimitedTransport("kms", DefaultTransport())))
 	if err != nil {
 		session.kmsErr = fmt.Errorf("[ERROR] Error occured while configuring key Service: %q", err)
 	}
 	session.kmsAPI = kmsAPIclient
 
-	var authenticator core.Authenticator
-
-	if c.BluemixAPIKey != "" || sess.BluemixSession.Config.IAMRefreshToken != "" {
-		if c.BluemixAPIKey != "" {
-			authenticator = &core.IamAuthenticator{
-				ApiKey: c.BluemixAPIKey,
-				URL:    EnvFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamURL),
-			}
-		} else {
-			// Construct the IamAuthenticator with the IAM refresh token.
-			authenticator = &core.IamAuthenticator{
-				RefreshToken: sess.BluemixSession.Config.IAMRefreshToken,
-				ClientId:     "bx",
-				ClientSecret: "bx",
-				URL:          EnvFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamURL),
-			}
-		}
-	} else if strings.HasPrefix(sess.BluemixSession.Config.IAMAccessToken, "Bearer") {
-		authenticator = &core.BearerTokenAuthenticator{
-			BearerToken: sess.BluemixSession.Config.IAMAccessToken[7:],
-		}
-	} else {
-		authenticator = &core.BearerTokenAuthenticator{
-			BearerToken: sess.BluemixSession.Config.IAMAccessToken,
-		}
-	}
-
 	projectEndpoint := project.DefaultServiceURL
 	// Construct an "options" struct for creating the service client.
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		projectEndpoint = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_PROJECT_API_ENDPOINT", c.Region, project.DefaultServiceURL)
-	}
+	projectEndpoint = c.fileFallBack("IBMCLOUD_PROJECT_API_ENDPOINT", projectEndpoint)
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		session.projectClientErr = fmt.Errorf("Project Service API does not support private endpoints")
 	}
@@ -1610,7 +1691,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.projectClient, err = project.NewProjectV1(projectClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.projectClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("project", session.projectClient.Service)
 		// Add custom header for analytics
 		session.projectClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1621,13 +1702,10 @@ func (c *Config) ClientSession() (interface{}, error) {
 
 	// Construct an "options" struct for creating the service client.
 	logsEndpoint := ContructEndpoint(fmt.Sprintf("api.%s.logs", c.Region), cloudEndpoint)
-
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		logsEndpoint = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_LOGS_API_ENDPOINT", c.Region, logsEndpoint)
-	}
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		logsEndpoint = ContructEndpoint(fmt.Sprintf("api.private.%s.logs", c.Region), cloudEndpoint)
 	}
+	logsEndpoint = c.fileFallBack("IBMCLOUD_LOGS_API_ENDPOINT", logsEndpoint)
 
 	logsClientOptions := &logsv0.LogsV0Options{
 		Authenticator: authenticator,
@@ -1638,7 +1716,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.logsClient, err = logsv0.NewLogsV0(logsClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.logsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("logs", session.logsClient.Service)
 		// Add custom header for analytics
 		session.logsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1662,16 +1740,17 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if logsrouterURLErr != nil {
 		logsrouterClientURL = ibmcloudlogsroutingv0.DefaultServiceURL
 	}
+	logsrouterClientURL = c.fileFallBack("IBMCLOUD_LOGS_ROUTING_API_ENDPOINT", logsrouterClientURL)
 	ibmCloudLogsRoutingClientOptions := &ibmcloudlogsroutingv0.IBMCloudLogsRoutingV0Options{
 		Authenticator: authenticator,
-		URL:           logsrouterClientURL,
+		URL:           EnvFallBack([]string{"IBMCLOUD_LOGS_ROUTING_API_ENDPOINT"}, logsrouterClientURL),
 	}
 
 	// Construct the service client.
 	session.ibmCloudLogsRoutingClient, err = ibmcloudlogsroutingv0.NewIBMCloudLogsRoutingV0(ibmCloudLogsRoutingClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.ibmCloudLogsRoutingClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("logs_routing", session.ibmCloudLogsRoutingClient.Service)
 		// Add custom header for analytics
 		session.ibmCloudLogsRoutingClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1689,7 +1768,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.ukoClient, err = ukov4.NewUkoV4(ukoClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.ukoClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("uko", session.ukoClient.Service)
 		// Add custom header for analytics
 		session.ukoClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1703,9 +1782,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" {
 		session.appidErr = fmt.Errorf("App Id resources doesnot support private endpoints")
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		appIDEndpoint = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_APPID_MANAGEMENT_API_ENDPOINT", c.Region, appIDEndpoint)
-	}
+	appIDEndpoint = c.fileFallBack("IBMCLOUD_APPID_MANAGEMENT_API_ENDPOINT", appIDEndpoint)
 	appIDClientOptions := &appid.AppIDManagementV4Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_APPID_MANAGEMENT_API_ENDPOINT"}, appIDEndpoint),
@@ -1715,7 +1792,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.appidErr = fmt.Errorf("error occured while configuring AppID service: #{err}")
 	}
 	if appIDClient != nil && appIDClient.Service != nil {
-		appIDClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("appid", appIDClient.Service)
 		appIDClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -1731,9 +1808,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			cbrURL = ContructEndpoint("private.cbr", cloudEndpoint)
 		}
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		cbrURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_CONTEXT_BASED_RESTRICTIONS_ENDPOINT", c.Region, cbrURL)
-	}
+	cbrURL = c.fileFallBack("IBMCLOUD_CONTEXT_BASED_RESTRICTIONS_ENDPOINT", cbrURL)
 	contextBasedRestrictionsClientOptions := &contextbasedrestrictionsv1.ContextBasedRestrictionsV1Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_CONTEXT_BASED_RESTRICTIONS_ENDPOINT"}, cbrURL),
@@ -1743,7 +1818,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.contextBasedRestrictionsClient, err = contextbasedrestrictionsv1.NewContextBasedRestrictionsV1(contextBasedRestrictionsClientOptions)
 	if err == nil && session.contextBasedRestrictionsClient != nil {
 		// Enable retries for API calls
-		session.contextBasedRestrictionsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cbr", session.contextBasedRestrictionsClient.Service)
 		// Add custom header for analytics
 		session.contextBasedRestrictionsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1757,9 +1832,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" {
 		session.partnerCenterSellClientErr = fmt.Errorf("partner center sell does not support private endpoints")
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		partnerCenterSellURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_PARTNER_CENTER_SELL_API_ENDPOINT", c.Region, partnerCenterSellURL)
-	}
+	partnerCenterSellURL = c.fileFallBack("IBMCLOUD_PARTNER_CENTER_SELL_API_ENDPOINT", partnerCenterSellURL)
 	partnerCenterSellClientOptions := &partnercentersellv1.PartnerCenterSellV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_PARTNER_CENTER_SELL_API_ENDPOINT"}, partnerCenterSellURL),
 		Authenticator: authenticator,
@@ -1771,7 +1844,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	if session.partnerCenterSellClient != nil && session.partnerCenterSellClient.Service != nil {
 		// Enable retries for API calls
-		session.partnerCenterSellClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("partner_center_sell", session.partnerCenterSellClient.Service)
 		// Add custom header for analytics
 		session.partnerCenterSellClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1796,9 +1869,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			usageReportsURL = usagereportsv4.DefaultServiceURL
 		}
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		usageReportsURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_USAGE_REPORTS_API_ENDPOINT", c.Region, usageReportsURL)
-	}
+	usageReportsURL = c.fileFallBack("IBMCLOUD_USAGE_REPORTS_API_ENDPOINT", usageReportsURL)
 	usageReportsClientOptions := &usagereportsv4.UsageReportsV4Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_USAGE_REPORTS_API_ENDPOINT"}, usageReportsURL),
@@ -1808,7 +1879,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.usageReportsClientErr = fmt.Errorf("[ERROR] Error occurred while configuring IBM Cloud Usage Reports API service: %q", err)
 	}
 	if usageReportsClient != nil && usageReportsClient.Service != nil {
-		usageReportsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("usage_reports", usageReportsClient.Service)
 		usageReportsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -1820,9 +1891,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" {
 		session.catalogManagementClientErr = fmt.Errorf("Catalog Management resource doesnot support private endpoints")
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		catalogManagementURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_CATALOG_MANAGEMENT_API_ENDPOINT", c.Region, catalogManagementURL)
-	}
+	catalogManagementURL = c.fileFallBack("IBMCLOUD_CATALOG_MANAGEMENT_API_ENDPOINT", catalogManagementURL)
 	catalogManagementClientOptions := &catalogmanagementv1.CatalogManagementV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_CATALOG_MANAGEMENT_API_ENDPOINT"}, catalogManagementURL),
 		Authenticator: authenticator,
@@ -1834,7 +1903,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	if session.catalogManagementClient != nil && session.catalogManagementClient.Service != nil {
 		// Enable retries for API calls
-		session.catalogManagementClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("catalog_management", session.catalogManagementClient.Service)
 		// Add custom header for analytics
 		session.catalogManagementClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1856,9 +1925,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if atrackerURLV2Err != nil {
 		atrackerClientV2URL = atrackerv2.DefaultServiceURL
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		atrackerClientV2URL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_ATRACKER_API_ENDPOINT", c.Region, atrackerClientV2URL)
-	}
+	atrackerClientV2URL = c.fileFallBack("IBMCLOUD_ATRACKER_API_ENDPOINT", atrackerClientV2URL)
 	atrackerClientV2Options := &atrackerv2.AtrackerV2Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_ATRACKER_API_ENDPOINT"}, atrackerClientV2URL),
@@ -1871,7 +1938,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.atrackerClientV2, err = atrackerv2.NewAtrackerV2(atrackerClientV2Options)
 	if err == nil {
 		// Enable retries for API calls
-		session.atrackerClientV2.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("atracker", session.atrackerClientV2.Service)
 		// Add custom header for analytics
 		session.atrackerClientV2.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1895,9 +1962,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if metricsRouterURLV3Err != nil {
 		metricsRouterClientURL = metricsrouterv3.DefaultServiceURL
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		metricsRouterClientURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_METRICS_ROUTING_API_ENDPOINT", c.Region, metricsRouterClientURL)
-	}
+	metricsRouterClientURL = c.fileFallBack("IBMCLOUD_METRICS_ROUTING_API_ENDPOINT", metricsRouterClientURL)
 	metricsRouterClientOptions := &metricsrouterv3.MetricsRouterV3Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_METRICS_ROUTING_API_ENDPOINT"}, metricsRouterClientURL),
@@ -1907,7 +1972,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.metricsRouterClient, err = metricsrouterv3.NewMetricsRouterV3(metricsRouterClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.metricsRouterClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("metrics_router", session.metricsRouterClient.Service)
 		// Add custom header for analytics
 		session.metricsRouterClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1922,6 +1987,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if regionURL, sccRegionErr := scc.GetServiceURLForRegion(c.Region); sccRegionErr == nil {
 		sccApiClientURL = regionURL
 	}
+	sccApiClientURL = c.fileFallBack("IBMCLOUD_SCC_API_ENDPOINT", sccApiClientURL)
 	sccApiClientOptions := &scc.SecurityAndComplianceCenterApiV3Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_SCC_API_ENDPOINT"}, sccApiClientURL),
@@ -1931,7 +1997,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.securityAndComplianceCenterClient, err = scc.NewSecurityAndComplianceCenterApiV3(sccApiClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.securityAndComplianceCenterClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("scc", session.securityAndComplianceCenterClient.Service)
 		// Add custom header for analytics
 		session.securityAndComplianceCenterClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -1946,9 +2012,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		schematicsEndpoint = ContructEndpoint(fmt.Sprintf("private-%s.schematics", c.Region), cloudEndpoint)
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		schematicsEndpoint = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_SCHEMATICS_API_ENDPOINT", c.Region, schematicsEndpoint)
-	}
+	schematicsEndpoint = c.fileFallBack("IBMCLOUD_SCHEMATICS_API_ENDPOINT", schematicsEndpoint)
 	schematicsClientOptions := &schematicsv1.SchematicsV1Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_SCHEMATICS_API_ENDPOINT"}, schematicsEndpoint),
@@ -1960,7 +2024,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	// Enable retries for API calls
 	if schematicsClient != nil && schematicsClient.Service != nil {
-		schematicsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("schematics", schematicsClient.Service)
 		schematicsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -1972,9 +2036,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		vpcurl = ContructEndpoint(fmt.Sprintf("%s.private.iaas", c.Region), fmt.Sprintf("%s/v1", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		vpcurl = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_IS_NG_API_ENDPOINT", c.Region, vpcurl)
-	}
+	vpcurl = c.fileFallBack("IBMCLOUD_IS_NG_API_ENDPOINT", vpcurl)
 	vpcoptions := &vpc.VpcV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_IS_NG_API_ENDPOINT"}, vpcurl),
 		Authenticator: authenticator,
@@ -1984,7 +2046,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.vpcErr = fmt.Errorf("[ERROR] Error occured while configuring vpc service: %q", err)
 	}
 	if vpcclient != nil && vpcclient.Service != nil {
-		vpcclient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("vpc", vpcclient.Service)
 		vpcclient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2000,7 +2062,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.vpcbetaErr = fmt.Errorf("[ERROR] Error occured while configuring vpc beta service: %q", err)
 	}
 	if vpcbetaclient != nil && vpcbetaclient.Service != nil {
-		vpcbetaclient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("vpc", vpcbetaclient.Service)
 		vpcbetaclient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2012,9 +2074,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" {
 		session.pushServiceClientErr = fmt.Errorf("Push Notifications Service API doesnot support private endpoints")
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		pnurl = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_PUSH_API_ENDPOINT", c.Region, pnurl)
-	}
+	pnurl = c.fileFallBack("IBMCLOUD_PUSH_API_ENDPOINT", pnurl)
 	pushNotificationOptions := &pushservicev1.PushServiceV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_PUSH_API_ENDPOINT"}, pnurl),
 		Authenticator: authenticator,
@@ -2025,7 +2085,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	if pnclient != nil && pnclient.Service != nil {
 		// Enable retries for API calls
-		pnclient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("push_notifications", pnclient.Service)
 		pnclient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2039,9 +2099,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		enurl = fmt.Sprintf("https://private.%s.event-notifications.cloud.ibm.com/event-notifications", c.Region)
 	}
 
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		enurl = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_EVENT_NOTIFICATIONS_API_ENDPOINT", c.Region, enurl)
-	}
+	enurl = c.fileFallBack("IBMCLOUD_EVENT_NOTIFICATIONS_API_ENDPOINT", enurl)
 	enClientOptions := &eventnotificationsv1.EventNotificationsV1Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_EVENT_NOTIFICATIONS_API_ENDPOINT"}, enurl),
@@ -2054,7 +2112,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	if session.eventNotificationsApiClient != nil && session.eventNotificationsApiClient.Service != nil {
 		// Enable retries for API calls
-		session.eventNotificationsApiClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("event_notifications", session.eventNotificationsApiClient.Service)
 		session.eventNotificationsApiClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2065,9 +2123,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		appconfigurl = ContructEndpoint(fmt.Sprintf("%s.private", c.Region), fmt.Sprintf("%s.apprapp", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		appconfigurl = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_APP_CONFIG_ENDPOINT", c.Region, appconfigurl)
-	}
+	appconfigurl = c.fileFallBack("IBMCLOUD_APP_CONFIG_ENDPOINT", appconfigurl)
 	appConfigurationClientOptions := &appconfigurationv1.AppConfigurationV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_APP_CONFIG_ENDPOINT"}, appconfigurl),
 		Authenticator: authenticator,
@@ -2076,7 +2132,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	appConfigClient, err := appconfigurationv1.NewAppConfigurationV1(appConfigurationClientOptions)
 	if appConfigClient != nil {
 		// Enable retries for API calls
-		appConfigClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("app_configuration", appConfigClient.Service)
 		session.appConfigurationClient = appConfigClient
 	} else {
 		session.appConfigurationClientErr = fmt.Errorf("[ERROR] Error occurred while configuring App Configuration service: %q", err)
@@ -2094,9 +2150,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			containerRegistryClientURL, _ = GetPrivateServiceURLForRegion("global")
 		}
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		containerRegistryClientURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_CR_API_ENDPOINT", c.Region, containerRegistryClientURL)
-	}
+	containerRegistryClientURL = c.fileFallBack("IBMCLOUD_CR_API_ENDPOINT", containerRegistryClientURL)
 	containerRegistryClientOptions := &containerregistryv1.ContainerRegistryV1Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_CR_API_ENDPOINT"}, containerRegistryClientURL),
@@ -2109,7 +2163,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	if session.containerRegistryClient != nil && session.containerRegistryClient.Service != nil {
 		// Enable retries for API calls
-		session.containerRegistryClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("container_registry", session.containerRegistryClient.Service)
 		// Add custom header for analytics
 		session.containerRegistryClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -2118,9 +2172,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 
 	// OBJECT STORAGE Service
 	cosconfigurl := "https://config.cloud-object-storage.cloud.ibm.com/v1"
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		cosconfigurl = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_COS_CONFIG_ENDPOINT", c.Region, cosconfigurl)
-	}
+	cosconfigurl = c.fileFallBack("IBMCLOUD_COS_CONFIG_ENDPOINT", cosconfigurl)
 	cosconfigoptions := &cosconfig.ResourceConfigurationV1Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_COS_CONFIG_ENDPOINT"}, cosconfigurl),
@@ -2129,6 +2181,9 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if err != nil {
 		session.cosConfigErr = fmt.Errorf("[ERROR] Error occured while configuring COS config service: %q", err)
 	}
+	if cosconfigclient != nil && cosconfigclient.Service != nil {
+		c.enableRetries("cos_config", cosconfigclient.Service)
+	}
 	session.cosConfigAPI = cosconfigclient
 
 	globalSearchAPI, err := globalsearchv2.New(sess.BluemixSession)
@@ -2154,9 +2209,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 		globalTaggingEndpoint = ContructEndpoint(fmt.Sprintf("tags.private.%s", globalTaggingRegion), fmt.Sprintf("global-search-tagging.%s", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		globalTaggingEndpoint = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_GT_API_ENDPOINT", c.Region, globalTaggingEndpoint)
-	}
+	globalTaggingEndpoint = c.fileFallBack("IBMCLOUD_GT_API_ENDPOINT", globalTaggingEndpoint)
 	globalTaggingV1Options := &globaltaggingv1.GlobalTaggingV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_GT_API_ENDPOINT"}, globalTaggingEndpoint),
 		Authenticator: authenticator,
@@ -2167,7 +2220,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	if globalTaggingAPIV1 != nil && globalTaggingAPIV1.Service != nil {
 		session.globalTaggingServiceAPIV1 = *globalTaggingAPIV1
-		session.globalTaggingServiceAPIV1.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("global_tagging", session.globalTaggingServiceAPIV1.Service)
 		session.globalTaggingServiceAPIV1.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2183,9 +2236,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		}
 		globalSearchEndpoint = ContructEndpoint(fmt.Sprintf("api.private.%s", globalSearchRegion), fmt.Sprintf("global-search-tagging.%s", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		globalSearchEndpoint = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_GS_API_ENDPOINT", c.Region, searchv2.DefaultServiceURL)
-	}
+	globalSearchEndpoint = c.fileFallBack("IBMCLOUD_GS_API_ENDPOINT", globalSearchEndpoint)
 	globalSearchV2Options := &searchv2.GlobalSearchV2Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_GS_API_ENDPOINT"}, globalSearchEndpoint),
 		Authenticator: authenticator,
@@ -2196,7 +2247,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	if globalSearchAPIV2 != nil && globalSearchAPIV2.Service != nil {
 		session.globalSearchServiceAPIV2 = *globalSearchAPIV2
-		session.globalSearchServiceAPIV2.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("global_search", session.globalSearchServiceAPIV2.Service)
 		session.globalSearchServiceAPIV2.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2215,6 +2266,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	} else {
 		cloudDatabasesEndpoint = fmt.Sprintf("https://api.%s.databases.cloud.ibm.com/v5/ibm", c.Region)
 	}
+	cloudDatabasesEndpoint = c.fileFallBack("IBMCLOUD_DATABASES_API_ENDPOINT", cloudDatabasesEndpoint)
 
 	// Construct an "options" struct for creating the service client.
 	cloudDatabasesClientOptions := &clouddatabasesv5.CloudDatabasesV5Options{
@@ -2226,7 +2278,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.cloudDatabasesClient, err = clouddatabasesv5.NewCloudDatabasesV5(cloudDatabasesClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.cloudDatabasesClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cloud_databases", session.cloudDatabasesClient.Service)
 		// Add custom header for analytics
 		session.cloudDatabasesClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -2276,9 +2328,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		apicurl = ContructEndpoint(fmt.Sprintf("api.private.%s.apigw", c.Region), fmt.Sprintf("%s/controller", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		apicurl = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_API_GATEWAY_ENDPOINT", c.Region, apicurl)
-	}
+	apicurl = c.fileFallBack("IBMCLOUD_API_GATEWAY_ENDPOINT", apicurl)
 	APIGatewayControllerAPIV1Options := &apigateway.ApiGatewayControllerApiV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_API_GATEWAY_ENDPOINT"}, apicurl),
 		Authenticator: &core.NoAuthAuthenticator{},
@@ -2287,10 +2337,16 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if err != nil {
 		session.apigatewayErr = fmt.Errorf("[ERROR] Error occured while configuring  APIGateway service: %q", err)
 	}
+	// the client of the API Gateway SDK is built on go-sdk-core v3, which doesn't
+	// implement httpClientService
+	if apigatewayAPI != nil && apigatewayAPI.Service != nil && apigatewayAPI.Service.Client != nil {
+		apigatewayAPI.Service.Client.Transport = c.transport("api_gateway", apigatewayAPI.Service.Client.Transport)
+	}
 	session.apigatewayAPI = apigatewayAPI
 
 	// POWER SYSTEMS Service
 	piURL := ContructEndpoint(c.Region, "power-iaas.cloud.ibm.com")
+	piURL = c.fileFallBack("IBMCLOUD_PI_API_ENDPOINT", piURL)
 	ibmPIOptions := &ibmpisession.IBMPIOptions{
 		Authenticator: authenticator,
 		Debug:         os.Getenv("TF_LOG") != "",
@@ -2303,6 +2359,11 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if err != nil {
 		session.ibmpiConfigErr = fmt.Errorf("Error occured while configuring ibmpisession: %q", err)
 	}
+	if ibmpisession != nil {
+		if runtime, ok := ibmpisession.Power.Transport.(*httptransport.Runtime); ok {
+			runtime.Transport = c.transport("power", runtime.Transport)
+		}
+	}
 	session.ibmpiSession = ibmpisession
 
 	// PRIVATE DNS Service
@@ -2310,9 +2371,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		pdnsURL = ContructEndpoint("api.private.dns-svcs", fmt.Sprintf("%s/v1", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		pdnsURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_PRIVATE_DNS_API_ENDPOINT", c.Region, pdnsURL)
-	}
+	pdnsURL = c.fileFallBack("IBMCLOUD_PRIVATE_DNS_API_ENDPOINT", pdnsURL)
 	dnsOptions := &dns.DnsSvcsV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_PRIVATE_DNS_API_ENDPOINT"}, pdnsURL),
 		Authenticator: authenticator,
@@ -2322,7 +2381,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.pDNSErr = fmt.Errorf("[ERROR] Error occured while configuring PrivateDNS Service: %s", session.pDNSErr)
 	}
 	if session.pDNSClient != nil && session.pDNSClient.Service != nil {
-		session.pDNSClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("dns_services", session.pDNSClient.Service)
 		session.pDNSClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2334,9 +2393,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		dlURL = ContructEndpoint("private.directlink", fmt.Sprintf("%s/v1", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		dlURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_DL_API_ENDPOINT", c.Region, dlURL)
-	}
+	dlURL = c.fileFallBack("IBMCLOUD_DL_API_ENDPOINT", dlURL)
 	directlinkOptions := &dl.DirectLinkV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_DL_API_ENDPOINT"}, dlURL),
 		Authenticator: authenticator,
@@ -2347,7 +2404,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.directlinkErr = fmt.Errorf("[ERROR] Error occured while configuring Direct Link Service: %s", session.directlinkErr)
 	}
 	if session.directlinkAPI != nil && session.directlinkAPI.Service != nil {
-		session.directlinkAPI.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("direct_link", session.directlinkAPI.Service)
 		session.directlinkAPI.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2358,9 +2415,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		dlproviderURL = ContructEndpoint("private.directlink", fmt.Sprintf("%s/provider/v2", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		dlproviderURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_DL_PROVIDER_API_ENDPOINT", c.Region, dlproviderURL)
-	}
+	dlproviderURL = c.fileFallBack("IBMCLOUD_DL_PROVIDER_API_ENDPOINT", dlproviderURL)
 	directLinkProviderV2Options := &dlProviderV2.DirectLinkProviderV2Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_DL_PROVIDER_API_ENDPOINT"}, dlproviderURL),
 		Authenticator: authenticator,
@@ -2371,7 +2426,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.dlProviderErr = fmt.Errorf("[ERROR] Error occured while configuring Direct Link Provider Service: %s", session.dlProviderErr)
 	}
 	if session.dlProviderAPI != nil && session.dlProviderAPI.Service != nil {
-		session.dlProviderAPI.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("direct_link", session.dlProviderAPI.Service)
 		session.dlProviderAPI.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2382,9 +2437,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		tgURL = ContructEndpoint("private.transit", fmt.Sprintf("%s/v1", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		tgURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_TG_API_ENDPOINT", c.Region, tgURL)
-	}
+	tgURL = c.fileFallBack("IBMCLOUD_TG_API_ENDPOINT", tgURL)
 	transitgatewayOptions := &tg.TransitGatewayApisV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_TG_API_ENDPOINT"}, tgURL),
 		Authenticator: authenticator,
@@ -2395,7 +2448,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.transitgatewayErr = fmt.Errorf("[ERROR] Error occured while configuring Transit Gateway Service: %s", session.transitgatewayErr)
 	}
 	if session.transitgatewayAPI != nil && session.transitgatewayAPI.Service != nil {
-		session.transitgatewayAPI.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("transit_gateway", session.transitgatewayAPI.Service)
 		// session.transitgatewayAPI.SetDefaultHeaders(gohttp.Header{
 		// 	"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		// })
@@ -2404,17 +2457,18 @@ func (c *Config) ClientSession() (interface{}, error) {
 	// Construct an instance of the 'Configuration Aggregator' service.
 	var configBaseURL string
 	configBaseURL = ContructEndpoint(fmt.Sprintf("%s.apprapp", c.Region), cloudEndpoint)
+	configBaseURL = c.fileFallBack("IBMCLOUD_CONFIGURATION_AGGREGATOR_API_ENDPOINT", configBaseURL)
 
 	configurationAggregatorClientOptions := &configurationaggregatorv1.ConfigurationAggregatorV1Options{
 		Authenticator: authenticator,
-		URL:           configBaseURL,
+		URL:           EnvFallBack([]string{"IBMCLOUD_CONFIGURATION_AGGREGATOR_API_ENDPOINT"}, configBaseURL),
 	}
 
 	// Construct the service client.
 	session.configurationAggregatorClient, err = configurationaggregatorv1.NewConfigurationAggregatorV1(configurationAggregatorClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.configurationAggregatorClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("configuration_aggregator", session.configurationAggregatorClient.Service)
 		// Add custom header for analytics
 		session.configurationAggregatorClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -2453,9 +2507,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.cisMtlsErr = fmt.Errorf("CIS Service doesnt support private endpoints.")
 
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		cisURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_CIS_API_ENDPOINT", c.Region, cisURL)
-	}
+	cisURL = c.fileFallBack("IBMCLOUD_CIS_API_ENDPOINT", cisURL)
 	cisEndPoint := EnvFallBack([]string{"IBMCLOUD_CIS_API_ENDPOINT"}, cisURL)
 
 	// IBM Network CIS Zones service
@@ -2471,7 +2523,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisZonesErr)
 	}
 	if session.cisZonesV1Client != nil && session.cisZonesV1Client.Service != nil {
-		session.cisZonesV1Client.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisZonesV1Client.Service)
 		session.cisZonesV1Client.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2489,7 +2541,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.cisDNSErr = fmt.Errorf("[ERROR] Error occured while configuring CIS DNS Service: %s", session.cisDNSErr)
 	}
 	if session.cisDNSRecordsClient != nil && session.cisDNSRecordsClient.Service != nil {
-		session.cisDNSRecordsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisDNSRecordsClient.Service)
 		session.cisDNSRecordsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2509,7 +2561,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisDNSBulkErr)
 	}
 	if session.cisDNSRecordBulkClient != nil && session.cisDNSRecordBulkClient.Service != nil {
-		session.cisDNSRecordBulkClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisDNSRecordBulkClient.Service)
 		session.cisDNSRecordBulkClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2527,7 +2579,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisGLBPoolErr)
 	}
 	if session.cisGLBPoolClient != nil && session.cisGLBPoolClient.Service != nil {
-		session.cisGLBPoolClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisGLBPoolClient.Service)
 		session.cisGLBPoolClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2546,7 +2598,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisGLBErr)
 	}
 	if session.cisGLBClient != nil && session.cisGLBClient.Service != nil {
-		session.cisGLBClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisGLBClient.Service)
 		session.cisGLBClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2564,7 +2616,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisGLBHealthCheckErr)
 	}
 	if session.cisGLBHealthCheckClient != nil && session.cisGLBHealthCheckClient.Service != nil {
-		session.cisGLBHealthCheckClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisGLBHealthCheckClient.Service)
 		session.cisGLBHealthCheckClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2581,7 +2633,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisIPErr)
 	}
 	if session.cisIPClient != nil && session.cisIPClient.Service != nil {
-		session.cisIPClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisIPClient.Service)
 		session.cisIPClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2601,7 +2653,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisRLErr)
 	}
 	if session.cisRLClient != nil && session.cisRLClient.Service != nil {
-		session.cisRLClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisRLClient.Service)
 		session.cisRLClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2618,7 +2670,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisAlertsErr)
 	}
 	if session.cisAlertsClient != nil && session.cisAlertsClient.Service != nil {
-		session.cisAlertsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisAlertsClient.Service)
 		session.cisAlertsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2637,7 +2689,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisRulesetsErr)
 	}
 	if session.cisRulesetsClient != nil && session.cisRulesetsClient.Service != nil {
-		session.cisRulesetsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisRulesetsClient.Service)
 		session.cisRulesetsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2657,7 +2709,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisPageRuleErr)
 	}
 	if session.cisPageRuleClient != nil && session.cisPageRuleClient.Service != nil {
-		session.cisPageRuleClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisPageRuleClient.Service)
 		session.cisPageRuleClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2676,7 +2728,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisEdgeFunctionErr)
 	}
 	if session.cisEdgeFunctionClient != nil && session.cisEdgeFunctionClient.Service != nil {
-		session.cisEdgeFunctionClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisEdgeFunctionClient.Service)
 		session.cisEdgeFunctionClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2696,7 +2748,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisSSLErr)
 	}
 	if session.cisSSLClient != nil && session.cisSSLClient.Service != nil {
-		session.cisSSLClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisSSLClient.Service)
 		session.cisSSLClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2715,7 +2767,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisWAFPackageErr)
 	}
 	if session.cisWAFPackageClient != nil && session.cisWAFPackageClient.Service != nil {
-		session.cisWAFPackageClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisWAFPackageClient.Service)
 		session.cisWAFPackageClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2734,7 +2786,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisDomainSettingsErr)
 	}
 	if session.cisDomainSettingsClient != nil && session.cisDomainSettingsClient.Service != nil {
-		session.cisDomainSettingsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisDomainSettingsClient.Service)
 		session.cisDomainSettingsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2753,7 +2805,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisRoutingErr)
 	}
 	if session.cisRoutingClient != nil && session.cisRoutingClient.Service != nil {
-		session.cisRoutingClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisRoutingClient.Service)
 		session.cisRoutingClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2772,7 +2824,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisWAFGroupErr)
 	}
 	if session.cisWAFGroupClient != nil && session.cisWAFGroupClient.Service != nil {
-		session.cisWAFGroupClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisWAFGroupClient.Service)
 		session.cisWAFGroupClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2791,7 +2843,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisCacheErr)
 	}
 	if session.cisCacheClient != nil && session.cisCacheClient.Service != nil {
-		session.cisCacheClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisCacheClient.Service)
 		session.cisCacheClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2811,7 +2863,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisCustomPageErr)
 	}
 	if session.cisCustomPageClient != nil && session.cisCustomPageClient.Service != nil {
-		session.cisCustomPageClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisCustomPageClient.Service)
 		session.cisCustomPageClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2830,7 +2882,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisAccessRuleErr)
 	}
 	if session.cisAccessRuleClient != nil && session.cisAccessRuleClient.Service != nil {
-		session.cisAccessRuleClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisAccessRuleClient.Service)
 		session.cisAccessRuleClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2849,7 +2901,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisUARuleErr)
 	}
 	if session.cisUARuleClient != nil && session.cisUARuleClient.Service != nil {
-		session.cisUARuleClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisUARuleClient.Service)
 		session.cisUARuleClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2868,7 +2920,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisLockdownErr)
 	}
 	if session.cisLockdownClient != nil && session.cisLockdownClient.Service != nil {
-		session.cisLockdownClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisLockdownClient.Service)
 		session.cisLockdownClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2887,7 +2939,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisRangeAppErr)
 	}
 	if session.cisRangeAppClient != nil && session.cisRangeAppClient.Service != nil {
-		session.cisRangeAppClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisRangeAppClient.Service)
 		session.cisRangeAppClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2907,7 +2959,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisWAFRuleErr)
 	}
 	if session.cisWAFRuleClient != nil && session.cisWAFRuleClient.Service != nil {
-		session.cisWAFRuleClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisWAFRuleClient.Service)
 		session.cisWAFRuleClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2927,7 +2979,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisLogpushJobsErr)
 	}
 	if session.cisLogpushJobsClient != nil && session.cisLogpushJobsClient.Service != nil {
-		session.cisLogpushJobsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisLogpushJobsClient.Service)
 		session.cisLogpushJobsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2945,7 +2997,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisMtlsErr)
 	}
 	if session.cisMtlsClient != nil && session.cisMtlsClient.Service != nil {
-		session.cisMtlsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisMtlsClient.Service)
 		session.cisMtlsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2964,7 +3016,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisBotManagementErr)
 	}
 	if session.cisBotManagementClient != nil && session.cisBotManagementClient.Service != nil {
-		session.cisBotManagementClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisBotManagementClient.Service)
 		session.cisBotManagementClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -2983,7 +3035,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisBotAnalyticsErr)
 	}
 	if session.cisBotAnalyticsClient != nil && session.cisBotAnalyticsClient.Service != nil {
-		session.cisBotAnalyticsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisBotAnalyticsClient.Service)
 		session.cisBotAnalyticsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3001,7 +3053,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisWebhooksErr)
 	}
 	if session.cisWebhooksClient != nil && session.cisWebhooksClient.Service != nil {
-		session.cisWebhooksClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisWebhooksClient.Service)
 		session.cisWebhooksClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3017,7 +3069,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisFiltersErr)
 	}
 	if session.cisFiltersClient != nil && session.cisFiltersClient.Service != nil {
-		session.cisFiltersClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisFiltersClient.Service)
 		session.cisFiltersClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3034,7 +3086,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisFirewallRulesErr)
 	}
 	if session.cisFirewallRulesClient != nil && session.cisFirewallRulesClient.Service != nil {
-		session.cisFirewallRulesClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisFirewallRulesClient.Service)
 		session.cisFirewallRulesClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3055,7 +3107,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			session.cisOriginAuthPullErr)
 	}
 	if session.cisOriginAuthClient != nil && session.cisOriginAuthClient.Service != nil {
-		session.cisOriginAuthClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cis", session.cisOriginAuthClient.Service)
 		session.cisOriginAuthClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3071,9 +3123,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			iamIdenityURL = ContructEndpoint("private.iam", cloudEndpoint)
 		}
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		iamIdenityURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_IAM_API_ENDPOINT", c.Region, iamIdenityURL)
-	}
+	iamIdenityURL = c.fileFallBack("IBMCLOUD_IAM_API_ENDPOINT", iamIdenityURL)
 	iamIdentityOptions := &iamidentity.IamIdentityV1Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamIdenityURL),
@@ -3083,7 +3133,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.iamIdentityErr = fmt.Errorf("[ERROR] Error occured while configuring IAM Identity service: %q", err)
 	}
 	if iamIdentityClient != nil && iamIdentityClient.Service != nil {
-		iamIdentityClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("iam_identity", iamIdentityClient.Service)
 		iamIdentityClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3099,9 +3149,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			iamPolicyManagementURL = ContructEndpoint("private.iam", cloudEndpoint)
 		}
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		iamPolicyManagementURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_IAM_API_ENDPOINT", c.Region, iamPolicyManagementURL)
-	}
+	iamPolicyManagementURL = c.fileFallBack("IBMCLOUD_IAM_API_ENDPOINT", iamPolicyManagementURL)
 	iamPolicyManagementOptions := &iampolicymanagement.IamPolicyManagementV1Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamPolicyManagementURL),
@@ -3111,7 +3159,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.iamPolicyManagementErr = fmt.Errorf("[ERROR] Error occured while configuring IAM Policy Management service: %q", err)
 	}
 	if iamPolicyManagementClient != nil && iamPolicyManagementClient.Service != nil {
-		iamPolicyManagementClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("iam_policy_management", iamPolicyManagementClient.Service)
 		iamPolicyManagementClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3127,9 +3175,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			iamAccessGroupsURL = ContructEndpoint("private.iam", cloudEndpoint)
 		}
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		iamAccessGroupsURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_IAM_API_ENDPOINT", c.Region, iamAccessGroupsURL)
-	}
+	iamAccessGroupsURL = c.fileFallBack("IBMCLOUD_IAM_API_ENDPOINT", iamAccessGroupsURL)
 	iamAccessGroupsOptions := &iamaccessgroups.IamAccessGroupsV2Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_IAM_API_ENDPOINT"}, iamAccessGroupsURL),
@@ -3139,7 +3185,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.iamAccessGroupsErr = fmt.Errorf("[ERROR] Error occured while configuring IAM Access Group service: %q", err)
 	}
 	if iamAccessGroupsClient != nil && iamAccessGroupsClient.Service != nil {
-		iamAccessGroupsClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("iam_access_groups", iamAccessGroupsClient.Service)
 		iamAccessGroupsClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3163,9 +3209,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			rmURL = resourcemanager.DefaultServiceURL
 		}
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		rmURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT", c.Region, rmURL)
-	}
+	rmURL = c.fileFallBack("IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT", rmURL)
 	resourceManagerOptions := &resourcemanager.ResourceManagerV2Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT"}, rmURL),
@@ -3175,7 +3219,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.resourceManagerErr = fmt.Errorf("[ERROR] Error occured while configuring Resource Manager service: %q", err)
 	}
 	if resourceManagerClient != nil && resourceManagerClient.Service != nil {
-		resourceManagerClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("resource_manager", resourceManagerClient.Service)
 		resourceManagerClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3184,9 +3228,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 
 	// CLOUD SHELL Service
 	cloudShellUrl := ibmcloudshellv1.DefaultServiceURL
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		cloudShellUrl = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_CLOUD_SHELL_API_ENDPOINT", c.Region, cloudShellUrl)
-	}
+	cloudShellUrl = c.fileFallBack("IBMCLOUD_CLOUD_SHELL_API_ENDPOINT", cloudShellUrl)
 	ibmCloudShellClientOptions := &ibmcloudshellv1.IBMCloudShellV1Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_CLOUD_SHELL_API_ENDPOINT"}, cloudShellUrl),
@@ -3196,7 +3238,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.ibmCloudShellClientErr = fmt.Errorf("[ERROR] Error occurred while configuring IBM Cloud Shell service: %q", err)
 	}
 	if session.ibmCloudShellClient != nil && session.ibmCloudShellClient.Service != nil {
-		session.ibmCloudShellClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cloud_shell", session.ibmCloudShellClient.Service)
 		session.ibmCloudShellClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3220,9 +3262,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			enterpriseURL = enterprisemanagementv1.DefaultServiceURL
 		}
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		enterpriseURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_ENTERPRISE_API_ENDPOINT", c.Region, enterpriseURL)
-	}
+	enterpriseURL = c.fileFallBack("IBMCLOUD_ENTERPRISE_API_ENDPOINT", enterpriseURL)
 	enterpriseManagementClientOptions := &enterprisemanagementv1.EnterpriseManagementV1Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_ENTERPRISE_API_ENDPOINT"}, enterpriseURL),
@@ -3232,7 +3272,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.enterpriseManagementClientErr = fmt.Errorf("[ERROR] Error occurred while configuring IBM Cloud Enterprise Management API service: %q", err)
 	}
 	if enterpriseManagementClient != nil && enterpriseManagementClient.Service != nil {
-		enterpriseManagementClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("enterprise_management", enterpriseManagementClient.Service)
 		enterpriseManagementClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3256,9 +3296,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 			rcURL = resourcecontroller.DefaultServiceURL
 		}
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		rcURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT", c.Region, rcURL)
-	}
+	rcURL = c.fileFallBack("IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT", rcURL)
 	resourceControllerOptions := &resourcecontroller.ResourceControllerV2Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT"}, rcURL),
@@ -3268,7 +3306,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.resourceControllerErr = fmt.Errorf("[ERROR] Error occured while configuring Resource Controller service: %q", err)
 	}
 	if resourceControllerClient != nil && resourceControllerClient.Service != nil {
-		resourceControllerClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("resource_controller", resourceControllerClient.Service)
 		resourceControllerClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3283,17 +3321,18 @@ func (c *Config) ClientSession() (interface{}, error) {
 	} else {
 		smBaseUrl = ContructEndpoint(fmt.Sprintf("secrets-manager.%s", c.Region), cloudEndpoint)
 	}
+	smBaseUrl = c.fileFallBack("IBMCLOUD_SECRETS_MANAGER_API_ENDPOINT", smBaseUrl)
 
 	secretsManagerClientOptionsV2 := &secretsmanagerv2.SecretsManagerV2Options{
 		Authenticator: authenticator,
-		URL:           smBaseUrl,
+		URL:           EnvFallBack([]string{"IBMCLOUD_SECRETS_MANAGER_API_ENDPOINT"}, smBaseUrl),
 	}
 
 	// Construct the service client.
 	session.secretsManagerClient, err = secretsmanagerv2.NewSecretsManagerV2UsingExternalConfig(secretsManagerClientOptionsV2)
 	if err == nil {
 		// Enable retries for API calls
-		session.secretsManagerClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("secrets_manager", session.secretsManagerClient.Service)
 		// Add custom header for analytics
 		session.secretsManagerClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -3307,9 +3346,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		containerEndpoint = ContructEndpoint(fmt.Sprintf("private.%s.containers", c.Region), fmt.Sprintf("%s/global", cloudEndpoint))
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		containerEndpoint = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_SATELLITE_API_ENDPOINT", c.Region, containerEndpoint)
-	}
+	containerEndpoint = c.fileFallBack("IBMCLOUD_SATELLITE_API_ENDPOINT", containerEndpoint)
 	kubernetesServiceV1Options := &kubernetesserviceapiv1.KubernetesServiceApiV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_SATELLITE_API_ENDPOINT"}, containerEndpoint),
 		Authenticator: authenticator,
@@ -3321,7 +3358,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 
 	// Enable retries for API calls
 	if session.satelliteClient != nil && session.satelliteClient.Service != nil {
-		session.satelliteClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("satellite", session.satelliteClient.Service)
 		session.satelliteClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3333,9 +3370,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		satelliteLinkEndpoint = ContructEndpoint("private.api.link.satellite", cloudEndpoint)
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		satelliteLinkEndpoint = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_SATELLITE_LINK_API_ENDPOINT", c.Region, satelliteLinkEndpoint)
-	}
+	satelliteLinkEndpoint = c.fileFallBack("IBMCLOUD_SATELLITE_LINK_API_ENDPOINT", satelliteLinkEndpoint)
 	satelliteLinkClientOptions := &satellitelinkv1.SatelliteLinkV1Options{
 		URL:           EnvFallBack([]string{"IBMCLOUD_SATELLITE_LINK_API_ENDPOINT"}, satelliteLinkEndpoint),
 		Authenticator: authenticator,
@@ -3346,7 +3381,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	if session.satelliteLinkClient != nil && session.satelliteLinkClient.Service != nil {
 		// Enable retries for API calls
-		session.satelliteLinkClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("satellite", session.satelliteLinkClient.Service)
 		// Add custom header for analytics
 		session.satelliteLinkClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -3361,7 +3396,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.esSchemaRegistryErr = fmt.Errorf("[ERROR] Error occured while configuring Event Streams schema registry: %q", err)
 	}
 	if session.esSchemaRegistryClient != nil && session.esSchemaRegistryClient.Service != nil {
-		session.esSchemaRegistryClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("event_streams", session.esSchemaRegistryClient.Service)
 		session.esSchemaRegistryClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3375,7 +3410,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 		session.esAdminRestErr = fmt.Errorf("[ERROR] Error occured while configuring Event Streams admin rest: %q", err)
 	}
 	if session.esAdminRestClient != nil && session.esAdminRestClient.Service != nil {
-		session.esAdminRestClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("event_streams", session.esAdminRestClient.Service)
 		session.esAdminRestClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
 		})
@@ -3394,9 +3429,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if err != nil {
 		session.cdToolchainClientErr = fmt.Errorf("Error occurred while configuring Toolchain service: %q", err)
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		cdToolchainClientURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_TOOLCHAIN_ENDPOINT", c.Region, cdToolchainClientURL)
-	}
+	cdToolchainClientURL = c.fileFallBack("IBMCLOUD_TOOLCHAIN_ENDPOINT", cdToolchainClientURL)
 	cdToolchainClientOptions := &cdtoolchainv2.CdToolchainV2Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_TOOLCHAIN_ENDPOINT"}, cdToolchainClientURL),
@@ -3406,7 +3439,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.cdToolchainClient, err = cdtoolchainv2.NewCdToolchainV2(cdToolchainClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.cdToolchainClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cd_toolchain", session.cdToolchainClient.Service)
 		// Add custom header for analytics
 		session.cdToolchainClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -3428,9 +3461,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if err != nil {
 		cdTektonPipelineClientURL = cdtektonpipelinev2.DefaultServiceURL
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		cdTektonPipelineClientURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_TEKTON_PIPELINE_ENDPOINT", c.Region, cdTektonPipelineClientURL)
-	}
+	cdTektonPipelineClientURL = c.fileFallBack("IBMCLOUD_TEKTON_PIPELINE_ENDPOINT", cdTektonPipelineClientURL)
 	cdTektonPipelineClientOptions := &cdtektonpipelinev2.CdTektonPipelineV2Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_TEKTON_PIPELINE_ENDPOINT"}, cdTektonPipelineClientURL),
@@ -3439,7 +3470,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.cdTektonPipelineClient, err = cdtektonpipelinev2.NewCdTektonPipelineV2(cdTektonPipelineClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.cdTektonPipelineClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("cd_tekton_pipeline", session.cdTektonPipelineClient.Service)
 		// Add custom header for analytics
 		session.cdTektonPipelineClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -3453,9 +3484,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		mqCloudURL = ContructEndpoint(fmt.Sprintf("api.private.%s.mq2", c.Region), cloudEndpoint)
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		mqCloudURL = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_MQCLOUD_CONFIG_ENDPOINT", c.Region, mqCloudURL)
-	}
+	mqCloudURL = c.fileFallBack("IBMCLOUD_MQCLOUD_CONFIG_ENDPOINT", mqCloudURL)
 	accept_language := os.Getenv("IBMCLOUD_MQCLOUD_ACCEPT_LANGUAGE")
 	mqcloudClientOptions := &mqcloudv1.MqcloudV1Options{
 		Authenticator:  authenticator,
@@ -3467,7 +3496,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.mqcloudClient, err = mqcloudv1.NewMqcloudV1(mqcloudClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.mqcloudClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("mqcloud", session.mqcloudClient.Service)
 		// Add custom header for analytics
 		session.mqcloudClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -3479,16 +3508,17 @@ func (c *Config) ClientSession() (interface{}, error) {
 	// VMware as a Service
 	// Construct the service options.
 	vmwareURL := ContructEndpoint(fmt.Sprintf("api.%s.vmware", c.Region), cloudEndpoint+"/v1")
+	vmwareURL = c.fileFallBack("IBMCLOUD_VMWARE_API_ENDPOINT", vmwareURL)
 	vmwareClientOptions := &vmwarev1.VmwareV1Options{
 		Authenticator: authenticator,
-		URL:           EnvFallBack([]string{"VMWARE_URL"}, vmwareURL),
+		URL:           EnvFallBack([]string{"IBMCLOUD_VMWARE_API_ENDPOINT", "VMWARE_URL"}, vmwareURL),
 	}
 
 	// Construct the service client.
 	session.vmwareClient, err = vmwarev1.NewVmwareV1(vmwareClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.vmwareClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("vmware", session.vmwareClient.Service)
 		// Add custom header for analytics
 		session.vmwareClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -3502,9 +3532,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	if c.Visibility == "private" || c.Visibility == "public-and-private" {
 		codeEngineEndpoint = ContructEndpoint(fmt.Sprintf("api.private.%s.codeengine", c.Region), cloudEndpoint+"/v2")
 	}
-	if fileMap != nil && c.Visibility != "public-and-private" {
-		codeEngineEndpoint = fileFallBack(fileMap, c.Visibility, "IBMCLOUD_CODE_ENGINE_API_ENDPOINT", c.Region, codeEngineEndpoint)
-	}
+	codeEngineEndpoint = c.fileFallBack("IBMCLOUD_CODE_ENGINE_API_ENDPOINT", codeEngineEndpoint)
 	codeEngineClientOptions := &codeengine.CodeEngineV2Options{
 		Authenticator: authenticator,
 		URL:           EnvFallBack([]string{"IBMCLOUD_CODE_ENGINE_API_ENDPOINT"}, codeEngineEndpoint),
@@ -3514,7 +3542,7 @@ func (c *Config) ClientSession() (interface{}, error) {
 	session.codeEngineClient, err = codeengine.NewCodeEngineV2(codeEngineClientOptions)
 	if err == nil {
 		// Enable retries for API calls
-		session.codeEngineClient.Service.EnableRetries(c.RetryCount, c.RetryDelay)
+		c.enableRetries("code_engine", session.codeEngineClient.Service)
 		// Add custom header for analytics
 		session.codeEngineClient.SetDefaultHeaders(gohttp.Header{
 			"X-Original-User-Agent": {fmt.Sprintf("terraform-provider-ibm/%s", version.Version)},
@@ -3544,13 +3572,13 @@ func newSession(c *Config) (*Session, error) {
 	ibmSession := &Session{}
 
 	softlayerSession := &slsession.Session{
-		Endpoint:  c.SoftLayerEndpointURL,
-		Timeout:   c.SoftLayerTimeout,
-		UserName:  c.SoftLayerUserName,
-		APIKey:    c.SoftLayerAPIKey,
-		Debug:     os.Getenv("TF_LOG") != "",
-		Retries:   c.RetryCount,
-		RetryWait: c.RetryDelay,
+		Endpoint: c.fileFallBack("IBMCLOUD_IAAS_CLASSIC_API_ENDPOINT", c.SoftLayerEndpointURL),
+		Timeout:  c.SoftLayerTimeout,
+		UserName: c.SoftLayerUserName,
+		APIKey:   c.SoftLayerAPIKey,
+		Debug:    os.Getenv("TF_LOG") != "",
+		// requests are retried by the transport of the retry policy
+		HTTPClient: &gohttp.Client{Transport: c.transport("softlayer", DefaultTransport())},
 	}
 
 	if c.IAMToken != "" {
@@ -3572,6 +3600,27 @@ func newSession(c *Config) (*Session, error) {
 	if c.IAMTrustedProfileID != "" && c.IAMToken == "" {
 		return nil, fmt.Errorf("iam_token and iam_profile_id must be provided")
 	}
+	for _, profile := range c.AssumeProfiles {
+		if err := profile.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Requests of the bluemix-go clients are retried by the transport of the retry policy
+	// rather than by the clients themselves.
+	noRetries := 0
+	bluemixClient := &gohttp.Client{
+		Transport: c.transport("bluemix", http.NewTraceLoggingTransport(&gohttp.Transport{
+			Proxy: gohttp.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   50 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout:   20 * time.Second,
+			ResponseHeaderTimeout: c.BluemixTimeout,
+			DisableCompression:    true,
+		})),
+	}
 
 	if c.IAMToken != "" {
 		log.Println("Configuring IBM Cloud Session with token")
@@ -3584,11 +3633,13 @@ func newSession(c *Config) (*Session, error) {
 			HTTPTimeout:   c.BluemixTimeout,
 			Region:        c.Region,
 			ResourceGroup: c.ResourceGroup,
-			RetryDelay:    &c.RetryDelay,
-			MaxRetries:    &c.RetryCount,
+			MaxRetries:    &noRetries,
+			HTTPClient:    bluemixClient,
 			Visibility:    c.Visibility,
 			EndpointsFile: c.EndpointsFile,
-			UserAgent:     fmt.Sprintf("terraform-provider-ibm/%s", version.Version),
+			// the endpoints file is resolved by the provider to support version 2 of its schema
+			EndpointLocator: c.endpointLocator(),
+			UserAgent:       fmt.Sprintf("terraform-provider-ibm/%s", version.Version),
 		}
 		sess, err := bxsession.New(bmxConfig)
 		if err != nil {
@@ -3607,11 +3658,13 @@ func newSession(c *Config) (*Session, error) {
 			HTTPTimeout:   c.BluemixTimeout,
 			Region:        c.Region,
 			ResourceGroup: c.ResourceGroup,
-			RetryDelay:    &c.RetryDelay,
-			MaxRetries:    &c.RetryCount,
+			MaxRetries:    &noRetries,
+			HTTPClient:    bluemixClient,
 			Visibility:    c.Visibility,
 			EndpointsFile: c.EndpointsFile,
-			UserAgent:     fmt.Sprintf("terraform-provider-ibm/%s", version.Version),
+			// the endpoints file is resolved by the provider to support version 2 of its schema
+			EndpointLocator: c.endpointLocator(),
+			UserAgent:       fmt.Sprintf("terraform-provider-ibm/%s", version.Version),
 		}
 		sess, err := bxsession.New(bmxConfig)
 		if err != nil {
@@ -3630,6 +3683,7 @@ func authenticateAPIKey(sess *bxsession.Session) error {
 			"User-Agent":            []string{http.UserAgent()},
 			"X-Original-User-Agent": []string{config.UserAgent},
 		},
+		HTTPClient: config.HTTPClient,
 	})
 	if err != nil {
 		return err
@@ -3688,6 +3742,7 @@ func RefreshToken(sess *bxsession.Session) error {
 			"User-Agent":            []string{http.UserAgent()},
 			"X-Original-User-Agent": []string{config.UserAgent},
 		},
+		HTTPClient: config.HTTPClient,
 	})
 	if err != nil {
 		return err
@@ -3705,36 +3760,27 @@ func EnvFallBack(envs []string, defaultValue string) string {
 	return defaultValue
 }
 
-func FileFallBack(endpointsFile, visibility, key, region, defaultValue string) string {
-	var fileMap map[string]interface{}
-	if f := EnvFallBack([]string{"IBMCLOUD_ENDPOINTS_FILE_PATH", "IC_ENDPOINTS_FILE_PATH"}, endpointsFile); f != "" {
-		jsonFile, err := os.Open(f)
-		if err != nil {
-			log.Fatalf("Unable to open Endpoints File %s", err)
-		}
-		defer jsonFile.Close()
-		bytes, err := io.ReadAll(jsonFile)
-		if err != nil {
-			log.Fatalf("Unable to read Endpoints File %s", err)
-		}
-		err = json.Unmarshal([]byte(bytes), &fileMap)
-		if err != nil {
-			log.Fatalf("Unable to unmarshal Endpoints File %s", err)
+// FileFallBack returns the endpoint the endpoints file of the provider sets for key in
+// region, or defaultValue. It uses the endpoints parsed when the provider was configured,
+// and the zone of the provider for zone overrides when region is the region of the
+// provider.
+func FileFallBack(bmxConfig *bluemix.Config, visibility, key, region, defaultValue string) (string, error) {
+	locator, ok := bmxConfig.EndpointLocator.(*endpointLocator)
+	if !ok {
+		if f := EnvFallBack([]string{"IBMCLOUD_ENDPOINTS_FILE_PATH", "IC_ENDPOINTS_FILE_PATH"}, bmxConfig.EndpointsFile); f != "" {
+			return "", fmt.Errorf("[ERROR] The endpoints file (%s) is not loaded by the session", f)
 		}
+		return defaultValue, nil
 	}
-
-	return fileFallBack(fileMap, visibility, key, region, defaultValue)
-}
-
-func fileFallBack(fileMap map[string]interface{}, visibility, key, region, defaultValue string) string {
-	if val, ok := fileMap[key]; ok {
-		if v, ok := val.(map[string]interface{})[visibility]; ok {
-			if r, ok := v.(map[string]interface{})[region]; ok && r.(string) != "" {
-				return r.(string)
-			}
-		}
+	c := locator.config
+	zone := ""
+	if region == c.Region {
+		zone = c.Zone
 	}
-	return defaultValue
+	if endpoint, ok := c.endpoints.Endpoint(key, visibility, region, zone); ok {
+		return endpoint, nil
+	}
+	return defaultValue, nil
 }
 
 // DefaultTransport ...
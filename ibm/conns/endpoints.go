@@ -0,0 +1,366 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/IBM-Cloud/bluemix-go/endpoints"
+)
+
+// EndpointsFileVersion is the latest version of the schema of the endpoints file. A file
+// without a version is read with the layout of version 1, which is
+//
+//	{"<key>": {"<visibility>": {"<region>": "<url>"}}}
+//
+// Version 2 nests the same mapping under "endpoints", next to "version": 2, and allows the
+// region to be a zone or a wildcard pattern.
+const EndpointsFileVersion = 2
+
+// EndpointsFileKeys are the keys the endpoints file can set the endpoint of a service
+// for. Each key is also the environment variable that overrides the endpoint.
+var EndpointsFileKeys = []string{
+	"IBMCLOUD_ACCOUNT_MANAGEMENT_API_ENDPOINT",
+	"IBMCLOUD_API_GATEWAY_ENDPOINT",
+	"IBMCLOUD_APPID_MANAGEMENT_API_ENDPOINT",
+	"IBMCLOUD_APP_CONFIG_ENDPOINT",
+	"IBMCLOUD_ATRACKER_API_ENDPOINT",
+	"IBMCLOUD_CATALOG_MANAGEMENT_API_ENDPOINT",
+	"IBMCLOUD_CERTIFICATE_MANAGER_API_ENDPOINT",
+	"IBMCLOUD_CF_API_ENDPOINT",
+	"IBMCLOUD_CIS_API_ENDPOINT",
+	"IBMCLOUD_CLOUD_SHELL_API_ENDPOINT",
+	"IBMCLOUD_CODE_ENGINE_API_ENDPOINT",
+	"IBMCLOUD_CONFIGURATION_AGGREGATOR_API_ENDPOINT",
+	"IBMCLOUD_CONTEXT_BASED_RESTRICTIONS_ENDPOINT",
+	"IBMCLOUD_COS_CONFIG_ENDPOINT",
+	"IBMCLOUD_COS_ENDPOINT",
+	"IBMCLOUD_CR_API_ENDPOINT",
+	"IBMCLOUD_CSE_ENDPOINT",
+	"IBMCLOUD_CS_API_ENDPOINT",
+	"IBMCLOUD_DATABASES_API_ENDPOINT",
+	"IBMCLOUD_DL_API_ENDPOINT",
+	"IBMCLOUD_DL_PROVIDER_API_ENDPOINT",
+	"IBMCLOUD_ENTERPRISE_API_ENDPOINT",
+	"IBMCLOUD_EVENT_NOTIFICATIONS_API_ENDPOINT",
+	"IBMCLOUD_FUNCTIONS_API_ENDPOINT",
+	"IBMCLOUD_GS_API_ENDPOINT",
+	"IBMCLOUD_GT_API_ENDPOINT",
+	"IBMCLOUD_HPCS_API_ENDPOINT",
+	"IBMCLOUD_IAAS_CLASSIC_API_ENDPOINT",
+	"IBMCLOUD_IAMPAP_API_ENDPOINT",
+	"IBMCLOUD_IAM_API_ENDPOINT",
+	"IBMCLOUD_ICD_API_ENDPOINT",
+	"IBMCLOUD_IS_NG_API_ENDPOINT",
+	"IBMCLOUD_KP_API_ENDPOINT",
+	"IBMCLOUD_LOGS_API_ENDPOINT",
+	"IBMCLOUD_LOGS_ROUTING_API_ENDPOINT",
+	"IBMCLOUD_MCCP_API_ENDPOINT",
+	"IBMCLOUD_METRICS_ROUTING_API_ENDPOINT",
+	"IBMCLOUD_MQCLOUD_CONFIG_ENDPOINT",
+	"IBMCLOUD_PARTNER_CENTER_SELL_API_ENDPOINT",
+	"IBMCLOUD_PI_API_ENDPOINT",
+	"IBMCLOUD_PRIVATE_DNS_API_ENDPOINT",
+	"IBMCLOUD_PROJECT_API_ENDPOINT",
+	"IBMCLOUD_PUSH_API_ENDPOINT",
+	"IBMCLOUD_RESOURCE_CATALOG_API_ENDPOINT",
+	"IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT",
+	"IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT",
+	"IBMCLOUD_SATELLITE_API_ENDPOINT",
+	"IBMCLOUD_SATELLITE_LINK_API_ENDPOINT",
+	"IBMCLOUD_SAT_API_ENDPOINT",
+	"IBMCLOUD_SCC_API_ENDPOINT",
+	"IBMCLOUD_SCHEMATICS_API_ENDPOINT",
+	"IBMCLOUD_SECRETS_MANAGER_API_ENDPOINT",
+	"IBMCLOUD_TEKTON_PIPELINE_ENDPOINT",
+	"IBMCLOUD_TG_API_ENDPOINT",
+	"IBMCLOUD_TOOLCHAIN_ENDPOINT",
+	"IBMCLOUD_UAA_ENDPOINT",
+	"IBMCLOUD_USAGE_REPORTS_API_ENDPOINT",
+	"IBMCLOUD_USER_MANAGEMENT_ENDPOINT",
+	"IBMCLOUD_VMWARE_API_ENDPOINT",
+}
+
+// EndpointsFile is the mapping of the endpoints file from key to visibility to region,
+// zone or wildcard pattern to URL.
+type EndpointsFile struct {
+	Version   int
+	Endpoints map[string]map[string]map[string]string
+}
+
+// ReadEndpointsFile reads and validates the endpoints file at path.
+func ReadEndpointsFile(path string) (*EndpointsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error reading the endpoints file %s: %s", path, err)
+	}
+	file, err := ParseEndpointsFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error in the endpoints file %s: %s", path, err)
+	}
+	return file, nil
+}
+
+// ParseEndpointsFile parses and validates the contents of an endpoints file. Unknown
+// keys, visibilities and malformed regions or URLs are errors rather than being
+// ignored, so that a typo does not silently fall back to the default endpoint.
+func ParseEndpointsFile(data []byte) (*EndpointsFile, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	file := &EndpointsFile{Version: 1}
+	raw := data
+	if version, ok := fields["version"]; ok {
+		if err := json.Unmarshal(version, &file.Version); err != nil {
+			return nil, fmt.Errorf("version must be a number: %s", err)
+		}
+		if file.Version < 1 || file.Version > EndpointsFileVersion {
+			return nil, fmt.Errorf("unsupported version %d, the latest version is %d", file.Version, EndpointsFileVersion)
+		}
+	}
+	if file.Version > 1 {
+		for field := range fields {
+			if field != "version" && field != "endpoints" {
+				return nil, fmt.Errorf("unknown field %q, the endpoints are set in \"endpoints\"", field)
+			}
+		}
+		if raw = fields["endpoints"]; raw == nil {
+			return nil, fmt.Errorf("missing \"endpoints\"")
+		}
+	} else {
+		delete(fields, "version")
+		raw, _ = json.Marshal(fields)
+	}
+
+	if err := json.Unmarshal(raw, &file.Endpoints); err != nil {
+		return nil, fmt.Errorf("endpoints must map a key to a visibility to a region to a URL: %s", err)
+	}
+	return file, file.validate()
+}
+
+func (f *EndpointsFile) validate() error {
+	visibilities := []string{"public", "private", "public-and-private"}
+	for key, byVisibility := range f.Endpoints {
+		i := sort.SearchStrings(EndpointsFileKeys, key)
+		if i == len(EndpointsFileKeys) || EndpointsFileKeys[i] != key {
+			return fmt.Errorf("unknown key %q", key)
+		}
+		for visibility, byRegion := range byVisibility {
+			if !contains(visibilities, visibility) {
+				return fmt.Errorf("unknown visibility %q of %s, expected one of %s", visibility, key, strings.Join(visibilities, ", "))
+			}
+			for region, endpoint := range byRegion {
+				if f.Version == 1 && strings.Contains(region, "*") {
+					return fmt.Errorf("the region %q of %s %s is a wildcard, which requires version 2", region, key, visibility)
+				}
+				if _, err := path.Match(region, ""); err != nil || region == "" {
+					return fmt.Errorf("invalid region %q of %s %s", region, key, visibility)
+				}
+				placeholders := strings.NewReplacer("{region}", "region", "{zone}", "zone")
+				if u, err := url.Parse(placeholders.Replace(endpoint)); err != nil || endpoint == "" || (u.Scheme != "" && u.Host == "") {
+					return fmt.Errorf("invalid URL %q for %s %s %s", endpoint, key, visibility, region)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Endpoint returns the endpoint the file sets for key in the zone of the region. From
+// version 2, an endpoint of the zone takes precedence over one of the region, which takes
+// precedence over the wildcard patterns matching either of them, of which the longest
+// wins. The placeholders {region} and {zone} in the URL are replaced.
+func (f *EndpointsFile) Endpoint(key, visibility, region, zone string) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	byRegion := f.Endpoints[key][visibility]
+	endpoint, ok := "", false
+	if zone != "" && f.Version > 1 {
+		endpoint, ok = byRegion[zone]
+	}
+	if !ok {
+		endpoint, ok = byRegion[region]
+	}
+	if !ok {
+		var patterns []string
+		for pattern := range byRegion {
+			if strings.Contains(pattern, "*") {
+				patterns = append(patterns, pattern)
+			}
+		}
+		sort.Slice(patterns, func(i, j int) bool {
+			if len(patterns[i]) != len(patterns[j]) {
+				return len(patterns[i]) > len(patterns[j])
+			}
+			return patterns[i] < patterns[j]
+		})
+		for _, pattern := range patterns {
+			if matchRegion(pattern, zone) || matchRegion(pattern, region) {
+				endpoint, ok = byRegion[pattern], true
+				break
+			}
+		}
+	}
+	if !ok {
+		return "", false
+	}
+	return strings.NewReplacer("{region}", region, "{zone}", zone).Replace(endpoint), true
+}
+
+func matchRegion(pattern, region string) bool {
+	matched, _ := path.Match(pattern, region)
+	return region != "" && matched
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// loadEndpointsFile reads the endpoints file of the provider, if one is set.
+func (c *Config) loadEndpointsFile() error {
+	c.endpoints = nil
+	if f := EnvFallBack([]string{"IBMCLOUD_ENDPOINTS_FILE_PATH", "IC_ENDPOINTS_FILE_PATH"}, c.EndpointsFile); f != "" {
+		file, err := ReadEndpointsFile(f)
+		if err != nil {
+			return err
+		}
+		c.endpoints = file
+	}
+	return nil
+}
+
+// fileFallBack returns the endpoint the endpoints file sets for key in the region and
+// zone of the provider, or defaultValue.
+func (c *Config) fileFallBack(key, defaultValue string) string {
+	if endpoint, ok := c.endpoints.Endpoint(key, c.Visibility, c.Region, c.Zone); ok {
+		return endpoint
+	}
+	return defaultValue
+}
+
+// endpointLocator resolves the endpoints of the bluemix-go clients from the endpoints
+// file of the provider before the defaults of bluemix-go. The environment variables
+// keep their precedence.
+type endpointLocator struct {
+	endpoints.EndpointLocator
+	config *Config
+}
+
+func (c *Config) endpointLocator() endpoints.EndpointLocator {
+	return &endpointLocator{
+		EndpointLocator: endpoints.NewEndpointLocator(c.Region, c.Visibility, ""),
+		config:          c,
+	}
+}
+
+func (l *endpointLocator) locate(key string, fallback func() (string, error)) (string, error) {
+	if os.Getenv(key) == "" {
+		if endpoint, ok := l.config.endpoints.Endpoint(key, l.config.Visibility, l.config.Region, l.config.Zone); ok {
+			return endpoint, nil
+		}
+	}
+	return fallback()
+}
+
+func (l *endpointLocator) AccountManagementEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_ACCOUNT_MANAGEMENT_API_ENDPOINT", l.EndpointLocator.AccountManagementEndpoint)
+}
+
+func (l *endpointLocator) CertificateManagerEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_CERTIFICATE_MANAGER_API_ENDPOINT", l.EndpointLocator.CertificateManagerEndpoint)
+}
+
+func (l *endpointLocator) CFAPIEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_CF_API_ENDPOINT", l.EndpointLocator.CFAPIEndpoint)
+}
+
+func (l *endpointLocator) ContainerEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_CS_API_ENDPOINT", l.EndpointLocator.ContainerEndpoint)
+}
+
+func (l *endpointLocator) ContainerRegistryEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_CR_API_ENDPOINT", l.EndpointLocator.ContainerRegistryEndpoint)
+}
+
+func (l *endpointLocator) CisEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_CIS_API_ENDPOINT", l.EndpointLocator.CisEndpoint)
+}
+
+func (l *endpointLocator) GlobalSearchEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_GS_API_ENDPOINT", l.EndpointLocator.GlobalSearchEndpoint)
+}
+
+func (l *endpointLocator) GlobalTaggingEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_GT_API_ENDPOINT", l.EndpointLocator.GlobalTaggingEndpoint)
+}
+
+func (l *endpointLocator) IAMEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_IAM_API_ENDPOINT", l.EndpointLocator.IAMEndpoint)
+}
+
+func (l *endpointLocator) IAMPAPEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_IAMPAP_API_ENDPOINT", l.EndpointLocator.IAMPAPEndpoint)
+}
+
+func (l *endpointLocator) ICDEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_ICD_API_ENDPOINT", l.EndpointLocator.ICDEndpoint)
+}
+
+func (l *endpointLocator) MCCPAPIEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_MCCP_API_ENDPOINT", l.EndpointLocator.MCCPAPIEndpoint)
+}
+
+func (l *endpointLocator) ResourceManagementEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT", l.EndpointLocator.ResourceManagementEndpoint)
+}
+
+func (l *endpointLocator) ResourceControllerEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT", l.EndpointLocator.ResourceControllerEndpoint)
+}
+
+func (l *endpointLocator) ResourceCatalogEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_RESOURCE_CATALOG_API_ENDPOINT", l.EndpointLocator.ResourceCatalogEndpoint)
+}
+
+func (l *endpointLocator) UAAEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_UAA_ENDPOINT", l.EndpointLocator.UAAEndpoint)
+}
+
+func (l *endpointLocator) CseEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_CSE_ENDPOINT", l.EndpointLocator.CseEndpoint)
+}
+
+func (l *endpointLocator) SchematicsEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_SCHEMATICS_API_ENDPOINT", l.EndpointLocator.SchematicsEndpoint)
+}
+
+func (l *endpointLocator) UserManagementEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_USER_MANAGEMENT_ENDPOINT", l.EndpointLocator.UserManagementEndpoint)
+}
+
+func (l *endpointLocator) HpcsEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_HPCS_API_ENDPOINT", l.EndpointLocator.HpcsEndpoint)
+}
+
+func (l *endpointLocator) FunctionsEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_FUNCTIONS_API_ENDPOINT", l.EndpointLocator.FunctionsEndpoint)
+}
+
+func (l *endpointLocator) SatelliteEndpoint() (string, error) {
+	return l.locate("IBMCLOUD_SAT_API_ENDPOINT", l.EndpointLocator.SatelliteEndpoint)
+}
@@ -0,0 +1,195 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	bluemix "github.com/IBM-Cloud/bluemix-go"
+)
+
+func TestEndpointsFileKeysSorted(t *testing.T) {
+	if !sort.StringsAreSorted(EndpointsFileKeys) {
+		t.Fatal("EndpointsFileKeys must be sorted")
+	}
+}
+
+func TestParseEndpointsFileVersion1(t *testing.T) {
+	file, err := ParseEndpointsFile([]byte(`{
+		"IBMCLOUD_IS_NG_API_ENDPOINT": {
+			"private": {"us-south": "https://us-south.private.iaas.example.com/v1"}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Version != 1 {
+		t.Fatalf("expected version 1, got %d", file.Version)
+	}
+	if endpoint, ok := file.Endpoint("IBMCLOUD_IS_NG_API_ENDPOINT", "private", "us-south", "us-south-1"); !ok || endpoint != "https://us-south.private.iaas.example.com/v1" {
+		t.Fatalf("unexpected endpoint %q", endpoint)
+	}
+	if _, ok := file.Endpoint("IBMCLOUD_IS_NG_API_ENDPOINT", "public", "us-south", ""); ok {
+		t.Fatal("expected no public endpoint")
+	}
+	if _, ok := file.Endpoint("IBMCLOUD_IS_NG_API_ENDPOINT", "private", "eu-de", ""); ok {
+		t.Fatal("expected no endpoint in eu-de")
+	}
+}
+
+func TestParseEndpointsFileVersion2(t *testing.T) {
+	file, err := ParseEndpointsFile([]byte(`{
+		"version": 2,
+		"endpoints": {
+			"IBMCLOUD_IS_NG_API_ENDPOINT": {
+				"private": {
+					"*": "https://{region}.private.iaas.example.com/v1",
+					"eu-*": "https://eu.private.iaas.example.com/v1",
+					"eu-de-*": "https://{zone}.private.iaas.example.com/v1",
+					"us-east": "https://us-east.proxy.example.com/v1",
+					"us-east-2": "https://us-east-2.proxy.example.com/v1"
+				}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		region, zone, endpoint string
+	}{
+		{"us-south", "", "https://us-south.private.iaas.example.com/v1"},
+		{"eu-gb", "", "https://eu.private.iaas.example.com/v1"},
+		{"eu-de", "", "https://eu.private.iaas.example.com/v1"},
+		{"eu-de", "eu-de-2", "https://eu-de-2.private.iaas.example.com/v1"},
+		{"us-east", "us-east-1", "https://us-east.proxy.example.com/v1"},
+		{"us-east", "us-east-2", "https://us-east-2.proxy.example.com/v1"},
+	} {
+		endpoint, ok := file.Endpoint("IBMCLOUD_IS_NG_API_ENDPOINT", "private", tc.region, tc.zone)
+		if !ok || endpoint != tc.endpoint {
+			t.Errorf("%s %s: expected %s, got %q", tc.region, tc.zone, tc.endpoint, endpoint)
+		}
+	}
+}
+
+func TestParseEndpointsFileErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		contents, err string
+	}{
+		"unknown key": {
+			`{"IBMCLOUD_IS_NG_API_ENDPIONT": {"private": {"us-south": "https://example.com"}}}`,
+			`unknown key "IBMCLOUD_IS_NG_API_ENDPIONT"`,
+		},
+		"unknown visibility": {
+			`{"IBMCLOUD_IS_NG_API_ENDPOINT": {"privat": {"us-south": "https://example.com"}}}`,
+			`unknown visibility "privat"`,
+		},
+		"wildcard in version 1": {
+			`{"IBMCLOUD_IS_NG_API_ENDPOINT": {"private": {"*": "https://example.com"}}}`,
+			"requires version 2",
+		},
+		"invalid URL": {
+			`{"version": 2, "endpoints": {"IBMCLOUD_IS_NG_API_ENDPOINT": {"private": {"us-south": "https://"}}}}`,
+			"invalid URL",
+		},
+		"invalid pattern": {
+			`{"version": 2, "endpoints": {"IBMCLOUD_IS_NG_API_ENDPOINT": {"private": {"us-[": "https://example.com"}}}}`,
+			"invalid region",
+		},
+		"unsupported version": {
+			`{"version": 3, "endpoints": {}}`,
+			"unsupported version 3",
+		},
+		"unknown field": {
+			`{"version": 2, "endpoints": {}, "IBMCLOUD_IS_NG_API_ENDPOINT": {}}`,
+			`unknown field "IBMCLOUD_IS_NG_API_ENDPOINT"`,
+		},
+		"missing endpoints": {
+			`{"version": 2}`,
+			`missing "endpoints"`,
+		},
+	} {
+		_, err := ParseEndpointsFile([]byte(tc.contents))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, tc.err, err)
+		}
+	}
+}
+
+func TestConfigFileFallBack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.json")
+	contents := `{"version": 2, "endpoints": {"IBMCLOUD_PI_API_ENDPOINT": {"private": {"*": "https://{zone}.power.example.com"}}}}`
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Config{EndpointsFile: path, Visibility: "private", Region: "us-south", Zone: "dal10"}
+	if err := c.loadEndpointsFile(); err != nil {
+		t.Fatal(err)
+	}
+	if endpoint := c.fileFallBack("IBMCLOUD_PI_API_ENDPOINT", "https://default"); endpoint != "https://dal10.power.example.com" {
+		t.Fatalf("unexpected endpoint %s", endpoint)
+	}
+	if endpoint := c.fileFallBack("IBMCLOUD_IS_NG_API_ENDPOINT", "https://default"); endpoint != "https://default" {
+		t.Fatalf("expected the default endpoint, got %s", endpoint)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"IBMCLOUD_PI_API": {}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.loadEndpointsFile(); err == nil {
+		t.Fatal("expected an error for an invalid endpoints file")
+	}
+}
+
+func TestFileFallBack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.json")
+	contents := `{"version": 2, "endpoints": {"IBMCLOUD_COS_ENDPOINT": {"private": {
+		"us-south": "https://s3.private.us-south.example.com",
+		"us-south-1": "https://s3.private.us-south-1.example.com",
+		"eu-*": "https://s3.private.{region}.example.com"
+	}}}}`
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c := &Config{EndpointsFile: path, Visibility: "private", Region: "us-south", Zone: "us-south-1"}
+	if err := c.loadEndpointsFile(); err != nil {
+		t.Fatal(err)
+	}
+	// the endpoints parsed by the provider are used, not the file
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	bmxConfig := &bluemix.Config{EndpointsFile: path, EndpointLocator: c.endpointLocator()}
+
+	for _, tc := range []struct {
+		visibility, region, expected string
+	}{
+		// the zone of the provider is only in the region of the provider
+		{"private", "us-south", "https://s3.private.us-south-1.example.com"},
+		{"private", "eu-de", "https://s3.private.eu-de.example.com"},
+		{"private", "jp-tok", "https://default"},
+		{"public", "us-south", "https://default"},
+	} {
+		endpoint, err := FileFallBack(bmxConfig, tc.visibility, "IBMCLOUD_COS_ENDPOINT", tc.region, "https://default")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if endpoint != tc.expected {
+			t.Errorf("FileFallBack(%s, %s) = %s, expected %s", tc.visibility, tc.region, endpoint, tc.expected)
+		}
+	}
+
+	// a session that isn't configured by the provider has no parsed endpoints
+	if _, err := FileFallBack(&bluemix.Config{EndpointsFile: path}, "private", "IBMCLOUD_COS_ENDPOINT", "us-south", "https://default"); err == nil {
+		t.Error("expected an error for an endpoints file that isn't loaded")
+	}
+	if endpoint, err := FileFallBack(&bluemix.Config{}, "private", "IBMCLOUD_COS_ENDPOINT", "us-south", "https://default"); err != nil || endpoint != "https://default" {
+		t.Errorf("FileFallBack() without endpoints file = %s, %v, expected the default endpoint", endpoint, err)
+	}
+}
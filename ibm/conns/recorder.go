@@ -0,0 +1,485 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	gohttp "net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	jwt "github.com/golang-jwt/jwt"
+)
+
+const (
+	// HTTPRecorderModeRecord appends the HTTP exchanges of the provider to the cassette.
+	HTTPRecorderModeRecord = "record"
+	// HTTPRecorderModeReplay serves the responses recorded in the cassette instead of
+	// sending requests.
+	HTTPRecorderModeReplay = "replay"
+
+	redacted = "[redacted]"
+	// redactedSigningKey signs the access tokens that are written to a cassette in
+	// place of the real ones. The claims of the token are kept, so that the account of
+	// the user is known when the cassette is replayed.
+	redactedSigningKey = "redacted"
+)
+
+// HTTPRecorderModes are the supported modes of the HTTP recorder.
+var HTTPRecorderModes = []string{HTTPRecorderModeRecord, HTTPRecorderModeReplay}
+
+// redactedHeaders are the headers whose values are never written to a cassette.
+var redactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Auth-Token",
+	"X-Auth-Refresh-Token",
+	"X-Auth-User-Token",
+	"X-Auth-Uaa-Token",
+	"X-Api-Key",
+	"Refresh-Token",
+}
+
+// secretEndpoints are the APIs whose JSON bodies carry secrets in fields that are not
+// secret for other APIs, such as the data of a key-value secret of Secrets Manager.
+var secretEndpoints = []struct {
+	host   string
+	fields []string
+}{
+	{"secrets-manager.appdomain.cloud", []string{"data", "intermediate"}},
+}
+
+// unrecordedEndpoints are the APIs whose bodies are never written to a cassette, such as
+// the cluster configurations of the Kubernetes Service that contain the admin key.
+var unrecordedEndpoints = []struct {
+	host string
+	path string
+}{
+	{"containers.cloud.ibm.com", "/config"},
+	{"containers.cloud.ibm.com", "kubeconfig"},
+}
+
+// cassetteInteraction is an HTTP exchange, written to a cassette as one JSON line.
+type cassetteInteraction struct {
+	Service  string           `json:"service"`
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+
+	used bool
+}
+
+type cassetteRequest struct {
+	Method      string        `json:"method"`
+	URL         string        `json:"url"`
+	Headers     gohttp.Header `json:"headers,omitempty"`
+	Body        string        `json:"body,omitempty"`
+	BodyBase64  bool          `json:"body_base64,omitempty"`
+	BodyOmitted bool          `json:"body_omitted,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode  int           `json:"status_code"`
+	Headers     gohttp.Header `json:"headers,omitempty"`
+	Body        string        `json:"body,omitempty"`
+	BodyBase64  bool          `json:"body_base64,omitempty"`
+	BodyOmitted bool          `json:"body_omitted,omitempty"`
+}
+
+// cassette is the file that the HTTP exchanges of all clients of a provider are
+// recorded to or replayed from.
+type cassette struct {
+	path string
+	mode string
+
+	lock         sync.Mutex
+	interactions []*cassetteInteraction
+}
+
+// openRecorder opens the cassette of the HTTP recorder if it is enabled. A cassette is
+// appended to when recording, so that several provider configurations can share it.
+func (c *Config) openRecorder() error {
+	if c.HTTPRecorderFile == "" || c.recorder != nil {
+		return nil
+	}
+	r := &cassette{path: c.HTTPRecorderFile, mode: c.HTTPRecorderMode}
+	switch r.mode {
+	case "", HTTPRecorderModeRecord:
+		r.mode = HTTPRecorderModeRecord
+		// the provider has no hook to close files when it exits, so the cassette is
+		// opened for each interaction that is written
+		file, err := r.open()
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error opening the HTTP recorder file %s: %s", r.path, err)
+		}
+		file.Close()
+		log.Printf("[INFO] Recording the HTTP requests of the provider to %s", r.path)
+	case HTTPRecorderModeReplay:
+		interactions, err := readCassette(r.path)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error reading the HTTP recorder file %s: %s", r.path, err)
+		}
+		r.interactions = interactions
+		log.Printf("[INFO] Replaying %d HTTP requests of the provider from %s", len(interactions), r.path)
+	default:
+		return fmt.Errorf("[ERROR] Unknown HTTP recorder mode %q, must be one of %s", r.mode, strings.Join(HTTPRecorderModes, ", "))
+	}
+	c.recorder = r
+	return nil
+}
+
+func readCassette(path string) ([]*cassetteInteraction, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var interactions []*cassetteInteraction
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		interaction := &cassetteInteraction{}
+		if err := json.Unmarshal(scanner.Bytes(), interaction); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	return interactions, scanner.Err()
+}
+
+// recordTransport returns a round tripper that records the requests of a service to
+// the cassette, or replays them from it, and next if the recorder is disabled.
+func (c *Config) recordTransport(service string, next gohttp.RoundTripper) gohttp.RoundTripper {
+	if c.recorder == nil {
+		return next
+	}
+	if next == nil {
+		next = gohttp.DefaultTransport
+	}
+	return &recorderTransport{service: service, cassette: c.recorder, next: next}
+}
+
+// recordHTTPClient records the requests of a client that is not rate limited or
+// retried by the provider.
+func (c *Config) recordHTTPClient(service string, client *gohttp.Client) {
+	if client != nil {
+		client.Transport = c.recordTransport(service, client.Transport)
+	}
+}
+
+type recorderTransport struct {
+	service  string
+	cassette *cassette
+	next     gohttp.RoundTripper
+}
+
+func (t *recorderTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := cassetteRequest{
+		Method:  req.Method,
+		URL:     redactURL(req.URL),
+		Headers: redactHeaders(req.Header),
+	}
+	recorded.Body, recorded.BodyBase64, recorded.BodyOmitted = recordedBody(req.URL, req.Header.Get("Content-Type"), reqBody)
+
+	if t.cassette.mode == HTTPRecorderModeReplay {
+		interaction := t.cassette.match(recorded)
+		if interaction == nil {
+			return nil, fmt.Errorf("no HTTP response for %s %s of service %s is recorded in %s", recorded.Method, recorded.URL, t.service, t.cassette.path)
+		}
+		return interaction.Response.httpResponse(req)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		// connection errors cannot be replayed, so they are not recorded
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &cassetteInteraction{
+		Service: t.service,
+		Request: recorded,
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    redactHeaders(resp.Header),
+		},
+	}
+	interaction.Response.Body, interaction.Response.BodyBase64, interaction.Response.BodyOmitted = recordedBody(req.URL, resp.Header.Get("Content-Type"), respBody)
+	if err := t.cassette.write(interaction); err != nil {
+		log.Printf("[WARN] Failed to record %s %s to %s: %s", recorded.Method, recorded.URL, t.cassette.path, err)
+	}
+	return resp, nil
+}
+
+func (r *cassette) write(interaction *cassetteInteraction) error {
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	file, err := r.open()
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (r *cassette) open() (*os.File, error) {
+	return os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+}
+
+// match returns the first unused interaction that was recorded for the same method, URL
+// and body, or else for the same method and URL. Requests that were sent more often
+// than recorded, such as the polling of a status, get the last response again.
+func (r *cassette) match(req cassetteRequest) *cassetteInteraction {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var sameURL, last *cassetteInteraction
+	for _, interaction := range r.interactions {
+		if interaction.Request.Method != req.Method || interaction.Request.URL != req.URL {
+			continue
+		}
+		last = interaction
+		if interaction.used {
+			continue
+		}
+		if interaction.Request.Body == req.Body {
+			interaction.used = true
+			return interaction
+		}
+		if sameURL == nil {
+			sameURL = interaction
+		}
+	}
+	if sameURL != nil {
+		sameURL.used = true
+		return sameURL
+	}
+	return last
+}
+
+func (r cassetteResponse) httpResponse(req *gohttp.Request) (*gohttp.Response, error) {
+	body := []byte(r.Body)
+	if r.BodyBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = decoded
+	}
+	return &gohttp.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, gohttp.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody reads the body of a request and leaves a copy of it in place.
+func readRequestBody(req *gohttp.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == gohttp.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// recordedBody returns a body as it is written to a cassette. The secrets of JSON and
+// form bodies are redacted. Other bodies are only recorded if they are XML, such as the
+// responses of the S3 API of Cloud Object Storage, since binary, YAML or plain text
+// bodies like kubeconfig files or uploaded objects cannot be redacted.
+func recordedBody(u *url.URL, contentType string, body []byte) (encoded string, isBase64 bool, omitted bool) {
+	if len(body) == 0 {
+		return "", false, false
+	}
+	for _, endpoint := range unrecordedEndpoints {
+		if strings.Contains(u.Hostname(), endpoint.host) && strings.Contains(strings.ToLower(u.Path), endpoint.path) {
+			return "", false, true
+		}
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case mediaType == "application/x-www-form-urlencoded", mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+	case mediaType == "application/xml", mediaType == "text/xml", strings.HasSuffix(mediaType, "+xml"):
+		if !utf8.Valid(body) {
+			return "", false, true
+		}
+		return string(body), false, false
+	case mediaType == "" && json.Valid(body):
+		// some APIs send JSON without a content type
+	default:
+		return "", false, true
+	}
+	var fields []string
+	for _, endpoint := range secretEndpoints {
+		if strings.Contains(u.Hostname(), endpoint.host) {
+			fields = append(fields, endpoint.fields...)
+		}
+	}
+	encoded, isBase64 = encodeBody(redactBody(mediaType, body, fields...))
+	return encoded, isBase64, false
+}
+
+func encodeBody(body []byte) (string, bool) {
+	if utf8.Valid(body) {
+		return string(body), false
+	}
+	return base64.StdEncoding.EncodeToString(body), true
+}
+
+func redactHeaders(headers gohttp.Header) gohttp.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	redactedCopy := headers.Clone()
+	for _, name := range redactedHeaders {
+		if _, ok := redactedCopy[gohttp.CanonicalHeaderKey(name)]; ok {
+			redactedCopy.Set(name, redacted)
+		}
+	}
+	return redactedCopy
+}
+
+func redactURL(u *url.URL) string {
+	redactedCopy := *u
+	redactedCopy.User = nil
+	if query := u.Query(); len(query) > 0 {
+		for k := range query {
+			if isSecretKey(k) {
+				query.Set(k, redacted)
+			}
+		}
+		redactedCopy.RawQuery = query.Encode()
+	}
+	return redactedCopy.String()
+}
+
+// redactBody redacts the secrets of a JSON or form body, as well as the given secret
+// fields of the API. Other bodies are returned as they are.
+func redactBody(contentType string, body []byte, secretFields ...string) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	if strings.Contains(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for k := range form {
+				if isSecretKey(k) {
+					form.Set(k, redacted)
+				}
+			}
+			return []byte(form.Encode())
+		}
+	}
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	isSecret := func(key string) bool {
+		for _, field := range secretFields {
+			if strings.EqualFold(key, field) {
+				return true
+			}
+		}
+		return isSecretKey(key)
+	}
+	if redactedBody, err := json.Marshal(redactJSON("", value, isSecret)); err == nil {
+		return redactedBody
+	}
+	return body
+}
+
+func redactJSON(key string, value interface{}, isSecret func(string) bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			// everything below a secret is redacted too
+			if isSecret(key) {
+				v[k] = redactJSON(key, field, isSecret)
+			} else {
+				v[k] = redactJSON(k, field, isSecret)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(key, item, isSecret)
+		}
+		return v
+	case string:
+		if !isSecret(key) {
+			return v
+		}
+		if strings.EqualFold(key, "access_token") {
+			return redactAccessToken(v)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+// redactAccessToken replaces an access token with one that carries the same claims but
+// is signed with a well-known key, so it is useless against the APIs.
+func redactAccessToken(token string) string {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil {
+		return redacted
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(redactedSigningKey))
+	if err != nil {
+		return redacted
+	}
+	return signed
+}
+
+// isSecretKey reports whether a JSON field, form field or query parameter holds a secret.
+func isSecretKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	// composed holds the connection strings of Cloud Databases, with the password of the user
+	for _, secret := range []string{"password", "passphrase", "secret", "apikey", "privatekey", "secretaccesskey", "secretdata", "token", "payload", "composed", "certificate", "certificatebase64", "adminkey", "keydata"} {
+		if strings.HasSuffix(normalized, secret) {
+			return true
+		}
+	}
+	return false
+}
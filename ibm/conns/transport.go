@@ -0,0 +1,364 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	gohttp "net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RetryPolicyDefaultMinDelay is the delay before the first retry of a request unless
+// the retry policy sets one.
+const RetryPolicyDefaultMinDelay = 1 * time.Second
+
+// RetryPolicyServices are the names of the services that the retry_policy block of the
+// provider can override the retry policy for. Clients of the same service share one rate
+// limiter.
+var RetryPolicyServices = []string{
+	"api_gateway",
+	"app_configuration",
+	"appid",
+	"atracker",
+	"bluemix",
+	"catalog_management",
+	"cbr",
+	"cd_tekton_pipeline",
+	"cd_toolchain",
+	"cis",
+	"cloud_databases",
+	"cloud_shell",
+	"code_engine",
+	"configuration_aggregator",
+	"container_registry",
+	"cos_config",
+	"direct_link",
+	"dns_services",
+	"enterprise_management",
+	"event_notifications",
+	"event_streams",
+	"global_search",
+	"global_tagging",
+	"iam",
+	"iam_access_groups",
+	"iam_identity",
+	"iam_policy_management",
+	"kms",
+	"logs",
+	"logs_routing",
+	"metrics_router",
+	"mqcloud",
+	"partner_center_sell",
+	"power",
+	"project",
+	"push_notifications",
+	"resource_controller",
+	"resource_manager",
+	"satellite",
+	"schematics",
+	"scc",
+	"secrets_manager",
+	"softlayer",
+	"transit_gateway",
+	"uko",
+	"usage_reports",
+	"vmware",
+	"vpc",
+}
+
+// RetryPolicy configures how the requests to an IBM Cloud service are rate limited and
+// retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int
+	// MaxRetriesSet marks a MaxRetries of zero as set, so that an override can turn
+	// retries off.
+	MaxRetriesSet bool
+	// MinDelay and MaxDelay bound the exponential backoff between two attempts.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// RequestsPerSecond is the rate at which requests are sent to the service, zero
+	// means the requests are not rate limited.
+	RequestsPerSecond float64
+	// Burst is the number of requests that can be sent at once before the rate limit
+	// applies.
+	Burst int
+}
+
+// Merge returns the policy with the settings of override that are not zero, and the
+// MaxRetries of override if it is set.
+func (p RetryPolicy) Merge(override RetryPolicy) RetryPolicy {
+	if override.MaxRetries != 0 || override.MaxRetriesSet {
+		p.MaxRetries = override.MaxRetries
+	}
+	if override.MinDelay != 0 {
+		p.MinDelay = override.MinDelay
+	}
+	if override.MaxDelay != 0 {
+		p.MaxDelay = override.MaxDelay
+	}
+	if override.RequestsPerSecond != 0 {
+		p.RequestsPerSecond = override.RequestsPerSecond
+	}
+	if override.Burst != 0 {
+		p.Burst = override.Burst
+	}
+	return p
+}
+
+// Backoff returns how long to wait before retrying a request that failed with resp.
+// The delay grows exponentially with the attempt, starting at 0, and is jittered so
+// that concurrent requests spread out. The Retry-After header of a 429 or 503 response
+// takes precedence.
+func (p RetryPolicy) Backoff(attempt int, resp *gohttp.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == gohttp.StatusTooManyRequests || resp.StatusCode == gohttp.StatusServiceUnavailable) {
+		if delay, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return delay
+		}
+	}
+
+	delay := p.MaxDelay
+	if attempt < 32 {
+		if d := p.MinDelay << uint(attempt); d > 0 && d < p.MaxDelay {
+			delay = d
+		}
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := gohttp.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// retryPolicy returns the retry policy of a service, which is the retry_policy block of
+// the provider with the overrides for the service applied.
+func (c *Config) retryPolicy(service string) RetryPolicy {
+	policy := RetryPolicy{
+		MaxRetries: c.RetryCount,
+		MinDelay:   RetryPolicyDefaultMinDelay,
+		MaxDelay:   c.RetryDelay,
+	}.Merge(c.RetryPolicy)
+	if override, ok := c.ServiceRetryPolicies[service]; ok {
+		policy = policy.Merge(override)
+	}
+	if policy.MaxDelay < policy.MinDelay {
+		policy.MaxDelay = policy.MinDelay
+	}
+	return policy
+}
+
+// transport returns a round tripper that rate limits and retries the requests to a
+// service according to its retry policy before passing them on to next.
+func (c *Config) transport(service string, next gohttp.RoundTripper) gohttp.RoundTripper {
+	return c.newRetryTransport(service, c.retryPolicy(service), next)
+}
+
+// rateLimitedTransport returns a round tripper that only rate limits the requests to a
+// service, for clients that retry on their own.
+func (c *Config) rateLimitedTransport(service string, next gohttp.RoundTripper) gohttp.RoundTripper {
+	policy := c.retryPolicy(service)
+	policy.MaxRetries = 0
+	return c.newRetryTransport(service, policy, next)
+}
+
+func (c *Config) newRetryTransport(service string, policy RetryPolicy, next gohttp.RoundTripper) *retryTransport {
+	if next == nil {
+		next = gohttp.DefaultTransport
+	}
+	next = c.recordTransport(service, next)
+	if c.rateLimiters == nil {
+		c.rateLimiters = &rateLimiters{limiters: map[string]*rateLimiter{}}
+	}
+	return &retryTransport{
+		service: service,
+		policy:  policy,
+		limiter: c.rateLimiters.get(service, policy),
+		next:    next,
+	}
+}
+
+// httpClientService is implemented by the base service of the clients generated with
+// the IBM Cloud Go SDK core.
+type httpClientService interface {
+	GetHTTPClient() *gohttp.Client
+}
+
+// enableRetries routes the requests of an IBM Cloud Go SDK client through the transport
+// of its service.
+func (c *Config) enableRetries(service string, s httpClientService) {
+	client := s.GetHTTPClient()
+	if client == nil {
+		return
+	}
+	client.Transport = c.transport(service, client.Transport)
+}
+
+// rateLimiters holds the rate limiter of every service, so that all clients of a
+// service share it.
+type rateLimiters struct {
+	lock     sync.Mutex
+	limiters map[string]*rateLimiter
+}
+
+func (r *rateLimiters) get(service string, policy RetryPolicy) *rateLimiter {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if l, ok := r.limiters[service]; ok {
+		return l
+	}
+	l := newRateLimiter(policy.RequestsPerSecond, policy.Burst)
+	r.limiters[service] = l
+	return l
+}
+
+// rateLimiter is a token bucket that can additionally be paused when the service
+// responds that too many requests were sent.
+type rateLimiter struct {
+	limiter *rate.Limiter
+
+	lock  sync.Mutex
+	until time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	limit := rate.Inf
+	if requestsPerSecond > 0 {
+		limit = rate.Limit(requestsPerSecond)
+		if burst <= 0 {
+			burst = int(requestsPerSecond)
+		}
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &rateLimiter{limiter: rate.NewLimiter(limit, burst)}
+}
+
+// pause holds back all requests to the service for the delay.
+func (l *rateLimiter) pause(delay time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if until := time.Now().Add(delay); until.After(l.until) {
+		l.until = until
+	}
+}
+
+// wait blocks until a request can be sent to the service.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.lock.Lock()
+	delay := time.Until(l.until)
+	l.lock.Unlock()
+	if err := sleep(ctx, delay); err != nil {
+		return err
+	}
+	return l.limiter.Wait(ctx)
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryTransport rate limits the requests to a service and retries the ones that fail
+// with a connection error, 429 or a 5xx status code other than 501.
+type retryTransport struct {
+	service string
+	policy  RetryPolicy
+	limiter *rateLimiter
+	next    gohttp.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *gohttp.Request) (*gohttp.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.policy.MaxRetries || ctx.Err() != nil || !retryable(resp, err) {
+			return resp, err
+		}
+
+		// A request can only be sent again if its body can be rewound.
+		if req.Body != nil && req.Body != gohttp.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		delay := t.policy.Backoff(attempt, resp)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			if resp.StatusCode == gohttp.StatusTooManyRequests {
+				t.limiter.pause(delay)
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+		log.Printf("[DEBUG] Retrying %s %s of service %s in %s (%d/%d): %s", req.Method, req.URL.Redacted(), t.service, delay, attempt+1, t.policy.MaxRetries, reason)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func retryable(resp *gohttp.Response, err error) bool {
+	if err != nil {
+		var unknownAuthority x509.UnknownAuthorityError
+		var invalidCertificate x509.CertificateInvalidError
+		return !errors.As(err, &unknownAuthority) && !errors.As(err, &invalidCertificate)
+	}
+	if resp.StatusCode == gohttp.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode >= 500 && resp.StatusCode != gohttp.StatusNotImplemented
+}
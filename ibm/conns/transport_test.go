@@ -0,0 +1,179 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"context"
+	"io"
+	gohttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MinDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			if delay := policy.Backoff(attempt, nil); delay < max/2 || delay > max {
+				t.Fatalf("attempt %d: backoff %s not within [%s, %s]", attempt, delay, max/2, max)
+			}
+		}
+	}
+	if delay := policy.Backoff(100, nil); delay < 500*time.Millisecond || delay > time.Second {
+		t.Fatalf("backoff %s exceeds the maximum delay", delay)
+	}
+}
+
+func TestRetryPolicyBackoffRetryAfter(t *testing.T) {
+	policy := RetryPolicy{MinDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	resp := &gohttp.Response{StatusCode: gohttp.StatusTooManyRequests, Header: gohttp.Header{"Retry-After": {"7"}}}
+	if delay := policy.Backoff(0, resp); delay != 7*time.Second {
+		t.Fatalf("expected Retry-After of 7s, got %s", delay)
+	}
+
+	resp.StatusCode = gohttp.StatusServiceUnavailable
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(gohttp.TimeFormat))
+	if delay := policy.Backoff(0, resp); delay < 58*time.Second || delay > time.Minute {
+		t.Fatalf("expected Retry-After of about 1m, got %s", delay)
+	}
+
+	// Retry-After is only honored on 429 and 503
+	resp.StatusCode = gohttp.StatusInternalServerError
+	if delay := policy.Backoff(0, resp); delay > 100*time.Millisecond {
+		t.Fatalf("expected exponential backoff, got %s", delay)
+	}
+}
+
+func TestConfigRetryPolicy(t *testing.T) {
+	c := &Config{
+		RetryCount:  10,
+		RetryDelay:  5 * time.Second,
+		RetryPolicy: RetryPolicy{MaxDelay: 30 * time.Second, RequestsPerSecond: 20},
+		ServiceRetryPolicies: map[string]RetryPolicy{
+			"vpc": {MaxRetries: 3, RequestsPerSecond: 5},
+			"iam": {MaxRetries: 0, MaxRetriesSet: true},
+		},
+	}
+	expected := RetryPolicy{MaxRetries: 10, MinDelay: RetryPolicyDefaultMinDelay, MaxDelay: 30 * time.Second, RequestsPerSecond: 20}
+	if policy := c.retryPolicy("cis"); policy != expected {
+		t.Fatalf("expected %+v, got %+v", expected, policy)
+	}
+	expected.MaxRetries = 3
+	expected.RequestsPerSecond = 5
+	if policy := c.retryPolicy("vpc"); policy != expected {
+		t.Fatalf("expected %+v, got %+v", expected, policy)
+	}
+	// a max_retries of zero turns the retries off, unlike an unset one
+	if policy := c.retryPolicy("iam"); policy.MaxRetries != 0 {
+		t.Fatalf("expected no retries, got %+v", policy)
+	}
+	c.RetryPolicy = RetryPolicy{MaxRetriesSet: true}
+	if policy := c.retryPolicy("cis"); policy.MaxRetries != 0 {
+		t.Fatalf("expected no retries, got %+v", policy)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("unexpected body %q on attempt %d", body, atomic.LoadInt32(&requests)+1)
+		}
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(gohttp.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(gohttp.StatusBadGateway)
+		default:
+			w.WriteHeader(gohttp.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	c := &Config{RetryPolicy: RetryPolicy{MaxRetries: 2, MinDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}}
+	client := &gohttp.Client{Transport: c.transport("vpc", nil)}
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusOK || requests != 3 {
+		t.Fatalf("expected success after 3 requests, got %d after %d", resp.StatusCode, requests)
+	}
+
+	// the retries are exhausted
+	atomic.StoreInt32(&requests, 0)
+	c = &Config{RetryPolicy: RetryPolicy{MaxRetries: 1, MinDelay: time.Millisecond}}
+	client = &gohttp.Client{Transport: c.transport("vpc", nil)}
+	resp, err = client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusBadGateway || requests != 2 {
+		t.Fatalf("expected 502 after 2 requests, got %d after %d", resp.StatusCode, requests)
+	}
+}
+
+func TestRetryTransportNotRetryable(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(gohttp.StatusNotImplemented)
+	}))
+	defer server.Close()
+
+	c := &Config{RetryPolicy: RetryPolicy{MaxRetries: 3, MinDelay: time.Millisecond}}
+	client := &gohttp.Client{Transport: c.transport("vpc", nil)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if requests != 1 {
+		t.Fatalf("expected a single request, got %d", requests)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	c := &Config{RetryPolicy: RetryPolicy{RequestsPerSecond: 50, Burst: 1}}
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {}))
+	defer server.Close()
+
+	// clients of the same service share the limiter
+	first := &gohttp.Client{Transport: c.transport("cis", nil)}
+	second := &gohttp.Client{Transport: c.transport("cis", nil)}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		for _, client := range []*gohttp.Client{first, second} {
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+		}
+	}
+	// 10 requests at 50 per second with a burst of 1 take at least 180ms
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("requests were not rate limited, 10 requests took %s", elapsed)
+	}
+}
+
+func TestRateLimiterPause(t *testing.T) {
+	l := newRateLimiter(0, 0)
+	l.pause(50 * time.Millisecond)
+	start := time.Now()
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected the limiter to be paused, waited %s", elapsed)
+	}
+}
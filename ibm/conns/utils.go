@@ -4,6 +4,7 @@
 package conns
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -36,3 +37,21 @@ func GetPrivateServiceURLForRegion(region string) (string, error) {
 	}
 	return "", fmt.Errorf("service URL for region '%s' not found", region)
 }
+
+// ErrFrameworkProviderNotConfigured is returned by ephemeral resources that are opened
+// without a ClientSession, which happens when the provider configuration is not known yet.
+var ErrFrameworkProviderNotConfigured = errors.New("the provider is not configured yet, its configuration may depend on values that are not known until apply")
+
+// FrameworkClientSession returns the ClientSession that the framework provider passes to
+// ephemeral resources as provider data. It returns nil before the provider is configured,
+// so Open must check for ErrFrameworkProviderNotConfigured.
+func FrameworkClientSession(providerData interface{}) (ClientSession, error) {
+	if providerData == nil {
+		return nil, nil
+	}
+	session, ok := providerData.(ClientSession)
+	if !ok {
+		return nil, fmt.Errorf("unexpected provider data type %T, expected conns.ClientSession", providerData)
+	}
+	return session, nil
+}
@@ -0,0 +1,151 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package flex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// transactionIDHeaders are the response headers the IBM Cloud services
+// return the transaction ID of a request in, in order of preference.
+var transactionIDHeaders = []string{
+	"X-Global-Transaction-Id",
+	"Transaction-Id",
+	"X-Transaction-Id",
+}
+
+// ProblemRecord is the JSON representation of a TerraformProblem that is
+// written to the problem export file.
+type ProblemRecord struct {
+	Timestamp        string                 `json:"timestamp"`
+	ID               string                 `json:"id"`
+	Summary          string                 `json:"summary"`
+	Severity         string                 `json:"severity"`
+	Resource         string                 `json:"resource"`
+	Operation        string                 `json:"operation"`
+	Discriminator    string                 `json:"discriminator,omitempty"`
+	Component        *core.ProblemComponent `json:"component,omitempty"`
+	OperationID      string                 `json:"operation_id,omitempty"`
+	ServiceErrorCode string                 `json:"service_error_code,omitempty"`
+	StatusCode       int                    `json:"status_code,omitempty"`
+	RequestID        string                 `json:"request_id,omitempty"`
+	CorrelationID    string                 `json:"correlation_id,omitempty"`
+	TransactionID    string                 `json:"transaction_id,omitempty"`
+}
+
+// problemExport holds the export files of the provider configurations of the
+// process, by path. The problems turned into diagnostics by the resources are not
+// tied to a configuration, so each file receives the problems of every
+// configuration.
+var problemExport struct {
+	lock  sync.Mutex
+	files map[string]*os.File
+}
+
+// AddProblemExportFile makes every TerraformProblem that is turned into a
+// diagnostic be appended to the file at path as a line of JSON. The files added
+// by other provider configurations, such as aliases, keep receiving the problems,
+// and a file that is already open is not opened again. An empty path adds
+// nothing.
+func AddProblemExportFile(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	problemExport.lock.Lock()
+	defer problemExport.lock.Unlock()
+
+	if _, ok := problemExport.files[path]; ok {
+		return nil
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error opening the diagnostics export file %s: %s", path, err)
+	}
+	if problemExport.files == nil {
+		problemExport.files = make(map[string]*os.File)
+	}
+	problemExport.files[path] = file
+	return nil
+}
+
+// closeProblemExportFiles stops the export. The files are otherwise open until
+// the provider process exits.
+func closeProblemExportFiles() {
+	problemExport.lock.Lock()
+	defer problemExport.lock.Unlock()
+
+	for path, file := range problemExport.files {
+		file.Close()
+		delete(problemExport.files, path)
+	}
+}
+
+// GetRecord returns the fields of the problem that are exported, including
+// the status code, service error code and request IDs of the HTTP response
+// that caused it, if any.
+func (e *TerraformProblem) GetRecord() ProblemRecord {
+	record := ProblemRecord{
+		Timestamp:     time.Now().UTC().Format(time.RFC3339Nano),
+		ID:            e.GetID(),
+		Summary:       e.Summary,
+		Severity:      string(e.Severity),
+		Resource:      e.Resource,
+		Operation:     e.Operation,
+		Discriminator: e.discriminator,
+		Component:     e.Component,
+	}
+
+	var httpProblem *core.HTTPProblem
+	if errors.As(e, &httpProblem) && httpProblem.Response != nil {
+		record.OperationID = httpProblem.OperationID
+		record.StatusCode = httpProblem.Response.GetStatusCode()
+		// The service error code is only computed for the console message.
+		for _, item := range httpProblem.GetConsoleOrderedMaps().GetMaps() {
+			if code, ok := item.Value.(string); ok && item.Key == "error_code" {
+				record.ServiceErrorCode = code
+			}
+		}
+		headers := httpProblem.Response.GetHeaders()
+		record.RequestID = headers.Get("X-Request-Id")
+		record.CorrelationID = headers.Get("X-Correlation-Id")
+		for _, header := range transactionIDHeaders {
+			if record.TransactionID = headers.Get(header); record.TransactionID != "" {
+				break
+			}
+		}
+	}
+
+	return record
+}
+
+// Export appends the problem to the problem export files, if any are set.
+func (e *TerraformProblem) Export() {
+	problemExport.lock.Lock()
+	defer problemExport.lock.Unlock()
+
+	if len(problemExport.files) == 0 {
+		return
+	}
+	line, err := json.Marshal(e.GetRecord())
+	if err != nil {
+		log.Printf("[WARN] Error encoding the problem %s for the diagnostics export: %s", e.GetID(), err)
+		return
+	}
+	line = append(line, '\n')
+	for path, file := range problemExport.files {
+		// A single write keeps the lines of concurrent provider processes intact.
+		if _, err := file.Write(line); err != nil {
+			log.Printf("[WARN] Error writing the problem %s to the diagnostics export file %s: %s", e.GetID(), path, err)
+		}
+	}
+}
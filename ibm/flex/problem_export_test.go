@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package flex
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerraformProblemGetRecord(t *testing.T) {
+	httpProb := &core.HTTPProblem{
+		IBMProblem:  core.IBMErrorf(nil, core.NewProblemComponent("vpc", "1.0.0"), "VPC not found.", ""),
+		OperationID: "get_vpc",
+		Response: &core.DetailedResponse{
+			StatusCode: 404,
+			Headers: http.Header{
+				"X-Request-Id":            {"request-1"},
+				"X-Global-Transaction-Id": {"transaction-1"},
+			},
+			Result: map[string]interface{}{"errors": []interface{}{map[string]interface{}{"code": "not_found", "message": "VPC not found."}}},
+		},
+	}
+	terraformProb := DiscriminatedTerraformErrorf(httpProb, "Read failed.", "ibm_is_vpc", "read", "get-vpc")
+
+	record := terraformProb.GetRecord()
+	assert.NotEmpty(t, record.Timestamp)
+	assert.Equal(t, terraformProb.GetID(), record.ID)
+	assert.Equal(t, "Read failed.", record.Summary)
+	assert.Equal(t, "error", record.Severity)
+	assert.Equal(t, "ibm_is_vpc", record.Resource)
+	assert.Equal(t, "read", record.Operation)
+	assert.Equal(t, "get-vpc", record.Discriminator)
+	assert.Equal(t, "get_vpc", record.OperationID)
+	assert.Equal(t, "not_found", record.ServiceErrorCode)
+	assert.Equal(t, 404, record.StatusCode)
+	assert.Equal(t, "request-1", record.RequestID)
+	assert.Equal(t, "transaction-1", record.TransactionID)
+
+	// a problem that was not caused by a response
+	record = getPopulatedTerraformProblem().GetRecord()
+	assert.Equal(t, "ibm_some_resource", record.Resource)
+	assert.Zero(t, record.StatusCode)
+	assert.Empty(t, record.RequestID)
+}
+
+func TestTerraformProblemExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "diagnostics.jsonl")
+	assert.Nil(t, AddProblemExportFile(path))
+	defer closeProblemExportFiles()
+
+	getPopulatedTerraformProblem().GetDiag()
+	TerraformErrorf(nil, "Delete failed.", "ibm_some_resource", "delete").GetFrameworkDiag()
+
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+
+	var records []ProblemRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record ProblemRecord
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	assert.Len(t, records, 2)
+	assert.Equal(t, "create", records[0].Operation)
+	assert.Equal(t, "delete", records[1].Operation)
+
+	// nothing is exported once the export is stopped
+	closeProblemExportFiles()
+	getPopulatedTerraformProblem().GetDiag()
+	contents, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, strings.Count(string(contents), "\n"))
+}
+
+func TestTerraformProblemExportConfigurations(t *testing.T) {
+	directory := t.TempDir()
+	first := filepath.Join(directory, "first.jsonl")
+	second := filepath.Join(directory, "second.jsonl")
+	defer closeProblemExportFiles()
+
+	// an alias with another file, the same file, or no file at all keeps the
+	// files of the other configurations open
+	assert.Nil(t, AddProblemExportFile(first))
+	assert.Nil(t, AddProblemExportFile(""))
+	assert.Nil(t, AddProblemExportFile(second))
+	assert.Nil(t, AddProblemExportFile(first))
+
+	getPopulatedTerraformProblem().GetDiag()
+
+	for _, path := range []string{first, second} {
+		contents, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, strings.Count(string(contents), "\n"), path)
+	}
+}
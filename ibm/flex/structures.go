@@ -1236,6 +1236,28 @@ func RoutingRuleRedirectGet(in *s3.Redirect) []map[string]interface{} {
 	return redirect
 }
 
+func CorsRulesGet(in []*s3.CORSRule) []map[string]interface{} {
+	corsRules := make([]map[string]interface{}, 0, len(in))
+	for _, corsRuleValue := range in {
+		rule := make(map[string]interface{})
+
+		rule["allowed_methods"] = aws.StringValueSlice(corsRuleValue.AllowedMethods)
+		rule["allowed_origins"] = aws.StringValueSlice(corsRuleValue.AllowedOrigins)
+		if corsRuleValue.AllowedHeaders != nil {
+			rule["allowed_headers"] = aws.StringValueSlice(corsRuleValue.AllowedHeaders)
+		}
+		if corsRuleValue.ExposeHeaders != nil {
+			rule["expose_headers"] = aws.StringValueSlice(corsRuleValue.ExposeHeaders)
+		}
+		if corsRuleValue.MaxAgeSeconds != nil {
+			rule["max_age_seconds"] = int(aws.Int64Value(corsRuleValue.MaxAgeSeconds))
+		}
+
+		corsRules = append(corsRules, rule)
+	}
+	return corsRules
+}
+
 func FlattenLimits(in *whisk.Limits) []interface{} {
 	att := make(map[string]interface{})
 	if in.Timeout != nil {
@@ -2383,13 +2405,7 @@ func EscapeUrlParm(urlParm string) string {
 	return urlParm
 }
 func GetLocation(instance models.ServiceInstanceV2) string {
-	region := instance.Crn.Region
-	cName := instance.Crn.CName
-	if cName == "bluemix" || cName == "staging" {
-		return region
-	} else {
-		return cName + "-" + region
-	}
+	return CRN{CName: instance.Crn.CName, Region: instance.Crn.Region}.Location()
 }
 
 type CRN struct {
@@ -2444,18 +2460,22 @@ func Parse(s string) (CRN, error) {
 
 	return crn, nil
 }
+
+// Location returns the location of the resource identified by the CRN. Public cloud
+// regions are returned as is, other cloud names are prefixed to the region.
+func (c CRN) Location() string {
+	if c.CName == "bluemix" || c.CName == "staging" {
+		return c.Region
+	}
+	return c.CName + "-" + c.Region
+}
+
 func GetLocationV2(instance rc.ResourceInstance) string {
 	crn, err := Parse(*instance.CRN)
 	if err != nil {
 		log.Fatal(err)
 	}
-	region := crn.Region
-	cName := crn.CName
-	if cName == "bluemix" || cName == "staging" {
-		return region
-	} else {
-		return cName + "-" + region
-	}
+	return crn.Location()
 }
 
 func GetTags(d *schema.ResourceData, meta interface{}) error {
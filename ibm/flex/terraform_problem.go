@@ -6,6 +6,7 @@ import (
 
 	v "github.com/IBM-Cloud/terraform-provider-ibm/version"
 	"github.com/IBM/go-sdk-core/v5/core"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
@@ -16,6 +17,10 @@ type TerraformProblem struct {
 
 	Resource  string
 	Operation string
+
+	// discriminator is kept for the export of the problem, the IBMProblem
+	// does not expose its own.
+	discriminator string
 }
 
 // GetID returns a hash value computed from stable fields in the
@@ -64,9 +69,17 @@ func (e *TerraformProblem) GetDebugOrderedMaps() *core.OrderedMaps {
 // message as the summary. It is used to create a Diagnostics
 // object from a TerraformProblem in the resource/data source code.
 func (e *TerraformProblem) GetDiag() diag.Diagnostics {
+	e.Export()
 	return diag.Errorf("%s", e.GetConsoleMessage())
 }
 
+// GetFrameworkDiag returns the same diagnostic as GetDiag for the
+// terraform-plugin-framework based code, such as ephemeral resources.
+func (e *TerraformProblem) GetFrameworkDiag() fwdiag.Diagnostic {
+	e.Export()
+	return fwdiag.NewErrorDiagnostic(e.GetConsoleMessage(), "")
+}
+
 // TerraformErrorf creates and returns a new instance of `TerraformProblem`
 // with "error" level severity and a blank discriminator - the "caused by"
 // error is used to ensure uniqueness. This is a convenience function to
@@ -82,9 +95,10 @@ func TerraformErrorf(err error, summary, resource, operation string) *TerraformP
 // other problem scenarios in the same resource/operation.
 func DiscriminatedTerraformErrorf(err error, summary, resource, operation, discriminator string) *TerraformProblem {
 	return &TerraformProblem{
-		IBMProblem: core.IBMErrorf(err, getComponentInfo(), summary, discriminator),
-		Resource:   resource,
-		Operation:  operation,
+		IBMProblem:    core.IBMErrorf(err, getComponentInfo(), summary, discriminator),
+		Resource:      resource,
+		Operation:     operation,
+		discriminator: discriminator,
 	}
 }
 
@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+)
+
+var _ function.Function = &CIDROverlapsFunction{}
+
+// CIDROverlapsFunction implements provider::ibm::cidr_overlaps.
+type CIDROverlapsFunction struct{}
+
+func NewCIDROverlapsFunction() function.Function {
+	return &CIDROverlapsFunction{}
+}
+
+func (f *CIDROverlapsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_overlaps"
+}
+
+func (f *CIDROverlapsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check whether two CIDR blocks overlap",
+		Description: "Returns true when the two CIDR blocks share at least one address.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "first",
+				Description: "The first CIDR block, for example 10.240.0.0/18.",
+			},
+			function.StringParameter{
+				Name:        "second",
+				Description: "The second CIDR block.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *CIDROverlapsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var first, second string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &first, &second))
+	if resp.Error != nil {
+		return
+	}
+
+	overlaps, err := validate.CIDRsOverlap(first, second)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(err.Error()))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, overlaps))
+}
@@ -0,0 +1,34 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package function_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	ibmfunction "github.com/IBM-Cloud/terraform-provider-ibm/ibm/function"
+)
+
+func TestCIDROverlapsFunction(t *testing.T) {
+	testCases := []struct {
+		first, second string
+		overlaps      bool
+	}{
+		{"10.240.0.0/18", "10.240.64.0/18", false},
+		{"10.240.0.0/16", "10.240.64.0/18", true},
+		{"10.240.64.0/24", "10.240.0.0/16", true},
+		{"192.168.0.0/24", "192.168.0.0/24", true},
+		{"10.0.0.0/8", "fd00::/8", false},
+	}
+	for _, tc := range testCases {
+		resp := runFunction(t, ibmfunction.NewCIDROverlapsFunction(), types.StringValue(tc.first), types.StringValue(tc.second))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.BoolValue(tc.overlaps), resp.Result.Value(), "%s and %s", tc.first, tc.second)
+	}
+
+	resp := runFunction(t, ibmfunction.NewCIDROverlapsFunction(), types.StringValue("10.0.0.0"), types.StringValue("10.0.0.0/8"))
+	assert.NotNil(t, resp.Error)
+}
@@ -0,0 +1,52 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &CRNLocationFunction{}
+
+// CRNLocationFunction implements provider::ibm::crn_location.
+type CRNLocationFunction struct{}
+
+func NewCRNLocationFunction() function.Function {
+	return &CRNLocationFunction{}
+}
+
+func (f *CRNLocationFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "crn_location"
+}
+
+func (f *CRNLocationFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Location of a Cloud Resource Name",
+		Description: "Returns the location of the resource identified by a CRN, such as us-south, or the cloud name and region for non-public clouds.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "crn",
+				Description: "The CRN of the resource.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CRNLocationFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	crn, err := parseCRN(input)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, crn.Location()))
+}
@@ -0,0 +1,29 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package function_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	ibmfunction "github.com/IBM-Cloud/terraform-provider-ibm/ibm/function"
+)
+
+func TestCRNLocationFunction(t *testing.T) {
+	testCases := map[string]string{
+		"crn:v1:bluemix:public:is:us-south:a/0123456789abcdef::vpc:r006-1234":             "us-south",
+		"crn:v1:staging:public:cloud-object-storage:global:a/0123456789abcdef:instance::": "global",
+		"crn:v1:satellite:public:kms:eu-de:a/0123456789abcdef:instance::":                 "satellite-eu-de",
+	}
+	for crn, location := range testCases {
+		resp := runFunction(t, ibmfunction.NewCRNLocationFunction(), types.StringValue(crn))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.StringValue(location), resp.Result.Value())
+	}
+
+	resp := runFunction(t, ibmfunction.NewCRNLocationFunction(), types.StringValue("not-a-crn"))
+	assert.NotNil(t, resp.Error)
+}
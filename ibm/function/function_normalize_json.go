@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+var _ function.Function = &NormalizeJSONFunction{}
+
+// NormalizeJSONFunction implements provider::ibm::normalize_json.
+type NormalizeJSONFunction struct{}
+
+func NewNormalizeJSONFunction() function.Function {
+	return &NormalizeJSONFunction{}
+}
+
+func (f *NormalizeJSONFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_json"
+}
+
+func (f *NormalizeJSONFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize a JSON document",
+		Description: "Returns the compact form of a JSON document with object keys sorted, so that equivalent documents compare equal.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "The JSON document to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeJSONFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := flex.NormalizeJSONString(input)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("invalid JSON: %s", err)))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalized))
+}
@@ -0,0 +1,22 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package function_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	ibmfunction "github.com/IBM-Cloud/terraform-provider-ibm/ibm/function"
+)
+
+func TestNormalizeJSONFunction(t *testing.T) {
+	resp := runFunction(t, ibmfunction.NewNormalizeJSONFunction(), types.StringValue("{\n  \"b\": [1, 2],\n  \"a\": {\"d\": true, \"c\": null}\n}"))
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, types.StringValue(`{"a":{"c":null,"d":true},"b":[1,2]}`), resp.Result.Value())
+
+	resp = runFunction(t, ibmfunction.NewNormalizeJSONFunction(), types.StringValue("{"))
+	assert.NotNil(t, resp.Error)
+}
@@ -0,0 +1,103 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+var crnAttrTypes = map[string]attr.Type{
+	"scheme":           types.StringType,
+	"version":          types.StringType,
+	"cname":            types.StringType,
+	"ctype":            types.StringType,
+	"service_name":     types.StringType,
+	"region":           types.StringType,
+	"scope_type":       types.StringType,
+	"scope":            types.StringType,
+	"service_instance": types.StringType,
+	"resource_type":    types.StringType,
+	"resource":         types.StringType,
+}
+
+var _ function.Function = &ParseCRNFunction{}
+
+// ParseCRNFunction implements provider::ibm::parse_crn.
+type ParseCRNFunction struct{}
+
+func NewParseCRNFunction() function.Function {
+	return &ParseCRNFunction{}
+}
+
+func (f *ParseCRNFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_crn"
+}
+
+func (f *ParseCRNFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse a Cloud Resource Name",
+		Description: "Splits an IBM Cloud CRN into its segments and returns them as an object.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "crn",
+				Description: "The CRN to parse, for example crn:v1:bluemix:public:is:us-south:a/<account_id>::vpc:<vpc_id>.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: crnAttrTypes,
+		},
+	}
+}
+
+func (f *ParseCRNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	crn, err := parseCRN(input)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+
+	result, diags := types.ObjectValue(crnAttrTypes, map[string]attr.Value{
+		"scheme":           types.StringValue(crn.Scheme),
+		"version":          types.StringValue(crn.Version),
+		"cname":            types.StringValue(crn.CName),
+		"ctype":            types.StringValue(crn.CType),
+		"service_name":     types.StringValue(crn.ServiceName),
+		"region":           types.StringValue(crn.Region),
+		"scope_type":       types.StringValue(crn.ScopeType),
+		"scope":            types.StringValue(crn.Scope),
+		"service_instance": types.StringValue(crn.ServiceInstance),
+		"resource_type":    types.StringValue(crn.ResourceType),
+		"resource":         types.StringValue(crn.Resource),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// parseCRN parses a CRN, rejecting the empty string that flex.Parse accepts.
+func parseCRN(input string) (flex.CRN, error) {
+	if input == "" {
+		return flex.CRN{}, flex.ErrMalformedCRN
+	}
+	crn, err := flex.Parse(input)
+	if err != nil {
+		return flex.CRN{}, fmt.Errorf("invalid CRN %q: %s", input, err)
+	}
+	return crn, nil
+}
@@ -0,0 +1,47 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	ibmfunction "github.com/IBM-Cloud/terraform-provider-ibm/ibm/function"
+)
+
+func TestParseCRNFunction(t *testing.T) {
+	resp := runFunction(t, ibmfunction.NewParseCRNFunction(), types.StringValue("crn:v1:bluemix:public:is:us-south:a/0123456789abcdef::vpc:r006-1234"))
+	assert.Nil(t, resp.Error)
+
+	result := resp.Result.Value().(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("is"), result["service_name"])
+	assert.Equal(t, types.StringValue("us-south"), result["region"])
+	assert.Equal(t, types.StringValue("a"), result["scope_type"])
+	assert.Equal(t, types.StringValue("0123456789abcdef"), result["scope"])
+	assert.Equal(t, types.StringValue("vpc"), result["resource_type"])
+	assert.Equal(t, types.StringValue("r006-1234"), result["resource"])
+
+	resp = runFunction(t, ibmfunction.NewParseCRNFunction(), types.StringValue("crn:v1:bluemix:public:is"))
+	assert.NotNil(t, resp.Error)
+	resp = runFunction(t, ibmfunction.NewParseCRNFunction(), types.StringValue(""))
+	assert.NotNil(t, resp.Error)
+}
+
+// runFunction calls a function directly with the given arguments.
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) *function.RunResponse {
+	ctx := context.Background()
+	definition := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definition)
+
+	result, err := definition.Definition.Return.NewResultData(ctx)
+	assert.Nil(t, err)
+	resp := &function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp
+}
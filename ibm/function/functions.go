@@ -0,0 +1,20 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+// Package function contains the provider-defined functions, which are served by the
+// terraform-plugin-framework half of the provider and called as provider::ibm::<name>.
+package function
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Functions returns the provider-defined functions.
+func Functions() []func() function.Function {
+	return []func() function.Function{
+		NewCIDROverlapsFunction,
+		NewCRNLocationFunction,
+		NewNormalizeJSONFunction,
+		NewParseCRNFunction,
+	}
+}
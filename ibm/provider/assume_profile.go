@@ -0,0 +1,60 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+)
+
+// assumeProfileSchema returns the settings of a trusted profile of the
+// assume_profile block.
+func assumeProfileSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"profile_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The ID of the trusted profile to assume.",
+		},
+		"profile_crn": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The CRN of the trusted profile to assume.",
+		},
+		"profile_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The name of the trusted profile to assume. Requires account_id.",
+		},
+		"account_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The ID of the account of the trusted profile that is assumed by name.",
+		},
+	}
+}
+
+// expandAssumeProfiles returns the trusted profiles of the assume_profile
+// block in the order they are assumed in.
+func expandAssumeProfiles(d *schema.ResourceData) ([]conns.AssumeProfile, error) {
+	var profiles []conns.AssumeProfile
+	for _, v := range d.Get("assume_profile").([]interface{}) {
+		if v == nil {
+			continue
+		}
+		m := v.(map[string]interface{})
+		profile := conns.AssumeProfile{
+			ProfileID:   m["profile_id"].(string),
+			ProfileCRN:  m["profile_crn"].(string),
+			ProfileName: m["profile_name"].(string),
+			AccountID:   m["account_id"].(string),
+		}
+		if err := profile.Validate(); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
@@ -0,0 +1,37 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+)
+
+func TestExpandAssumeProfiles(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"assume_profile": []interface{}{
+			map[string]interface{}{"profile_id": "Profile-1"},
+			map[string]interface{}{"profile_name": "deployer", "account_id": "account-2"},
+		},
+	})
+
+	profiles, err := expandAssumeProfiles(d)
+	assert.Nil(t, err)
+	assert.Equal(t, []conns.AssumeProfile{
+		{ProfileID: "Profile-1"},
+		{ProfileName: "deployer", AccountID: "account-2"},
+	}, profiles)
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"assume_profile": []interface{}{
+			map[string]interface{}{"profile_name": "deployer"},
+		},
+	})
+	_, err = expandAssumeProfiles(d)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,282 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+const (
+	defaultTagsAll       = "tags_all"
+	defaultAccessTagsAll = "access_tags_all"
+)
+
+// defaultTagsResource names the attributes of a resource that the default_tags of the
+// provider are merged into. The tags are attached with the global tagging API to the
+// CRN held by the crn attribute.
+type defaultTagsResource struct {
+	crn        string
+	tags       string
+	accessTags string
+}
+
+// defaultTagsExcluded lists the resources that have a CRN and tags, but do not attach the
+// tags to the CRN with the global tagging API.
+var defaultTagsExcluded = map[string]bool{
+	// the tags are not attached at all
+	"ibm_cr_namespace":   true,
+	"ibm_iam_service_id": true,
+	"ibm_resource_group": true,
+	"ibm_resource_key":   true,
+	// the tags are kept by the catalog management and schematics APIs
+	"ibm_cm_catalog":           true,
+	"ibm_cm_object":            true,
+	"ibm_cm_offering":          true,
+	"ibm_cm_version":           true,
+	"ibm_schematics_action":    true,
+	"ibm_schematics_policy":    true,
+	"ibm_schematics_workspace": true,
+}
+
+// defaultTagsAttributes lists the attributes that can hold the user tags of a resource, in
+// order of preference.
+var defaultTagsAttributes = []string{"tags", "pi_user_tags"}
+
+// newDefaultTagsResource returns the attributes that the default_tags of the provider are
+// merged into, and whether the resource receives them. Resources receive them when they
+// have a CRN and configurable sets of user or access tags, which they attach with the
+// global tagging API, and can be updated when the default tags change.
+func newDefaultTagsResource(name string, r *schema.Resource) (defaultTagsResource, bool) {
+	var t defaultTagsResource
+	if defaultTagsExcluded[name] || (r.Update == nil && r.UpdateContext == nil && r.UpdateWithoutTimeout == nil) {
+		return t, false
+	}
+	resourceSchema := r.Schema
+	for _, crn := range []string{"crn", "resource_crn"} {
+		if s, ok := resourceSchema[crn]; ok && s.Type == schema.TypeString {
+			t.crn = crn
+			break
+		}
+	}
+	for _, tags := range defaultTagsAttributes {
+		if isDefaultTagsAttribute(resourceSchema[tags]) {
+			t.tags = tags
+			break
+		}
+	}
+	if isDefaultTagsAttribute(resourceSchema["access_tags"]) {
+		t.accessTags = "access_tags"
+	}
+	return t, t.crn != "" && (t.tags != "" || t.accessTags != "")
+}
+
+// isDefaultTagsAttribute reports whether the attribute is a configurable set of tags.
+func isDefaultTagsAttribute(s *schema.Schema) bool {
+	if s == nil || s.Type != schema.TypeSet || !s.Optional {
+		return false
+	}
+	elem, ok := s.Elem.(*schema.Schema)
+	return ok && elem.Type == schema.TypeString
+}
+
+// defaultTagsKind is one kind of tag, user or access, of a resource.
+type defaultTagsKind struct {
+	attribute string
+	all       string
+	tagType   string
+	defaults  func(conns.ClientSession) []string
+}
+
+func (t defaultTagsResource) kinds() []defaultTagsKind {
+	var kinds []defaultTagsKind
+	if t.tags != "" {
+		kinds = append(kinds, defaultTagsKind{
+			attribute: t.tags,
+			all:       defaultTagsAll,
+			tagType:   "user",
+			defaults:  conns.ClientSession.DefaultTags,
+		})
+	}
+	if t.accessTags != "" {
+		kinds = append(kinds, defaultTagsKind{
+			attribute: t.accessTags,
+			all:       defaultAccessTagsAll,
+			tagType:   "access",
+			defaults:  conns.ClientSession.DefaultAccessTags,
+		})
+	}
+	return kinds
+}
+
+// defaultTags returns the default tags of the provider, or none if the provider is not
+// configured.
+func (k defaultTagsKind) defaultTags(meta interface{}) *schema.Set {
+	var tags []string
+	if sess, ok := meta.(conns.ClientSession); ok {
+		tags = k.defaults(sess)
+	}
+	return flex.NewStringSet(flex.ResourceIBMVPCHash, tags)
+}
+
+// wrap adds the tags_all and access_tags_all attributes to the wrapped resource and
+// attaches the default tags after the resource is created or updated. The attributes of
+// the resource itself keep only the tags of its configuration, so that the default tags
+// do not show up as a difference on every plan.
+func (t defaultTagsResource) wrap(name string, r *schema.Resource) {
+	resourceSchema := make(map[string]*schema.Schema, len(r.Schema)+2)
+	for k, v := range r.Schema {
+		resourceSchema[k] = v
+	}
+	for _, kind := range t.kinds() {
+		resourceSchema[kind.all] = &schema.Schema{
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         flex.ResourceIBMVPCHash,
+			Description: fmt.Sprintf("All %s tags of the resource, including the default tags of the provider.", kind.tagType),
+		}
+	}
+	r.Schema = resourceSchema
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, diff, meta); err != nil {
+				return err
+			}
+		}
+		return wrapDiffErrors(t.customizeDiff(diff, meta), name)
+	}
+
+	r.CreateContext = t.wrapApply(name, "create", r.CreateContext)
+	r.CreateWithoutTimeout = t.wrapApply(name, "create", r.CreateWithoutTimeout)
+	r.UpdateContext = t.wrapApply(name, "update", r.UpdateContext)
+	r.UpdateWithoutTimeout = t.wrapApply(name, "update", r.UpdateWithoutTimeout)
+	r.ReadContext = t.wrapRead(r.ReadContext)
+	r.ReadWithoutTimeout = t.wrapRead(r.ReadWithoutTimeout)
+}
+
+// customizeDiff plans tags_all as the tags of the configuration merged with the default
+// tags. Existing resources are left alone until default tags are configured, so that
+// upgrading the provider does not plan an update of every tagged resource.
+func (t defaultTagsResource) customizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	for _, kind := range t.kinds() {
+		if !diff.NewValueKnown(kind.attribute) {
+			if err := diff.SetNewComputed(kind.all); err != nil {
+				return err
+			}
+			continue
+		}
+		defaults := kind.defaultTags(meta)
+		o := tagSet(diff.Get(kind.all))
+		if diff.Id() != "" && defaults.Len() == 0 && o.Len() == 0 {
+			continue
+		}
+		all := tagSet(diff.Get(kind.attribute)).Union(defaults)
+		if !all.Equal(o) {
+			if err := diff.SetNew(kind.all, all); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t defaultTagsResource) wrapApply(
+	name, operationName string,
+	function func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if function == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := function(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, wrapError(t.attachDefaultTags(d, meta, operationName == "create"), name, operationName, false)...)
+	}
+}
+
+// attachDefaultTags attaches the default tags that the resource did not attach itself
+// as part of its tags, and detaches the default tags that were removed from the
+// provider configuration.
+func (t defaultTagsResource) attachDefaultTags(d *schema.ResourceData, meta interface{}, isCreate bool) error {
+	for _, kind := range t.kinds() {
+		defaults := kind.defaultTags(meta)
+		o, _ := d.GetChange(kind.all)
+		oldAll := tagSet(o)
+		if !isCreate && defaults.Len() == 0 && oldAll.Len() == 0 {
+			continue
+		}
+		o, n := d.GetChange(kind.attribute)
+		oldTags, newTags := tagSet(o), tagSet(n)
+
+		// the tags of the resource are attached by the resource itself, anything else
+		// in tags_all was attached as a default tag by an earlier apply
+		attached := newTags.Union(oldAll.Difference(oldTags))
+		all := newTags.Union(defaults)
+		if !all.Equal(attached) {
+			crn := d.Get(t.crn).(string)
+			if crn == "" {
+				return fmt.Errorf("[ERROR] Error attaching the default %s tags: the resource has no CRN", kind.tagType)
+			}
+			if err := flex.UpdateGlobalTagsUsingCRN(attached, all, meta, crn, "", kind.tagType); err != nil {
+				return fmt.Errorf("[ERROR] Error attaching the default %s tags: %s", kind.tagType, err)
+			}
+		}
+		d.Set(kind.all, all)
+	}
+	return nil
+}
+
+// wrapRead moves the default tags that the configuration does not set itself from the
+// tags attribute, as read from the API, to tags_all. Resources that do not read their
+// tags back keep the default tags recorded in tags_all.
+func (t defaultTagsResource) wrapRead(
+	function func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if function == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		priorTags := make(map[string]*schema.Set)
+		priorAll := make(map[string]*schema.Set)
+		for _, kind := range t.kinds() {
+			priorTags[kind.attribute] = tagSet(d.Get(kind.attribute))
+			priorAll[kind.all] = tagSet(d.Get(kind.all))
+		}
+
+		diags := function(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+
+		for _, kind := range t.kinds() {
+			tags := tagSet(d.Get(kind.attribute))
+			all := tags.Union(priorAll[kind.all].Difference(priorTags[kind.attribute]))
+			hidden := kind.defaultTags(meta).Difference(priorTags[kind.attribute])
+			d.Set(kind.attribute, tags.Difference(hidden))
+			d.Set(kind.all, all)
+		}
+		return diags
+	}
+}
+
+// tagSet copies a set of tags into a set hashed like tags_all, as set operations only
+// work between sets with the same hash function.
+func tagSet(v interface{}) *schema.Set {
+	var tags []string
+	if set, ok := v.(*schema.Set); ok {
+		tags = flex.ExpandStringList(set.List())
+	}
+	return flex.NewStringSet(flex.ResourceIBMVPCHash, tags)
+}
@@ -0,0 +1,184 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+type defaultTagsSession struct {
+	conns.ClientSession
+	tags      []string
+	protected []conns.ProtectedResources
+}
+
+func (s defaultTagsSession) DefaultTags() []string {
+	return s.tags
+}
+
+func (s defaultTagsSession) DefaultAccessTags() []string {
+	return nil
+}
+
+func (s defaultTagsSession) ProtectedResources() []conns.ProtectedResources {
+	return s.protected
+}
+
+func defaultTagsTestResource(apiTags []string) *schema.Resource {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      flex.ResourceIBMVPCHash,
+			},
+			"crn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if apiTags != nil {
+				d.Set("tags", apiTags)
+			}
+			return nil
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+	}
+	defaultTagsResource{crn: "crn", tags: "tags"}.wrap("ibm_test", r)
+	return r
+}
+
+func sortedTags(v interface{}) []string {
+	tags := flex.ExpandStringList(v.(*schema.Set).List())
+	sort.Strings(tags)
+	return tags
+}
+
+func TestProviderDefaultTagsResources(t *testing.T) {
+	p := Provider()
+	for name, r := range p.ResourcesMap {
+		_, hasCRN := r.Schema["crn"]
+		if _, ok := r.Schema["resource_crn"]; ok {
+			hasCRN = true
+		}
+		if !hasCRN {
+			continue
+		}
+		// every resource with a CRN and configurable tags receives the default tags,
+		// unless it is excluded explicitly
+		for attribute, s := range r.Schema {
+			if (attribute != "tags" && !strings.HasSuffix(attribute, "_tags")) || !s.Optional || defaultTagsExcluded[name] {
+				continue
+			}
+			_, hasAll := r.Schema[defaultTagsAll]
+			_, hasAccessAll := r.Schema[defaultAccessTagsAll]
+			assert.True(t, hasAll || hasAccessAll, "%s: %s is not covered by default_tags", name, attribute)
+		}
+	}
+
+	for name := range defaultTagsExcluded {
+		if assert.Contains(t, p.ResourcesMap, name) {
+			assert.NotContains(t, p.ResourcesMap[name].Schema, defaultTagsAll, name)
+		}
+	}
+	for _, name := range []string{"ibm_is_instance", "ibm_database", "ibm_pi_instance", "ibm_is_vpn_server"} {
+		tagged, ok := newDefaultTagsResource(name, p.ResourcesMap[name])
+		if assert.True(t, ok, name) {
+			for _, kind := range tagged.kinds() {
+				assert.Contains(t, p.ResourcesMap[name].Schema, kind.all, name)
+			}
+		}
+	}
+}
+
+func TestDefaultTagsRead(t *testing.T) {
+	meta := defaultTagsSession{tags: []string{"owner:team-a", "cost-center:42"}}
+	r := defaultTagsTestResource([]string{"env:prod", "owner:team-a", "cost-center:42"})
+
+	d := r.TestResourceData()
+	d.SetId("test")
+	d.Set("tags", []string{"env:prod", "owner:team-a"})
+	assert.False(t, r.ReadContext(context.Background(), d, meta).HasError())
+
+	// owner:team-a is part of the configuration and stays, cost-center:42 is only a default
+	assert.Equal(t, []string{"env:prod", "owner:team-a"}, sortedTags(d.Get("tags")))
+	assert.Equal(t, []string{"cost-center:42", "env:prod", "owner:team-a"}, sortedTags(d.Get(defaultTagsAll)))
+}
+
+func TestDefaultTagsReadWithoutTagRefresh(t *testing.T) {
+	meta := defaultTagsSession{tags: []string{"cost-center:42"}}
+	r := defaultTagsTestResource(nil)
+
+	d := r.TestResourceData()
+	d.SetId("test")
+	d.Set("tags", []string{"env:prod"})
+	d.Set(defaultTagsAll, []string{"env:prod", "cost-center:42"})
+	assert.False(t, r.ReadContext(context.Background(), d, meta).HasError())
+
+	assert.Equal(t, []string{"env:prod"}, sortedTags(d.Get("tags")))
+	assert.Equal(t, []string{"cost-center:42", "env:prod"}, sortedTags(d.Get(defaultTagsAll)))
+}
+
+func TestDefaultTagsDiff(t *testing.T) {
+	ctx := context.Background()
+	r := defaultTagsTestResource(nil)
+	state := &terraform.InstanceState{
+		ID: "test",
+		Attributes: map[string]string{
+			"id":         "test",
+			"name":       "test",
+			"crn":        "crn:v1:bluemix:public:is:us-south:a/0123456789abcdef::vpc:r006-1234",
+			"tags.#":     "1",
+			"tags.0":     "env:prod",
+			"tags_all.#": "1",
+			"tags_all.0": "env:prod",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "test",
+		"tags": []interface{}{"env:prod"},
+	})
+
+	// without default tags an existing resource is not updated
+	diff, err := r.Diff(ctx, state, config, defaultTagsSession{})
+	assert.Nil(t, err)
+	assert.True(t, diff == nil || diff.Empty())
+
+	// default tags are planned into tags_all, not tags
+	diff, err = r.Diff(ctx, state, config, defaultTagsSession{tags: []string{"cost-center:42"}})
+	assert.Nil(t, err)
+	if assert.NotNil(t, diff) {
+		assert.Equal(t, "2", diff.Attributes["tags_all.#"].New)
+		for k := range diff.Attributes {
+			assert.NotRegexp(t, `^tags\.`, k)
+		}
+	}
+
+	// once tags_all matches, the plan is empty
+	state.Attributes["tags_all.#"] = "2"
+	state.Attributes["tags_all.1"] = "cost-center:42"
+	diff, err = r.Diff(ctx, state, config, defaultTagsSession{tags: []string{"cost-center:42"}})
+	assert.Nil(t, err)
+	assert.True(t, diff == nil || diff.Empty())
+}
@@ -0,0 +1,219 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	ibmfunction "github.com/IBM-Cloud/terraform-provider-ibm/ibm/function"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/iamidentity"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/kubernetes"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/secretsmanager"
+	"github.com/IBM-Cloud/terraform-provider-ibm/version"
+)
+
+var (
+	_ fwprovider.Provider                       = &FrameworkProvider{}
+	_ fwprovider.ProviderWithFunctions          = &FrameworkProvider{}
+	_ fwprovider.ProviderWithEphemeralResources = &FrameworkProvider{}
+)
+
+// FrameworkProvider is the terraform-plugin-framework half of the provider. It serves the
+// features that SDKv2 cannot, such as provider-defined functions and ephemeral resources,
+// and is muxed with the SDKv2 provider by ProviderServer. Its configuration schema is
+// derived from the SDKv2 provider so that both halves accept the same provider block.
+type FrameworkProvider struct {
+	sdkProvider    *schema.Provider
+	providerSchema *tfprotov5.Schema
+}
+
+// NewFrameworkProvider returns the framework provider paired with sdkProvider, whose
+// protocol schema is providerSchema.
+func NewFrameworkProvider(sdkProvider *schema.Provider, providerSchema *tfprotov5.Schema) fwprovider.Provider {
+	return &FrameworkProvider{
+		sdkProvider:    sdkProvider,
+		providerSchema: providerSchema,
+	}
+}
+
+func (p *FrameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "ibm"
+	resp.Version = version.Version
+}
+
+func (p *FrameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	resp.Schema = frameworkProviderSchema(p.providerSchema)
+}
+
+// Configure shares the client session of the SDKv2 provider, which the muxer configures
+// first, so that both halves talk to IBM Cloud with the same credentials and endpoints.
+func (p *FrameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	resp.EphemeralResourceData = p.sdkProvider.Meta()
+}
+
+func (p *FrameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *FrameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *FrameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		iamidentity.NewIBMIAMAccessTokenEphemeralResource,
+		kubernetes.NewIBMContainerClusterCredentialsEphemeralResource,
+		secretsmanager.NewIbmSmSecretEphemeralResource,
+	}
+}
+
+func (p *FrameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return ibmfunction.Functions()
+}
+
+// ProviderServer returns a factory for the protocol version 5 server that muxes the
+// SDKv2 provider with the framework provider.
+func ProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	sdkProvider := Provider()
+	sdkServer := schema.NewGRPCProviderServer(sdkProvider)
+	schemaResp, err := sdkServer.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	frameworkServer := providerserver.NewProtocol5(NewFrameworkProvider(sdkProvider, schemaResp.Provider))
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		func() tfprotov5.ProviderServer {
+			return &protectedResourcesServer{
+				ProviderServer: sdkServer,
+				provider:       sdkProvider,
+			}
+		},
+		func() tfprotov5.ProviderServer {
+			return &frameworkProviderServer{
+				ProviderServer: frameworkServer(),
+				providerSchema: schemaResp.Provider,
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProviderServer reports the SDKv2 provider schema verbatim. The framework
+// cannot express every SDKv2 detail, such as MaxItems on nested blocks, and the muxer
+// requires both halves to report identical provider schemas.
+type frameworkProviderServer struct {
+	tfprotov5.ProviderServer
+	providerSchema *tfprotov5.Schema
+}
+
+func (s *frameworkProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if resp != nil {
+		resp.Provider = s.providerSchema
+	}
+	return resp, err
+}
+
+// frameworkProviderSchema converts the SDKv2 provider schema into a framework schema of
+// the same type, so that the framework can decode the provider block. Deprecations are
+// left out as the SDKv2 provider already warns about them.
+func frameworkProviderSchema(providerSchema *tfprotov5.Schema) fwschema.Schema {
+	if providerSchema == nil || providerSchema.Block == nil {
+		return fwschema.Schema{}
+	}
+	attributes, blocks := frameworkSchemaBlock(providerSchema.Block)
+	return fwschema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+}
+
+func frameworkSchemaBlock(block *tfprotov5.SchemaBlock) (map[string]fwschema.Attribute, map[string]fwschema.Block) {
+	attributes := make(map[string]fwschema.Attribute, len(block.Attributes))
+	for _, a := range block.Attributes {
+		attributes[a.Name] = frameworkSchemaAttribute(a)
+	}
+
+	blocks := make(map[string]fwschema.Block, len(block.BlockTypes))
+	for _, b := range block.BlockTypes {
+		nestedAttributes, nestedBlocks := frameworkSchemaBlock(b.Block)
+		nested := fwschema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+		switch b.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[b.TypeName] = fwschema.SetNestedBlock{NestedObject: nested, Description: b.Block.Description}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			blocks[b.TypeName] = fwschema.SingleNestedBlock{Attributes: nestedAttributes, Blocks: nestedBlocks, Description: b.Block.Description}
+		default:
+			blocks[b.TypeName] = fwschema.ListNestedBlock{NestedObject: nested, Description: b.Block.Description}
+		}
+	}
+	return attributes, blocks
+}
+
+func frameworkSchemaAttribute(a *tfprotov5.SchemaAttribute) fwschema.Attribute {
+	switch t := frameworkAttrType(a.Type).(type) {
+	case basetypes.StringType:
+		return fwschema.StringAttribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive}
+	case basetypes.BoolType:
+		return fwschema.BoolAttribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive}
+	case basetypes.NumberType:
+		return fwschema.NumberAttribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive}
+	case basetypes.ListType:
+		return fwschema.ListAttribute{ElementType: t.ElemType, Description: a.Description, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive}
+	case basetypes.SetType:
+		return fwschema.SetAttribute{ElementType: t.ElemType, Description: a.Description, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive}
+	case basetypes.MapType:
+		return fwschema.MapAttribute{ElementType: t.ElemType, Description: a.Description, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive}
+	case basetypes.ObjectType:
+		return fwschema.ObjectAttribute{AttributeTypes: t.AttrTypes, Description: a.Description, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive}
+	default:
+		return fwschema.DynamicAttribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive}
+	}
+}
+
+func frameworkAttrType(t tftypes.Type) attr.Type {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType
+	case t.Is(tftypes.Bool):
+		return types.BoolType
+	case t.Is(tftypes.Number):
+		return types.NumberType
+	case t.Is(tftypes.List{}):
+		return types.ListType{ElemType: frameworkAttrType(t.(tftypes.List).ElementType)}
+	case t.Is(tftypes.Set{}):
+		return types.SetType{ElemType: frameworkAttrType(t.(tftypes.Set).ElementType)}
+	case t.Is(tftypes.Map{}):
+		return types.MapType{ElemType: frameworkAttrType(t.(tftypes.Map).ElementType)}
+	case t.Is(tftypes.Object{}):
+		attrTypes := map[string]attr.Type{}
+		for name, attrType := range t.(tftypes.Object).AttributeTypes {
+			attrTypes[name] = frameworkAttrType(attrType)
+		}
+		return types.ObjectType{AttrTypes: attrTypes}
+	}
+	return types.DynamicType
+}
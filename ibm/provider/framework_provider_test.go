@@ -0,0 +1,72 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/provider"
+)
+
+func TestProviderServer(t *testing.T) {
+	ctx := context.Background()
+	serverFactory, err := provider.ProviderServer(ctx)
+	assert.Nil(t, err)
+	server := serverFactory()
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, schemaResp.Diagnostics)
+	assert.Contains(t, schemaResp.ResourceSchemas, "ibm_is_vpc")
+	for _, name := range []string{"parse_crn", "crn_location", "cidr_overlaps", "normalize_json"} {
+		assert.Contains(t, schemaResp.Functions, name)
+	}
+	for _, name := range []string{"ibm_iam_access_token", "ibm_container_cluster_credentials", "ibm_sm_secret"} {
+		assert.Contains(t, schemaResp.EphemeralResourceSchemas, name)
+	}
+
+	arg, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, "crn:v1:bluemix:public:is:us-south:a/0123456789abcdef::vpc:r006-1234"))
+	assert.Nil(t, err)
+	callResp, err := server.CallFunction(ctx, &tfprotov5.CallFunctionRequest{
+		Name:      "crn_location",
+		Arguments: []*tfprotov5.DynamicValue{&arg},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, callResp.Error)
+
+	result, err := callResp.Result.Unmarshal(tftypes.String)
+	assert.Nil(t, err)
+	var location string
+	assert.Nil(t, result.As(&location))
+	assert.Equal(t, "us-south", location)
+}
+
+func TestProviderServerValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	serverFactory, err := provider.ProviderServer(ctx)
+	assert.Nil(t, err)
+	server := serverFactory()
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	assert.Nil(t, err)
+	configType := schemaResp.Provider.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range configType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["region"] = tftypes.NewValue(tftypes.String, "us-south")
+	config, err := tfprotov5.NewDynamicValue(configType, tftypes.NewValue(configType, values))
+	assert.Nil(t, err)
+
+	resp, err := server.PrepareProviderConfig(ctx, &tfprotov5.PrepareProviderConfigRequest{Config: &config})
+	assert.Nil(t, err)
+	for _, diag := range resp.Diagnostics {
+		assert.NotEqual(t, tfprotov5.DiagnosticSeverityError, diag.Severity, diag.Summary+": "+diag.Detail)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+)
+
+// protectedResourceTagAttributes are the attributes that the tags of a protected_resources
+// block are matched against.
+var protectedResourceTagAttributes = []string{"tags", "access_tags", defaultTagsAll, defaultAccessTagsAll, "pi_user_tags"}
+
+func validateResourceTypePattern(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := path.Match(v.(string), ""); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid resource type pattern: %s", v, err))
+	}
+	return
+}
+
+// resourceState is implemented by both schema.ResourceData and schema.ResourceDiff.
+type resourceState interface {
+	GetChange(string) (interface{}, interface{})
+}
+
+// protectResource makes the wrapped resource refuse to be deleted when it is selected by
+// the protected_resources configuration of the provider. Replacements are refused by
+// protectedResourcesServer, which sees the plan once the SDK has computed it.
+func protectResource(name string, r *schema.Resource) {
+	resourceSchema := r.Schema
+
+	r.DeleteContext = protectDelete(name, resourceSchema, r.DeleteContext)
+	r.DeleteWithoutTimeout = protectDelete(name, resourceSchema, r.DeleteWithoutTimeout)
+}
+
+func protectDelete(
+	name string,
+	resourceSchema map[string]*schema.Schema,
+	function func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if function == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if protected, ok := protectedBy(meta, name, resourceSchema, d); ok {
+			log.Printf("[DEBUG] Resource %s %s is protected by the provider configuration", name, d.Id())
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Deletion of %s %s is refused, the resource %s", name, d.Id(), protectionReason(protected)),
+					Detail:   "Remove the resource from protected_resources in the provider configuration, apply and then destroy if deletion should proceed",
+				},
+			}
+		}
+		return function(ctx, d, meta)
+	}
+}
+
+// protectedBy returns the protected_resources block of the provider that selects the
+// resource, judging by its type and the tags in its state.
+func protectedBy(meta interface{}, resourceType string, resourceSchema map[string]*schema.Schema, d resourceState) (conns.ProtectedResources, bool) {
+	sess, ok := meta.(conns.ClientSession)
+	if !ok {
+		return conns.ProtectedResources{}, false
+	}
+	for _, protected := range sess.ProtectedResources() {
+		if !matchResourceType(protected.ResourceTypes, resourceType) {
+			continue
+		}
+		if len(protected.Tags) == 0 {
+			return protected, true
+		}
+
+		tags := tagSet(nil)
+		for _, k := range protectedResourceTagAttributes {
+			if s, ok := resourceSchema[k]; ok && s.Type == schema.TypeSet {
+				o, _ := d.GetChange(k)
+				tags = tags.Union(tagSet(o))
+			}
+		}
+		selected := true
+		for _, tag := range protected.Tags {
+			selected = selected && tags.Contains(tag)
+		}
+		if selected {
+			return protected, true
+		}
+	}
+	return conns.ProtectedResources{}, false
+}
+
+func matchResourceType(patterns []string, resourceType string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, resourceType); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func protectionReason(protected conns.ProtectedResources) string {
+	reason := fmt.Sprintf("is protected by the protected_resources block of the provider for %s", strings.Join(protected.ResourceTypes, ", "))
+	if len(protected.Tags) > 0 {
+		reason += fmt.Sprintf(" tagged %s", strings.Join(protected.Tags, ", "))
+	}
+	return reason
+}
+
+// protectedResourcesServer refuses plans that replace a resource selected by the
+// protected_resources configuration of the provider. It relies on the attributes that the
+// SDK reports as requiring replacement, so that a replacement forced by the CustomizeDiff
+// of a resource is refused as well as a change of a ForceNew attribute.
+type protectedResourcesServer struct {
+	tfprotov5.ProviderServer
+	provider *schema.Provider
+}
+
+func (s *protectedResourcesServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil || len(resp.RequiresReplace) == 0 || req.PriorState == nil || len(req.PriorState.MsgPack) == 0 {
+		return resp, err
+	}
+	res, ok := s.provider.ResourcesMap[req.TypeName]
+	if !ok {
+		return resp, nil
+	}
+
+	prior, err := msgpack.Unmarshal(req.PriorState.MsgPack, res.CoreConfigSchema().ImpliedType())
+	if err != nil || prior.IsNull() {
+		return resp, nil
+	}
+	state, err := res.ShimInstanceStateFromValue(prior)
+	if err != nil {
+		return resp, nil
+	}
+	d := res.Data(state)
+	if protected, ok := protectedBy(s.provider.Meta(), req.TypeName, res.Schema, d); ok {
+		err := fmt.Errorf("changing %s requires replacing %s, which %s. Remove the resource from protected_resources and apply before replacing it", strings.Join(requiresReplaceAttributes(resp.RequiresReplace), ", "), d.Id(), protectionReason(protected))
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  wrapDiffErrors(err, req.TypeName).Error(),
+		})
+	}
+	return resp, nil
+}
+
+// requiresReplaceAttributes returns the sorted top level attributes of the paths that
+// require replacing the resource. The id, which the SDK always reports, is left out.
+func requiresReplaceAttributes(paths []*tftypes.AttributePath) []string {
+	var attributes []string
+	seen := map[string]bool{}
+	for _, p := range paths {
+		if p == nil || len(p.Steps()) == 0 {
+			continue
+		}
+		name, ok := p.Steps()[0].(tftypes.AttributeName)
+		if !ok || name == "id" || seen[string(name)] {
+			continue
+		}
+		seen[string(name)] = true
+		attributes = append(attributes, string(name))
+	}
+	sort.Strings(attributes)
+	return attributes
+}
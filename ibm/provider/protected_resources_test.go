@@ -0,0 +1,149 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+func protectedTestResource(deleted *bool) *schema.Resource {
+	return wrapResource("ibm_kms_key", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      flex.ResourceIBMVPCHash,
+			},
+		},
+		// rotating the key replaces it, as ibm_kms_key does for some of its changes
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			if diff.Id() != "" && diff.Get("description").(string) == "rotate" {
+				return diff.ForceNew("description")
+			}
+			return nil
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			*deleted = true
+			return nil
+		},
+	})
+}
+
+func TestProtectedResourcesDelete(t *testing.T) {
+	for name, tc := range map[string]struct {
+		protected []conns.ProtectedResources
+		refused   bool
+	}{
+		"no protection":    {},
+		"exact type":       {protected: []conns.ProtectedResources{{ResourceTypes: []string{"ibm_kms_key"}}}, refused: true},
+		"type pattern":     {protected: []conns.ProtectedResources{{ResourceTypes: []string{"ibm_database", "ibm_kms_*"}}}, refused: true},
+		"other type":       {protected: []conns.ProtectedResources{{ResourceTypes: []string{"ibm_cos_bucket"}}}},
+		"matching tags":    {protected: []conns.ProtectedResources{{ResourceTypes: []string{"ibm_*"}, Tags: []string{"ENV:PROD"}}}, refused: true},
+		"missing tag":      {protected: []conns.ProtectedResources{{ResourceTypes: []string{"ibm_*"}, Tags: []string{"env:prod", "tier:1"}}}},
+		"any of the block": {protected: []conns.ProtectedResources{{ResourceTypes: []string{"ibm_database"}}, {ResourceTypes: []string{"ibm_kms_key"}}}, refused: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var deleted bool
+			r := protectedTestResource(&deleted)
+			d := r.Data(&terraform.InstanceState{
+				ID: "key-1",
+				Attributes: map[string]string{
+					"id":       "key-1",
+					"key_name": "root",
+					"tags.#":   "1",
+					"tags.0":   "env:prod",
+				},
+			})
+
+			diags := r.DeleteContext(context.Background(), d, defaultTagsSession{protected: tc.protected})
+			assert.Equal(t, tc.refused, diags.HasError())
+			assert.Equal(t, !tc.refused, deleted)
+		})
+	}
+}
+
+func TestProtectedResourcesReplace(t *testing.T) {
+	ctx := context.Background()
+	var deleted bool
+	r := protectedTestResource(&deleted)
+	ty := r.CoreConfigSchema().ImpliedType()
+	dynamicValue := func(keyName, description string, id cty.Value) *tfprotov5.DynamicValue {
+		b, err := msgpack.Marshal(cty.ObjectVal(map[string]cty.Value{
+			"id":          id,
+			"key_name":    cty.StringVal(keyName),
+			"description": cty.StringVal(description),
+			"tags":        cty.NullVal(cty.Set(cty.String)),
+		}), ty)
+		assert.Nil(t, err)
+		return &tfprotov5.DynamicValue{MsgPack: b}
+	}
+	protected := defaultTagsSession{protected: []conns.ProtectedResources{{ResourceTypes: []string{"ibm_kms_key"}}}}
+
+	for name, tc := range map[string]struct {
+		keyName     string
+		description string
+		meta        defaultTagsSession
+		refused     string
+	}{
+		"in-place update":          {keyName: "root", description: "new", meta: protected},
+		"forced by the schema":     {keyName: "other", description: "old", meta: protected, refused: "changing key_name requires replacing key-1"},
+		"forced by customize diff": {keyName: "root", description: "rotate", meta: protected, refused: "changing description requires replacing key-1"},
+		"not protected":            {keyName: "other", description: "rotate"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := &schema.Provider{ResourcesMap: map[string]*schema.Resource{"ibm_kms_key": r}}
+			p.SetMeta(tc.meta)
+			server := &protectedResourcesServer{ProviderServer: schema.NewGRPCProviderServer(p), provider: p}
+
+			resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+				TypeName:         "ibm_kms_key",
+				PriorState:       dynamicValue("root", "old", cty.StringVal("key-1")),
+				ProposedNewState: dynamicValue(tc.keyName, tc.description, cty.StringVal("key-1")),
+				Config:           dynamicValue(tc.keyName, tc.description, cty.NullVal(cty.String)),
+			})
+			assert.Nil(t, err)
+			if tc.refused == "" {
+				for _, d := range resp.Diagnostics {
+					assert.NotEqual(t, tfprotov5.DiagnosticSeverityError, d.Severity, d.Summary)
+				}
+				return
+			}
+			if assert.Len(t, resp.Diagnostics, 1) {
+				assert.Equal(t, tfprotov5.DiagnosticSeverityError, resp.Diagnostics[0].Severity)
+				assert.Contains(t, resp.Diagnostics[0].Summary, tc.refused)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
@@ -176,6 +177,25 @@ func Provider() *schema.Provider {
 				Description: "The retry count to set for API calls.",
 				DefaultFunc: schema.EnvDefaultFunc("MAX_RETRIES", 10),
 			},
+			"http_recorder_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The file that the HTTP requests and responses of the provider are recorded to, with secrets redacted, or replayed from.",
+				DefaultFunc: schema.EnvDefaultFunc("IBMCLOUD_HTTP_RECORDER_FILE", nil),
+			},
+			"http_recorder_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(conns.HTTPRecorderModes, false),
+				Description:  "Whether to record the HTTP requests to http_recorder_file or to replay the responses recorded in it.",
+				DefaultFunc:  schema.EnvDefaultFunc("IBMCLOUD_HTTP_RECORDER_MODE", conns.HTTPRecorderModeRecord),
+			},
+			"diagnostics_export_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The file that every error of the provider is appended to as a line of JSON.",
+				DefaultFunc: schema.EnvDefaultFunc("IBMCLOUD_DIAGNOSTICS_EXPORT_FILE", nil),
+			},
 			"function_namespace": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -215,6 +235,14 @@ func Provider() *schema.Provider {
 				Description: "IAM Authentication refresh token",
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_IAM_REFRESH_TOKEN", "IBMCLOUD_IAM_REFRESH_TOKEN"}, nil),
 			},
+			"assume_profile": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Trusted profiles that are assumed in turn, possibly in other accounts. The provider authenticates as the last one.",
+				Elem: &schema.Resource{
+					Schema: assumeProfileSchema(),
+				},
+			},
 			"visibility": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -228,6 +256,78 @@ func Provider() *schema.Provider {
 				Description: "Path of the file that contains private and public regional endpoints mapping",
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_ENDPOINTS_FILE_PATH", "IBMCLOUD_ENDPOINTS_FILE_PATH"}, nil),
 			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags that are attached to every resource that supports tagging, in addition to the tags of the resource.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         flex.ResourceIBMVPCHash,
+							Description: "User tags that are attached to every resource that supports user tags.",
+						},
+						"access_tags": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         flex.ResourceIBMVPCHash,
+							Description: "Access management tags that are attached to every resource that supports access tags.",
+						},
+					},
+				},
+			},
+			"protected_resources": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Resources that the provider refuses to delete or replace.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_types": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateResourceTypePattern},
+							Set:         schema.HashString,
+							Description: "Resource type patterns, such as ibm_database or ibm_kms_*.",
+						},
+						"tags": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         flex.ResourceIBMVPCHash,
+							Description: "Only protect the resources that carry all of these user or access tags.",
+						},
+					},
+				},
+			},
+			"retry_policy": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "How requests to IBM Cloud services are rate limited and retried.",
+				Elem: &schema.Resource{
+					Schema: func() map[string]*schema.Schema {
+						policy := retryPolicySchema()
+						service := retryPolicySchema()
+						service["name"] = &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(conns.RetryPolicyServices, false),
+							Description:  "The name of the service, such as vpc or cis.",
+						}
+						policy["service"] = &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Overrides of the retry policy for a service.",
+							Elem:        &schema.Resource{Schema: service},
+						}
+						return policy
+					}(),
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -550,6 +650,7 @@ func Provider() *schema.Provider {
 			"ibm_is_vpc":                         vpc.DataSourceIBMISVPC(),
 			"ibm_is_vpc_dns_resolution_binding":  vpc.DataSourceIBMIsVPCDnsResolutionBinding(),
 			"ibm_is_vpc_dns_resolution_bindings": vpc.DataSourceIBMIsVPCDnsResolutionBindings(),
+			"ibm_is_vpc_topology":                vpc.DataSourceIBMISVPCTopology(),
 			"ibm_is_vpcs":                        vpc.DataSourceIBMISVPCs(),
 			"ibm_is_vpn_gateway":                 vpc.DataSourceIBMISVPNGateway(),
 			"ibm_is_vpn_gateways":                vpc.DataSourceIBMISVPNGateways(),
@@ -560,6 +661,7 @@ func Provider() *schema.Provider {
 
 			"ibm_is_vpn_gateway_connection_local_cidrs": vpc.DataSourceIBMIsVPNGatewayConnectionLocalCidrs(),
 			"ibm_is_vpn_gateway_connection_peer_cidrs":  vpc.DataSourceIBMIsVPNGatewayConnectionPeerCidrs(),
+			"ibm_is_vpn_gateway_connection_peer_config": vpc.DataSourceIBMIsVPNGatewayConnectionPeerConfig(),
 
 			"ibm_is_vpc_default_routing_table":       vpc.DataSourceIBMISVPCDefaultRoutingTable(),
 			"ibm_is_vpc_routing_table":               vpc.DataSourceIBMIBMIsVPCRoutingTable(),
@@ -581,6 +683,7 @@ func Provider() *schema.Provider {
 			"ibm_is_network_acl":                     vpc.DataSourceIBMIsNetworkACL(),
 			"ibm_is_network_acl_rule":                vpc.DataSourceIBMISNetworkACLRule(),
 			"ibm_is_network_acl_rules":               vpc.DataSourceIBMISNetworkACLRules(),
+			"ibm_is_network_reachability":            vpc.DataSourceIBMISNetworkReachability(),
 			"ibm_lbaas":                              classicinfrastructure.DataSourceIBMLbaas(),
 			"ibm_network_vlan":                       classicinfrastructure.DataSourceIBMNetworkVlan(),
 			"ibm_org":                                cloudfoundry.DataSourceIBMOrg(),
@@ -1032,6 +1135,16 @@ func Provider() *schema.Provider {
 			"ibm_function_namespace": functions.ResourceIBMFunctionNamespace(),
 
 			"ibm_cis":                                 cis.ResourceIBMCISInstance(),
+			"ibm_database_allowlist_entry":            database.ResourceIBMDatabaseAllowlistEntry(),
+			"ibm_database_configuration":              database.ResourceIBMDatabaseConfiguration(),
+			"ibm_database_mysql_database":             database.ResourceIBMDatabaseMysqlDatabase(),
+			"ibm_database_mysql_grant":                database.ResourceIBMDatabaseMysqlGrant(),
+			"ibm_database_mysql_user":                 database.ResourceIBMDatabaseMysqlUser(),
+			"ibm_database_postgresql_database":        database.ResourceIBMDatabasePostgresqlDatabase(),
+			"ibm_database_postgresql_grant":           database.ResourceIBMDatabasePostgresqlGrant(),
+			"ibm_database_postgresql_role":            database.ResourceIBMDatabasePostgresqlRole(),
+			"ibm_database_restore":                    database.ResourceIBMDatabaseRestore(),
+			"ibm_database_user":                       database.ResourceIBMDatabaseUser(),
 			"ibm_database":                            database.ResourceIBMDatabaseInstance(),
 			"ibm_cis_domain":                          cis.ResourceIBMCISDomain(),
 			"ibm_cis_domain_settings":                 cis.ResourceIBMCISSettings(),
@@ -1115,8 +1228,12 @@ func Provider() *schema.Provider {
 			"ibm_cos_bucket":                               cos.ResourceIBMCOSBucket(),
 			"ibm_cos_bucket_replication_rule":              cos.ResourceIBMCOSBucketReplicationConfiguration(),
 			"ibm_cos_bucket_object":                        cos.ResourceIBMCOSBucketObject(),
+			"ibm_cos_bucket_objects_sync":                  cos.ResourceIBMCOSBucketObjectsSync(),
 			"ibm_cos_bucket_object_lock_configuration":     cos.ResourceIBMCOSBucketObjectlock(),
 			"ibm_cos_bucket_website_configuration":         cos.ResourceIBMCOSBucketWebsiteConfiguration(),
+			"ibm_cos_bucket_cors_configuration":            cos.ResourceIBMCOSBucketCorsConfiguration(),
+			"ibm_cos_bucket_notification_configuration":    cos.ResourceIBMCOSBucketNotificationConfiguration(),
+			"ibm_cos_bucket_inventory_configuration":       cos.ResourceIBMCOSBucketInventoryConfiguration(),
 			"ibm_cos_bucket_lifecycle_configuration":       cos.ResourceIBMCOSBucketLifecycleConfiguration(),
 			"ibm_dns_domain":                               classicinfrastructure.ResourceIBMDNSDomain(),
 			"ibm_dns_domain_registration_nameservers":      classicinfrastructure.ResourceIBMDNSDomainRegistrationNameservers(),
@@ -1209,6 +1326,7 @@ func Provider() *schema.Provider {
 			"ibm_is_lb_listener_policy_rule":                     vpc.ResourceIBMISLBListenerPolicyRule(),
 			"ibm_is_lb_pool":                                     vpc.ResourceIBMISLBPool(),
 			"ibm_is_lb_pool_member":                              vpc.ResourceIBMISLBPoolMember(),
+			"ibm_is_lb_pool_members":                             vpc.ResourceIBMISLBPoolMembers(),
 			"ibm_is_network_acl":                                 vpc.ResourceIBMISNetworkACL(),
 			"ibm_is_network_acl_rule":                            vpc.ResourceIBMISNetworkACLRule(),
 			"ibm_is_public_gateway":                              vpc.ResourceIBMISPublicGateway(),
@@ -1219,6 +1337,7 @@ func Provider() *schema.Provider {
 			"ibm_is_private_path_service_gateway_operations":                          vpc.ResourceIBMIsPrivatePathServiceGatewayOperations(),
 			"ibm_is_security_group":                        vpc.ResourceIBMISSecurityGroup(),
 			"ibm_is_security_group_rule":                   vpc.ResourceIBMISSecurityGroupRule(),
+			"ibm_is_security_group_rules":                  vpc.ResourceIBMISSecurityGroupRules(),
 			"ibm_is_security_group_target":                 vpc.ResourceIBMISSecurityGroupTarget(),
 			"ibm_is_share":                                 vpc.ResourceIbmIsShare(),
 			"ibm_is_share_replica_operations":              vpc.ResourceIbmIsShareReplicaOperations(),
@@ -1324,6 +1443,7 @@ func Provider() *schema.Provider {
 			"ibm_pi_host":                            power.ResourceIBMPIHost(),
 			"ibm_pi_ike_policy":                      power.ResourceIBMPIIKEPolicy(),
 			"ibm_pi_image_export":                    power.ResourceIBMPIImageExport(),
+			"ibm_pi_image_replication":               power.ResourceIBMPIImageReplication(),
 			"ibm_pi_image":                           power.ResourceIBMPIImage(),
 			"ibm_pi_instance_action":                 power.ResourceIBMPIInstanceAction(),
 			"ibm_pi_instance":                        power.ResourceIBMPIInstance(),
@@ -1350,6 +1470,7 @@ func Provider() *schema.Provider {
 			"ibm_pi_volume":                          power.ResourceIBMPIVolume(),
 			"ibm_pi_vpn_connection":                  power.ResourceIBMPIVPNConnection(),
 			"ibm_pi_workspace":                       power.ResourceIBMPIWorkspace(),
+			"ibm_pi_workspace_clone":                 power.ResourceIBMPIWorkspaceClone(),
 
 			// Private DNS related resources
 			"ibm_dns_zone":              dnsservices.ResourceIBMPrivateDNSZone(),
@@ -1630,7 +1751,7 @@ func wrapProvider(provider schema.Provider) schema.Provider {
 }
 
 func wrapResource(name string, resource *schema.Resource) *schema.Resource {
-	return &schema.Resource{
+	wrapped := &schema.Resource{
 		Schema:               resource.Schema,
 		SchemaVersion:        resource.SchemaVersion,
 		MigrateState:         resource.MigrateState,
@@ -1651,6 +1772,11 @@ func wrapResource(name string, resource *schema.Resource) *schema.Resource {
 		Description:          resource.Description,
 		UseJSONNumber:        resource.UseJSONNumber,
 	}
+	if tagged, ok := newDefaultTagsResource(name, resource); ok {
+		tagged.wrap(name, wrapped)
+	}
+	protectResource(name, wrapped)
+	return wrapped
 }
 
 func wrapDataSource(name string, resource *schema.Resource) *schema.Resource {
@@ -1731,6 +1857,7 @@ func wrapError(err error, resourceName, operationName string, isDataSource bool)
 	}
 
 	log.Printf("[DEBUG] %s", tfError.GetDebugMessage())
+	tfError.Export()
 	return append(
 		diags,
 		diag.Diagnostic{
@@ -1763,6 +1890,7 @@ func wrapDiffErrors(err error, resourceName string) error {
 		// Although it would be ideal to return the full TerraformError object, it is sufficient
 		// to package the console message into a new error so that the user gets the information.
 		log.Printf("[DEBUG] %s", tfError.GetDebugMessage())
+		tfError.Export()
 		return errors.New(tfError.GetConsoleMessage())
 	}
 
@@ -2243,9 +2371,41 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	region := d.Get("region").(string)
 	zone := d.Get("zone").(string)
 	retryCount := d.Get("max_retries").(int)
+	httpRecorderFile := d.Get("http_recorder_file").(string)
+	httpRecorderMode := d.Get("http_recorder_mode").(string)
 	wskNameSpace := d.Get("function_namespace").(string)
 	riaasEndPoint := d.Get("riaas_endpoint").(string)
 
+	var defaultTags, defaultAccessTags []string
+	if v, ok := d.GetOk("default_tags"); ok && v.([]interface{})[0] != nil {
+		defaults := v.([]interface{})[0].(map[string]interface{})
+		defaultTags = flex.ExpandStringList(defaults["tags"].(*schema.Set).List())
+		defaultAccessTags = flex.ExpandStringList(defaults["access_tags"].(*schema.Set).List())
+	}
+
+	var protectedResources []conns.ProtectedResources
+	for _, v := range d.Get("protected_resources").([]interface{}) {
+		if v == nil {
+			continue
+		}
+		protected := v.(map[string]interface{})
+		protectedResources = append(protectedResources, conns.ProtectedResources{
+			ResourceTypes: flex.ExpandStringList(protected["resource_types"].(*schema.Set).List()),
+			Tags:          flex.ExpandStringList(protected["tags"].(*schema.Set).List()),
+		})
+	}
+
+	retryPolicy, serviceRetryPolicies := expandRetryPolicies(d)
+
+	assumeProfiles, err := expandAssumeProfiles(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := flex.AddProblemExportFile(d.Get("diagnostics_export_file").(string)); err != nil {
+		return nil, err
+	}
+
 	wskEnvVal, err := schema.EnvDefaultFunc("FUNCTION_NAMESPACE", "")()
 	if err != nil {
 		return nil, err
@@ -2274,6 +2434,14 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Visibility:           visibility,
 		EndpointsFile:        file,
 		IAMTrustedProfileID:  iamTrustedProfileId,
+		AssumeProfiles:       assumeProfiles,
+		DefaultTags:          defaultTags,
+		DefaultAccessTags:    defaultAccessTags,
+		ProtectedResources:   protectedResources,
+		RetryPolicy:          retryPolicy,
+		ServiceRetryPolicies: serviceRetryPolicies,
+		HTTPRecorderFile:     httpRecorderFile,
+		HTTPRecorderMode:     httpRecorderMode,
 	}
 
 	return config.ClientSession()
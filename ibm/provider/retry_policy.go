@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+)
+
+// retryPolicySchema returns the settings of the retry_policy block, which also make up
+// its service overrides.
+func retryPolicySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"max_retries": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The number of times a request that failed with a connection error, 429 or 5xx is retried.",
+		},
+		"min_delay": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateRetryDelay,
+			Description:  "The delay before the first retry, such as 500ms. The delay doubles with every retry.",
+		},
+		"max_delay": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateRetryDelay,
+			Description:  "The longest delay between two retries, unless the service asks for a longer one with a Retry-After header.",
+		},
+		"requests_per_second": {
+			Type:         schema.TypeFloat,
+			Optional:     true,
+			ValidateFunc: validation.FloatAtLeast(0),
+			Description:  "The rate at which requests are sent to a service.",
+		},
+		"burst": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The number of requests that can be sent at once before requests_per_second applies.",
+		},
+	}
+}
+
+func validateRetryDelay(v interface{}, k string) (ws []string, errors []error) {
+	if d, err := time.ParseDuration(v.(string)); err != nil || d < 0 {
+		errors = append(errors, fmt.Errorf("%q must be a positive duration such as 500ms or 10s, got %q", k, v))
+	}
+	return
+}
+
+// expandRetryPolicies returns the retry policy of the retry_policy block and its
+// overrides by service.
+func expandRetryPolicies(d *schema.ResourceData) (conns.RetryPolicy, map[string]conns.RetryPolicy) {
+	var policy conns.RetryPolicy
+	services := map[string]conns.RetryPolicy{}
+	for i, v := range d.Get("retry_policy").([]interface{}) {
+		if v == nil {
+			continue
+		}
+		m := v.(map[string]interface{})
+		key := fmt.Sprintf("retry_policy.%d", i)
+		policy = expandRetryPolicy(d, key, m)
+		for j, s := range m["service"].([]interface{}) {
+			if s == nil {
+				continue
+			}
+			service := s.(map[string]interface{})
+			services[service["name"].(string)] = expandRetryPolicy(d, fmt.Sprintf("%s.service.%d", key, j), service)
+		}
+	}
+	return policy, services
+}
+
+func expandRetryPolicy(d *schema.ResourceData, key string, m map[string]interface{}) conns.RetryPolicy {
+	// the durations are validated by the schema
+	minDelay, _ := time.ParseDuration(m["min_delay"].(string))
+	maxDelay, _ := time.ParseDuration(m["max_delay"].(string))
+	// max_retries = 0 turns retries off, so it is told apart from an unset max_retries
+	_, maxRetriesSet := d.GetOkExists(key + ".max_retries")
+	return conns.RetryPolicy{
+		MaxRetries:        m["max_retries"].(int),
+		MaxRetriesSet:     maxRetriesSet,
+		MinDelay:          minDelay,
+		MaxDelay:          maxDelay,
+		RequestsPerSecond: m["requests_per_second"].(float64),
+		Burst:             m["burst"].(int),
+	}
+}
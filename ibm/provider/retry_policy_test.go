@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+)
+
+func TestExpandRetryPolicies(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"retry_policy": []interface{}{
+			map[string]interface{}{
+				"max_retries":         5,
+				"max_delay":           "30s",
+				"requests_per_second": 20.0,
+				"service": []interface{}{
+					map[string]interface{}{
+						"name":                "vpc",
+						"min_delay":           "500ms",
+						"requests_per_second": 5.0,
+						"burst":               10,
+					},
+					map[string]interface{}{
+						"name":        "iam",
+						"max_retries": 0,
+					},
+				},
+			},
+		},
+	})
+
+	policy, services := expandRetryPolicies(d)
+	assert.Equal(t, conns.RetryPolicy{MaxRetries: 5, MaxRetriesSet: true, MaxDelay: 30 * time.Second, RequestsPerSecond: 20}, policy)
+	assert.Equal(t, map[string]conns.RetryPolicy{
+		"vpc": {MinDelay: 500 * time.Millisecond, RequestsPerSecond: 5, Burst: 10},
+		// an explicit zero turns the retries off
+		"iam": {MaxRetries: 0, MaxRetriesSet: true},
+	}, services)
+}
+
+func TestValidateRetryPolicy(t *testing.T) {
+	_, errs := validateRetryDelay("10s", "min_delay")
+	assert.Empty(t, errs)
+	_, errs = validateRetryDelay("10", "min_delay")
+	assert.NotEmpty(t, errs)
+
+	p := Provider()
+	diags := p.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"retry_policy": []interface{}{
+			map[string]interface{}{
+				"service": []interface{}{
+					map[string]interface{}{"name": "unknown"},
+				},
+			},
+		},
+	}))
+	assert.True(t, diags.HasError())
+}
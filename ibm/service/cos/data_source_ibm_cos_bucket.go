@@ -727,7 +727,10 @@ func dataSourceIBMCosBucketRead(d *schema.ResourceData, meta interface{}) error
 
 	}
 
-	apiEndpoint = conns.FileFallBack(rsConClient.Config.EndpointsFile, visibility, "IBMCLOUD_COS_ENDPOINT", bucketRegion, apiEndpoint)
+	apiEndpoint, err = conns.FileFallBack(rsConClient.Config, visibility, "IBMCLOUD_COS_ENDPOINT", bucketRegion, apiEndpoint)
+	if err != nil {
+		return err
+	}
 	apiEndpoint = conns.EnvFallBack([]string{"IBMCLOUD_COS_ENDPOINT"}, apiEndpoint)
 	if apiEndpoint == "" {
 		return fmt.Errorf("[ERROR] The endpoint doesn't exists for given location %s and endpoint type %s", bucketRegion, endpointType)
@@ -827,7 +830,10 @@ func dataSourceIBMCosBucketRead(d *schema.ResourceData, meta interface{}) error
 	}
 	if endpointType != "public" {
 		// User is expected to define both private and direct url type under "private" in endpoints file since visibility type "direct" is not supported.
-		cosConfigURL := conns.FileFallBack(rsConClient.Config.EndpointsFile, "private", "IBMCLOUD_COS_CONFIG_ENDPOINT", bucketRegion, cosConfigUrls[endpointType])
+		cosConfigURL, err := conns.FileFallBack(rsConClient.Config, "private", "IBMCLOUD_COS_CONFIG_ENDPOINT", bucketRegion, cosConfigUrls[endpointType])
+		if err != nil {
+			return err
+		}
 		cosConfigURL = conns.EnvFallBack([]string{"IBMCLOUD_COS_CONFIG_ENDPOINT"}, cosConfigURL)
 		if cosConfigURL != "" {
 			sess.SetServiceURL(cosConfigURL)
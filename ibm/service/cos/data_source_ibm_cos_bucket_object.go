@@ -112,7 +112,7 @@ func dataSourceIBMCosBucketObjectRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	s3Client, err := getS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -775,7 +775,10 @@ func resourceIBMCOSBucketUpdate(d *schema.ResourceData, meta interface{}) error
 
 	}
 
-	apiEndpoint = conns.FileFallBack(rsConClient.Config.EndpointsFile, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	apiEndpoint, err = conns.FileFallBack(rsConClient.Config, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	if err != nil {
+		return err
+	}
 	apiEndpoint = conns.EnvFallBack([]string{"IBMCLOUD_COS_ENDPOINT"}, apiEndpoint)
 
 	authEndpoint, err := rsConClient.Config.EndpointLocator.IAMEndpoint()
@@ -943,7 +946,10 @@ func resourceIBMCOSBucketUpdate(d *schema.ResourceData, meta interface{}) error
 
 	if endpointType != "public" {
 		// User is expected to define both private and direct url type under "private" in endpoints file since visibility type "direct" is not supported.
-		cosConfigURL := conns.FileFallBack(rsConClient.Config.EndpointsFile, "private", "IBMCLOUD_COS_CONFIG_ENDPOINT", bLocation, cosConfigUrls[endpointType])
+		cosConfigURL, err := conns.FileFallBack(rsConClient.Config, "private", "IBMCLOUD_COS_CONFIG_ENDPOINT", bLocation, cosConfigUrls[endpointType])
+		if err != nil {
+			return err
+		}
 		cosConfigURL = conns.EnvFallBack([]string{"IBMCLOUD_COS_CONFIG_ENDPOINT"}, cosConfigURL)
 		if cosConfigURL != "" {
 			sess.SetServiceURL(cosConfigURL)
@@ -1142,7 +1148,10 @@ func resourceIBMCOSBucketRead(d *schema.ResourceData, meta interface{}) error {
 
 	}
 
-	apiEndpoint = conns.FileFallBack(rsConClient.Config.EndpointsFile, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	apiEndpoint, err = conns.FileFallBack(rsConClient.Config, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	if err != nil {
+		return err
+	}
 	apiEndpoint = conns.EnvFallBack([]string{"IBMCLOUD_COS_ENDPOINT"}, apiEndpoint)
 
 	authEndpoint, err := rsConClient.Config.EndpointLocator.IAMEndpoint()
@@ -1237,7 +1246,10 @@ func resourceIBMCOSBucketRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	if endpointType != "public" {
 		// User is expected to define both private and direct url type under "private" in endpoints file since visibility type "direct" is not supported.
-		cosConfigURL := conns.FileFallBack(rsConClient.Config.EndpointsFile, "private", "IBMCLOUD_COS_CONFIG_ENDPOINT", bLocation, cosConfigUrls[endpointType])
+		cosConfigURL, err := conns.FileFallBack(rsConClient.Config, "private", "IBMCLOUD_COS_CONFIG_ENDPOINT", bLocation, cosConfigUrls[endpointType])
+		if err != nil {
+			return err
+		}
 		cosConfigURL = conns.EnvFallBack([]string{"IBMCLOUD_COS_CONFIG_ENDPOINT"}, cosConfigURL)
 		if cosConfigURL != "" {
 			sess.SetServiceURL(cosConfigURL)
@@ -1445,7 +1457,10 @@ func resourceIBMCOSBucketCreate(d *schema.ResourceData, meta interface{}) error
 
 	}
 
-	apiEndpoint = conns.FileFallBack(rsConClient.Config.EndpointsFile, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	apiEndpoint, err = conns.FileFallBack(rsConClient.Config, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	if err != nil {
+		return err
+	}
 	apiEndpoint = conns.EnvFallBack([]string{"IBMCLOUD_COS_ENDPOINT"}, apiEndpoint)
 
 	if apiEndpoint == "" {
@@ -1570,7 +1585,10 @@ func resourceIBMCOSBucketDelete(d *schema.ResourceData, meta interface{}) error
 
 	}
 
-	apiEndpoint = conns.FileFallBack(rsConClient.Config.EndpointsFile, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	apiEndpoint, err := conns.FileFallBack(rsConClient.Config, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	if err != nil {
+		return err
+	}
 	apiEndpoint = conns.EnvFallBack([]string{"IBMCLOUD_COS_ENDPOINT"}, apiEndpoint)
 
 	if apiEndpoint == "" {
@@ -1704,7 +1722,10 @@ func resourceIBMCOSBucketExists(d *schema.ResourceData, meta interface{}) (bool,
 
 	}
 
-	apiEndpoint = conns.FileFallBack(rsConClient.Config.EndpointsFile, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	apiEndpoint, err = conns.FileFallBack(rsConClient.Config, visibility, "IBMCLOUD_COS_ENDPOINT", bLocation, apiEndpoint)
+	if err != nil {
+		return false, err
+	}
 
 	apiEndpoint = conns.EnvFallBack([]string{"IBMCLOUD_COS_ENDPOINT"}, apiEndpoint)
 
@@ -0,0 +1,242 @@
+package cos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceIBMCOSBucketCorsConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMCOSBucketCorsConfigurationCreate,
+		ReadContext:   resourceIBMCOSBucketCorsConfigurationRead,
+		UpdateContext: resourceIBMCOSBucketCorsConfigurationUpdate,
+		DeleteContext: resourceIBMCOSBucketCorsConfigurationDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket_crn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "COS bucket CRN",
+			},
+			"bucket_location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "COS bucket location",
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private", "direct"}),
+				Description:  "COS endpoint type: public, private, direct",
+				Default:      "public",
+			},
+			"cors_rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    100,
+				Description: "Rules that define the origins and methods allowed to access the bucket across origins.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allowed_headers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Headers that are allowed in a preflight OPTIONS request through the Access-Control-Request-Headers header.",
+						},
+						"allowed_methods": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"GET", "PUT", "POST", "DELETE", "HEAD"}, false),
+							},
+							Description: "HTTP methods that the origins are allowed to execute: GET, PUT, POST, DELETE, HEAD.",
+						},
+						"allowed_origins": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Origins that are allowed to access the bucket.",
+						},
+						"expose_headers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Headers in the response that the applications are allowed to access.",
+						},
+						"max_age_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Time in seconds that the browser caches the response of a preflight request.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func corsRuleSet(corsRuleList []interface{}) []*s3.CORSRule {
+	var rules []*s3.CORSRule
+	for _, l := range corsRuleList {
+		ruleMap, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cors_rule := s3.CORSRule{
+			AllowedMethods: aws.StringSlice(flex.ExpandStringList(ruleMap["allowed_methods"].([]interface{}))),
+			AllowedOrigins: aws.StringSlice(flex.ExpandStringList(ruleMap["allowed_origins"].([]interface{}))),
+		}
+		if allowedHeaders, ok := ruleMap["allowed_headers"].([]interface{}); ok && len(allowedHeaders) > 0 {
+			cors_rule.AllowedHeaders = aws.StringSlice(flex.ExpandStringList(allowedHeaders))
+		}
+		if exposeHeaders, ok := ruleMap["expose_headers"].([]interface{}); ok && len(exposeHeaders) > 0 {
+			cors_rule.ExposeHeaders = aws.StringSlice(flex.ExpandStringList(exposeHeaders))
+		}
+		if maxAgeSeconds, ok := ruleMap["max_age_seconds"].(int); ok && maxAgeSeconds > 0 {
+			cors_rule.MaxAgeSeconds = aws.Int64(int64(maxAgeSeconds))
+		}
+		rules = append(rules, &cors_rule)
+	}
+	return rules
+}
+
+func resourceIBMCOSBucketCorsConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := d.Get("bucket_crn").(string)
+	bucketName := strings.Split(bucketCRN, ":bucket:")[1]
+	instanceCRN := fmt.Sprintf("%s::", strings.Split(bucketCRN, ":bucket:")[0])
+	bucketLocation := d.Get("bucket_location").(string)
+	endpointType := d.Get("endpoint_type").(string)
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	putBucketCorsInput := s3.PutBucketCorsInput{
+		Bucket: aws.String(bucketName),
+		CORSConfiguration: &s3.CORSConfiguration{
+			CORSRules: corsRuleSet(d.Get("cors_rule").([]interface{})),
+		},
+	}
+	_, err = s3Client.PutBucketCors(&putBucketCorsInput)
+	if err != nil {
+		return diag.Errorf("Failed to put CORS configuration on the COS bucket %s, %v", bucketName, err)
+	}
+	bktID := fmt.Sprintf("%s:%s:%s:meta:%s:%s", strings.Replace(instanceCRN, "::", "", -1), "bucket", bucketName, bucketLocation, endpointType)
+	d.SetId(bktID)
+	return resourceIBMCOSBucketCorsConfigurationRead(ctx, d, meta)
+}
+
+func resourceIBMCOSBucketCorsConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := d.Get("bucket_crn").(string)
+	bucketName := strings.Split(bucketCRN, ":bucket:")[1]
+	instanceCRN := fmt.Sprintf("%s::", strings.Split(bucketCRN, ":bucket:")[0])
+	bucketLocation := d.Get("bucket_location").(string)
+	endpointType := d.Get("endpoint_type").(string)
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	if d.HasChange("cors_rule") {
+		putBucketCorsInput := s3.PutBucketCorsInput{
+			Bucket: aws.String(bucketName),
+			CORSConfiguration: &s3.CORSConfiguration{
+				CORSRules: corsRuleSet(d.Get("cors_rule").([]interface{})),
+			},
+		}
+		_, err = s3Client.PutBucketCors(&putBucketCorsInput)
+		if err != nil {
+			return diag.Errorf("Failed to update CORS configuration on the COS bucket %s, %v", bucketName, err)
+		}
+	}
+	return resourceIBMCOSBucketCorsConfigurationRead(ctx, d, meta)
+}
+
+func resourceIBMCOSBucketCorsConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := parseWebsiteId(d.Id(), "bucketCRN")
+	bucketName := parseWebsiteId(d.Id(), "bucketName")
+	bucketLocation := parseWebsiteId(d.Id(), "bucketLocation")
+	instanceCRN := parseWebsiteId(d.Id(), "instanceCRN")
+	endpointType := parseWebsiteId(d.Id(), "endpointType")
+	d.Set("bucket_crn", bucketCRN)
+	d.Set("bucket_location", bucketLocation)
+	if endpointType != "" {
+		d.Set("endpoint_type", endpointType)
+	}
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	getBucketCorsInput := &s3.GetBucketCorsInput{
+		Bucket: aws.String(bucketName),
+	}
+	output, err := s3Client.GetBucketCors(getBucketCorsInput)
+	if err != nil {
+		// The CORS configuration was removed outside of Terraform
+		if strings.Contains(err.Error(), "NoSuchCORSConfiguration") {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[ERROR] Error getting CORS configuration for the bucket %s, %v", bucketName, err)
+	}
+	if output.CORSRules != nil {
+		d.Set("cors_rule", flex.CorsRulesGet(output.CORSRules))
+	} else {
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceIBMCOSBucketCorsConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketName := parseWebsiteId(d.Id(), "bucketName")
+	bucketLocation := parseWebsiteId(d.Id(), "bucketLocation")
+	instanceCRN := parseWebsiteId(d.Id(), "instanceCRN")
+	endpointType := parseWebsiteId(d.Id(), "endpointType")
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	deleteBucketCorsInput := &s3.DeleteBucketCorsInput{
+		Bucket: aws.String(bucketName),
+	}
+	_, err = s3Client.DeleteBucketCors(deleteBucketCorsInput)
+	if err != nil {
+		return diag.Errorf("failed to delete the CORS configuration on the COS bucket %s, %v", bucketName, err)
+	}
+	return nil
+}
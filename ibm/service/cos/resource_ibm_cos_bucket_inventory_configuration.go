@@ -0,0 +1,413 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/aws/request"
+	"github.com/IBM/ibm-cos-sdk-go/private/checksum"
+	"github.com/IBM/ibm-cos-sdk-go/private/protocol"
+	"github.com/IBM/ibm-cos-sdk-go/private/protocol/restxml"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceIBMCOSBucketInventoryConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMCOSBucketInventoryConfigurationCreate,
+		ReadContext:   resourceIBMCOSBucketInventoryConfigurationRead,
+		UpdateContext: resourceIBMCOSBucketInventoryConfigurationUpdate,
+		DeleteContext: resourceIBMCOSBucketInventoryConfigurationDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket_crn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "COS bucket CRN",
+			},
+			"bucket_location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "COS bucket location",
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private", "direct"}),
+				Description:  "COS endpoint type: public, private, direct",
+				Default:      "public",
+			},
+			"inventory_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(cosInventoryIDRegexp, "must contain only letters, numbers, periods, hyphens and underscores"),
+				Description:  "The ID of the inventory configuration.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the inventory is generated.",
+			},
+			"included_object_versions": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"All", "Current"}, false),
+				Description:  "The object versions listed in the inventory: All, Current.",
+			},
+			"frequency": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Daily", "Weekly"}, false),
+				Description:  "How often the inventory is generated: Daily, Weekly.",
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only the objects whose keys start with the prefix are listed in the inventory.",
+			},
+			"optional_fields": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Additional fields listed in the inventory, for example Size, LastModifiedDate or ETag.",
+			},
+			"destination": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The bucket the inventory is written to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket_crn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "CRN of the bucket the inventory is written to.",
+						},
+						"format": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "CSV",
+							ValidateFunc: validation.StringInSlice([]string{"CSV", "ORC", "Parquet"}, false),
+							Description:  "The format of the inventory files: CSV, ORC, Parquet.",
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The prefix of the inventory files.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// The COS SDK has no inventory configuration API, so the operations are built with the
+// REST XML protocol of the S3 client.
+type cosInventoryConfiguration struct {
+	_ struct{} `type:"structure"`
+
+	Destination *cosInventoryDestination `type:"structure" required:"true"`
+
+	Filter *cosInventoryFilter `type:"structure"`
+
+	Id *string `type:"string" required:"true"`
+
+	IncludedObjectVersions *string `type:"string" required:"true"`
+
+	IsEnabled *bool `type:"boolean" required:"true"`
+
+	OptionalFields []*string `locationNameList:"Field" type:"list"`
+
+	Schedule *cosInventorySchedule `type:"structure" required:"true"`
+}
+
+type cosInventoryDestination struct {
+	_ struct{} `type:"structure"`
+
+	BucketDestination *cosInventoryBucketDestination `locationName:"S3BucketDestination" type:"structure" required:"true"`
+}
+
+type cosInventoryBucketDestination struct {
+	_ struct{} `type:"structure"`
+
+	Bucket *string `type:"string" required:"true"`
+
+	Format *string `type:"string" required:"true"`
+
+	Prefix *string `type:"string"`
+}
+
+type cosInventoryFilter struct {
+	_ struct{} `type:"structure"`
+
+	Prefix *string `type:"string" required:"true"`
+}
+
+type cosInventorySchedule struct {
+	_ struct{} `type:"structure"`
+
+	Frequency *string `type:"string" required:"true"`
+}
+
+type putBucketInventoryConfigurationInput struct {
+	_ struct{} `locationName:"PutBucketInventoryConfigurationRequest" type:"structure" payload:"InventoryConfiguration"`
+
+	Bucket *string `location:"uri" locationName:"Bucket" type:"string" required:"true"`
+
+	Id *string `location:"querystring" locationName:"id" type:"string" required:"true"`
+
+	InventoryConfiguration *cosInventoryConfiguration `locationName:"InventoryConfiguration" type:"structure" required:"true" xmlURI:"http://s3.amazonaws.com/doc/2006-03-01/"`
+}
+
+type cosInventoryConfigurationInput struct {
+	_ struct{} `type:"structure"`
+
+	Bucket *string `location:"uri" locationName:"Bucket" type:"string" required:"true"`
+
+	Id *string `location:"querystring" locationName:"id" type:"string" required:"true"`
+}
+
+type getBucketInventoryConfigurationOutput struct {
+	_ struct{} `type:"structure" payload:"InventoryConfiguration"`
+
+	InventoryConfiguration *cosInventoryConfiguration `type:"structure"`
+}
+
+var cosInventoryIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+func putBucketInventoryConfiguration(s3Client *s3.S3, bucketName string, configuration *cosInventoryConfiguration) error {
+	op := &request.Operation{
+		Name:       "PutBucketInventoryConfiguration",
+		HTTPMethod: "PUT",
+		HTTPPath:   "/{Bucket}?inventory",
+	}
+	input := &putBucketInventoryConfigurationInput{
+		Bucket:                 aws.String(bucketName),
+		Id:                     configuration.Id,
+		InventoryConfiguration: configuration,
+	}
+	req := s3Client.NewRequest(op, input, &struct{}{})
+	req.Handlers.Unmarshal.Swap(restxml.UnmarshalHandler.Name, protocol.UnmarshalDiscardBodyHandler)
+	req.Handlers.Build.PushBackNamed(request.NamedHandler{
+		Name: "contentMd5Handler",
+		Fn:   checksum.AddBodyContentMD5Handler,
+	})
+	return req.Send()
+}
+
+func getBucketInventoryConfiguration(s3Client *s3.S3, bucketName string, inventoryID string) (*cosInventoryConfiguration, error) {
+	op := &request.Operation{
+		Name:       "GetBucketInventoryConfiguration",
+		HTTPMethod: "GET",
+		HTTPPath:   "/{Bucket}?inventory",
+	}
+	input := &cosInventoryConfigurationInput{
+		Bucket: aws.String(bucketName),
+		Id:     aws.String(inventoryID),
+	}
+	output := &getBucketInventoryConfigurationOutput{}
+	err := s3Client.NewRequest(op, input, output).Send()
+	return output.InventoryConfiguration, err
+}
+
+func deleteBucketInventoryConfiguration(s3Client *s3.S3, bucketName string, inventoryID string) error {
+	op := &request.Operation{
+		Name:       "DeleteBucketInventoryConfiguration",
+		HTTPMethod: "DELETE",
+		HTTPPath:   "/{Bucket}?inventory",
+	}
+	input := &cosInventoryConfigurationInput{
+		Bucket: aws.String(bucketName),
+		Id:     aws.String(inventoryID),
+	}
+	req := s3Client.NewRequest(op, input, &struct{}{})
+	req.Handlers.Unmarshal.Swap(restxml.UnmarshalHandler.Name, protocol.UnmarshalDiscardBodyHandler)
+	return req.Send()
+}
+
+func inventoryConfigurationSet(d *schema.ResourceData) *cosInventoryConfiguration {
+	configuration := &cosInventoryConfiguration{
+		Id:                     aws.String(d.Get("inventory_id").(string)),
+		IsEnabled:              aws.Bool(d.Get("enabled").(bool)),
+		IncludedObjectVersions: aws.String(d.Get("included_object_versions").(string)),
+		Schedule: &cosInventorySchedule{
+			Frequency: aws.String(d.Get("frequency").(string)),
+		},
+	}
+	if prefix, ok := d.GetOk("prefix"); ok {
+		configuration.Filter = &cosInventoryFilter{Prefix: aws.String(prefix.(string))}
+	}
+	if optionalFields := d.Get("optional_fields").(*schema.Set); optionalFields.Len() > 0 {
+		configuration.OptionalFields = aws.StringSlice(flex.ExpandStringList(optionalFields.List()))
+	}
+	destinationMap := d.Get("destination").([]interface{})[0].(map[string]interface{})
+	bucketDestination := &cosInventoryBucketDestination{
+		Bucket: aws.String(destinationMap["bucket_crn"].(string)),
+		Format: aws.String(destinationMap["format"].(string)),
+	}
+	if prefix, ok := destinationMap["prefix"].(string); ok && prefix != "" {
+		bucketDestination.Prefix = aws.String(prefix)
+	}
+	configuration.Destination = &cosInventoryDestination{BucketDestination: bucketDestination}
+	return configuration
+}
+
+func inventoryDestinationGet(in *cosInventoryDestination) []map[string]interface{} {
+	destination := make([]map[string]interface{}, 0, 1)
+	if in != nil && in.BucketDestination != nil {
+		destination = append(destination, map[string]interface{}{
+			"bucket_crn": aws.StringValue(in.BucketDestination.Bucket),
+			"format":     aws.StringValue(in.BucketDestination.Format),
+			"prefix":     aws.StringValue(in.BucketDestination.Prefix),
+		})
+	}
+	return destination
+}
+
+func parseInventoryId(id string, info string) string {
+	if info == "inventoryID" {
+		return strings.Split(id, ":inventory:")[1]
+	}
+	return parseWebsiteId(strings.Split(id, ":inventory:")[0], info)
+}
+
+func resourceIBMCOSBucketInventoryConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := d.Get("bucket_crn").(string)
+	bucketName := strings.Split(bucketCRN, ":bucket:")[1]
+	instanceCRN := fmt.Sprintf("%s::", strings.Split(bucketCRN, ":bucket:")[0])
+	bucketLocation := d.Get("bucket_location").(string)
+	endpointType := d.Get("endpoint_type").(string)
+	inventoryID := d.Get("inventory_id").(string)
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	err = putBucketInventoryConfiguration(s3Client, bucketName, inventoryConfigurationSet(d))
+	if err != nil {
+		return diag.Errorf("Failed to put inventory configuration %s on the COS bucket %s, %v", inventoryID, bucketName, err)
+	}
+	bktID := fmt.Sprintf("%s:%s:%s:meta:%s:%s:inventory:%s", strings.Replace(instanceCRN, "::", "", -1), "bucket", bucketName, bucketLocation, endpointType, inventoryID)
+	d.SetId(bktID)
+	return resourceIBMCOSBucketInventoryConfigurationRead(ctx, d, meta)
+}
+
+func resourceIBMCOSBucketInventoryConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := d.Get("bucket_crn").(string)
+	bucketName := strings.Split(bucketCRN, ":bucket:")[1]
+	instanceCRN := fmt.Sprintf("%s::", strings.Split(bucketCRN, ":bucket:")[0])
+	bucketLocation := d.Get("bucket_location").(string)
+	endpointType := d.Get("endpoint_type").(string)
+	inventoryID := d.Get("inventory_id").(string)
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	if d.HasChanges("enabled", "included_object_versions", "frequency", "prefix", "optional_fields", "destination") {
+		err = putBucketInventoryConfiguration(s3Client, bucketName, inventoryConfigurationSet(d))
+		if err != nil {
+			return diag.Errorf("Failed to update inventory configuration %s on the COS bucket %s, %v", inventoryID, bucketName, err)
+		}
+	}
+	return resourceIBMCOSBucketInventoryConfigurationRead(ctx, d, meta)
+}
+
+func resourceIBMCOSBucketInventoryConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := parseInventoryId(d.Id(), "bucketCRN")
+	bucketName := parseInventoryId(d.Id(), "bucketName")
+	bucketLocation := parseInventoryId(d.Id(), "bucketLocation")
+	instanceCRN := parseInventoryId(d.Id(), "instanceCRN")
+	endpointType := parseInventoryId(d.Id(), "endpointType")
+	inventoryID := parseInventoryId(d.Id(), "inventoryID")
+	d.Set("bucket_crn", bucketCRN)
+	d.Set("bucket_location", bucketLocation)
+	d.Set("inventory_id", inventoryID)
+	if endpointType != "" {
+		d.Set("endpoint_type", endpointType)
+	}
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	output, err := getBucketInventoryConfiguration(s3Client, bucketName, inventoryID)
+	if err != nil {
+		// The inventory configuration was removed outside of Terraform
+		if strings.Contains(err.Error(), "NoSuchConfiguration") {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[ERROR] Error getting inventory configuration %s for the bucket %s, %v", inventoryID, bucketName, err)
+	}
+	if output == nil {
+		d.SetId("")
+		return nil
+	}
+	d.Set("enabled", aws.BoolValue(output.IsEnabled))
+	d.Set("included_object_versions", aws.StringValue(output.IncludedObjectVersions))
+	if output.Schedule != nil {
+		d.Set("frequency", aws.StringValue(output.Schedule.Frequency))
+	}
+	prefix := ""
+	if output.Filter != nil {
+		prefix = aws.StringValue(output.Filter.Prefix)
+	}
+	d.Set("prefix", prefix)
+	d.Set("optional_fields", aws.StringValueSlice(output.OptionalFields))
+	d.Set("destination", inventoryDestinationGet(output.Destination))
+	return nil
+}
+
+func resourceIBMCOSBucketInventoryConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketName := parseInventoryId(d.Id(), "bucketName")
+	bucketLocation := parseInventoryId(d.Id(), "bucketLocation")
+	instanceCRN := parseInventoryId(d.Id(), "instanceCRN")
+	endpointType := parseInventoryId(d.Id(), "endpointType")
+	inventoryID := parseInventoryId(d.Id(), "inventoryID")
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	err = deleteBucketInventoryConfiguration(s3Client, bucketName, inventoryID)
+	if err != nil {
+		return diag.Errorf("failed to delete the inventory configuration %s on the COS bucket %s, %v", inventoryID, bucketName, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package cos_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMCosBucket_Inventory_Configuration_Basic(t *testing.T) {
+	serviceName := fmt.Sprintf("terraform_%d", acctest.RandIntRange(10, 100))
+	bucketName := fmt.Sprintf("terraform-inventory%d", acctest.RandIntRange(10, 100))
+	bucketRegion := "us-south"
+	bucketClass := "standard"
+	bucketRegionType := "region_location"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMCosBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCosBucket_Inventory_Configuration_Basic(serviceName, bucketName, bucketRegion, bucketClass, "Daily"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMCosBucketExists("ibm_resource_instance.instance", "ibm_cos_bucket.bucket", bucketRegionType, bucketRegion, bucketName),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_inventory_configuration.inventory", "inventory_id", "daily"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_inventory_configuration.inventory", "enabled", "true"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_inventory_configuration.inventory", "included_object_versions", "Current"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_inventory_configuration.inventory", "frequency", "Daily"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_inventory_configuration.inventory", "optional_fields.#", "2"),
+					resource.TestCheckResourceAttrPair("ibm_cos_bucket_inventory_configuration.inventory", "destination.0.bucket_crn", "ibm_cos_bucket.reports", "crn"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_inventory_configuration.inventory", "destination.0.format", "CSV"),
+				),
+			},
+			{
+				Config: testAccCheckIBMCosBucket_Inventory_Configuration_Basic(serviceName, bucketName, bucketRegion, bucketClass, "Weekly"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_cos_bucket_inventory_configuration.inventory", "frequency", "Weekly"),
+				),
+			},
+			{
+				ResourceName:      "ibm_cos_bucket_inventory_configuration.inventory",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCosBucket_Inventory_Configuration_Basic(cosServiceName string, bucketName string, region string, storageClass string, frequency string) string {
+
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "cos_group" {
+		name = "Default"
+	}
+
+	resource "ibm_resource_instance" "instance" {
+		name              = "%[1]s"
+		service           = "cloud-object-storage"
+		plan              = "standard"
+		location          = "global"
+		resource_group_id = data.ibm_resource_group.cos_group.id
+	}
+	resource "ibm_cos_bucket" "bucket" {
+		bucket_name          = "%[2]s"
+		resource_instance_id = ibm_resource_instance.instance.id
+		region_location      = "%[3]s"
+		storage_class        = "%[4]s"
+	}
+	resource "ibm_cos_bucket" "reports" {
+		bucket_name          = "%[2]s-reports"
+		resource_instance_id = ibm_resource_instance.instance.id
+		region_location      = "%[3]s"
+		storage_class        = "%[4]s"
+	}
+
+	resource ibm_cos_bucket_inventory_configuration "inventory" {
+		bucket_crn               = ibm_cos_bucket.bucket.crn
+		bucket_location          = ibm_cos_bucket.bucket.region_location
+		inventory_id             = "daily"
+		included_object_versions = "Current"
+		frequency                = "%[5]s"
+		optional_fields          = ["Size", "LastModifiedDate"]
+		destination {
+			bucket_crn = ibm_cos_bucket.reports.crn
+			prefix     = "inventory"
+		}
+	}
+	`, cosServiceName, bucketName, region, storageClass, frequency)
+}
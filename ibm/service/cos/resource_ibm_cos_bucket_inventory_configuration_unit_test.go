@@ -0,0 +1,93 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cos
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestInventoryConfigurationRequests(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceIBMCOSBucketInventoryConfiguration().Schema, map[string]interface{}{
+		"bucket_crn":               "crn:v1:bluemix:public:cloud-object-storage:global:a/1234:5678:bucket:bucket",
+		"bucket_location":          "us-south",
+		"inventory_id":             "daily",
+		"included_object_versions": "Current",
+		"frequency":                "Daily",
+		"prefix":                   "data/",
+		"optional_fields":          []interface{}{"Size"},
+		"destination": []interface{}{
+			map[string]interface{}{
+				"bucket_crn": "crn:v1:bluemix:public:cloud-object-storage:global:a/1234:5678:bucket:reports",
+			},
+		},
+	})
+
+	s3Client, lastRequest, lastBody := newTestS3Client(t, http.StatusOK, "")
+	err := putBucketInventoryConfiguration(s3Client, "bucket", inventoryConfigurationSet(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastRequest.Method != "PUT" || lastRequest.URL.Path != "/bucket" || lastRequest.URL.Query().Get("id") != "daily" || !lastRequest.URL.Query().Has("inventory") {
+		t.Errorf("unexpected request %s %s", lastRequest.Method, lastRequest.URL)
+	}
+	for _, element := range []string{
+		"<S3BucketDestination><Bucket>crn:v1:bluemix:public:cloud-object-storage:global:a/1234:5678:bucket:reports</Bucket><Format>CSV</Format></S3BucketDestination>",
+		"<Filter><Prefix>data/</Prefix></Filter>",
+		"<Id>daily</Id>",
+		"<IncludedObjectVersions>Current</IncludedObjectVersions>",
+		"<IsEnabled>true</IsEnabled>",
+		"<OptionalFields><Field>Size</Field></OptionalFields>",
+		"<Schedule><Frequency>Daily</Frequency></Schedule>",
+	} {
+		if !strings.Contains(*lastBody, element) {
+			t.Errorf("expected %s in %s", element, *lastBody)
+		}
+	}
+
+	s3Client, _, _ = newTestS3Client(t, http.StatusOK, `<InventoryConfiguration>`+
+		`<Destination><S3BucketDestination><Bucket>reports-crn</Bucket><Format>CSV</Format><Prefix>inventory</Prefix></S3BucketDestination></Destination>`+
+		`<IsEnabled>false</IsEnabled><Id>daily</Id><IncludedObjectVersions>All</IncludedObjectVersions>`+
+		`<OptionalFields><Field>Size</Field><Field>ETag</Field></OptionalFields><Schedule><Frequency>Weekly</Frequency></Schedule>`+
+		`</InventoryConfiguration>`)
+	output, err := getBucketInventoryConfiguration(s3Client, "bucket", "daily")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *output.IsEnabled || *output.IncludedObjectVersions != "All" || *output.Schedule.Frequency != "Weekly" || output.Filter != nil {
+		t.Errorf("unexpected configuration %v", output)
+	}
+	if len(output.OptionalFields) != 2 || *output.OptionalFields[1] != "ETag" {
+		t.Errorf("unexpected optional fields %v", output.OptionalFields)
+	}
+	expected := []map[string]interface{}{{"bucket_crn": "reports-crn", "format": "CSV", "prefix": "inventory"}}
+	if actual := inventoryDestinationGet(output.Destination); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	s3Client, _, _ = newTestS3Client(t, http.StatusNotFound, `<Error><Code>NoSuchConfiguration</Code><Message>The specified configuration does not exist.</Message></Error>`)
+	_, err = getBucketInventoryConfiguration(s3Client, "bucket", "daily")
+	if err == nil || !strings.Contains(err.Error(), "NoSuchConfiguration") {
+		t.Errorf("expected NoSuchConfiguration, got %v", err)
+	}
+}
+
+func TestParseInventoryId(t *testing.T) {
+	id := "crn:v1:bluemix:public:cloud-object-storage:global:a/1234:5678:bucket:bucket:meta:us-south:private:inventory:daily"
+	for info, expected := range map[string]string{
+		"bucketCRN":      "crn:v1:bluemix:public:cloud-object-storage:global:a/1234:5678:bucket:bucket",
+		"bucketName":     "bucket",
+		"bucketLocation": "us-south",
+		"endpointType":   "private",
+		"inventoryID":    "daily",
+	} {
+		if actual := parseInventoryId(id, info); actual != expected {
+			t.Errorf("%s: expected %s, got %s", info, expected, actual)
+		}
+	}
+}
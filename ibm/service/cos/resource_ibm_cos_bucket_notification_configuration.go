@@ -0,0 +1,344 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/aws/request"
+	"github.com/IBM/ibm-cos-sdk-go/private/checksum"
+	"github.com/IBM/ibm-cos-sdk-go/private/protocol"
+	"github.com/IBM/ibm-cos-sdk-go/private/protocol/restxml"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceIBMCOSBucketNotificationConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMCOSBucketNotificationConfigurationCreate,
+		ReadContext:   resourceIBMCOSBucketNotificationConfigurationRead,
+		UpdateContext: resourceIBMCOSBucketNotificationConfigurationUpdate,
+		DeleteContext: resourceIBMCOSBucketNotificationConfigurationDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket_crn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "COS bucket CRN",
+			},
+			"bucket_location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "COS bucket location",
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private", "direct"}),
+				Description:  "COS endpoint type: public, private, direct",
+				Default:      "public",
+			},
+			"notification_rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Rules that define the events of the bucket that are sent to a destination.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Unique identifier for the rule.",
+						},
+						"destination_crn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "CRN of the Event Notifications instance or Code Engine project that receives the events.",
+						},
+						"events": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Events of the bucket that are sent, for example s3:ObjectCreated:*.",
+						},
+						"filter": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "The objects the rule applies to.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"prefix": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The prefix of the object keys.",
+									},
+									"suffix": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The suffix of the object keys.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// The COS SDK has no notification configuration API, so the operations are built with the
+// REST XML protocol of the S3 client.
+type cosNotificationConfiguration struct {
+	_ struct{} `type:"structure"`
+
+	TopicConfigurations []*cosTopicConfiguration `locationName:"TopicConfiguration" type:"list" flattened:"true"`
+}
+
+type cosTopicConfiguration struct {
+	_ struct{} `type:"structure"`
+
+	Events []*string `locationName:"Event" type:"list" flattened:"true" required:"true"`
+
+	Filter *cosNotificationFilter `type:"structure"`
+
+	Id *string `type:"string"`
+
+	Topic *string `type:"string" required:"true"`
+}
+
+type cosNotificationFilter struct {
+	_ struct{} `type:"structure"`
+
+	Key *cosKeyFilter `locationName:"S3Key" type:"structure"`
+}
+
+type cosKeyFilter struct {
+	_ struct{} `type:"structure"`
+
+	FilterRules []*cosFilterRule `locationName:"FilterRule" type:"list" flattened:"true"`
+}
+
+type cosFilterRule struct {
+	_ struct{} `type:"structure"`
+
+	Name *string `type:"string"`
+
+	Value *string `type:"string"`
+}
+
+type putBucketNotificationConfigurationInput struct {
+	_ struct{} `locationName:"PutBucketNotificationConfigurationRequest" type:"structure" payload:"NotificationConfiguration"`
+
+	Bucket *string `location:"uri" locationName:"Bucket" type:"string" required:"true"`
+
+	NotificationConfiguration *cosNotificationConfiguration `locationName:"NotificationConfiguration" type:"structure" required:"true" xmlURI:"http://s3.amazonaws.com/doc/2006-03-01/"`
+}
+
+type getBucketNotificationConfigurationInput struct {
+	_ struct{} `locationName:"GetBucketNotificationConfigurationRequest" type:"structure"`
+
+	Bucket *string `location:"uri" locationName:"Bucket" type:"string" required:"true"`
+}
+
+func putBucketNotificationConfiguration(s3Client *s3.S3, bucketName string, configuration *cosNotificationConfiguration) error {
+	op := &request.Operation{
+		Name:       "PutBucketNotificationConfiguration",
+		HTTPMethod: "PUT",
+		HTTPPath:   "/{Bucket}?notification",
+	}
+	input := &putBucketNotificationConfigurationInput{
+		Bucket:                    aws.String(bucketName),
+		NotificationConfiguration: configuration,
+	}
+	req := s3Client.NewRequest(op, input, &struct{}{})
+	req.Handlers.Unmarshal.Swap(restxml.UnmarshalHandler.Name, protocol.UnmarshalDiscardBodyHandler)
+	req.Handlers.Build.PushBackNamed(request.NamedHandler{
+		Name: "contentMd5Handler",
+		Fn:   checksum.AddBodyContentMD5Handler,
+	})
+	return req.Send()
+}
+
+func getBucketNotificationConfiguration(s3Client *s3.S3, bucketName string) (*cosNotificationConfiguration, error) {
+	op := &request.Operation{
+		Name:       "GetBucketNotificationConfiguration",
+		HTTPMethod: "GET",
+		HTTPPath:   "/{Bucket}?notification",
+	}
+	input := &getBucketNotificationConfigurationInput{
+		Bucket: aws.String(bucketName),
+	}
+	output := &cosNotificationConfiguration{}
+	err := s3Client.NewRequest(op, input, output).Send()
+	return output, err
+}
+
+func notificationRuleSet(notificationRuleList []interface{}) *cosNotificationConfiguration {
+	configuration := &cosNotificationConfiguration{}
+	for _, l := range notificationRuleList {
+		ruleMap, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		notification_rule := cosTopicConfiguration{
+			Events: aws.StringSlice(flex.ExpandStringList(ruleMap["events"].([]interface{}))),
+			Topic:  aws.String(ruleMap["destination_crn"].(string)),
+		}
+		if ruleID, ok := ruleMap["rule_id"].(string); ok && ruleID != "" {
+			notification_rule.Id = aws.String(ruleID)
+		}
+		if filter, ok := ruleMap["filter"].([]interface{}); ok && len(filter) > 0 && filter[0] != nil {
+			filterMap := filter[0].(map[string]interface{})
+			keyFilter := &cosKeyFilter{}
+			for _, name := range []string{"prefix", "suffix"} {
+				if value, ok := filterMap[name].(string); ok && value != "" {
+					keyFilter.FilterRules = append(keyFilter.FilterRules, &cosFilterRule{Name: aws.String(name), Value: aws.String(value)})
+				}
+			}
+			if len(keyFilter.FilterRules) > 0 {
+				notification_rule.Filter = &cosNotificationFilter{Key: keyFilter}
+			}
+		}
+		configuration.TopicConfigurations = append(configuration.TopicConfigurations, &notification_rule)
+	}
+	return configuration
+}
+
+func notificationRulesGet(in []*cosTopicConfiguration) []map[string]interface{} {
+	notificationRules := make([]map[string]interface{}, 0, len(in))
+	for _, topicConfiguration := range in {
+		rule := make(map[string]interface{})
+		rule["rule_id"] = aws.StringValue(topicConfiguration.Id)
+		rule["destination_crn"] = aws.StringValue(topicConfiguration.Topic)
+		rule["events"] = aws.StringValueSlice(topicConfiguration.Events)
+		if topicConfiguration.Filter != nil && topicConfiguration.Filter.Key != nil && len(topicConfiguration.Filter.Key.FilterRules) > 0 {
+			filter := make(map[string]interface{})
+			for _, filterRule := range topicConfiguration.Filter.Key.FilterRules {
+				// The names of the filter rules are case insensitive
+				filter[strings.ToLower(aws.StringValue(filterRule.Name))] = aws.StringValue(filterRule.Value)
+			}
+			rule["filter"] = []map[string]interface{}{filter}
+		}
+		notificationRules = append(notificationRules, rule)
+	}
+	return notificationRules
+}
+
+func resourceIBMCOSBucketNotificationConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := d.Get("bucket_crn").(string)
+	bucketName := strings.Split(bucketCRN, ":bucket:")[1]
+	instanceCRN := fmt.Sprintf("%s::", strings.Split(bucketCRN, ":bucket:")[0])
+	bucketLocation := d.Get("bucket_location").(string)
+	endpointType := d.Get("endpoint_type").(string)
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	err = putBucketNotificationConfiguration(s3Client, bucketName, notificationRuleSet(d.Get("notification_rule").([]interface{})))
+	if err != nil {
+		return diag.Errorf("Failed to put notification configuration on the COS bucket %s, %v", bucketName, err)
+	}
+	bktID := fmt.Sprintf("%s:%s:%s:meta:%s:%s", strings.Replace(instanceCRN, "::", "", -1), "bucket", bucketName, bucketLocation, endpointType)
+	d.SetId(bktID)
+	return resourceIBMCOSBucketNotificationConfigurationRead(ctx, d, meta)
+}
+
+func resourceIBMCOSBucketNotificationConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := d.Get("bucket_crn").(string)
+	bucketName := strings.Split(bucketCRN, ":bucket:")[1]
+	instanceCRN := fmt.Sprintf("%s::", strings.Split(bucketCRN, ":bucket:")[0])
+	bucketLocation := d.Get("bucket_location").(string)
+	endpointType := d.Get("endpoint_type").(string)
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	if d.HasChange("notification_rule") {
+		err = putBucketNotificationConfiguration(s3Client, bucketName, notificationRuleSet(d.Get("notification_rule").([]interface{})))
+		if err != nil {
+			return diag.Errorf("Failed to update notification configuration on the COS bucket %s, %v", bucketName, err)
+		}
+	}
+	return resourceIBMCOSBucketNotificationConfigurationRead(ctx, d, meta)
+}
+
+func resourceIBMCOSBucketNotificationConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := parseWebsiteId(d.Id(), "bucketCRN")
+	bucketName := parseWebsiteId(d.Id(), "bucketName")
+	bucketLocation := parseWebsiteId(d.Id(), "bucketLocation")
+	instanceCRN := parseWebsiteId(d.Id(), "instanceCRN")
+	endpointType := parseWebsiteId(d.Id(), "endpointType")
+	d.Set("bucket_crn", bucketCRN)
+	d.Set("bucket_location", bucketLocation)
+	if endpointType != "" {
+		d.Set("endpoint_type", endpointType)
+	}
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	output, err := getBucketNotificationConfiguration(s3Client, bucketName)
+	if err != nil {
+		return diag.Errorf("[ERROR] Error getting notification configuration for the bucket %s, %v", bucketName, err)
+	}
+	// The notification configuration was removed outside of Terraform
+	if len(output.TopicConfigurations) == 0 {
+		d.SetId("")
+		return nil
+	}
+	d.Set("notification_rule", notificationRulesGet(output.TopicConfigurations))
+	return nil
+}
+
+func resourceIBMCOSBucketNotificationConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketName := parseWebsiteId(d.Id(), "bucketName")
+	bucketLocation := parseWebsiteId(d.Id(), "bucketLocation")
+	instanceCRN := parseWebsiteId(d.Id(), "instanceCRN")
+	endpointType := parseWebsiteId(d.Id(), "endpointType")
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	s3Client, err := getS3ClientSession(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.Errorf("%v", err)
+	}
+	// An empty notification configuration disables the notifications of the bucket
+	err = putBucketNotificationConfiguration(s3Client, bucketName, &cosNotificationConfiguration{})
+	if err != nil {
+		return diag.Errorf("failed to delete the notification configuration on the COS bucket %s, %v", bucketName, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package cos_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMCosBucket_Notification_Configuration_Basic(t *testing.T) {
+	serviceName := fmt.Sprintf("terraform_%d", acctest.RandIntRange(10, 100))
+	bucketName := fmt.Sprintf("terraform-notification%d", acctest.RandIntRange(10, 100))
+	bucketRegion := "us-south"
+	bucketClass := "standard"
+	bucketRegionType := "region_location"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMCosBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCosBucket_Notification_Configuration_Basic(serviceName, bucketName, bucketRegion, bucketClass, "s3:ObjectCreated:*"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMCosBucketExists("ibm_resource_instance.instance", "ibm_cos_bucket.bucket", bucketRegionType, bucketRegion, bucketName),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_notification_configuration.notification", "notification_rule.#", "1"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_notification_configuration.notification", "notification_rule.0.rule_id", "uploads"),
+					resource.TestCheckResourceAttrPair("ibm_cos_bucket_notification_configuration.notification", "notification_rule.0.destination_crn", "ibm_resource_instance.event_notifications", "crn"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_notification_configuration.notification", "notification_rule.0.events.0", "s3:ObjectCreated:*"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_notification_configuration.notification", "notification_rule.0.filter.0.prefix", "uploads/"),
+				),
+			},
+			{
+				Config: testAccCheckIBMCosBucket_Notification_Configuration_Basic(serviceName, bucketName, bucketRegion, bucketClass, "s3:ObjectRemoved:*"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_cos_bucket_notification_configuration.notification", "notification_rule.#", "1"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_notification_configuration.notification", "notification_rule.0.events.0", "s3:ObjectRemoved:*"),
+				),
+			},
+			{
+				ResourceName:      "ibm_cos_bucket_notification_configuration.notification",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCosBucket_Notification_Configuration_Basic(cosServiceName string, bucketName string, region string, storageClass string, event string) string {
+
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "cos_group" {
+		name = "Default"
+	}
+
+	resource "ibm_resource_instance" "instance" {
+		name              = "%[1]s"
+		service           = "cloud-object-storage"
+		plan              = "standard"
+		location          = "global"
+		resource_group_id = data.ibm_resource_group.cos_group.id
+	}
+	resource "ibm_resource_instance" "event_notifications" {
+		name              = "%[1]s-en"
+		service           = "event-notifications"
+		plan              = "standard"
+		location          = "%[3]s"
+		resource_group_id = data.ibm_resource_group.cos_group.id
+	}
+	resource "ibm_cos_bucket" "bucket" {
+		bucket_name          = "%[2]s"
+		resource_instance_id = ibm_resource_instance.instance.id
+		region_location      = "%[3]s"
+		storage_class        = "%[4]s"
+	}
+
+	resource ibm_cos_bucket_notification_configuration "notification" {
+		bucket_crn      = ibm_cos_bucket.bucket.crn
+		bucket_location = ibm_cos_bucket.bucket.region_location
+		notification_rule {
+			rule_id         = "uploads"
+			destination_crn = ibm_resource_instance.event_notifications.crn
+			events          = ["%[5]s"]
+			filter {
+				prefix = "uploads/"
+			}
+		}
+	}
+	`, cosServiceName, bucketName, region, storageClass, event)
+}
@@ -0,0 +1,95 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cos
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/aws/credentials"
+	"github.com/IBM/ibm-cos-sdk-go/aws/session"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+)
+
+// newTestS3Client returns a client for a server that records the last request and answers
+// with the given status and body.
+func newTestS3Client(t *testing.T, status int, response string) (*s3.S3, *http.Request, *string) {
+	var lastRequest http.Request
+	var lastBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lastRequest, lastBody = *r, string(body)
+		w.WriteHeader(status)
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+	s3Conf := aws.NewConfig().WithEndpoint(server.URL).WithRegion("us-south").WithCredentials(credentials.AnonymousCredentials).WithS3ForcePathStyle(true)
+	return s3.New(session.Must(session.NewSession()), s3Conf), &lastRequest, &lastBody
+}
+
+func TestNotificationConfigurationRequests(t *testing.T) {
+	rules := []interface{}{
+		map[string]interface{}{
+			"rule_id":         "uploads",
+			"destination_crn": "crn:v1:bluemix:public:event-notifications:us-south:a/1234::",
+			"events":          []interface{}{"s3:ObjectCreated:*"},
+			"filter": []interface{}{
+				map[string]interface{}{"prefix": "uploads/", "suffix": ""},
+			},
+		},
+	}
+
+	s3Client, lastRequest, lastBody := newTestS3Client(t, http.StatusOK, "")
+	err := putBucketNotificationConfiguration(s3Client, "bucket", notificationRuleSet(rules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastRequest.Method != "PUT" || lastRequest.URL.Path != "/bucket" || !lastRequest.URL.Query().Has("notification") {
+		t.Errorf("unexpected request %s %s", lastRequest.Method, lastRequest.URL)
+	}
+	for _, element := range []string{
+		"<Event>s3:ObjectCreated:*</Event>",
+		"<Filter><S3Key><FilterRule><Name>prefix</Name><Value>uploads/</Value></FilterRule></S3Key></Filter>",
+		"<Id>uploads</Id>",
+		"<Topic>crn:v1:bluemix:public:event-notifications:us-south:a/1234::</Topic>",
+	} {
+		if !strings.Contains(*lastBody, element) {
+			t.Errorf("expected %s in %s", element, *lastBody)
+		}
+	}
+
+	s3Client, _, _ = newTestS3Client(t, http.StatusOK, `<NotificationConfiguration>`+
+		`<TopicConfiguration><Id>uploads</Id><Topic>crn:v1:bluemix:public:event-notifications:us-south:a/1234::</Topic>`+
+		`<Event>s3:ObjectCreated:*</Event><Filter><S3Key><FilterRule><Name>Prefix</Name><Value>uploads/</Value></FilterRule></S3Key></Filter>`+
+		`</TopicConfiguration></NotificationConfiguration>`)
+	output, err := getBucketNotificationConfiguration(s3Client, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]interface{}{
+		{
+			"rule_id":         "uploads",
+			"destination_crn": "crn:v1:bluemix:public:event-notifications:us-south:a/1234::",
+			"events":          []string{"s3:ObjectCreated:*"},
+			"filter":          []map[string]interface{}{{"prefix": "uploads/"}},
+		},
+	}
+	if actual := notificationRulesGet(output.TopicConfigurations); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	s3Client, _, _ = newTestS3Client(t, http.StatusOK, `<NotificationConfiguration></NotificationConfiguration>`)
+	output, err = getBucketNotificationConfiguration(s3Client, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(output.TopicConfigurations) != 0 {
+		t.Errorf("expected no rules, got %v", output.TopicConfigurations)
+	}
+}
@@ -168,7 +168,7 @@ func resourceIBMCOSBucketObjectCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	s3Client, err := getS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -271,7 +271,7 @@ func resourceIBMCOSBucketObjectRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	s3Client, err := getS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -358,7 +358,7 @@ func resourceIBMCOSBucketObjectUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	s3Client, err := getS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -458,7 +458,7 @@ func resourceIBMCOSBucketObjectDelete(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	s3Client, err := getS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -493,14 +493,19 @@ func getCosEndpoint(bucketLocation string, endpointType string) string {
 	return ""
 }
 
-func getS3Client(bxSession *bxsession.Session, bucketLocation string, endpointType string, instanceCRN string) (*s3.S3, error) {
+// GetS3Client returns a client for the objects of the buckets of the COS
+// instance instanceCRN in bucketLocation, authenticated like the provider.
+func GetS3Client(bxSession *bxsession.Session, bucketLocation string, endpointType string, instanceCRN string) (*s3.S3, error) {
 	var s3Conf *aws.Config
 	visibility := endpointType
 	if endpointType == "direct" {
 		visibility = "private"
 	}
 	apiEndpoint := getCosEndpoint(bucketLocation, endpointType)
-	apiEndpoint = conns.FileFallBack(bxSession.Config.EndpointsFile, visibility, "IBMCLOUD_COS_ENDPOINT", bucketLocation, apiEndpoint)
+	apiEndpoint, err := conns.FileFallBack(bxSession.Config, visibility, "IBMCLOUD_COS_ENDPOINT", bucketLocation, apiEndpoint)
+	if err != nil {
+		return nil, err
+	}
 	apiEndpoint = conns.EnvFallBack([]string{"IBMCLOUD_COS_ENDPOINT"}, apiEndpoint)
 	if apiEndpoint == "" {
 		return nil, fmt.Errorf("the endpoint doesn't exists for given location %s and endpoint type %s", bucketLocation, endpointType)
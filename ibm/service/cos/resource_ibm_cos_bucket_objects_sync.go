@@ -0,0 +1,640 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cos
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3/s3manager"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// objectsSyncMD5Metadata is the user metadata holding the MD5 of the uploaded file, as the
+// ETag of an object uploaded in parts is not the MD5 of its content.
+const objectsSyncMD5Metadata = "Md5"
+
+func ResourceIBMCOSBucketObjectsSync() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMCOSBucketObjectsSyncCreate,
+		ReadContext:   resourceIBMCOSBucketObjectsSyncRead,
+		UpdateContext: resourceIBMCOSBucketObjectsSyncUpdate,
+		DeleteContext: resourceIBMCOSBucketObjectsSyncDelete,
+
+		CustomizeDiff: resourceIBMCOSBucketObjectsSyncDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket_crn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "COS bucket CRN",
+			},
+			"bucket_location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "COS bucket location",
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private", "direct"}),
+				Description:  "COS endpoint type: public, private, direct",
+				Default:      "public",
+			},
+			"source_directory": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Local directory that is mirrored to the bucket",
+			},
+			"key_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Prefix of the keys of the objects in the bucket, for example site/",
+			},
+			"include": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Glob patterns of the files to upload, relative to the source directory. All files are uploaded by default.",
+			},
+			"exclude": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Glob patterns of the files not to upload, relative to the source directory",
+			},
+			"delete_orphans": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the objects under the key prefix that match the patterns but have no file in the source directory",
+			},
+			"content_types": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Content types by file extension, for example .wasm = application/wasm, that override the detected content types",
+			},
+			"part_size_mb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      64,
+				ValidateFunc: validation.IntBetween(5, 5120),
+				Description:  "Size in MB of the parts of multipart uploads. Files larger than a part are uploaded in parts.",
+			},
+			"concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 64),
+				Description:  "Number of files, and of parts of each file, uploaded in parallel",
+			},
+			"files": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "MD5 of the synchronized files, by path relative to the source directory",
+			},
+			"etags": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "ETag of the synchronized objects, by path relative to the source directory",
+			},
+		},
+	}
+}
+
+type objectsSyncFile struct {
+	path string
+	md5  string
+	size int64
+}
+
+// objectsSyncMatch reports whether the slash separated path relative to the source directory
+// matches a glob pattern. Patterns without a slash match the file name in any directory. A **
+// segment matches any number of directories, so patterns ending with /** match everything below
+// a directory and patterns starting with **/ match below any directory.
+func objectsSyncMatch(pattern, rel string) bool {
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(rel))
+		return matched
+	}
+	return objectsSyncMatchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+func objectsSyncMatchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if objectsSyncMatchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+func objectsSyncSelected(rel string, include, exclude []string) bool {
+	if len(include) > 0 {
+		included := false
+		for _, pattern := range include {
+			if objectsSyncMatch(pattern, rel) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	for _, pattern := range exclude {
+		if objectsSyncMatch(pattern, rel) {
+			return false
+		}
+	}
+	return true
+}
+
+// objectsSyncLocalFiles walks the source directory and returns the selected files by slash
+// separated path relative to the directory. Symbolic links to files are uploaded as the file
+// they point to, while symbolic links to directories are not followed.
+func objectsSyncLocalFiles(directory string, include, exclude []string) (map[string]objectsSyncFile, error) {
+	files := make(map[string]objectsSyncFile)
+	err := filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type()&fs.ModeSymlink != 0 {
+			info, err := os.Stat(filePath)
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				log.Printf("[WARN] Skipping symbolic link (%s), which does not point to a file", filePath)
+				return nil
+			}
+		} else if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(directory, filePath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !objectsSyncSelected(rel, include, exclude) {
+			return nil
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		hash := md5.New()
+		size, err := io.Copy(hash, file)
+		if err != nil {
+			return err
+		}
+
+		files[rel] = objectsSyncFile{
+			path: filePath,
+			md5:  hex.EncodeToString(hash.Sum(nil)),
+			size: size,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error reading source directory (%s): %s", directory, err)
+	}
+	return files, nil
+}
+
+func resourceIBMCOSBucketObjectsSyncDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("source_directory") || !diff.NewValueKnown("include") || !diff.NewValueKnown("exclude") {
+		if err := diff.SetNewComputed("files"); err != nil {
+			return err
+		}
+		return diff.SetNewComputed("etags")
+	}
+
+	localFiles, err := objectsSyncLocalFiles(
+		diff.Get("source_directory").(string),
+		flex.ExpandStringList(diff.Get("include").([]interface{})),
+		flex.ExpandStringList(diff.Get("exclude").([]interface{})),
+	)
+	if err != nil {
+		return err
+	}
+
+	// The files uploaded by the first sync are only known once it ends, as a failed first sync
+	// keeps the files that it uploaded
+	if diff.Id() == "" {
+		if err := diff.SetNewComputed("files"); err != nil {
+			return err
+		}
+		return diff.SetNewComputed("etags")
+	}
+
+	files := make(map[string]interface{}, len(localFiles))
+	for rel, file := range localFiles {
+		files[rel] = file.md5
+	}
+
+	state := diff.Get("files").(map[string]interface{})
+	changed := len(state) != len(files)
+	for rel, md5 := range files {
+		if state[rel] != md5 {
+			changed = true
+			break
+		}
+	}
+	if changed {
+		if err := diff.SetNew("files", files); err != nil {
+			return err
+		}
+		return diff.SetNewComputed("etags")
+	}
+	return nil
+}
+
+func resourceIBMCOSBucketObjectsSyncCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := d.Get("bucket_crn").(string)
+	keyPrefix := d.Get("key_prefix").(string)
+	bucketLocation := d.Get("bucket_location").(string)
+
+	d.SetId(fmt.Sprintf("%s:prefix:%s:location:%s", bucketCRN, keyPrefix, bucketLocation))
+
+	// A failed first sync that uploaded files is reported as a warning rather than an error,
+	// as an error would taint the resource and the replacement would delete the uploaded
+	// objects. The uploaded files are kept in the state, so the next apply resumes the sync.
+	if err := objectsSyncApply(ctx, d, meta, map[string]interface{}{}, d.Timeout(schema.TimeoutCreate)); err != nil {
+		if len(d.Get("files").(map[string]interface{})) == 0 {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		diags := diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "The first sync of the objects is incomplete",
+			Detail:   fmt.Sprintf("%s\n\nThe uploaded files are kept in the state, and the next apply uploads the remaining files.", err),
+		}}
+		return append(diags, resourceIBMCOSBucketObjectsSyncRead(ctx, d, meta)...)
+	}
+
+	return resourceIBMCOSBucketObjectsSyncRead(ctx, d, meta)
+}
+
+func resourceIBMCOSBucketObjectsSyncUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The state holds the files as found in the bucket by the last refresh
+	state, _ := d.GetChange("files")
+	if err := objectsSyncApply(ctx, d, meta, state.(map[string]interface{}), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceIBMCOSBucketObjectsSyncRead(ctx, d, meta)
+}
+
+// objectsSyncApply uploads the local files whose MD5 differs from the state and, if requested,
+// deletes the orphan objects. It records the uploaded files in the state as it goes, so that an
+// interrupted sync resumes where it stopped.
+func objectsSyncApply(ctx context.Context, d *schema.ResourceData, meta interface{}, state map[string]interface{}, timeout time.Duration) error {
+	bucketCRN := d.Get("bucket_crn").(string)
+	bucketName := strings.Split(bucketCRN, ":bucket:")[1]
+	instanceCRN := fmt.Sprintf("%s::", strings.Split(bucketCRN, ":bucket:")[0])
+	bucketLocation := d.Get("bucket_location").(string)
+	endpointType := d.Get("endpoint_type").(string)
+	keyPrefix := d.Get("key_prefix").(string)
+	include := flex.ExpandStringList(d.Get("include").([]interface{}))
+	exclude := flex.ExpandStringList(d.Get("exclude").([]interface{}))
+
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return err
+	}
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return err
+	}
+
+	localFiles, err := objectsSyncLocalFiles(d.Get("source_directory").(string), include, exclude)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	concurrency := d.Get("concurrency").(int)
+	uploader := s3manager.NewUploaderWithClient(s3Client, func(u *s3manager.Uploader) {
+		u.PartSize = int64(d.Get("part_size_mb").(int)) * 1024 * 1024
+		u.Concurrency = concurrency
+	})
+	contentTypes := d.Get("content_types").(map[string]interface{})
+
+	files := make(map[string]interface{}, len(localFiles))
+	etags := make(map[string]interface{}, len(localFiles))
+	if v, ok := d.GetOk("etags"); ok {
+		for rel, etag := range v.(map[string]interface{}) {
+			if state[rel] != nil {
+				etags[rel] = etag
+			}
+		}
+	}
+
+	var mutex sync.Mutex
+	var uploadErr error
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+	for rel, file := range localFiles {
+		if state[rel] == file.md5 {
+			files[rel] = file.md5
+			continue
+		}
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(rel string, file objectsSyncFile) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			etag, err := objectsSyncUpload(ctx, uploader, bucketName, keyPrefix+rel, file, objectsSyncContentType(rel, contentTypes))
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				if uploadErr == nil {
+					uploadErr = err
+					cancel()
+				}
+				return
+			}
+			files[rel] = file.md5
+			etags[rel] = etag
+		}(rel, file)
+	}
+	wg.Wait()
+
+	if uploadErr != nil {
+		// The files that were not uploaded keep their previous state
+		for rel, md5 := range state {
+			if _, ok := files[rel]; !ok {
+				files[rel] = md5
+			}
+		}
+		d.Set("files", files)
+		d.Set("etags", etags)
+		return uploadErr
+	}
+
+	d.Set("files", files)
+	d.Set("etags", etags)
+
+	if d.Get("delete_orphans").(bool) {
+		remote, err := objectsSyncRemoteObjects(ctx, s3Client, bucketName, keyPrefix, include, exclude)
+		if err != nil {
+			return err
+		}
+
+		orphans := []string{}
+		for rel := range remote {
+			if _, ok := localFiles[rel]; !ok {
+				orphans = append(orphans, rel)
+			}
+		}
+		if err := objectsSyncDelete(ctx, s3Client, bucketName, keyPrefix, orphans); err != nil {
+			return err
+		}
+		for _, rel := range orphans {
+			delete(files, rel)
+			delete(etags, rel)
+		}
+		d.Set("files", files)
+		d.Set("etags", etags)
+	}
+	return nil
+}
+
+func objectsSyncContentType(rel string, contentTypes map[string]interface{}) string {
+	extension := strings.ToLower(path.Ext(rel))
+	if contentType, ok := contentTypes[extension]; ok {
+		return contentType.(string)
+	}
+	if contentType := mime.TypeByExtension(extension); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+func objectsSyncUpload(ctx context.Context, uploader *s3manager.Uploader, bucketName, key string, file objectsSyncFile, contentType string) (string, error) {
+	body, err := os.Open(file.path)
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error opening COS object file (%s): %s", file.path, err)
+	}
+	defer func() {
+		err := body.Close()
+		if err != nil {
+			log.Printf("[WARN] Failed closing COS object file (%s): %s", file.path, err)
+		}
+	}()
+
+	log.Printf("[DEBUG] Uploading %s (%d bytes) to COS bucket (%s) object (%s)", file.path, file.size, bucketName, key)
+	output, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+		Metadata: map[string]*string{
+			objectsSyncMD5Metadata: aws.String(file.md5),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error putting object (%s) in COS bucket (%s): %s", key, bucketName, err)
+	}
+	return strings.Trim(aws.StringValue(output.ETag), `"`), nil
+}
+
+// objectsSyncRemoteObjects lists the ETags of the objects under the key prefix that match the
+// patterns, by key relative to the prefix.
+func objectsSyncRemoteObjects(ctx context.Context, s3Client *s3.S3, bucketName, keyPrefix string, include, exclude []string) (map[string]string, error) {
+	objects := make(map[string]string)
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+	}
+	if keyPrefix != "" {
+		input.Prefix = aws.String(keyPrefix)
+	}
+	err := s3Client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			rel := strings.TrimPrefix(aws.StringValue(object.Key), keyPrefix)
+			if rel == "" || strings.HasSuffix(rel, "/") || !objectsSyncSelected(rel, include, exclude) {
+				continue
+			}
+			objects[rel] = strings.Trim(aws.StringValue(object.ETag), `"`)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error listing objects of COS bucket (%s): %s", bucketName, err)
+	}
+	return objects, nil
+}
+
+// objectsSyncDelete deletes the objects with the given keys relative to the prefix, in batches
+// of the maximum supported by the API.
+func objectsSyncDelete(ctx context.Context, s3Client *s3.S3, bucketName, keyPrefix string, keys []string) error {
+	const batchSize = 1000
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		objects := make([]*s3.ObjectIdentifier, 0, end-start)
+		for _, rel := range keys[start:end] {
+			objects = append(objects, &s3.ObjectIdentifier{Key: aws.String(keyPrefix + rel)})
+		}
+		output, err := s3Client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucketName),
+			Delete: &s3.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error deleting objects of COS bucket (%s): %s", bucketName, err)
+		}
+		if len(output.Errors) > 0 {
+			return fmt.Errorf("[ERROR] Error deleting object (%s) of COS bucket (%s): %s", aws.StringValue(output.Errors[0].Key), bucketName, aws.StringValue(output.Errors[0].Message))
+		}
+	}
+	return nil
+}
+
+func resourceIBMCOSBucketObjectsSyncRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := d.Get("bucket_crn").(string)
+	bucketName := strings.Split(bucketCRN, ":bucket:")[1]
+	instanceCRN := fmt.Sprintf("%s::", strings.Split(bucketCRN, ":bucket:")[0])
+	bucketLocation := d.Get("bucket_location").(string)
+	endpointType := d.Get("endpoint_type").(string)
+	keyPrefix := d.Get("key_prefix").(string)
+
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	remote, err := objectsSyncRemoteObjects(ctx, s3Client, bucketName, keyPrefix,
+		flex.ExpandStringList(d.Get("include").([]interface{})),
+		flex.ExpandStringList(d.Get("exclude").([]interface{})))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	state := d.Get("files").(map[string]interface{})
+	stateEtags := d.Get("etags").(map[string]interface{})
+
+	// Other objects under the prefix are only tracked when they are to be deleted
+	tracked := []string{}
+	for rel := range remote {
+		if _, ok := state[rel]; ok || d.Get("delete_orphans").(bool) {
+			tracked = append(tracked, rel)
+		}
+	}
+
+	files := make(map[string]interface{}, len(tracked))
+	etags := make(map[string]interface{}, len(tracked))
+	for _, rel := range tracked {
+		etag := remote[rel]
+		etags[rel] = etag
+
+		// Objects with the ETag of the last sync are unchanged
+		if md5, ok := state[rel]; ok && stateEtags[rel] == etag {
+			files[rel] = md5
+			continue
+		}
+
+		head, err := s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(keyPrefix + rel),
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed getting COS bucket (%s) object (%s): %w", bucketName, keyPrefix+rel, err))
+		}
+		if md5, ok := head.Metadata[objectsSyncMD5Metadata]; ok && md5 != nil {
+			files[rel] = aws.StringValue(md5)
+		} else {
+			files[rel] = etag
+		}
+	}
+
+	d.Set("files", files)
+	d.Set("etags", etags)
+	return nil
+}
+
+func resourceIBMCOSBucketObjectsSyncDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketCRN := d.Get("bucket_crn").(string)
+	bucketName := strings.Split(bucketCRN, ":bucket:")[1]
+	instanceCRN := fmt.Sprintf("%s::", strings.Split(bucketCRN, ":bucket:")[0])
+	bucketLocation := d.Get("bucket_location").(string)
+	endpointType := d.Get("endpoint_type").(string)
+
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	keys := []string{}
+	for rel := range d.Get("files").(map[string]interface{}) {
+		keys = append(keys, rel)
+	}
+	if err := objectsSyncDelete(ctx, s3Client, bucketName, d.Get("key_prefix").(string), keys); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cos_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMCOSBucketObjectsSync_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-testacc-cos-sync-%d", acctest.RandIntRange(10, 100))
+	instanceCRN := acc.CosCRN
+	sourceDirectory := t.TempDir()
+	writeFile := func(rel string, content string) {
+		filePath := filepath.Join(sourceDirectory, rel)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("index.html", "<html>index</html>")
+	writeFile("css/site.css", "body {}")
+	writeFile("notes.tmp", "not synchronized")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheckCOS(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIBMCOSBucketObjectsSyncConfig(name, instanceCRN, sourceDirectory),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ibm_cos_bucket_objects_sync.testacc", "id"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_objects_sync.testacc", "files.%", "2"),
+					resource.TestCheckResourceAttrSet("ibm_cos_bucket_objects_sync.testacc", "files.index.html"),
+					resource.TestCheckResourceAttrSet("ibm_cos_bucket_objects_sync.testacc", "files.css/site.css"),
+				),
+			},
+			{
+				PreConfig: func() {
+					writeFile("index.html", "<html>updated</html>")
+					os.Remove(filepath.Join(sourceDirectory, "css/site.css"))
+				},
+				Config: testAccIBMCOSBucketObjectsSyncConfig(name, instanceCRN, sourceDirectory),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_cos_bucket_objects_sync.testacc", "files.%", "1"),
+					resource.TestCheckResourceAttr("ibm_cos_bucket_objects_sync.testacc", "files.index.html", "fcd7bf96e745831f19106498876ee2ae"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIBMCOSBucketObjectsSyncConfig(name string, instanceCRN string, sourceDirectory string) string {
+	return fmt.Sprintf(`
+		resource "ibm_cos_bucket" "testacc" {
+			bucket_name          = "%[1]s"
+			resource_instance_id = "%[2]s"
+			region_location      = "us-east"
+			storage_class        = "standard"
+			force_delete         = true
+		}
+		resource "ibm_cos_bucket_objects_sync" "testacc" {
+			bucket_crn       = ibm_cos_bucket.testacc.crn
+			bucket_location  = ibm_cos_bucket.testacc.region_location
+			source_directory = "%[3]s"
+			key_prefix       = "site/"
+			exclude          = ["*.tmp"]
+			delete_orphans   = true
+		}`, name, instanceCRN, sourceDirectory)
+}
@@ -0,0 +1,119 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cos
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestObjectsSyncMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		rel     string
+		match   bool
+	}{
+		// patterns ending with /** match the directory and everything below it
+		{"assets/**", "assets", true},
+		{"assets/**", "assets/app.js", true},
+		{"assets/**", "assets/img/logo.png", true},
+		{"assets/**", "assets-old/app.js", false},
+		{"assets/**", "static/assets/app.js", false},
+		{"static/assets/**", "static/assets/img/logo.png", true},
+		// patterns without a slash match the file name in any directory
+		{"*.html", "index.html", true},
+		{"*.html", "docs/guide/index.html", true},
+		{"*.html", "index.htm", false},
+		{".DS_Store", "img/.DS_Store", true},
+		{"index.html", "docs/index.html", true},
+		// patterns with a slash match the whole path
+		{"docs/*.html", "docs/index.html", true},
+		{"docs/*.html", "docs/guide/index.html", false},
+		{"docs/*.html", "index.html", false},
+		{"*/index.html", "docs/index.html", true},
+		// ** segments match any number of directories
+		{"**/index.html", "index.html", true},
+		{"**/index.html", "docs/guide/index.html", true},
+		{"docs/**/*.html", "docs/index.html", true},
+		{"docs/**/*.html", "docs/guide/v1/index.html", true},
+		{"docs/**/*.html", "docs/guide/logo.png", false},
+		{"docs/**/*.html", "blog/guide/index.html", false},
+		{"docs/**/img/**", "docs/guide/img/logo.png", true},
+		{"docs/**/img/**", "docs/guide/logo.png", false},
+	} {
+		if match := objectsSyncMatch(tc.pattern, tc.rel); match != tc.match {
+			t.Errorf("objectsSyncMatch(%q, %q) = %t, expected %t", tc.pattern, tc.rel, match, tc.match)
+		}
+	}
+}
+
+func TestObjectsSyncSelected(t *testing.T) {
+	for _, tc := range []struct {
+		rel      string
+		include  []string
+		exclude  []string
+		selected bool
+	}{
+		{"index.html", nil, nil, true},
+		{"index.html", []string{"*.html"}, nil, true},
+		{"app.js", []string{"*.html"}, nil, false},
+		{"app.js", []string{"*.html", "*.js"}, nil, true},
+		{"app.js.map", nil, []string{"*.map"}, false},
+		// exclude takes precedence over include
+		{"drafts/post.html", []string{"*.html"}, []string{"drafts/**"}, false},
+		{"posts/post.html", []string{"*.html"}, []string{"drafts/**"}, true},
+		{"assets/img/logo.png", []string{"assets/**"}, []string{"*.psd"}, true},
+		{"assets/img/logo.psd", []string{"assets/**"}, []string{"*.psd"}, false},
+		{"logo.png", []string{"assets/**"}, []string{"*.psd"}, false},
+	} {
+		if selected := objectsSyncSelected(tc.rel, tc.include, tc.exclude); selected != tc.selected {
+			t.Errorf("objectsSyncSelected(%q, %q, %q) = %t, expected %t", tc.rel, tc.include, tc.exclude, selected, tc.selected)
+		}
+	}
+}
+
+func TestObjectsSyncLocalFiles(t *testing.T) {
+	directory := t.TempDir()
+	outside := t.TempDir()
+	for _, name := range []string{"index.html", "docs/guide.html", "docs/logo.png"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(directory, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(outside, "shared.css"), []byte("body {}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// links to files are followed, links to directories are not
+	for link, target := range map[string]string{
+		"shared.css": filepath.Join(outside, "shared.css"),
+		"latest":     filepath.Join(directory, "docs"),
+	} {
+		if err := os.Symlink(target, filepath.Join(directory, link)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := objectsSyncLocalFiles(directory, nil, []string{"*.png"})
+	if err != nil {
+		t.Fatalf("objectsSyncLocalFiles() returned error: %s", err)
+	}
+	paths := []string{}
+	for rel := range files {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+	if expected := []string{"docs/guide.html", "index.html", "shared.css"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("objectsSyncLocalFiles() = %v, expected %v", paths, expected)
+	}
+	// md5 of "body {}"
+	if file := files["shared.css"]; file.md5 != "fcdce6b6d6e2175f6406869882f6f1ce" || file.size != 7 {
+		t.Errorf("objectsSyncLocalFiles() read %+v for the linked file", file)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/cos"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/unittest"
 
 	"github.com/IBM/ibm-cos-sdk-go/aws"
 	"github.com/IBM/ibm-cos-sdk-go/aws/credentials/ibmiam"
@@ -4211,3 +4212,45 @@ func TestCrossRegionLocationRegex(t *testing.T) {
 		}
 	}
 }
+
+func TestMockIBMCosBucketBasic(t *testing.T) {
+	server := unittest.NewMockServer(t)
+	objectStorage := server.ObjectStorage(t)
+	instanceCRN := fmt.Sprintf("crn:v1:bluemix:public:cloud-object-storage:global:a/%s:mock-cos-instance::", unittest.MockAccountID)
+
+	server.UnitTest(t,
+		resource.TestStep{
+			Config: testAccCheckIBMCosBucketMockConfig(instanceCRN, "mock-bucket"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ibm_cos_bucket.bucket", "bucket_name", "mock-bucket"),
+				resource.TestCheckResourceAttr("ibm_cos_bucket.bucket", "region_location", "us-south"),
+				resource.TestCheckResourceAttr("ibm_cos_bucket.bucket", "storage_class", "standard"),
+				resource.TestCheckResourceAttr("ibm_cos_bucket.bucket", "crn", strings.TrimSuffix(instanceCRN, "::")+":bucket:mock-bucket"),
+				func(s *terraform.State) error {
+					bucket := objectStorage.Bucket("mock-bucket")
+					if bucket == nil {
+						return fmt.Errorf("bucket mock-bucket was not created on the mock server")
+					}
+					if bucket.LocationConstraint != "us-south-standard" {
+						return fmt.Errorf("expected location constraint us-south-standard, got %s", bucket.LocationConstraint)
+					}
+					return nil
+				},
+			),
+		},
+	)
+	if objectStorage.Bucket("mock-bucket") != nil {
+		t.Errorf("bucket mock-bucket still exists on the mock server")
+	}
+}
+
+func testAccCheckIBMCosBucketMockConfig(instanceCRN string, bucketName string) string {
+	return fmt.Sprintf(`
+	resource "ibm_cos_bucket" "bucket" {
+		bucket_name          = "%s"
+		resource_instance_id = "%s"
+		region_location      = "us-south"
+		storage_class        = "standard"
+	}
+	`, bucketName, instanceCRN)
+}
@@ -361,7 +361,10 @@ func getS3ClientSession(bxSession *bxsession.Session, bucketLocation string, end
 		visibility = "private"
 	}
 	apiEndpoint := getCosEndpointType(bucketLocation, endpointType)
-	apiEndpoint = conns.FileFallBack(bxSession.Config.EndpointsFile, visibility, "IBMCLOUD_COS_ENDPOINT", bucketLocation, apiEndpoint)
+	apiEndpoint, err := conns.FileFallBack(bxSession.Config, visibility, "IBMCLOUD_COS_ENDPOINT", bucketLocation, apiEndpoint)
+	if err != nil {
+		return nil, err
+	}
 	apiEndpoint = conns.EnvFallBack([]string{"IBMCLOUD_COS_ENDPOINT"}, apiEndpoint)
 	if apiEndpoint == "" {
 		return nil, fmt.Errorf("the endpoint doesn't exists for given location %s and endpoint type %s", bucketLocation, endpointType)
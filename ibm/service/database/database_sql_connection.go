@@ -0,0 +1,252 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+// databaseSQLConnectionSchema returns the connection block shared by the resources that manage
+// objects inside a database engine. The values map to the hosts, certificate and authentication
+// attributes of the ibm_database_connection data source. Changing them, for example to rotate the
+// admin password, does not replace the managed objects.
+func databaseSQLConnectionSchema(defaultDatabase string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: "Connection details of the database deployment, as returned by the ibm_database_connection data source",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"host": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Hostname for connection",
+				},
+				"port": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IsPortNumber,
+					Description:  "Port number for connection",
+				},
+				"username": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "User name to connect with, usually the admin user of the deployment",
+				},
+				"password": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Sensitive:   true,
+					Description: "Password of the user to connect with",
+				},
+				"database": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     defaultDatabase,
+					Description: "Database to connect to",
+				},
+				"certificate_base64": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Base64 encoded CA certificate used to verify the server certificate",
+				},
+				"sslmode": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "verify-full",
+					ValidateFunc: validation.StringInSlice([]string{"disable", "require", "verify-ca", "verify-full"}, false),
+					Description:  "TLS mode of the connection",
+				},
+			},
+		},
+	}
+}
+
+type databaseSQLConnection struct {
+	Host              string
+	Port              int
+	Username          string
+	Password          string
+	Database          string
+	CertificateBase64 string
+	SSLMode           string
+}
+
+func expandDatabaseSQLConnection(d *schema.ResourceData) *databaseSQLConnection {
+	connection := d.Get("connection_details").([]interface{})[0].(map[string]interface{})
+
+	return &databaseSQLConnection{
+		Host:              connection["host"].(string),
+		Port:              connection["port"].(int),
+		Username:          connection["username"].(string),
+		Password:          connection["password"].(string),
+		Database:          connection["database"].(string),
+		CertificateBase64: connection["certificate_base64"].(string),
+		SSLMode:           connection["sslmode"].(string),
+	}
+}
+
+// hasDatabaseSQLConnection reports whether the connection details are in the state. They are
+// not right after an import, which only knows the ID, until the next apply adds them from the
+// configuration.
+func hasDatabaseSQLConnection(d *schema.ResourceData) bool {
+	return len(d.Get("connection_details").([]interface{})) > 0
+}
+
+// parseDatabaseSQLID returns the given number of fields that follow the connection address in
+// the ID of a resource using it. The last field keeps any further | separator.
+func parseDatabaseSQLID(id string, fields int) ([]string, error) {
+	parts := strings.SplitN(id, "|", fields+1)
+	if len(parts) != fields+1 || parts[0] == "" {
+		return nil, fmt.Errorf("[ERROR] Incorrect ID %s: the ID must be the connection address followed by %d fields separated by |", id, fields)
+	}
+	return parts[1:], nil
+}
+
+// address returns the host and port of the connection, which prefixes the ID of the resources
+// using it.
+func (c *databaseSQLConnection) address() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+func (c *databaseSQLConnection) certificate() (string, error) {
+	if c.CertificateBase64 == "" {
+		return "", nil
+	}
+
+	certificate, err := base64.StdEncoding.DecodeString(c.CertificateBase64)
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error decoding the connection certificate: %s", err)
+	}
+	return string(certificate), nil
+}
+
+// openPostgres connects to the given database, or to the database of the connection block if
+// database is empty.
+func (c *databaseSQLConnection) openPostgres(database string) (*sql.DB, error) {
+	if database == "" {
+		database = c.Database
+	}
+
+	query := url.Values{}
+	query.Set("sslmode", c.SSLMode)
+
+	certificate, err := c.certificate()
+	if err != nil {
+		return nil, err
+	}
+	if certificate != "" && c.SSLMode != "disable" {
+		query.Set("sslinline", "true")
+		query.Set("sslrootcert", certificate)
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.Username, c.Password),
+		Host:     c.address(),
+		Path:     "/" + database,
+		RawQuery: query.Encode(),
+	}
+
+	db, err := sql.Open("postgres", dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error connecting to %s: %s", c.address(), err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("[ERROR] Error connecting to %s: %s", c.address(), err)
+	}
+	return db, nil
+}
+
+func (c *databaseSQLConnection) openMySQL() (*sql.DB, error) {
+	config := mysql.NewConfig()
+	config.Net = "tcp"
+	config.Addr = c.address()
+	config.User = c.Username
+	config.Passwd = c.Password
+	config.DBName = c.Database
+
+	switch c.SSLMode {
+	case "disable":
+		config.TLSConfig = "false"
+	case "require":
+		config.TLSConfig = "skip-verify"
+	default:
+		certificate, err := c.certificate()
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig := &tls.Config{
+			ServerName: c.Host,
+		}
+		if certificate != "" {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(certificate)) {
+				return nil, fmt.Errorf("[ERROR] Error parsing the connection certificate")
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		// The driver only accepts TLS settings registered under a name, which is shared by the
+		// connections of the process. The name includes the certificate, as two configurations
+		// may reach the same address with different certificates.
+		hash := sha256.Sum256([]byte(certificate))
+		config.TLSConfig = "ibm-" + c.address() + "-" + hex.EncodeToString(hash[:])
+		if err := mysql.RegisterTLSConfig(config.TLSConfig, tlsConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := sql.Open("mysql", config.FormatDSN())
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error connecting to %s: %s", c.address(), err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("[ERROR] Error connecting to %s: %s", c.address(), err)
+	}
+	return db, nil
+}
+
+// postgresQuoteIdentifiers quotes a list of identifiers for use in a statement.
+func postgresQuoteIdentifiers(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, pq.QuoteIdentifier(name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// mysqlQuoteIdentifier quotes an identifier for use in a statement.
+func mysqlQuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// mysqlQuoteLiteral quotes a string literal for use in a statement.
+func mysqlQuoteLiteral(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
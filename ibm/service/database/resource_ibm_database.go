@@ -18,6 +18,7 @@ import (
 	"time"
 
 	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -142,9 +143,12 @@ func ResourceIBMDatabaseInstance() *schema.Resource {
 			validateGroupsDiff,
 			validateUsersDiff,
 			validateVersionDiff,
-			validateRemoteLeaderIDDiff),
+			validateRemoteLeaderIDDiff,
+			validateManageAllowlistDiff),
 
-		Importer: &schema.ResourceImporter{},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIBMDatabaseInstanceImport,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
@@ -347,6 +351,12 @@ func ResourceIBMDatabaseInstance() *schema.Resource {
 					},
 				},
 			},
+			"manage_allowlist": {
+				Description: "Whether the allowlist of the deployment is managed by the allowlist attribute. Set to false to manage the allowlist with ibm_database_allowlist_entry.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
 			"allowlist": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -1707,7 +1717,13 @@ func resourceIBMDatabaseInstanceRead(context context.Context, d *schema.Resource
 		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database allowlist: %s", err))
 	}
 
-	d.Set("allowlist", flex.FlattenAllowlist(allowlist.IPAddresses))
+	// The allowlist managed by ibm_database_allowlist_entry is not reported, so that its
+	// entries do not show up as drift
+	if d.Get("manage_allowlist").(bool) {
+		d.Set("allowlist", flex.FlattenAllowlist(allowlist.IPAddresses))
+	} else {
+		d.Set("allowlist", nil)
+	}
 
 	//ICD does not implement a GetUsers API. Users populated from tf configuration.
 	tfusers := d.Get("users").(*schema.Set)
@@ -1994,37 +2010,11 @@ func resourceIBMDatabaseInstanceUpdate(context context.Context, d *schema.Resour
 		}
 	}
 
-	if d.HasChange("allowlist") {
-		_, hasAllowlist := d.GetOk("allowlist")
-
-		var entries interface{}
-
-		if hasAllowlist {
-			_, entries = d.GetChange("allowlist")
-		}
-
-		if entries == nil {
-			entries = new(schema.Set)
-		}
-
-		allowlistEntries := flex.ExpandAllowlist(entries.(*schema.Set))
-
-		setAllowlistOptions := &clouddatabasesv5.SetAllowlistOptions{
-			ID:          &instanceID,
-			IPAddresses: allowlistEntries,
-		}
-
-		setAllowlistResponse, _, err := cloudDatabasesClient.SetAllowlist(setAllowlistOptions)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("[ERROR] Error updating database allowlist entry: %s", err))
-		}
-
-		taskId := *setAllowlistResponse.Task.ID
-
-		_, err = waitForDatabaseTaskComplete(taskId, d, meta, d.Timeout(schema.TimeoutCreate))
+	if d.HasChange("allowlist") && d.Get("manage_allowlist").(bool) {
+		oldList, newList := d.GetChange("allowlist")
+		err = updateDatabaseAllowlist(instanceID, oldList.(*schema.Set), newList.(*schema.Set), d, meta)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf(
-				"[ERROR] Error waiting for update of database (%s) allowlist task to complete: %s", instanceID, err))
+			return diag.FromErr(err)
 		}
 	}
 
@@ -2391,6 +2381,81 @@ func waitForDatabaseTaskComplete(taskId string, d *schema.ResourceData, meta int
 	}
 }
 
+// resourceIBMDatabaseInstanceImport records the default of manage_allowlist, so that Read
+// reports the allowlist of the imported deployment.
+func resourceIBMDatabaseInstanceImport(context context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("manage_allowlist", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+// validateManageAllowlistDiff refuses allowlist blocks for a deployment whose allowlist is
+// left to ibm_database_allowlist_entry.
+func validateManageAllowlistDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Get("manage_allowlist").(bool) {
+		return nil
+	}
+	if hasDatabaseAllowlistConfig(diff.GetRawConfig()) {
+		return fmt.Errorf("[ERROR] allowlist cannot be set when manage_allowlist is false")
+	}
+	return nil
+}
+
+// hasDatabaseAllowlistConfig reports whether the configuration may contain allowlist blocks.
+func hasDatabaseAllowlistConfig(config cty.Value) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	allowlist := config.GetAttr("allowlist")
+	return !allowlist.IsNull() && (!allowlist.IsKnown() || allowlist.LengthInt() > 0)
+}
+
+// updateDatabaseAllowlist applies the difference between two allowlist sets entry by entry,
+// rather than replacing the allowlist of the deployment.
+func updateDatabaseAllowlist(instanceID string, oldList, newList *schema.Set, d *schema.ResourceData, meta interface{}) error {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error getting database client settings: %s", err)
+	}
+
+	for _, entry := range flex.ExpandAllowlist(oldList.Difference(newList)) {
+		deleteAllowlistEntryOptions := &clouddatabasesv5.DeleteAllowlistEntryOptions{
+			ID:        &instanceID,
+			Ipaddress: entry.Address,
+		}
+
+		deleteAllowlistEntryResponse, response, err := cloudDatabasesClient.DeleteAllowlistEntry(deleteAllowlistEntryOptions)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error deleting database allowlist entry (%s): %s\n%s", *entry.Address, err, response)
+		}
+
+		_, err = waitForDatabaseTaskComplete(*deleteAllowlistEntryResponse.Task.ID, d, meta, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return fmt.Errorf(
+				"[ERROR] Error waiting for database (%s) allowlist entry (%s) delete task to complete: %s", instanceID, *entry.Address, err)
+		}
+	}
+
+	for _, entry := range flex.ExpandAllowlist(newList.Difference(oldList)) {
+		addAllowlistEntryOptions := &clouddatabasesv5.AddAllowlistEntryOptions{
+			ID:        &instanceID,
+			IPAddress: &entry,
+		}
+
+		addAllowlistEntryResponse, response, err := cloudDatabasesClient.AddAllowlistEntry(addAllowlistEntryOptions)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error adding database allowlist entry (%s): %s\n%s", *entry.Address, err, response)
+		}
+
+		_, err = waitForDatabaseTaskComplete(*addAllowlistEntryResponse.Task.ID, d, meta, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return fmt.Errorf(
+				"[ERROR] Error waiting for database (%s) allowlist entry (%s) add task to complete: %s", instanceID, *entry.Address, err)
+		}
+	}
+
+	return nil
+}
+
 func waitForDatabaseInstanceDelete(d *schema.ResourceData, meta interface{}) (interface{}, error) {
 	rsConClient, err := meta.(conns.ClientSession).ResourceControllerV2API()
 	if err != nil {
@@ -2979,23 +3044,14 @@ func validateUsersDiff(_ context.Context, diff *schema.ResourceDiff, meta interf
 	service := diff.Get("service").(string)
 
 	var versionStr string
-	var version int
 
 	if _version, ok := diff.GetOk("version"); ok {
 		versionStr = _version.(string)
 	}
 
-	if versionStr == "" {
-		// Latest Version
-		version = 0
-	} else {
-		_v, err := strconv.ParseFloat(versionStr, 64)
-
-		if err != nil {
-			return fmt.Errorf("invalid version: %s", versionStr)
-		}
-
-		version = int(_v)
+	version, err := databaseMajorVersion(versionStr)
+	if err != nil {
+		return err
 	}
 
 	oldUsers, newUsers := diff.GetChange("users")
@@ -3007,34 +3063,59 @@ func validateUsersDiff(_ context.Context, diff *schema.ResourceDiff, meta interf
 		}
 
 		if change.isCreate() || change.isUpdate() {
-			err = change.New.ValidatePassword()
+			err = validateDatabaseUser(service, version, change.New)
 
 			if err != nil {
 				return err
 			}
+		}
+	}
 
-			// TODO: Use Capability API
-			// RBAC roles supported for Redis 6.0 and above
-			if (service == "databases-for-redis") && !(version > 0 && version < 6) {
-				err = change.New.ValidateRBACRole()
-			} else if service == "databases-for-mongodb" && change.New.Type == "ops_manager" {
-				err = change.New.ValidateOpsManagerRole()
-			} else {
-				if change.New.Role != nil {
-					if *change.New.Role != "" {
-						err = errors.New("role is not supported for this deployment or user type")
-						err = &databaseUserValidationError{user: change.New, errs: []error{err}}
-					}
-				}
-			}
+	return
+}
 
-			if err != nil {
-				return err
+// databaseMajorVersion returns the major version of a deployment version string, or 0 for
+// the latest version when the string is empty.
+func databaseMajorVersion(versionStr string) (int, error) {
+	if versionStr == "" {
+		// Latest Version
+		return 0, nil
+	}
+
+	_v, err := strconv.ParseFloat(versionStr, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid version: %s", versionStr)
+	}
+
+	return int(_v), nil
+}
+
+// validateDatabaseUser validates the password and role of a user for the given service and
+// major version.
+func validateDatabaseUser(service string, version int, user *DatabaseUser) (err error) {
+	err = user.ValidatePassword()
+
+	if err != nil {
+		return err
+	}
+
+	// TODO: Use Capability API
+	// RBAC roles supported for Redis 6.0 and above
+	if (service == "databases-for-redis") && !(version > 0 && version < 6) {
+		err = user.ValidateRBACRole()
+	} else if service == "databases-for-mongodb" && user.Type == "ops_manager" {
+		err = user.ValidateOpsManagerRole()
+	} else {
+		if user.Role != nil {
+			if *user.Role != "" {
+				err = errors.New("role is not supported for this deployment or user type")
+				err = &databaseUserValidationError{user: user, errs: []error{err}}
 			}
 		}
 	}
 
-	return
+	return err
 }
 
 func expandUsers(_users []interface{}) []*DatabaseUser {
@@ -0,0 +1,192 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/cloud-databases-go-sdk/clouddatabasesv5"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func ResourceIBMDatabaseAllowlistEntry() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseAllowlistEntryCreate,
+		ReadContext:   resourceIBMDatabaseAllowlistEntryRead,
+		DeleteContext: resourceIBMDatabaseAllowlistEntryDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Description: "The ID of the database deployment",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"address": {
+				Description:  "Allowlist IP address in CIDR notation",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ValidateCIDR,
+			},
+			"description": {
+				Description:  "Unique allow list description",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 32),
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseAllowlistEntryCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	address := d.Get("address").(string)
+
+	// An entry that already exists is most likely still managed by the allowlist attribute
+	// of ibm_database, and adding it again would fail.
+	entry, err := getDatabaseAllowlistEntry(deploymentID, address, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if entry != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Database allowlist entry (%s) already exists on deployment %s, set manage_allowlist to false on ibm_database and import it with terraform import", address, deploymentID))
+	}
+
+	allowlistEntry := &clouddatabasesv5.AllowlistEntry{
+		Address: &address,
+	}
+	if description, ok := d.GetOk("description"); ok {
+		allowlistEntry.Description = core.StringPtr(description.(string))
+	}
+
+	addAllowlistEntryOptions := &clouddatabasesv5.AddAllowlistEntryOptions{
+		ID:        &deploymentID,
+		IPAddress: allowlistEntry,
+	}
+
+	addAllowlistEntryResponse, response, err := cloudDatabasesClient.AddAllowlistEntry(addAllowlistEntryOptions)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error adding database allowlist entry (%s): %s\n%s", address, err, response))
+	}
+
+	_, err = waitForDatabaseTaskComplete(*addAllowlistEntryResponse.Task.ID, d, meta, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) allowlist entry (%s) add task to complete: %s", deploymentID, address, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s", deploymentID, address))
+
+	return resourceIBMDatabaseAllowlistEntryRead(context, d, meta)
+}
+
+func resourceIBMDatabaseAllowlistEntryRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parts := strings.Split(d.Id(), "|")
+	if len(parts) != 2 {
+		return diag.FromErr(fmt.Errorf("[ERROR] Incorrect ID %s: ID should be a combination of deploymentID|address", d.Id()))
+	}
+	deploymentID, address := parts[0], parts[1]
+
+	entry, err := getDatabaseAllowlistEntry(deploymentID, address, meta)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	// The allowlist attribute of ibm_database removes the entries it does not list, unless
+	// manage_allowlist is false on the deployment.
+	if entry == nil {
+		d.SetId("")
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Database allowlist entry (%s) was removed from deployment %s", address, deploymentID),
+			Detail:   "If the deployment is managed by ibm_database, set manage_allowlist to false on it so that its allowlist attribute does not remove the entries of ibm_database_allowlist_entry.",
+		}}
+	}
+
+	d.Set("deployment_id", deploymentID)
+	d.Set("address", entry.Address)
+	d.Set("description", entry.Description)
+
+	return nil
+}
+
+func resourceIBMDatabaseAllowlistEntryDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	address := d.Get("address").(string)
+
+	deleteAllowlistEntryOptions := &clouddatabasesv5.DeleteAllowlistEntryOptions{
+		ID:        &deploymentID,
+		Ipaddress: &address,
+	}
+
+	deleteAllowlistEntryResponse, response, err := cloudDatabasesClient.DeleteAllowlistEntry(deleteAllowlistEntryOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("[ERROR] Error deleting database allowlist entry (%s): %s\n%s", address, err, response))
+	}
+
+	_, err = waitForDatabaseTaskComplete(*deleteAllowlistEntryResponse.Task.ID, d, meta, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) allowlist entry (%s) delete task to complete: %s", deploymentID, address, err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func getDatabaseAllowlistEntry(deploymentID, address string, meta interface{}) (*clouddatabasesv5.AllowlistEntry, error) {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting database client settings: %s", err)
+	}
+
+	allowlist, response, err := cloudDatabasesClient.GetAllowlist(&clouddatabasesv5.GetAllowlistOptions{
+		ID: &deploymentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting database allowlist: %s\n%s", err, response)
+	}
+
+	for _, entry := range allowlist.IPAddresses {
+		if entry.Address != nil && *entry.Address == address {
+			return &entry, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabaseAllowlistEntryBasic(t *testing.T) {
+	t.Parallel()
+	databaseResourceGroup := "default"
+	var databaseInstanceOne string
+	testName := fmt.Sprintf("tf-Pgress-allowlist-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_allowlist_entry.entry"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseAllowlistEntryConfig(databaseResourceGroup, testName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMDatabaseInstanceExists("ibm_database."+testName, &databaseInstanceOne),
+					resource.TestCheckResourceAttr(name, "address", "172.168.1.2/32"),
+					resource.TestCheckResourceAttr(name, "description", "desc1"),
+					resource.TestCheckResourceAttr("ibm_database."+testName, "allowlist.#", "0"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// The allowlist attribute would remove the entry on its next apply
+				Config:      testAccCheckIBMDatabaseAllowlistEntryInlineConfig(databaseResourceGroup, testName),
+				ExpectError: regexp.MustCompile("allowlist cannot be set when manage_allowlist is false"),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseAllowlistEntryConfig(databaseResourceGroup string, name string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		is_default = true
+		# name = "%[1]s"
+	}
+
+	resource "ibm_database" "%[2]s" {
+		resource_group_id = data.ibm_resource_group.test_acc.id
+		name              = "%[2]s"
+		service           = "databases-for-postgresql"
+		plan              = "standard"
+		location          = "%[3]s"
+		service_endpoints = "public"
+		manage_allowlist  = false
+	}
+
+	resource "ibm_database_allowlist_entry" "entry" {
+		deployment_id = ibm_database.%[2]s.id
+		address       = "172.168.1.2/32"
+		description   = "desc1"
+	}
+				`, databaseResourceGroup, name, acc.Region())
+}
+
+func testAccCheckIBMDatabaseAllowlistEntryInlineConfig(databaseResourceGroup string, name string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		is_default = true
+		# name = "%[1]s"
+	}
+
+	resource "ibm_database" "%[2]s" {
+		resource_group_id = data.ibm_resource_group.test_acc.id
+		name              = "%[2]s"
+		service           = "databases-for-postgresql"
+		plan              = "standard"
+		location          = "%[3]s"
+		service_endpoints = "public"
+		manage_allowlist  = false
+		allowlist {
+			address     = "172.168.1.1/32"
+			description = "desc"
+		}
+	}
+
+	resource "ibm_database_allowlist_entry" "entry" {
+		deployment_id = ibm_database.%[2]s.id
+		address       = "172.168.1.2/32"
+		description   = "desc1"
+	}
+				`, databaseResourceGroup, name, acc.Region())
+}
@@ -0,0 +1,154 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/cloud-databases-go-sdk/clouddatabasesv5"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func ResourceIBMDatabaseConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseConfigurationUpdate,
+		ReadContext:   resourceIBMDatabaseConfigurationRead,
+		UpdateContext: resourceIBMDatabaseConfigurationUpdate,
+		DeleteContext: resourceIBMDatabaseConfigurationDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Description: "The ID of the database deployment",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"configuration": {
+				Type:     schema.TypeString,
+				Required: true,
+				StateFunc: func(v interface{}) string {
+					json, err := flex.NormalizeJSONString(v)
+					if err != nil {
+						return fmt.Sprintf("%q", err.Error())
+					}
+					return json
+				},
+				Description: "The configuration in JSON format",
+			},
+			"configuration_schema": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The configuration schema in JSON format",
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseConfigurationUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+
+	var rawConfig map[string]json.RawMessage
+	err = json.Unmarshal([]byte(d.Get("configuration").(string)), &rawConfig)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] configuration JSON invalid\n%s", err))
+	}
+
+	var configuration clouddatabasesv5.ConfigurationIntf = new(clouddatabasesv5.Configuration)
+	err = core.UnmarshalModel(rawConfig, "", &configuration, clouddatabasesv5.UnmarshalConfiguration)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] database configuration is invalid"))
+	}
+
+	updateDatabaseConfigurationOptions := &clouddatabasesv5.UpdateDatabaseConfigurationOptions{
+		ID:            &deploymentID,
+		Configuration: configuration,
+	}
+
+	updateDatabaseConfigurationResponse, response, err := cloudDatabasesClient.UpdateDatabaseConfiguration(updateDatabaseConfigurationOptions)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error updating database configuration failed %s\n%s", err, response))
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	_, err = waitForDatabaseTaskComplete(*updateDatabaseConfigurationResponse.Task.ID, d, meta, timeout)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) configuration update task to complete: %s", deploymentID, err))
+	}
+
+	d.SetId(deploymentID)
+
+	return resourceIBMDatabaseConfigurationRead(context, d, meta)
+}
+
+func resourceIBMDatabaseConfigurationRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	deploymentID := d.Id()
+
+	deployment, err := getDatabaseDeployment(deploymentID, meta)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("deployment_id", deploymentID)
+
+	// The API does not return the applied configuration values, so configuration keeps the
+	// value from the config and only the schema is refreshed.
+	switch *deployment.Type {
+	case "postgresql", "redis", "enterprisedb":
+		icdClient, err := meta.(conns.ClientSession).ICDAPI()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+		}
+
+		configSchema, err := icdClient.Configurations().GetConfiguration(flex.EscapeUrlParm(deploymentID))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error getting database (%s) configuration schema : %s", deploymentID, err))
+		}
+		s, err := json.Marshal(configSchema)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error marshalling the database configuration schema: %s", err))
+		}
+
+		if err = d.Set("configuration_schema", string(s)); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error setting the database configuration schema: %s", err))
+		}
+	}
+
+	return nil
+}
+
+func resourceIBMDatabaseConfigurationDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The configuration of a deployment cannot be reset, so the applied values are kept.
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabaseConfigurationBasic(t *testing.T) {
+	t.Parallel()
+	databaseResourceGroup := "default"
+	var databaseInstanceOne string
+	testName := fmt.Sprintf("tf-Pgress-config-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_configuration.config"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseConfigurationConfig(databaseResourceGroup, testName, 200),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMDatabaseInstanceExists("ibm_database."+testName, &databaseInstanceOne),
+					resource.TestCheckResourceAttr(name, "configuration", `{"max_connections":200}`),
+					resource.TestCheckResourceAttrSet(name, "configuration_schema"),
+				),
+			},
+			{
+				Config: testAccCheckIBMDatabaseConfigurationConfig(databaseResourceGroup, testName, 250),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "configuration", `{"max_connections":250}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseConfigurationConfig(databaseResourceGroup string, name string, maxConnections int) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		is_default = true
+		# name = "%[1]s"
+	}
+
+	resource "ibm_database" "%[2]s" {
+		resource_group_id = data.ibm_resource_group.test_acc.id
+		name              = "%[2]s"
+		service           = "databases-for-postgresql"
+		plan              = "standard"
+		location          = "%[3]s"
+		service_endpoints = "public"
+	}
+
+	resource "ibm_database_configuration" "config" {
+		deployment_id = ibm_database.%[2]s.id
+		configuration = jsonencode({
+			max_connections = %[4]d
+		})
+	}
+				`, databaseResourceGroup, name, acc.Region(), maxConnections)
+}
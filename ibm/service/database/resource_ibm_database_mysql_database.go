@@ -0,0 +1,149 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceIBMDatabaseMysqlDatabase() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseMysqlDatabaseCreate,
+		ReadContext:   resourceIBMDatabaseMysqlDatabaseRead,
+		UpdateContext: resourceIBMDatabaseMysqlDatabaseUpdate,
+		DeleteContext: resourceIBMDatabaseMysqlDatabaseDelete,
+
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"connection_details": databaseSQLConnectionSchema(""),
+			"name": {
+				Description: "Name of the database",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"default_character_set": {
+				Description: "Default character set of the database",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"default_collation": {
+				Description: "Default collation of the database",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseMysqlDatabaseCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openMySQL()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	name := d.Get("name").(string)
+	statement := fmt.Sprintf("CREATE DATABASE %s%s", mysqlQuoteIdentifier(name), mysqlDatabaseOptions(d))
+	if _, err = db.ExecContext(context, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error creating database (%s): %s", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s", connection.address(), name))
+
+	return resourceIBMDatabaseMysqlDatabaseRead(context, d, meta)
+}
+
+func resourceIBMDatabaseMysqlDatabaseRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parts, err := parseDatabaseSQLID(d.Id(), 1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := parts[0]
+	d.Set("name", name)
+	if !hasDatabaseSQLConnection(d) {
+		return nil
+	}
+
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openMySQL()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	var characterSet, collation string
+	err = db.QueryRowContext(context,
+		"SELECT DEFAULT_CHARACTER_SET_NAME, DEFAULT_COLLATION_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = ?", name).
+		Scan(&characterSet, &collation)
+	if err == sql.ErrNoRows {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error reading database (%s): %s", name, err))
+	}
+
+	d.Set("default_character_set", characterSet)
+	d.Set("default_collation", collation)
+
+	return nil
+}
+
+func resourceIBMDatabaseMysqlDatabaseUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChanges("default_character_set", "default_collation") {
+		connection := expandDatabaseSQLConnection(d)
+		db, err := connection.openMySQL()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer db.Close()
+
+		name := d.Get("name").(string)
+		statement := fmt.Sprintf("ALTER DATABASE %s%s", mysqlQuoteIdentifier(name), mysqlDatabaseOptions(d))
+		if _, err = db.ExecContext(context, statement); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error updating database (%s): %s", name, err))
+		}
+	}
+
+	return resourceIBMDatabaseMysqlDatabaseRead(context, d, meta)
+}
+
+func resourceIBMDatabaseMysqlDatabaseDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openMySQL()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	name := d.Get("name").(string)
+	if _, err = db.ExecContext(context, fmt.Sprintf("DROP DATABASE IF EXISTS %s", mysqlQuoteIdentifier(name))); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error deleting database (%s): %s", name, err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func mysqlDatabaseOptions(d *schema.ResourceData) string {
+	var options string
+	if characterSet, ok := d.GetOk("default_character_set"); ok {
+		options += " CHARACTER SET " + mysqlQuoteLiteral(characterSet.(string))
+	}
+	if collation, ok := d.GetOk("default_collation"); ok {
+		options += " COLLATE " + mysqlQuoteLiteral(collation.(string))
+	}
+	return options
+}
@@ -0,0 +1,76 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabaseMysqlDatabaseBasic(t *testing.T) {
+	name := fmt.Sprintf("tf_mysql_database_%d", acctest.RandIntRange(10, 100))
+	resourceName := "ibm_database_mysql_database.database"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseMysqlDatabaseConfig(name, "utf8mb4_general_ci"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "default_character_set", "utf8mb4"),
+					resource.TestCheckResourceAttr(resourceName, "default_collation", "utf8mb4_general_ci"),
+				),
+			},
+			{
+				Config: testAccCheckIBMDatabaseMysqlDatabaseConfig(name, "utf8mb4_bin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "default_collation", "utf8mb4_bin"),
+				),
+			},
+			{
+				// The import only reads the attributes of the ID, the other ones are read
+				// once the connection details are applied
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"connection_details",
+					"default_character_set",
+					"default_collation",
+				},
+			},
+		},
+	})
+}
+
+// testAccIBMDatabaseMysqlConnection connects to a local MySQL container by default, for example
+// one started with docker run -e MYSQL_ROOT_PASSWORD=mysql -p 3306:3306 mysql.
+func testAccIBMDatabaseMysqlConnection() string {
+	return fmt.Sprintf(`
+		connection_details {
+			host     = "%s"
+			port     = %s
+			username = "%s"
+			password = "%s"
+			sslmode  = "disable"
+		}`, acc.IcdDbMysqlHost, acc.IcdDbMysqlPort, acc.IcdDbMysqlUser, acc.IcdDbMysqlPassword)
+}
+
+func testAccCheckIBMDatabaseMysqlDatabaseConfig(name string, collation string) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_mysql_database" "database" {
+		%[1]s
+		name                  = "%[2]s"
+		default_character_set = "utf8mb4"
+		default_collation     = "%[3]s"
+	}
+	`, testAccIBMDatabaseMysqlConnection(), name, collation)
+}
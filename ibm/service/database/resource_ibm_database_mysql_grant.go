@@ -0,0 +1,235 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mysqlGrantAllPrivileges are the privileges that have to be granted for ALL to be reported.
+var mysqlGrantAllPrivileges = []string{"ALTER", "CREATE", "DELETE", "DROP", "INDEX", "INSERT", "SELECT", "UPDATE"}
+
+func ResourceIBMDatabaseMysqlGrant() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseMysqlGrantCreate,
+		ReadContext:   resourceIBMDatabaseMysqlGrantRead,
+		UpdateContext: resourceIBMDatabaseMysqlGrantUpdate,
+		DeleteContext: resourceIBMDatabaseMysqlGrantDelete,
+
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"connection_details": databaseSQLConnectionSchema(""),
+			"user": {
+				Description: "User the privileges are granted to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"host": {
+				Description: "Host of the user",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "%",
+			},
+			"database": {
+				Description: "Database the privileges are granted on",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"table": {
+				Description: "Table the privileges are granted on, * for every table of the database",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "*",
+			},
+			"privileges": {
+				Description: "Privileges to grant, for example SELECT or ALL",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseMysqlGrantCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openMySQL()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	user := d.Get("user").(string)
+	statement := fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(mysqlGrantExpandPrivileges(d.Get("privileges").(*schema.Set)), ", "), mysqlGrantTarget(d), mysqlGrantUser(d))
+	if _, err = db.ExecContext(context, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error granting privileges to user (%s): %s", user, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s|%s|%s|%s", connection.address(), user, d.Get("host").(string), d.Get("database").(string), d.Get("table").(string)))
+
+	return resourceIBMDatabaseMysqlGrantRead(context, d, meta)
+}
+
+func resourceIBMDatabaseMysqlGrantRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parts, err := parseDatabaseSQLID(d.Id(), 4)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	user, database, table := parts[0], parts[2], parts[3]
+	d.Set("user", user)
+	d.Set("host", parts[1])
+	d.Set("database", database)
+	d.Set("table", table)
+	if !hasDatabaseSQLConnection(d) {
+		return nil
+	}
+
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openMySQL()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	query := "SELECT PRIVILEGE_TYPE FROM information_schema.SCHEMA_PRIVILEGES WHERE GRANTEE = ? AND TABLE_SCHEMA = ?"
+	args := []interface{}{mysqlGrantee(d), database}
+	if table != "*" {
+		query = "SELECT PRIVILEGE_TYPE FROM information_schema.TABLE_PRIVILEGES WHERE GRANTEE = ? AND TABLE_SCHEMA = ? AND TABLE_NAME = ?"
+		args = append(args, table)
+	}
+
+	rows, err := db.QueryContext(context, query, args...)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error reading privileges of user (%s): %s", user, err))
+	}
+	defer rows.Close()
+
+	granted := []string{}
+	for rows.Next() {
+		var privilege string
+		if err = rows.Scan(&privilege); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error reading privileges of user (%s): %s", user, err))
+		}
+		granted = append(granted, privilege)
+	}
+
+	// Only the configured privileges are tracked, so privileges granted by other means do not
+	// show up as drift.
+	configured := flex.ExpandStringList(d.Get("privileges").(*schema.Set).List())
+	privileges := []string{}
+	for _, privilege := range configured {
+		switch strings.ToUpper(privilege) {
+		case "ALL", "ALL PRIVILEGES":
+			all := true
+			for _, p := range mysqlGrantAllPrivileges {
+				all = all && flex.StringContains(granted, p)
+			}
+			if all {
+				privileges = append(privileges, privilege)
+			}
+		default:
+			if flex.StringContains(granted, strings.ToUpper(privilege)) {
+				privileges = append(privileges, privilege)
+			}
+		}
+	}
+
+	if len(privileges) == 0 {
+		d.SetId("")
+		return nil
+	}
+	sort.Strings(privileges)
+	d.Set("privileges", privileges)
+
+	return nil
+}
+
+// resourceIBMDatabaseMysqlGrantUpdate revokes the removed privileges and grants the added ones.
+// Every other argument, except the connection details, forces a new grant.
+func resourceIBMDatabaseMysqlGrantUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("privileges") {
+		connection := expandDatabaseSQLConnection(d)
+		db, err := connection.openMySQL()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer db.Close()
+
+		user := d.Get("user").(string)
+		oldPrivileges, newPrivileges := d.GetChange("privileges")
+
+		if revoked := oldPrivileges.(*schema.Set).Difference(newPrivileges.(*schema.Set)); revoked.Len() > 0 {
+			statement := fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(mysqlGrantExpandPrivileges(revoked), ", "), mysqlGrantTarget(d), mysqlGrantUser(d))
+			if _, err = db.ExecContext(context, statement); err != nil {
+				return diag.FromErr(fmt.Errorf("[ERROR] Error revoking privileges from user (%s): %s", user, err))
+			}
+		}
+		if granted := newPrivileges.(*schema.Set).Difference(oldPrivileges.(*schema.Set)); granted.Len() > 0 {
+			statement := fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(mysqlGrantExpandPrivileges(granted), ", "), mysqlGrantTarget(d), mysqlGrantUser(d))
+			if _, err = db.ExecContext(context, statement); err != nil {
+				return diag.FromErr(fmt.Errorf("[ERROR] Error granting privileges to user (%s): %s", user, err))
+			}
+		}
+	}
+
+	return resourceIBMDatabaseMysqlGrantRead(context, d, meta)
+}
+
+func resourceIBMDatabaseMysqlGrantDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openMySQL()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	user := d.Get("user").(string)
+	statement := fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(mysqlGrantExpandPrivileges(d.Get("privileges").(*schema.Set)), ", "), mysqlGrantTarget(d), mysqlGrantUser(d))
+	if _, err = db.ExecContext(context, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error revoking privileges from user (%s): %s", user, err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func mysqlGrantExpandPrivileges(set *schema.Set) []string {
+	privileges := flex.ExpandStringList(set.List())
+	for i, privilege := range privileges {
+		privileges[i] = strings.ToUpper(privilege)
+	}
+	sort.Strings(privileges)
+	return privileges
+}
+
+func mysqlGrantTarget(d *schema.ResourceData) string {
+	table := d.Get("table").(string)
+	if table != "*" {
+		table = mysqlQuoteIdentifier(table)
+	}
+	return mysqlQuoteIdentifier(d.Get("database").(string)) + "." + table
+}
+
+func mysqlGrantUser(d *schema.ResourceData) string {
+	return mysqlQuoteLiteral(d.Get("user").(string)) + "@" + mysqlQuoteLiteral(d.Get("host").(string))
+}
+
+// mysqlGrantee returns the user in the 'user'@'host' form of the GRANTEE column of
+// information_schema.
+func mysqlGrantee(d *schema.ResourceData) string {
+	return fmt.Sprintf("'%s'@'%s'", d.Get("user").(string), d.Get("host").(string))
+}
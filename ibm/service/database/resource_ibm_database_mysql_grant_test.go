@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabaseMysqlGrantBasic(t *testing.T) {
+	name := fmt.Sprintf("tf_mysql_grant_%d", acctest.RandIntRange(10, 100))
+	resourceName := "ibm_database_mysql_grant.grant"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseMysqlGrantConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "table", "*"),
+					resource.TestCheckResourceAttr(resourceName, "privileges.#", "2"),
+				),
+			},
+			{
+				// The import only reads the attributes of the ID, the other ones are read
+				// once the connection details are applied
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"connection_details",
+					"privileges",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseMysqlGrantConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_mysql_database" "database" {
+		%[1]s
+		name = "%[2]s"
+	}
+
+	resource "ibm_database_mysql_user" "user" {
+		%[1]s
+		name     = "%[2]s"
+		password = "secure-Password12345"
+	}
+
+	resource "ibm_database_mysql_grant" "grant" {
+		%[1]s
+		user       = ibm_database_mysql_user.user.name
+		database   = ibm_database_mysql_database.database.name
+		privileges = ["SELECT", "INSERT"]
+	}
+	`, testAccIBMDatabaseMysqlConnection(), name)
+}
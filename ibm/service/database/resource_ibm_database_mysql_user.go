@@ -0,0 +1,137 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceIBMDatabaseMysqlUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseMysqlUserCreate,
+		ReadContext:   resourceIBMDatabaseMysqlUserRead,
+		UpdateContext: resourceIBMDatabaseMysqlUserUpdate,
+		DeleteContext: resourceIBMDatabaseMysqlUserDelete,
+
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"connection_details": databaseSQLConnectionSchema(""),
+			"name": {
+				Description: "Name of the user",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"host": {
+				Description: "Host the user can connect from",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "%",
+			},
+			"password": {
+				Description: "Password of the user",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseMysqlUserCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openMySQL()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	name := d.Get("name").(string)
+	host := d.Get("host").(string)
+
+	statement := fmt.Sprintf("CREATE USER %s@%s IDENTIFIED BY %s", mysqlQuoteLiteral(name), mysqlQuoteLiteral(host), mysqlQuoteLiteral(d.Get("password").(string)))
+	if _, err = db.ExecContext(context, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error creating user (%s): %s", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s|%s", connection.address(), name, host))
+
+	return resourceIBMDatabaseMysqlUserRead(context, d, meta)
+}
+
+func resourceIBMDatabaseMysqlUserRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parts, err := parseDatabaseSQLID(d.Id(), 2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name, host := parts[0], parts[1]
+	d.Set("name", name)
+	d.Set("host", host)
+	if !hasDatabaseSQLConnection(d) {
+		return nil
+	}
+
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openMySQL()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	var count int
+	err = db.QueryRowContext(context, "SELECT count(*) FROM mysql.user WHERE User = ? AND Host = ?", name, host).Scan(&count)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error reading user (%s): %s", name, err))
+	}
+	if count == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourceIBMDatabaseMysqlUserUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("password") {
+		connection := expandDatabaseSQLConnection(d)
+		db, err := connection.openMySQL()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer db.Close()
+
+		name := d.Get("name").(string)
+		statement := fmt.Sprintf("ALTER USER %s@%s IDENTIFIED BY %s", mysqlQuoteLiteral(name), mysqlQuoteLiteral(d.Get("host").(string)), mysqlQuoteLiteral(d.Get("password").(string)))
+		if _, err = db.ExecContext(context, statement); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error updating user (%s) password: %s", name, err))
+		}
+	}
+
+	return resourceIBMDatabaseMysqlUserRead(context, d, meta)
+}
+
+func resourceIBMDatabaseMysqlUserDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openMySQL()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	name := d.Get("name").(string)
+	statement := fmt.Sprintf("DROP USER IF EXISTS %s@%s", mysqlQuoteLiteral(name), mysqlQuoteLiteral(d.Get("host").(string)))
+	if _, err = db.ExecContext(context, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error deleting user (%s): %s", name, err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
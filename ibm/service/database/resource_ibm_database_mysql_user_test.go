@@ -0,0 +1,60 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabaseMysqlUserBasic(t *testing.T) {
+	name := fmt.Sprintf("tf_mysql_user_%d", acctest.RandIntRange(10, 100))
+	resourceName := "ibm_database_mysql_user.user"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseMysqlUserConfig(name, "secure-Password12345"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "host", "%"),
+				),
+			},
+			{
+				Config: testAccCheckIBMDatabaseMysqlUserConfig(name, "secure-Password67890"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "password", "secure-Password67890"),
+				),
+			},
+			{
+				// The import only reads the attributes of the ID, the other ones are read
+				// once the connection details are applied
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"connection_details",
+					"password",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseMysqlUserConfig(name string, password string) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_mysql_user" "user" {
+		%[1]s
+		name     = "%[2]s"
+		password = "%[3]s"
+	}
+	`, testAccIBMDatabaseMysqlConnection(), name, password)
+}
@@ -0,0 +1,139 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+func ResourceIBMDatabasePostgresqlDatabase() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabasePostgresqlDatabaseCreate,
+		ReadContext:   resourceIBMDatabasePostgresqlDatabaseRead,
+		UpdateContext: resourceIBMDatabasePostgresqlDatabaseUpdate,
+		DeleteContext: resourceIBMDatabasePostgresqlDatabaseDelete,
+
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"connection_details": databaseSQLConnectionSchema("ibmclouddb"),
+			"name": {
+				Description: "Name of the database",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"owner": {
+				Description: "Role that owns the database. Defaults to the user of the connection.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceIBMDatabasePostgresqlDatabaseCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres("")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	name := d.Get("name").(string)
+	statement := fmt.Sprintf("CREATE DATABASE %s", pq.QuoteIdentifier(name))
+	if owner, ok := d.GetOk("owner"); ok {
+		statement += fmt.Sprintf(" OWNER %s", pq.QuoteIdentifier(owner.(string)))
+	}
+
+	if _, err = db.ExecContext(context, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error creating database (%s): %s", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s", connection.address(), name))
+
+	return resourceIBMDatabasePostgresqlDatabaseRead(context, d, meta)
+}
+
+func resourceIBMDatabasePostgresqlDatabaseRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parts, err := parseDatabaseSQLID(d.Id(), 1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := parts[0]
+	d.Set("name", name)
+	if !hasDatabaseSQLConnection(d) {
+		return nil
+	}
+
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres("")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	var owner string
+	err = db.QueryRowContext(context, "SELECT pg_catalog.pg_get_userbyid(datdba) FROM pg_catalog.pg_database WHERE datname = $1", name).Scan(&owner)
+	if err == sql.ErrNoRows {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error reading database (%s): %s", name, err))
+	}
+
+	d.Set("owner", owner)
+
+	return nil
+}
+
+func resourceIBMDatabasePostgresqlDatabaseUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("owner") {
+		connection := expandDatabaseSQLConnection(d)
+		db, err := connection.openPostgres("")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer db.Close()
+
+		name := d.Get("name").(string)
+		statement := fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(d.Get("owner").(string)))
+		if _, err = db.ExecContext(context, statement); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error updating database (%s) owner: %s", name, err))
+		}
+	}
+
+	return resourceIBMDatabasePostgresqlDatabaseRead(context, d, meta)
+}
+
+func resourceIBMDatabasePostgresqlDatabaseDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres("")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	name := d.Get("name").(string)
+	if strings.EqualFold(name, connection.Database) {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error deleting database (%s): the database of the connection cannot be deleted", name))
+	}
+
+	if _, err = db.ExecContext(context, fmt.Sprintf("DROP DATABASE IF EXISTS %s", pq.QuoteIdentifier(name))); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error deleting database (%s): %s", name, err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
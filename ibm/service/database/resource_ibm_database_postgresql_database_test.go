@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabasePostgresqlDatabaseBasic(t *testing.T) {
+	name := fmt.Sprintf("tf_pg_database_%d", acctest.RandIntRange(10, 100))
+	resourceName := "ibm_database_postgresql_database.database"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabasePostgresqlDatabaseConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "owner", name+"_owner"),
+				),
+			},
+			{
+				// The import only reads the attributes of the ID, the other ones are read
+				// once the connection details are applied
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"connection_details",
+					"owner",
+				},
+			},
+		},
+	})
+}
+
+// testAccIBMDatabasePostgresqlConnection connects to a local Postgres container by default, for
+// example one started with docker run -e POSTGRES_PASSWORD=postgres -p 5432:5432 postgres.
+func testAccIBMDatabasePostgresqlConnection() string {
+	return fmt.Sprintf(`
+		connection_details {
+			host     = "%s"
+			port     = %s
+			username = "%s"
+			password = "%s"
+			database = "postgres"
+			sslmode  = "disable"
+		}`, acc.IcdDbPostgresqlHost, acc.IcdDbPostgresqlPort, acc.IcdDbPostgresqlUser, acc.IcdDbPostgresqlPassword)
+}
+
+func testAccCheckIBMDatabasePostgresqlDatabaseConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_postgresql_role" "owner" {
+		%[1]s
+		name  = "%[2]s_owner"
+		login = false
+	}
+
+	resource "ibm_database_postgresql_database" "database" {
+		%[1]s
+		name  = "%[2]s"
+		owner = ibm_database_postgresql_role.owner.name
+	}
+	`, testAccIBMDatabasePostgresqlConnection(), name)
+}
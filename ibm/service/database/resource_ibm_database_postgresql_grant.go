@@ -0,0 +1,307 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+// postgresGrantPrivileges lists the privileges that can be granted on each object type.
+var postgresGrantPrivileges = map[string][]string{
+	"database": {"CONNECT", "CREATE", "TEMPORARY"},
+	"schema":   {"CREATE", "USAGE"},
+	"table":    {"DELETE", "INSERT", "REFERENCES", "SELECT", "TRIGGER", "TRUNCATE", "UPDATE"},
+	"sequence": {"SELECT", "UPDATE", "USAGE"},
+}
+
+func ResourceIBMDatabasePostgresqlGrant() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabasePostgresqlGrantCreate,
+		ReadContext:   resourceIBMDatabasePostgresqlGrantRead,
+		UpdateContext: resourceIBMDatabasePostgresqlGrantUpdate,
+		DeleteContext: resourceIBMDatabasePostgresqlGrantDelete,
+
+		Importer: &schema.ResourceImporter{},
+
+		CustomizeDiff: resourceIBMDatabasePostgresqlGrantValidate,
+
+		Schema: map[string]*schema.Schema{
+			"connection_details": databaseSQLConnectionSchema("ibmclouddb"),
+			"role": {
+				Description: "Role the privileges are granted to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"database": {
+				Description: "Database the privileges are granted on, or that contains the schema",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"schema": {
+				Description: "Schema the privileges are granted on, or that contains the tables or sequences",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"object_type": {
+				Description:  "Type of the object the privileges are granted on",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"database", "schema", "table", "sequence"}, false),
+			},
+			"privileges": {
+				Description: "Privileges to grant, for example SELECT or CONNECT",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceIBMDatabasePostgresqlGrantValidate(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	objectType := diff.Get("object_type").(string)
+	if objectType == "" {
+		return nil
+	}
+
+	if _, ok := diff.GetOk("schema"); !ok && objectType != "database" {
+		return fmt.Errorf("schema is required for object_type %s", objectType)
+	}
+
+	allowed := postgresGrantPrivileges[objectType]
+	for _, privilege := range diff.Get("privileges").(*schema.Set).List() {
+		privilege := privilege.(string)
+		// Unknown until apply
+		if privilege == "" {
+			continue
+		}
+		if privilege != "ALL" && !flex.StringContains(allowed, privilege) {
+			return fmt.Errorf("privilege %s is not supported for object_type %s, supported privileges are ALL, %s", privilege, objectType, strings.Join(allowed, ", "))
+		}
+	}
+
+	return nil
+}
+
+func resourceIBMDatabasePostgresqlGrantCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres(d.Get("database").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	role := d.Get("role").(string)
+	privileges := postgresGrantExpandPrivileges(d.Get("privileges").(*schema.Set))
+
+	statement := fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(privileges, ", "), postgresGrantTarget(d), pq.QuoteIdentifier(role))
+	if _, err = db.ExecContext(context, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error granting privileges to role (%s): %s", role, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s|%s|%s|%s", connection.address(), d.Get("database").(string), d.Get("schema").(string), d.Get("object_type").(string), role))
+
+	return resourceIBMDatabasePostgresqlGrantRead(context, d, meta)
+}
+
+func resourceIBMDatabasePostgresqlGrantRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parts, err := parseDatabaseSQLID(d.Id(), 4)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	database, schemaName, role := parts[0], parts[1], parts[3]
+	d.Set("database", database)
+	d.Set("schema", schemaName)
+	d.Set("object_type", parts[2])
+	d.Set("role", role)
+	if !hasDatabaseSQLConnection(d) {
+		return nil
+	}
+
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres(database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	// Privileges on all tables or sequences of a schema are only reported when every object
+	// of the schema has them, so that objects created later show up as drift.
+	var query string
+	var args []interface{}
+	switch d.Get("object_type").(string) {
+	case "database":
+		query = `SELECT DISTINCT a.privilege_type FROM pg_catalog.pg_database o,
+			LATERAL aclexplode(o.datacl) a
+			WHERE o.datname = $1 AND a.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $2)`
+		args = []interface{}{database, role}
+	case "schema":
+		query = `SELECT DISTINCT a.privilege_type FROM pg_catalog.pg_namespace o,
+			LATERAL aclexplode(o.nspacl) a
+			WHERE o.nspname = $1 AND a.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $2)`
+		args = []interface{}{schemaName, role}
+	default:
+		relkind := "r"
+		if d.Get("object_type").(string) == "sequence" {
+			relkind = "S"
+		}
+		query = `SELECT a.privilege_type FROM pg_catalog.pg_class o
+			JOIN pg_catalog.pg_namespace n ON o.relnamespace = n.oid,
+			LATERAL aclexplode(o.relacl) a
+			WHERE n.nspname = $1 AND o.relkind = $3 AND a.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $2)
+			GROUP BY a.privilege_type
+			HAVING count(DISTINCT o.oid) = (SELECT count(*) FROM pg_catalog.pg_class c
+				JOIN pg_catalog.pg_namespace cn ON c.relnamespace = cn.oid
+				WHERE cn.nspname = $1 AND c.relkind = $3)`
+		args = []interface{}{schemaName, role, relkind}
+	}
+
+	rows, err := db.QueryContext(context, query, args...)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error reading privileges of role (%s): %s", role, err))
+	}
+	defer rows.Close()
+
+	granted := []string{}
+	for rows.Next() {
+		var privilege string
+		if err = rows.Scan(&privilege); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error reading privileges of role (%s): %s", role, err))
+		}
+		granted = append(granted, privilege)
+	}
+
+	objectType := d.Get("object_type").(string)
+	if len(granted) == 0 && (objectType == "table" || objectType == "sequence") {
+		// A schema without tables or sequences has nothing to report
+		var count int
+		err = db.QueryRowContext(context, `SELECT count(*) FROM pg_catalog.pg_class c
+			JOIN pg_catalog.pg_namespace n ON c.relnamespace = n.oid
+			WHERE n.nspname = $1 AND c.relkind = $2`, args[0], args[2]).Scan(&count)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error reading privileges of role (%s): %s", role, err))
+		}
+		if count == 0 {
+			return nil
+		}
+	}
+
+	// Only the configured privileges are tracked, so privileges granted by other means do not
+	// show up as drift. ALL is kept while every privilege of the object type is granted.
+	configured := flex.ExpandStringList(d.Get("privileges").(*schema.Set).List())
+	privileges := []string{}
+	if flex.StringContains(configured, "ALL") {
+		all := true
+		for _, privilege := range postgresGrantPrivileges[objectType] {
+			all = all && flex.StringContains(granted, privilege)
+		}
+		if all {
+			privileges = append(privileges, "ALL")
+		}
+	} else {
+		for _, privilege := range configured {
+			if flex.StringContains(granted, privilege) {
+				privileges = append(privileges, privilege)
+			}
+		}
+	}
+
+	if len(privileges) == 0 {
+		d.SetId("")
+		return nil
+	}
+	sort.Strings(privileges)
+	d.Set("privileges", privileges)
+
+	return nil
+}
+
+// resourceIBMDatabasePostgresqlGrantUpdate revokes the removed privileges and grants the added
+// ones. Every other argument, except the connection details, forces a new grant.
+func resourceIBMDatabasePostgresqlGrantUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("privileges") {
+		connection := expandDatabaseSQLConnection(d)
+		db, err := connection.openPostgres(d.Get("database").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer db.Close()
+
+		role := d.Get("role").(string)
+		oldPrivileges, newPrivileges := d.GetChange("privileges")
+
+		if revoked := oldPrivileges.(*schema.Set).Difference(newPrivileges.(*schema.Set)); revoked.Len() > 0 {
+			statement := fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(postgresGrantExpandPrivileges(revoked), ", "), postgresGrantTarget(d), pq.QuoteIdentifier(role))
+			if _, err = db.ExecContext(context, statement); err != nil {
+				return diag.FromErr(fmt.Errorf("[ERROR] Error revoking privileges from role (%s): %s", role, err))
+			}
+		}
+		if granted := newPrivileges.(*schema.Set).Difference(oldPrivileges.(*schema.Set)); granted.Len() > 0 {
+			statement := fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(postgresGrantExpandPrivileges(granted), ", "), postgresGrantTarget(d), pq.QuoteIdentifier(role))
+			if _, err = db.ExecContext(context, statement); err != nil {
+				return diag.FromErr(fmt.Errorf("[ERROR] Error granting privileges to role (%s): %s", role, err))
+			}
+		}
+	}
+
+	return resourceIBMDatabasePostgresqlGrantRead(context, d, meta)
+}
+
+func resourceIBMDatabasePostgresqlGrantDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres(d.Get("database").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	role := d.Get("role").(string)
+	privileges := postgresGrantExpandPrivileges(d.Get("privileges").(*schema.Set))
+
+	statement := fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(privileges, ", "), postgresGrantTarget(d), pq.QuoteIdentifier(role))
+	if _, err = db.ExecContext(context, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error revoking privileges from role (%s): %s", role, err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func postgresGrantExpandPrivileges(set *schema.Set) []string {
+	privileges := flex.ExpandStringList(set.List())
+	if flex.StringContains(privileges, "ALL") {
+		return []string{"ALL"}
+	}
+	sort.Strings(privileges)
+	return privileges
+}
+
+func postgresGrantTarget(d *schema.ResourceData) string {
+	schemaName := pq.QuoteIdentifier(d.Get("schema").(string))
+
+	switch d.Get("object_type").(string) {
+	case "database":
+		return "DATABASE " + pq.QuoteIdentifier(d.Get("database").(string))
+	case "schema":
+		return "SCHEMA " + schemaName
+	case "sequence":
+		return "ALL SEQUENCES IN SCHEMA " + schemaName
+	default:
+		return "ALL TABLES IN SCHEMA " + schemaName
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabasePostgresqlGrantBasic(t *testing.T) {
+	name := fmt.Sprintf("tf_pg_grant_%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabasePostgresqlGrantConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_database_postgresql_grant.connect", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("ibm_database_postgresql_grant.usage", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("ibm_database_postgresql_grant.tables", "privileges.#", "2"),
+				),
+			},
+			{
+				// The import only reads the attributes of the ID, the other ones are read
+				// once the connection details are applied
+				ResourceName:      "ibm_database_postgresql_grant.tables",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"connection_details",
+					"privileges",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabasePostgresqlGrantConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_postgresql_role" "role" {
+		%[1]s
+		name = "%[2]s"
+	}
+
+	resource "ibm_database_postgresql_database" "database" {
+		%[1]s
+		name = "%[2]s"
+	}
+
+	resource "ibm_database_postgresql_grant" "connect" {
+		%[1]s
+		role        = ibm_database_postgresql_role.role.name
+		database    = ibm_database_postgresql_database.database.name
+		object_type = "database"
+		privileges  = ["CONNECT"]
+	}
+
+	resource "ibm_database_postgresql_grant" "usage" {
+		%[1]s
+		role        = ibm_database_postgresql_role.role.name
+		database    = ibm_database_postgresql_database.database.name
+		schema      = "public"
+		object_type = "schema"
+		privileges  = ["USAGE"]
+	}
+
+	resource "ibm_database_postgresql_grant" "tables" {
+		%[1]s
+		role        = ibm_database_postgresql_role.role.name
+		database    = ibm_database_postgresql_database.database.name
+		schema      = "public"
+		object_type = "table"
+		privileges  = ["SELECT", "INSERT"]
+	}
+	`, testAccIBMDatabasePostgresqlConnection(), name)
+}
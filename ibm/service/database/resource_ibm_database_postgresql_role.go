@@ -0,0 +1,244 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+func ResourceIBMDatabasePostgresqlRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabasePostgresqlRoleCreate,
+		ReadContext:   resourceIBMDatabasePostgresqlRoleRead,
+		UpdateContext: resourceIBMDatabasePostgresqlRoleUpdate,
+		DeleteContext: resourceIBMDatabasePostgresqlRoleDelete,
+
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"connection_details": databaseSQLConnectionSchema("ibmclouddb"),
+			"name": {
+				Description: "Name of the role",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"password": {
+				Description: "Password of the role",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"login": {
+				Description: "Whether the role can log in",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"create_database": {
+				Description: "Whether the role can create databases",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"create_role": {
+				Description: "Whether the role can create other roles",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"connection_limit": {
+				Description:  "Maximum number of concurrent connections of the role, -1 for no limit",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+			"member_of": {
+				Description: "Roles the role is a member of",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceIBMDatabasePostgresqlRoleCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres("")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	name := d.Get("name").(string)
+	statement := fmt.Sprintf("CREATE ROLE %s WITH %s", pq.QuoteIdentifier(name), postgresRoleOptions(d))
+	if _, err = db.ExecContext(context, statement); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error creating role (%s): %s", name, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s", connection.address(), name))
+
+	if roles := d.Get("member_of").(*schema.Set); roles.Len() > 0 {
+		statement = fmt.Sprintf("GRANT %s TO %s", postgresQuoteIdentifiers(flex.ExpandStringList(roles.List())), pq.QuoteIdentifier(name))
+		if _, err = db.ExecContext(context, statement); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error granting role membership to role (%s): %s", name, err))
+		}
+	}
+
+	return resourceIBMDatabasePostgresqlRoleRead(context, d, meta)
+}
+
+func resourceIBMDatabasePostgresqlRoleRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parts, err := parseDatabaseSQLID(d.Id(), 1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := parts[0]
+	d.Set("name", name)
+	if !hasDatabaseSQLConnection(d) {
+		return nil
+	}
+
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres("")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	var login, createDatabase, createRole bool
+	var connectionLimit int
+	err = db.QueryRowContext(context,
+		"SELECT rolcanlogin, rolcreatedb, rolcreaterole, rolconnlimit FROM pg_catalog.pg_roles WHERE rolname = $1", name).
+		Scan(&login, &createDatabase, &createRole, &connectionLimit)
+	if err == sql.ErrNoRows {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error reading role (%s): %s", name, err))
+	}
+
+	rows, err := db.QueryContext(context,
+		`SELECT r.rolname FROM pg_catalog.pg_auth_members m
+		JOIN pg_catalog.pg_roles r ON m.roleid = r.oid
+		JOIN pg_catalog.pg_roles u ON m.member = u.oid
+		WHERE u.rolname = $1`, name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error reading role (%s) memberships: %s", name, err))
+	}
+	defer rows.Close()
+
+	memberOf := []string{}
+	for rows.Next() {
+		var role string
+		if err = rows.Scan(&role); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error reading role (%s) memberships: %s", name, err))
+		}
+		memberOf = append(memberOf, role)
+	}
+
+	d.Set("login", login)
+	d.Set("create_database", createDatabase)
+	d.Set("create_role", createRole)
+	d.Set("connection_limit", connectionLimit)
+	d.Set("member_of", memberOf)
+
+	return nil
+}
+
+func resourceIBMDatabasePostgresqlRoleUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres("")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	name := d.Get("name").(string)
+
+	if d.HasChanges("password", "login", "create_database", "create_role", "connection_limit") {
+		statement := fmt.Sprintf("ALTER ROLE %s WITH %s", pq.QuoteIdentifier(name), postgresRoleOptions(d))
+		if _, err = db.ExecContext(context, statement); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error updating role (%s): %s", name, err))
+		}
+	}
+
+	if d.HasChange("member_of") {
+		oldRoles, newRoles := d.GetChange("member_of")
+
+		if revoked := oldRoles.(*schema.Set).Difference(newRoles.(*schema.Set)); revoked.Len() > 0 {
+			statement := fmt.Sprintf("REVOKE %s FROM %s", postgresQuoteIdentifiers(flex.ExpandStringList(revoked.List())), pq.QuoteIdentifier(name))
+			if _, err = db.ExecContext(context, statement); err != nil {
+				return diag.FromErr(fmt.Errorf("[ERROR] Error revoking role membership from role (%s): %s", name, err))
+			}
+		}
+		if granted := newRoles.(*schema.Set).Difference(oldRoles.(*schema.Set)); granted.Len() > 0 {
+			statement := fmt.Sprintf("GRANT %s TO %s", postgresQuoteIdentifiers(flex.ExpandStringList(granted.List())), pq.QuoteIdentifier(name))
+			if _, err = db.ExecContext(context, statement); err != nil {
+				return diag.FromErr(fmt.Errorf("[ERROR] Error granting role membership to role (%s): %s", name, err))
+			}
+		}
+	}
+
+	return resourceIBMDatabasePostgresqlRoleRead(context, d, meta)
+}
+
+func resourceIBMDatabasePostgresqlRoleDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connection := expandDatabaseSQLConnection(d)
+	db, err := connection.openPostgres("")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	name := d.Get("name").(string)
+	if _, err = db.ExecContext(context, fmt.Sprintf("DROP ROLE IF EXISTS %s", pq.QuoteIdentifier(name))); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error deleting role (%s): %s", name, err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func postgresRoleOptions(d *schema.ResourceData) string {
+	options := []string{}
+
+	if d.Get("login").(bool) {
+		options = append(options, "LOGIN")
+	} else {
+		options = append(options, "NOLOGIN")
+	}
+	if d.Get("create_database").(bool) {
+		options = append(options, "CREATEDB")
+	} else {
+		options = append(options, "NOCREATEDB")
+	}
+	if d.Get("create_role").(bool) {
+		options = append(options, "CREATEROLE")
+	} else {
+		options = append(options, "NOCREATEROLE")
+	}
+	options = append(options, fmt.Sprintf("CONNECTION LIMIT %d", d.Get("connection_limit").(int)))
+
+	if password, ok := d.GetOk("password"); ok {
+		options = append(options, "PASSWORD "+pq.QuoteLiteral(password.(string)))
+	} else {
+		options = append(options, "PASSWORD NULL")
+	}
+
+	return strings.Join(options, " ")
+}
@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabasePostgresqlRoleBasic(t *testing.T) {
+	name := fmt.Sprintf("tf_pg_role_%d", acctest.RandIntRange(10, 100))
+	resourceName := "ibm_database_postgresql_role.role"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabasePostgresqlRoleConfig(name, "secure-Password12345", 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "login", "true"),
+					resource.TestCheckResourceAttr(resourceName, "connection_limit", "5"),
+					resource.TestCheckResourceAttr(resourceName, "member_of.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckIBMDatabasePostgresqlRoleConfig(name, "secure-Password67890", 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "connection_limit", "10"),
+				),
+			},
+			{
+				// The import only reads the attributes of the ID, the other ones are read
+				// once the connection details are applied
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"connection_details",
+					"password",
+					"login",
+					"create_database",
+					"create_role",
+					"connection_limit",
+					"member_of",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabasePostgresqlRoleConfig(name string, password string, connectionLimit int) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_postgresql_role" "readers" {
+		%[1]s
+		name  = "%[2]s_readers"
+		login = false
+	}
+
+	resource "ibm_database_postgresql_role" "role" {
+		%[1]s
+		name             = "%[2]s"
+		password         = "%[3]s"
+		connection_limit = %[4]d
+		member_of        = [ibm_database_postgresql_role.readers.name]
+	}
+	`, testAccIBMDatabasePostgresqlConnection(), name, password, connectionLimit)
+}
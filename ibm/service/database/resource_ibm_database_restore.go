@@ -0,0 +1,570 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/cloud-databases-go-sdk/clouddatabasesv5"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func ResourceIBMDatabaseRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseRestoreCreate,
+		ReadContext:   resourceIBMDatabaseRestoreRead,
+		UpdateContext: resourceIBMDatabaseRestoreUpdate,
+		DeleteContext: resourceIBMDatabaseRestoreDelete,
+
+		CustomizeDiff: resourceIBMDatabaseRestoreValidate,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the restored deployment",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source_deployment_id": {
+				Description: "The ID of the deployment that is restored or cloned",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"restore_type": {
+				Description:  "How the deployment is created: from a backup, from a point in time of the source deployment, or as a read replica of the source deployment",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"backup", "point_in_time", "read_replica"}, false),
+			},
+			"backup_id": {
+				Description: "The CRN of the backup to restore. Required for the backup restore type.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"point_in_time_recovery_time": {
+				Description: "The timestamp in UTC format to restore to, for example 2024-05-01T10:00:00Z. Defaults to the latest point in time.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"promote": {
+				Description: "Promote the read replica to a standalone deployment once it is created",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"skip_initial_backup": {
+				Description: "Skip the initial backup when the read replica is promoted",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"location": {
+				Description: "The location of the restored deployment. Defaults to the location of the source deployment.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+			},
+			"resource_group_id": {
+				Description: "The resource group of the restored deployment. Defaults to the resource group of the source deployment.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+			},
+			"service_endpoints": {
+				Description:  "Types of the service endpoints of the restored deployment",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"public", "private", "public-and-private"}, false),
+			},
+			"copy_allowlist": {
+				Description: "Copy the allowlist of the source deployment to the restored deployment",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"delete_on_destroy": {
+				Description: "Delete the restored deployment when the resource is destroyed. By default the deployment is only removed from the state.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"dns_record": {
+				Description: "CIS DNS record that is pointed to the restored deployment with a CNAME, and pointed back when the restored deployment is deleted",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cis_id": {
+							Description: "The CRN of the CIS instance",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"domain_id": {
+							Description: "The ID of the CIS domain",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"record_id": {
+							Description: "The ID of the DNS record",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"endpoint_type": {
+							Description:  "The endpoint of the restored deployment the record points to",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "public",
+							ValidateFunc: validation.StringInSlice([]string{"public", "private"}, false),
+						},
+						"previous_type": {
+							Description: "The type of the record before it was pointed to the restored deployment",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"previous_content": {
+							Description: "The content of the record before it was pointed to the restored deployment",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"hostname": {
+				Description: "The hostname of the restored deployment",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "The status of the restored deployment",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseRestoreValidate(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	restoreType := diff.Get("restore_type").(string)
+
+	_, hasBackup := diff.GetOk("backup_id")
+	if restoreType == "backup" && !hasBackup {
+		return fmt.Errorf("[ERROR] backup_id is required for the backup restore type")
+	}
+	if restoreType != "backup" && hasBackup {
+		return fmt.Errorf("[ERROR] backup_id is only supported for the backup restore type")
+	}
+	if _, ok := diff.GetOk("point_in_time_recovery_time"); ok && restoreType != "point_in_time" {
+		return fmt.Errorf("[ERROR] point_in_time_recovery_time is only supported for the point_in_time restore type")
+	}
+	if records, ok := diff.GetOk("dns_record"); ok && diff.NewValueKnown("service_endpoints") {
+		record, _ := records.([]interface{})[0].(map[string]interface{})
+		if endpointType, _ := record["endpoint_type"].(string); endpointType != "" {
+			if err := validateDatabaseRestoreDNSEndpoint(diff.Get("service_endpoints").(string), endpointType); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateDatabaseRestoreDNSEndpoint checks that the restored deployment has the
+// endpoint that the DNS record points to. The endpoints default to those of the
+// plan when service_endpoints is not set, which is only known once the deployment
+// is created.
+func validateDatabaseRestoreDNSEndpoint(serviceEndpoints, endpointType string) error {
+	if serviceEndpoints == "" || serviceEndpoints == "public-and-private" || serviceEndpoints == endpointType {
+		return nil
+	}
+	return fmt.Errorf("[ERROR] dns_record.endpoint_type is %s, but the service_endpoints of the restored deployment are %s", endpointType, serviceEndpoints)
+}
+
+func resourceIBMDatabaseRestoreCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rsConClient, err := meta.(conns.ClientSession).ResourceControllerV2API()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sourceID := d.Get("source_deployment_id").(string)
+	source, response, err := rsConClient.GetResourceInstance(&rc.GetResourceInstanceOptions{
+		ID: &sourceID,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error retrieving source deployment (%s): %s %s", sourceID, err, response))
+	}
+
+	location := *source.RegionID
+	if l, ok := d.GetOk("location"); ok {
+		location = l.(string)
+	}
+	resourceGroupID := *source.ResourceGroupID
+	if rg, ok := d.GetOk("resource_group_id"); ok {
+		resourceGroupID = rg.(string)
+	}
+
+	rsCatClient, err := meta.(conns.ClientSession).ResourceCatalogAPI()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	deployments, err := rsCatClient.ResourceCatalog().ListDeployments(*source.ResourcePlanID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error retrieving deployment for plan %s : %s", *source.ResourcePlanID, err))
+	}
+	deployments, supportedLocations := filterDatabaseDeployments(deployments, location)
+	if len(deployments) == 0 {
+		locationList := make([]string, 0, len(supportedLocations))
+		for l := range supportedLocations {
+			locationList = append(locationList, l)
+		}
+		return diag.FromErr(fmt.Errorf("[ERROR] No deployment found for service plan %s at location %s.\nValid location(s) are: %q", *source.ResourcePlanID, location, locationList))
+	}
+
+	restoreType := d.Get("restore_type").(string)
+	params := Params{
+		ServiceEndpoints: d.Get("service_endpoints").(string),
+	}
+	switch restoreType {
+	case "backup":
+		params.BackupID = d.Get("backup_id").(string)
+	case "point_in_time":
+		params.PITRDeploymentID = sourceID
+		pitrTime := strings.TrimSpace(d.Get("point_in_time_recovery_time").(string))
+		params.PITRTimeStamp = &pitrTime
+	case "read_replica":
+		params.RemoteLeaderID = sourceID
+	}
+	parameters, _ := json.Marshal(params)
+	var raw map[string]interface{}
+	json.Unmarshal(parameters, &raw)
+
+	name := d.Get("name").(string)
+	catalogCRN := deployments[0].CatalogCRN
+	instance, response, err := rsConClient.CreateResourceInstance(&rc.CreateResourceInstanceOptions{
+		Name:           &name,
+		Target:         &catalogCRN,
+		ResourceGroup:  &resourceGroupID,
+		ResourcePlanID: source.ResourcePlanID,
+		Parameters:     raw,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error creating restored database instance: %s %s", err, response))
+	}
+	d.SetId(*instance.ID)
+
+	// A restore that fails before the DNS record is switched over deletes the
+	// deployment it created. Otherwise the tainted resource would be replaced by a
+	// second deployment with the same name, and with delete_on_destroy unset the
+	// first one would keep running outside of the state.
+	fail := func(err error) diag.Diagnostics {
+		log.Printf("[WARN] Deleting restored deployment (%s) after the restore failed: %s", d.Id(), err)
+		if deleteErr := deleteDatabaseRestoreDeployment(d, meta); deleteErr != nil {
+			return diag.FromErr(fmt.Errorf("%s\n[ERROR] Error deleting the restored deployment (%s) after the restore failed, it is kept in the state: %s", err, d.Id(), deleteErr))
+		}
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForDatabaseInstanceCreate(d, meta, *instance.ID)
+	if err != nil {
+		return fail(fmt.Errorf(
+			"[ERROR] Error waiting for create database instance (%s) to complete: %s", *instance.ID, err))
+	}
+
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return fail(fmt.Errorf("[ERROR] Error getting database client settings: %s", err))
+	}
+
+	instanceID := *instance.ID
+
+	if restoreType == "read_replica" && d.Get("promote").(bool) {
+		promoteReadOnlyReplicaOptions := &clouddatabasesv5.PromoteReadOnlyReplicaOptions{
+			ID: &instanceID,
+			Promotion: map[string]interface{}{
+				"skip_initial_backup": d.Get("skip_initial_backup").(bool),
+			},
+		}
+
+		promoteReadReplicaResponse, response, err := cloudDatabasesClient.PromoteReadOnlyReplica(promoteReadOnlyReplicaOptions)
+		if err != nil {
+			return fail(fmt.Errorf("[ERROR] Error promoting read replica: %s\n%s", err, response))
+		}
+
+		_, err = waitForDatabaseTaskComplete(*promoteReadReplicaResponse.Task.ID, d, meta, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return fail(fmt.Errorf("[ERROR] Error promoting read replica: %s", err))
+		}
+	}
+
+	if d.Get("copy_allowlist").(bool) {
+		allowlist, response, err := cloudDatabasesClient.GetAllowlist(&clouddatabasesv5.GetAllowlistOptions{
+			ID: &sourceID,
+		})
+		if err != nil {
+			return fail(fmt.Errorf("[ERROR] Error getting source database allowlist: %s\n%s", err, response))
+		}
+
+		if len(allowlist.IPAddresses) > 0 {
+			setAllowlistResponse, response, err := cloudDatabasesClient.SetAllowlist(&clouddatabasesv5.SetAllowlistOptions{
+				ID:          &instanceID,
+				IPAddresses: allowlist.IPAddresses,
+			})
+			if err != nil {
+				return fail(fmt.Errorf("[ERROR] Error updating database allowlist: %s\n%s", err, response))
+			}
+
+			_, err = waitForDatabaseTaskComplete(*setAllowlistResponse.Task.ID, d, meta, d.Timeout(schema.TimeoutCreate))
+			if err != nil {
+				return fail(fmt.Errorf(
+					"[ERROR] Error waiting for update of database (%s) allowlist task to complete: %s", instanceID, err))
+			}
+		}
+	}
+
+	if records, ok := d.GetOk("dns_record"); ok {
+		record := records.([]interface{})[0].(map[string]interface{})
+
+		hostname, err := getDatabaseHostname(instanceID, record["endpoint_type"].(string), meta)
+		if err != nil {
+			return fail(err)
+		}
+		d.Set("hostname", hostname)
+
+		previousType, previousContent, err := updateDatabaseDNSRecord(record, "CNAME", hostname, "", meta)
+		if err != nil {
+			return fail(err)
+		}
+		record["previous_type"] = previousType
+		record["previous_content"] = previousContent
+		d.Set("dns_record", []interface{}{record})
+	}
+
+	return resourceIBMDatabaseRestoreRead(context, d, meta)
+}
+
+func resourceIBMDatabaseRestoreRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rsConClient, err := meta.(conns.ClientSession).ResourceControllerV2API()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceID := d.Id()
+	instance, response, err := rsConClient.GetResourceInstance(&rc.GetResourceInstanceOptions{
+		ID: &instanceID,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Object not found") ||
+			strings.Contains(err.Error(), "status code: 404") {
+			log.Printf("[WARN] Removing record from state because it's not found via the API")
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("[ERROR] Error retrieving resource instance: %s %s", err, response))
+	}
+	if strings.Contains(*instance.State, "removed") {
+		log.Printf("[WARN] Removing instance from TF state because it's now in removed state")
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", instance.Name)
+	d.Set("location", instance.RegionID)
+	d.Set("resource_group_id", instance.ResourceGroupID)
+	d.Set("status", instance.State)
+
+	return nil
+}
+
+func resourceIBMDatabaseRestoreUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// delete_on_destroy is the only argument that can be updated, and it is only kept in the state
+	return resourceIBMDatabaseRestoreRead(context, d, meta)
+}
+
+func resourceIBMDatabaseRestoreDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.Get("delete_on_destroy").(bool) {
+		log.Printf("[WARN] Removing restored deployment (%s) from the state without deleting it, set delete_on_destroy to delete it", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// Point the DNS record back before the restored deployment goes away, unless it was
+	// pointed elsewhere since it was switched over to the restored deployment
+	if records, ok := d.GetOk("dns_record"); ok {
+		record := records.([]interface{})[0].(map[string]interface{})
+		hostname := d.Get("hostname").(string)
+		if previousContent := record["previous_content"].(string); previousContent != "" && hostname != "" {
+			_, content, err := updateDatabaseDNSRecord(record, record["previous_type"].(string), previousContent, hostname, meta)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if !databaseDNSRecordPointsTo(content, hostname) {
+				log.Printf("[WARN] DNS record (%s) no longer points to the restored deployment (%s), leaving it unchanged", record["record_id"], hostname)
+			}
+		}
+	}
+
+	if err := deleteDatabaseRestoreDeployment(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return nil
+}
+
+// deleteDatabaseRestoreDeployment deletes the restored deployment and waits until it
+// is gone.
+func deleteDatabaseRestoreDeployment(d *schema.ResourceData, meta interface{}) error {
+	rsConClient, err := meta.(conns.ClientSession).ResourceControllerV2API()
+	if err != nil {
+		return err
+	}
+	id := d.Id()
+	recursive := true
+	response, err := rsConClient.DeleteResourceInstance(&rc.DeleteResourceInstanceOptions{
+		Recursive: &recursive,
+		ID:        &id,
+	})
+	if err != nil {
+		// If prior delete occurs, instance is not immediately deleted, but remains in "removed" state"
+		// RC 410 with "Gone" returned as error
+		if strings.Contains(err.Error(), "Gone") ||
+			strings.Contains(err.Error(), "status code: 410") {
+			log.Printf("[WARN] Resource instance already deleted %s\n ", err)
+		} else if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			return nil
+		} else {
+			return fmt.Errorf("[ERROR] Error deleting resource instance: %s %s ", err, response)
+		}
+	}
+
+	_, err = waitForDatabaseInstanceDelete(d, meta)
+	if err != nil {
+		return fmt.Errorf(
+			"[ERROR] Error waiting for resource instance (%s) to be deleted: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// getDatabaseHostname returns the first host of the connection of the admin user of a deployment.
+func getDatabaseHostname(instanceID, endpointType string, meta interface{}) (string, error) {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error getting database client settings: %s", err)
+	}
+
+	deployment, err := getDatabaseDeployment(instanceID, meta)
+	if err != nil {
+		return "", err
+	}
+
+	getConnectionOptions := &clouddatabasesv5.GetConnectionOptions{
+		ID:           &instanceID,
+		UserType:     core.StringPtr("database"),
+		UserID:       core.StringPtr(deployment.AdminUsernames["database"]),
+		EndpointType: &endpointType,
+	}
+	connection, response, err := cloudDatabasesClient.GetConnection(getConnectionOptions)
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error getting database (%s) connection: %s\n%s", instanceID, err, response)
+	}
+
+	conn := connection.Connection.(*clouddatabasesv5.Connection)
+	var hosts []clouddatabasesv5.ConnectionHost
+	switch {
+	case conn.Postgres != nil:
+		hosts = conn.Postgres.Hosts
+	case conn.Mysql != nil:
+		hosts = conn.Mysql.Hosts
+	case conn.Rediss != nil:
+		hosts = conn.Rediss.Hosts
+	case conn.Mongodb != nil:
+		hosts = conn.Mongodb.Hosts
+	case conn.HTTPS != nil:
+		hosts = conn.HTTPS.Hosts
+	case conn.Amqps != nil:
+		hosts = conn.Amqps.Hosts
+	}
+	if len(hosts) == 0 || hosts[0].Hostname == nil {
+		return "", fmt.Errorf("[ERROR] No %s host found for database (%s)", endpointType, instanceID)
+	}
+
+	return *hosts[0].Hostname, nil
+}
+
+// updateDatabaseDNSRecord points a CIS DNS record to the given content and returns the type and
+// content it had before. When ifContent is set, the record is only updated if it still points
+// to ifContent.
+func updateDatabaseDNSRecord(record map[string]interface{}, recordType, content, ifContent string, meta interface{}) (string, string, error) {
+	sess, err := meta.(conns.ClientSession).CisDNSRecordClientSession()
+	if err != nil {
+		return "", "", err
+	}
+	// domain_id and record_id accept both the plain IDs and the IDs of the CIS resources
+	zoneID, _, _ := flex.ConvertTftoCisTwoVar(record["domain_id"].(string))
+	recordID, _, _ := flex.ConvertTftoCisTwoVar(record["record_id"].(string))
+	sess.Crn = core.StringPtr(record["cis_id"].(string))
+	sess.ZoneIdentifier = core.StringPtr(zoneID)
+
+	result, response, err := sess.GetDnsRecord(sess.NewGetDnsRecordOptions(recordID))
+	if err != nil {
+		return "", "", fmt.Errorf("[ERROR] Error reading DNS record (%s): %s %s", recordID, err, response)
+	}
+	if ifContent != "" && !databaseDNSRecordPointsTo(*result.Result.Content, ifContent) {
+		return *result.Result.Type, *result.Result.Content, nil
+	}
+
+	opt := sess.NewUpdateDnsRecordOptions(recordID)
+	opt.SetType(recordType)
+	opt.SetName(*result.Result.Name)
+	opt.SetContent(content)
+	if result.Result.TTL != nil {
+		opt.SetTTL(*result.Result.TTL)
+	}
+	if result.Result.Proxied != nil {
+		opt.SetProxied(*result.Result.Proxied)
+	}
+
+	_, response, err = sess.UpdateDnsRecord(opt)
+	if err != nil {
+		return "", "", fmt.Errorf("[ERROR] Error updating DNS record (%s): %s %s", recordID, err, response)
+	}
+
+	return *result.Result.Type, *result.Result.Content, nil
+}
+
+// databaseDNSRecordPointsTo reports whether the content of a DNS record is the hostname, which
+// CIS may return as a fully qualified name or in another case.
+func databaseDNSRecordPointsTo(content, hostname string) bool {
+	return strings.EqualFold(strings.TrimSuffix(content, "."), strings.TrimSuffix(hostname, "."))
+}
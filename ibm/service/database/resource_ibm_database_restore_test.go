@@ -0,0 +1,312 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/cloud-databases-go-sdk/clouddatabasesv5"
+	"github.com/IBM/go-sdk-core/v5/core"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMDatabaseRestoreBackup(t *testing.T) {
+	t.Parallel()
+	testName := fmt.Sprintf("tf-restore-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_restore.restore"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseRestoreDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseRestoreBackupConfig(testName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", testName),
+					resource.TestCheckResourceAttr(name, "restore_type", "backup"),
+					resource.TestCheckResourceAttr(name, "status", "active"),
+					resource.TestCheckResourceAttrSet(name, "location"),
+					resource.TestCheckResourceAttrSet(name, "resource_group_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIBMDatabaseRestorePointInTime(t *testing.T) {
+	t.Parallel()
+	testName := fmt.Sprintf("tf-pitr-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_restore.restore"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseRestoreDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseRestorePointInTimeConfig(testName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", testName),
+					resource.TestCheckResourceAttr(name, "restore_type", "point_in_time"),
+					resource.TestCheckResourceAttr(name, "status", "active"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIBMDatabaseRestoreReadReplica(t *testing.T) {
+	t.Parallel()
+	testName := fmt.Sprintf("tf-replica-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_restore.restore"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseRestoreDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseRestoreReadReplicaConfig(testName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", testName),
+					resource.TestCheckResourceAttr(name, "restore_type", "read_replica"),
+					resource.TestCheckResourceAttr(name, "promote", "true"),
+					resource.TestCheckResourceAttr(name, "status", "active"),
+					// the promotion task has completed once the resource is created
+					testAccCheckIBMDatabaseRestorePromoted(name),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIBMDatabaseRestoreDNSRecord(t *testing.T) {
+	t.Parallel()
+	testName := fmt.Sprintf("tf-dns-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_restore.restore"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseRestoreDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseRestoreDNSRecordConfig(testName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", testName),
+					resource.TestCheckResourceAttrSet(name, "hostname"),
+					resource.TestCheckResourceAttr(name, "dns_record.0.previous_type", "A"),
+					resource.TestCheckResourceAttr(name, "dns_record.0.previous_content", "192.168.0.10"),
+					testAccCheckIBMDatabaseRestoreDNSRecordPointsToHostname(name),
+				),
+			},
+			{
+				// deleting the restored deployment points the record back
+				Config: testAccCheckIBMDatabaseRestoreDNSRecordConfig(testName, false),
+				Check:  testAccCheckIBMDatabaseRestoreDNSRecord("ibm_cis_dns_record.database", "A", "192.168.0.10"),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseRestorePromoted(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		cloudDatabasesClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).CloudDatabasesV5()
+		if err != nil {
+			return err
+		}
+		remotes, response, err := cloudDatabasesClient.ListRemotes(&clouddatabasesv5.ListRemotesOptions{
+			ID: &rs.Primary.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error listing remotes of database (%s): %s %s", rs.Primary.ID, err, response)
+		}
+		if remotes.Remotes != nil && remotes.Remotes.Leader != nil && *remotes.Remotes.Leader != "" {
+			return fmt.Errorf("Database %s is still a read replica of %s", rs.Primary.ID, *remotes.Remotes.Leader)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMDatabaseRestoreDNSRecordPointsToHostname(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		hostname := rs.Primary.Attributes["hostname"]
+
+		recordType, content, err := testAccGetDatabaseRestoreDNSRecord(s, "ibm_cis_dns_record.database")
+		if err != nil {
+			return err
+		}
+		if recordType != "CNAME" || !strings.EqualFold(strings.TrimSuffix(content, "."), hostname) {
+			return fmt.Errorf("DNS record is %s %s, expected CNAME %s", recordType, content, hostname)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMDatabaseRestoreDNSRecord(n, expectedType, expectedContent string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		recordType, content, err := testAccGetDatabaseRestoreDNSRecord(s, n)
+		if err != nil {
+			return err
+		}
+		if recordType != expectedType || content != expectedContent {
+			return fmt.Errorf("DNS record is %s %s, expected %s %s", recordType, content, expectedType, expectedContent)
+		}
+		return nil
+	}
+}
+
+// testAccGetDatabaseRestoreDNSRecord returns the type and content of an ibm_cis_dns_record from CIS
+// rather than from the state, which is not refreshed after ibm_database_restore changes the record.
+func testAccGetDatabaseRestoreDNSRecord(s *terraform.State, n string) (string, string, error) {
+	rs, ok := s.RootModule().Resources[n]
+	if !ok {
+		return "", "", fmt.Errorf("Not found: %s", n)
+	}
+
+	cisClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).CisDNSRecordClientSession()
+	if err != nil {
+		return "", "", err
+	}
+	recordID, zoneID, cisID, _ := flex.ConvertTfToCisThreeVar(rs.Primary.ID)
+	cisClient.Crn = core.StringPtr(cisID)
+	cisClient.ZoneIdentifier = core.StringPtr(zoneID)
+	result, response, err := cisClient.GetDnsRecord(cisClient.NewGetDnsRecordOptions(recordID))
+	if err != nil {
+		return "", "", fmt.Errorf("[ERROR] Error reading DNS record (%s): %s %s", recordID, err, response)
+	}
+	return *result.Result.Type, *result.Result.Content, nil
+}
+
+func testAccCheckIBMDatabaseRestoreDestroy(s *terraform.State) error {
+	rsContClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).ResourceControllerV2API()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_database_restore" {
+			continue
+		}
+
+		instanceID := rs.Primary.ID
+		instance, response, err := rsContClient.GetResourceInstance(&rc.GetResourceInstanceOptions{
+			ID: &instanceID,
+		})
+		if err == nil {
+			if !strings.Contains(*instance.State, "removed") {
+				return fmt.Errorf("Database still exists: %s", rs.Primary.ID)
+			}
+		} else if !strings.Contains(err.Error(), "404") {
+			return fmt.Errorf("[ERROR] Error checking if instance (%s) has been destroyed: %s %s", rs.Primary.ID, err, response)
+		}
+	}
+	return nil
+}
+
+func testAccCheckIBMDatabaseRestoreBackupConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_restore" "restore" {
+		name                 = "%[1]s"
+		source_deployment_id = "%[2]s"
+		restore_type         = "backup"
+		backup_id            = "%[3]s"
+		delete_on_destroy    = true
+	}
+				`, name, acc.IcdDbDeploymentId, acc.IcdDbBackupId)
+}
+
+func testAccCheckIBMDatabaseRestorePointInTimeConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_restore" "restore" {
+		name                        = "%[1]s"
+		source_deployment_id        = "%[2]s"
+		restore_type                = "point_in_time"
+		point_in_time_recovery_time = ""
+		copy_allowlist              = false
+		delete_on_destroy           = true
+	}
+				`, name, acc.IcdDbDeploymentId)
+}
+
+func testAccCheckIBMDatabaseRestoreReadReplicaConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_restore" "restore" {
+		name                 = "%[1]s"
+		source_deployment_id = "%[2]s"
+		restore_type         = "read_replica"
+		promote              = true
+		skip_initial_backup  = true
+		copy_allowlist       = false
+		delete_on_destroy    = true
+	}
+				`, name, acc.IcdDbDeploymentId)
+}
+
+func testAccCheckIBMDatabaseRestoreDNSRecordConfig(name string, restore bool) string {
+	config := fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		name = "%[2]s"
+	}
+
+	data "ibm_cis" "cis" {
+		resource_group_id = data.ibm_resource_group.test_acc.id
+		name              = "%[3]s"
+	}
+
+	data "ibm_cis_domain" "cis_domain" {
+		cis_id = data.ibm_cis.cis.id
+		domain = "%[4]s"
+	}
+
+	resource "ibm_cis_dns_record" "database" {
+		cis_id    = data.ibm_cis.cis.id
+		domain_id = data.ibm_cis_domain.cis_domain.id
+		name      = "%[1]s"
+		content   = "192.168.0.10"
+		type      = "A"
+
+		lifecycle {
+			ignore_changes = [content, type]
+		}
+	}
+	`, name, acc.CisResourceGroup, acc.CisInstance, acc.CisDomainStatic)
+	if !restore {
+		return config
+	}
+	return config + fmt.Sprintf(`
+	resource "ibm_database_restore" "restore" {
+		name                        = "%[1]s"
+		source_deployment_id        = "%[2]s"
+		restore_type                = "point_in_time"
+		point_in_time_recovery_time = ""
+		copy_allowlist              = false
+		delete_on_destroy           = true
+
+		dns_record {
+			cis_id    = data.ibm_cis.cis.id
+			domain_id = data.ibm_cis_domain.cis_domain.id
+			record_id = ibm_cis_dns_record.database.id
+		}
+	}
+	`, name, acc.IcdDbDeploymentId)
+}
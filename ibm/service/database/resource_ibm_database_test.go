@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"gotest.tools/assert"
 )
@@ -229,3 +230,65 @@ func TestPublicServiceEndpointsWarning(t *testing.T) {
 		t.Errorf("expected summary %v, got %v", warningNote, diags[0].Summary)
 	}
 }
+
+func TestHasDatabaseAllowlistConfig(t *testing.T) {
+	entry := cty.ObjectVal(map[string]cty.Value{
+		"address":     cty.StringVal("172.168.1.1/32"),
+		"description": cty.NullVal(cty.String),
+	})
+	entryType := entry.Type()
+
+	for _, tc := range []struct {
+		name      string
+		allowlist cty.Value
+		expected  bool
+	}{
+		{"entries", cty.SetVal([]cty.Value{entry}), true},
+		{"unknown", cty.UnknownVal(cty.Set(entryType)), true},
+		{"empty", cty.SetValEmpty(entryType), false},
+		{"null", cty.NullVal(cty.Set(entryType)), false},
+	} {
+		config := cty.ObjectVal(map[string]cty.Value{"allowlist": tc.allowlist})
+		assert.Equal(t, hasDatabaseAllowlistConfig(config), tc.expected, tc.name)
+	}
+}
+
+func TestValidateDatabaseRestoreDNSEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		serviceEndpoints string
+		endpointType     string
+		valid            bool
+	}{
+		{"", "public", true},
+		{"", "private", true},
+		{"public", "public", true},
+		{"public", "private", false},
+		{"private", "private", true},
+		{"private", "public", false},
+		{"public-and-private", "public", true},
+		{"public-and-private", "private", true},
+	} {
+		err := validateDatabaseRestoreDNSEndpoint(tc.serviceEndpoints, tc.endpointType)
+		assert.Equal(t, err == nil, tc.valid, "service_endpoints %q, endpoint_type %q: %v", tc.serviceEndpoints, tc.endpointType, err)
+	}
+}
+
+func TestParseDatabaseSQLID(t *testing.T) {
+	parts, err := parseDatabaseSQLID("db.example.com:31000|app", 1)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, parts, []string{"app"})
+
+	// the schema of database grants is empty
+	parts, err = parseDatabaseSQLID("db.example.com:31000|app||database|reader", 4)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, parts, []string{"app", "", "database", "reader"})
+
+	parts, err = parseDatabaseSQLID("[2001:db8::1]:3306|user|%|app|*", 4)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, parts, []string{"user", "%", "app", "*"})
+
+	for _, id := range []string{"db.example.com:31000", "|app", "db.example.com:31000|user"} {
+		_, err = parseDatabaseSQLID(id, 2)
+		assert.ErrorContains(t, err, "Incorrect ID")
+	}
+}
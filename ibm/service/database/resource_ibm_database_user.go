@@ -0,0 +1,256 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	validation "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/cloud-databases-go-sdk/clouddatabasesv5"
+)
+
+func ResourceIBMDatabaseUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseUserCreate,
+		ReadContext:   resourceIBMDatabaseUserRead,
+		UpdateContext: resourceIBMDatabaseUserUpdate,
+		DeleteContext: resourceIBMDatabaseUserDelete,
+		Importer:      &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Description: "The ID of the database deployment",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description:  "User name",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(4, 32),
+			},
+			"password": {
+				Description:  "User password",
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(15, 32),
+			},
+			"type": {
+				Description:  "User type",
+				Type:         schema.TypeString,
+				Default:      "database",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"database", "ops_manager", "read_only_replica"}, false),
+			},
+			"role": {
+				Description: "User role. Only available for ops_manager user type and Redis 6.0 and above.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseUserCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	deploymentID := d.Get("deployment_id").(string)
+	user := expandDatabaseUserResource(d)
+
+	deployment, err := getDatabaseDeployment(deploymentID, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	version, err := databaseMajorVersion(*deployment.Version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = validateDatabaseUser("databases-for-"+*deployment.Type, version, user)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A user that already exists is most likely still managed by the users attribute of
+	// ibm_database, so creating it here would silently take it over.
+	exists, err := databaseUserExists(deploymentID, deployment, user, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if exists {
+		return diag.FromErr(fmt.Errorf("[ERROR] Database user (%s) of type %s already exists on deployment %s, remove it from the users attribute of ibm_database and import it with terraform import", user.Username, user.Type, deploymentID))
+	}
+
+	err = user.Create(deploymentID, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s|%s", deploymentID, user.Type, user.Username))
+
+	return resourceIBMDatabaseUserRead(context, d, meta)
+}
+
+func resourceIBMDatabaseUserRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parts := strings.Split(d.Id(), "|")
+	if len(parts) != 3 {
+		return diag.FromErr(fmt.Errorf("[ERROR] Incorrect ID %s: ID should be a combination of deploymentID|type|name", d.Id()))
+	}
+	deploymentID, userType, name := parts[0], parts[1], parts[2]
+
+	deployment, err := getDatabaseDeployment(deploymentID, meta)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	user := &DatabaseUser{
+		Username: name,
+		Type:     userType,
+	}
+	exists, err := databaseUserExists(deploymentID, deployment, user, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("deployment_id", deploymentID)
+	d.Set("type", userType)
+	d.Set("name", name)
+
+	return nil
+}
+
+func resourceIBMDatabaseUserUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	deploymentID := d.Get("deployment_id").(string)
+	user := expandDatabaseUserResource(d)
+
+	if d.HasChanges("password", "role") {
+		deployment, err := getDatabaseDeployment(deploymentID, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		version, err := databaseMajorVersion(*deployment.Version)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = validateDatabaseUser("databases-for-"+*deployment.Type, version, user)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// ops_manager users cannot be updated, so they are recreated with the new password
+		if user.isUpdatable() {
+			err = user.Update(deploymentID, d, meta)
+		} else {
+			err = user.Delete(deploymentID, d, meta)
+			if err == nil {
+				err = user.Create(deploymentID, d, meta)
+			}
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceIBMDatabaseUserRead(context, d, meta)
+}
+
+func resourceIBMDatabaseUserDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	deploymentID := d.Get("deployment_id").(string)
+	user := expandDatabaseUserResource(d)
+
+	err := user.Delete(deploymentID, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func expandDatabaseUserResource(d *schema.ResourceData) *DatabaseUser {
+	user := &DatabaseUser{
+		Username: d.Get("name").(string),
+		Password: d.Get("password").(string),
+		Type:     d.Get("type").(string),
+	}
+
+	if role, ok := d.GetOk("role"); ok {
+		r := role.(string)
+		user.Role = &r
+	}
+
+	return user
+}
+
+func getDatabaseDeployment(deploymentID string, meta interface{}) (*clouddatabasesv5.Deployment, error) {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting database client settings: %s", err)
+	}
+
+	getDeploymentInfoOptions := &clouddatabasesv5.GetDeploymentInfoOptions{
+		ID: &deploymentID,
+	}
+	getDeploymentInfoResponse, response, err := cloudDatabasesClient.GetDeploymentInfo(getDeploymentInfoOptions)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting database deployment (%s): %s\n%s", deploymentID, err, response)
+	}
+
+	return getDeploymentInfoResponse.Deployment, nil
+}
+
+// databaseUserExists reports whether a user exists on a deployment. The API has no way to
+// read a single user, so the connection strings of the user are looked up instead.
+func databaseUserExists(deploymentID string, deployment *clouddatabasesv5.Deployment, user *DatabaseUser, meta interface{}) (bool, error) {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return false, fmt.Errorf("[ERROR] Error getting database client settings: %s", err)
+	}
+
+	endpointType := "public"
+	if deployment.EnablePublicEndpoints != nil && !*deployment.EnablePublicEndpoints {
+		endpointType = "private"
+	}
+
+	getConnectionOptions := &clouddatabasesv5.GetConnectionOptions{
+		ID:           &deploymentID,
+		UserType:     &user.Type,
+		UserID:       &user.Username,
+		EndpointType: &endpointType,
+	}
+	_, response, err := cloudDatabasesClient.GetConnection(getConnectionOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("[ERROR] Error getting database user (%s) connection: %s\n%s", user.Username, err, response)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDatabaseUserBasic(t *testing.T) {
+	t.Parallel()
+	databaseResourceGroup := "default"
+	var databaseInstanceOne string
+	testName := fmt.Sprintf("tf-Pgress-user-%d", acctest.RandIntRange(10, 100))
+	name := "ibm_database_user.user"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseUserConfig(databaseResourceGroup, testName, "secure-Password12345"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMDatabaseInstanceExists("ibm_database."+testName, &databaseInstanceOne),
+					resource.TestCheckResourceAttr(name, "name", "tfuser"),
+					resource.TestCheckResourceAttr(name, "type", "database"),
+				),
+			},
+			{
+				Config: testAccCheckIBMDatabaseUserConfig(databaseResourceGroup, testName, "secure-Password67890"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "tfuser"),
+					resource.TestCheckResourceAttr(name, "password", "secure-Password67890"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseUserConfig(databaseResourceGroup string, name string, password string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		is_default = true
+		# name = "%[1]s"
+	}
+
+	resource "ibm_database" "%[2]s" {
+		resource_group_id = data.ibm_resource_group.test_acc.id
+		name              = "%[2]s"
+		service           = "databases-for-postgresql"
+		plan              = "standard"
+		location          = "%[3]s"
+		service_endpoints = "public"
+	}
+
+	resource "ibm_database_user" "user" {
+		deployment_id = ibm_database.%[2]s.id
+		name          = "tfuser"
+		password      = "%[4]s"
+	}
+				`, databaseResourceGroup, name, acc.Region(), password)
+}
@@ -0,0 +1,145 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package iamidentity
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+const accessTokenEphemeralResourceName = "ibm_iam_access_token"
+
+var (
+	_ ephemeral.EphemeralResource              = &IBMIAMAccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &IBMIAMAccessTokenEphemeralResource{}
+)
+
+// IBMIAMAccessTokenEphemeralResource returns the IAM access token of the provider session
+// without storing it in the plan or state.
+type IBMIAMAccessTokenEphemeralResource struct {
+	meta conns.ClientSession
+}
+
+type ibmIAMAccessTokenEphemeralResourceModel struct {
+	Refresh         types.Bool   `tfsdk:"refresh"`
+	IAMAccessToken  types.String `tfsdk:"iam_access_token"`
+	IAMRefreshToken types.String `tfsdk:"iam_refresh_token"`
+	ExpiresAt       types.String `tfsdk:"expires_at"`
+}
+
+func NewIBMIAMAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &IBMIAMAccessTokenEphemeralResource{}
+}
+
+func (r *IBMIAMAccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_access_token"
+}
+
+func (r *IBMIAMAccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Returns the IAM access token of the provider without persisting it in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"refresh": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Refresh the token before returning it, so that it is valid for its full lifetime. Requires a refresh token, which is available when the provider authenticates with an API key.",
+			},
+			"iam_access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The IAM access token, including the Bearer prefix.",
+			},
+			"iam_refresh_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The IAM refresh token.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the access token expires. The date format follows RFC 3339.",
+			},
+		},
+	}
+}
+
+func (r *IBMIAMAccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	meta, err := conns.FrameworkClientSession(req.ProviderData)
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", accessTokenEphemeralResourceName), "configure")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+	r.meta = meta
+}
+
+func (r *IBMIAMAccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ibmIAMAccessTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.meta == nil {
+		err := conns.ErrFrameworkProviderNotConfigured
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", accessTokenEphemeralResourceName), "open")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+
+	bmxSess, err := r.meta.BluemixSession()
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", accessTokenEphemeralResourceName), "open")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+
+	if data.Refresh.ValueBool() {
+		if bmxSess.Config.IAMRefreshToken == "" {
+			err = fmt.Errorf("the provider session has no refresh token, authenticate the provider with an API key to use refresh")
+			tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", accessTokenEphemeralResourceName), "open")
+			resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+			return
+		}
+		if err = conns.RefreshToken(bmxSess); err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error refreshing the IAM access token: %s", err.Error()), fmt.Sprintf("(Ephemeral) %s", accessTokenEphemeralResourceName), "open")
+			resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+			return
+		}
+	}
+
+	data.IAMAccessToken = types.StringValue(bmxSess.Config.IAMAccessToken)
+	data.IAMRefreshToken = types.StringValue(bmxSess.Config.IAMRefreshToken)
+	data.ExpiresAt = types.StringNull()
+	if expiresAt, err := accessTokenExpiration(bmxSess.Config.IAMAccessToken); err == nil {
+		data.ExpiresAt = types.StringValue(expiresAt.UTC().Format(time.RFC3339))
+	} else {
+		log.Printf("[WARN] Unable to read the expiration of the IAM access token: %s", err)
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// accessTokenExpiration returns the exp claim of an IAM access token. The signature is
+// not verified, the token is only inspected.
+func accessTokenExpiration(accessToken string) (time.Time, error) {
+	claims := jwt.MapClaims{}
+	_, _, err := new(jwt.Parser).ParseUnverified(strings.TrimPrefix(accessToken, "Bearer "), claims)
+	if err != nil {
+		return time.Time{}, err
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}, fmt.Errorf("the token has no exp claim")
+	}
+	return time.Unix(int64(exp), 0), nil
+}
@@ -6,14 +6,18 @@ package kms_test
 import (
 	"fmt"
 	"math/rand"
+	"net/http"
 	"regexp"
+	"sync"
 	"testing"
 
 	"time"
 
 	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/unittest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccIBMKMSResource_basic(t *testing.T) {
@@ -367,3 +371,133 @@ func testAccCheckIBMKmsCreateRootKeyConfig(instanceName, resource, KeyName, expi
 // 	  }
 // `, instanceName, resource, KeyName, dual_auth_delete)
 // }
+
+func TestMockIBMKMSResourceBasic(t *testing.T) {
+	server := unittest.NewMockServer(t)
+	keys := mockIBMKMSKeyAPI(server)
+
+	server.UnitTest(t,
+		resource.TestStep{
+			Config: testAccCheckIBMKmsResourceMockConfig("mock-key", "first description"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ibm_kms_key.test", "key_name", "mock-key"),
+				resource.TestCheckResourceAttr("ibm_kms_key.test", "standard_key", "false"),
+				resource.TestCheckResourceAttr("ibm_kms_key.test", "endpoint_type", "public"),
+				resource.TestCheckResourceAttr("ibm_kms_key.test", "type", "kms"),
+				resource.TestCheckResourceAttrSet("ibm_kms_key.test", "key_id"),
+			),
+		},
+		resource.TestStep{
+			// Description is immutable, the key is replaced
+			Config: testAccCheckIBMKmsResourceMockConfig("mock-key", "second description"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ibm_kms_key.test", "description", "second description"),
+				func(*terraform.State) error {
+					if n := keys.len(); n != 1 {
+						return fmt.Errorf("expected 1 key on the mock server, got %d", n)
+					}
+					return nil
+				},
+			),
+		},
+		resource.TestStep{
+			ResourceName:            "ibm_kms_key.test",
+			ImportState:             true,
+			ImportStateVerify:       true,
+			ImportStateVerifyIgnore: []string{"force_delete", "payload", "expiration_date"},
+		},
+	)
+}
+
+func testAccCheckIBMKmsResourceMockConfig(keyName, description string) string {
+	return fmt.Sprintf(`
+	resource "ibm_kms_key" "test" {
+		instance_id  = "%s"
+		key_name     = "%s"
+		description  = "%s"
+		standard_key = false
+		force_delete = true
+	}
+`, mockKMSInstanceID, keyName, description)
+}
+
+const mockKMSInstanceID = "c1b7e9a0-0000-4000-8000-000000000001"
+
+type mockKMSKeys struct {
+	sync.Mutex
+	keys map[string]map[string]interface{}
+}
+
+func (m *mockKMSKeys) len() int {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.keys)
+}
+
+// mockIBMKMSKeyAPI serves the resource controller and Key Protect endpoints used by ibm_kms_key from memory.
+func mockIBMKMSKeyAPI(server *unittest.MockServer) *mockKMSKeys {
+	instanceCRN := fmt.Sprintf("crn:v1:bluemix:public:kms:us-south:a/%s:%s::", unittest.MockAccountID, mockKMSInstanceID)
+	server.HandleJSON(http.MethodGet, "/v2/resource_instances/{id}", http.StatusOK, map[string]interface{}{
+		"id":   instanceCRN,
+		"guid": mockKMSInstanceID,
+		"crn":  instanceCRN,
+		"name": "mock-kms",
+		"extensions": map[string]interface{}{
+			"endpoints": map[string]interface{}{
+				"public":  server.URL,
+				"private": server.URL,
+			},
+		},
+	})
+
+	keys := &mockKMSKeys{keys: map[string]map[string]interface{}{}}
+	seq := 0
+	keyResponse := func(w http.ResponseWriter, status int, key map[string]interface{}) {
+		unittest.WriteJSON(w, status, map[string]interface{}{
+			"metadata":  map[string]interface{}{"collectionType": "application/vnd.ibm.kms.key+json", "collectionTotal": 1},
+			"resources": []interface{}{key},
+		})
+	}
+	server.Handle(http.MethodPost, "/api/v2/keys", func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resources := unittest.ReadJSON(r)["resources"].([]interface{})
+		key := resources[0].(map[string]interface{})
+		keys.Lock()
+		defer keys.Unlock()
+		seq++
+		id := fmt.Sprintf("a1b2c3d4-0000-4000-8000-%012d", seq)
+		key["id"] = id
+		key["crn"] = fmt.Sprintf("crn:v1:bluemix:public:kms:us-south:a/%s:%s:key:%s", unittest.MockAccountID, mockKMSInstanceID, id)
+		key["state"] = 1
+		key["keyRingID"] = "default"
+		key["deleted"] = false
+		keys.keys[id] = key
+		keyResponse(w, http.StatusCreated, key)
+	})
+	server.Handle(http.MethodGet, "/api/v2/keys/{id}", func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		keys.Lock()
+		defer keys.Unlock()
+		key, ok := keys.keys[params["id"]]
+		if !ok {
+			unittest.WriteError(w, http.StatusNotFound, "KEY_NOT_FOUND_ERR", "Key does not exist")
+			return
+		}
+		keyResponse(w, http.StatusOK, key)
+	})
+	server.Handle(http.MethodDelete, "/api/v2/keys/{id}", func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		keys.Lock()
+		defer keys.Unlock()
+		key, ok := keys.keys[params["id"]]
+		if !ok {
+			unittest.WriteError(w, http.StatusNotFound, "KEY_NOT_FOUND_ERR", "Key does not exist")
+			return
+		}
+		delete(keys.keys, params["id"])
+		key["state"] = 5
+		keyResponse(w, http.StatusOK, key)
+	})
+	server.HandleJSON(http.MethodGet, "/api/v2/keys/{id}/registrations", http.StatusOK, map[string]interface{}{
+		"metadata":  map[string]interface{}{"collectionType": "application/vnd.ibm.kms.registration+json", "collectionTotal": 0},
+		"resources": []interface{}{},
+	})
+	return keys
+}
@@ -4,8 +4,13 @@
 package kubernetes
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"log"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -14,11 +19,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	homedir "github.com/mitchellh/go-homedir"
+	yaml "gopkg.in/yaml.v3"
 
 	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
 	"github.com/IBM-Cloud/bluemix-go/helpers"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/go-sdk-core/v5/core"
 )
 
 func DataSourceIBMContainerClusterConfig() *schema.Resource {
@@ -83,10 +91,25 @@ func DataSourceIBMContainerClusterConfig() *schema.Resource {
 				Default:     false,
 			},
 			"network": {
-				Description: "If set to true will download the Calico network config with the Admin config",
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
+				Description:   "If set to true will download the Calico network config with the Admin config",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"in_memory"},
+			},
+			"in_memory": {
+				Description:   "If set to true will return the config in kubeconfig without writing any file",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"config_dir"},
+			},
+			"exec_credential": {
+				Description:   "If set to true will return an exec credential plugin that requests a new IAM token each time it runs, and use it for the user of kubeconfig",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"admin"},
 			},
 			"endpoint_type": {
 				Description: "It can specify what kind of server URL will be used for the cluster context",
@@ -127,6 +150,37 @@ func DataSourceIBMContainerClusterConfig() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"kubeconfig": {
+				Description: "The kubernetes config yml, with the certificates inlined, if in_memory is set to true",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"exec": {
+				Description: "The exec credential plugin, if exec_credential is set to true",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The API version of the ExecCredential returned by the plugin",
+						},
+						"command": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The command of the plugin",
+						},
+						"args": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The arguments of the command",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -162,6 +216,18 @@ func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interf
 	conns.IbmMutexKV.Lock(clusterId)
 	defer conns.IbmMutexKV.Unlock(clusterId)
 
+	if d.Get("in_memory").(bool) {
+		targetEnv, err := getVpcClusterTargetHeader(d)
+		if err != nil {
+			return err
+		}
+		if err := setClusterKubeconfig(d, meta, csAPI, name, admin, targetEnv, endpointType); err != nil {
+			return err
+		}
+		d.SetId(name)
+		return nil
+	}
+
 	if len(configDir) == 0 {
 		configDir, err = homedir.Dir()
 		if err != nil {
@@ -220,26 +286,7 @@ func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interf
 			d.Set("config_file_path", clusterKeyDetails.FilePath)
 
 		} else {
-			var clusterKeyDetails v1.ClusterKeyInfo
-			err = resource.Retry(5*time.Minute, func() *resource.RetryError {
-				var err error
-				clusterKeyDetails, err = csAPI.GetClusterConfigDetail(name, configDir, admin, targetEnv, endpointType)
-				if err != nil {
-					log.Printf("[DEBUG] Failed to fetch cluster config err %s", err)
-					if strings.Contains(err.Error(), "Could not login to openshift account runtime error:") {
-						return resource.RetryableError(err)
-					}
-					if intermittentUserLookupFailure, _ := regexp.MatchString("Error: lookup of user for \"(.+)\" failed", err.Error()); intermittentUserLookupFailure {
-						// Intermittent error resulting from synchronisation delay
-						return resource.RetryableError(err)
-					}
-					return resource.NonRetryableError(err)
-				}
-				return nil
-			})
-			if conns.IsResourceTimeoutError(err) {
-				clusterKeyDetails, err = csAPI.GetClusterConfigDetail(name, configDir, admin, targetEnv, endpointType)
-			}
+			clusterKeyDetails, err := getClusterConfigDetail(csAPI, name, configDir, admin, targetEnv, endpointType)
 			if err != nil {
 				return fmt.Errorf("[ERROR] Error downloading the cluster config [%s]: %s", name, err)
 			}
@@ -252,7 +299,367 @@ func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interf
 		}
 	}
 
+	if d.Get("exec_credential").(bool) {
+		targetEnv, err := getVpcClusterTargetHeader(d)
+		if err != nil {
+			return err
+		}
+		clusterInfo, err := csAPI.GetCluster(name, targetEnv)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error retrieving cluster (%s): %s", name, err)
+		}
+		args, err := clusterConfigExecArgs(meta, clusterInfo)
+		if err != nil {
+			return err
+		}
+		d.Set("exec", flattenClusterConfigExec(args))
+	}
+
 	d.SetId(name)
 	d.Set("config_dir", configDir)
 	return nil
 }
+
+// getClusterConfigDetail downloads the cluster config to configDir, retrying the
+// intermittent login and user lookup failures of newly created clusters.
+func getClusterConfigDetail(csAPI v2.Clusters, name, configDir string, admin bool, targetEnv v2.ClusterTargetHeader, endpointType string) (v1.ClusterKeyInfo, error) {
+	var clusterKeyDetails v1.ClusterKeyInfo
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		var err error
+		clusterKeyDetails, err = csAPI.GetClusterConfigDetail(name, configDir, admin, targetEnv, endpointType)
+		if err != nil {
+			return clusterConfigRetryError(err)
+		}
+		return nil
+	})
+	if conns.IsResourceTimeoutError(err) {
+		clusterKeyDetails, err = csAPI.GetClusterConfigDetail(name, configDir, admin, targetEnv, endpointType)
+	}
+	return clusterKeyDetails, err
+}
+
+// clusterConfigRetryError retries the intermittent login and user lookup
+// failures of newly created clusters.
+func clusterConfigRetryError(err error) *resource.RetryError {
+	log.Printf("[DEBUG] Failed to fetch cluster config err %s", err)
+	if strings.Contains(err.Error(), "Could not login to openshift account runtime error:") {
+		return resource.RetryableError(err)
+	}
+	if intermittentUserLookupFailure, _ := regexp.MatchString("Error: lookup of user for \"(.+)\" failed", err.Error()); intermittentUserLookupFailure {
+		// Intermittent error resulting from synchronisation delay
+		return resource.RetryableError(err)
+	}
+	return resource.NonRetryableError(err)
+}
+
+// clusterKubeconfig is a kubeconfig downloaded into memory, with the
+// credentials it contains.
+type clusterKubeconfig struct {
+	config           []byte
+	host             string
+	token            string
+	caCertificate    string
+	adminKey         string
+	adminCertificate string
+}
+
+// setClusterKubeconfig sets the kubeconfig of a cluster and its credentials
+// without writing to disk.
+func setClusterKubeconfig(d *schema.ResourceData, meta interface{}, csAPI v2.Clusters, name string, admin bool, targetEnv v2.ClusterTargetHeader, endpointType string) error {
+	clusterInfo, err := csAPI.GetCluster(name, targetEnv)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error retrieving cluster (%s): %s", name, err)
+	}
+
+	var execArgs []string
+	if d.Get("exec_credential").(bool) {
+		execArgs, err = clusterConfigExecArgs(meta, clusterInfo)
+		if err != nil {
+			return err
+		}
+		d.Set("exec", flattenClusterConfigExec(execArgs))
+	}
+
+	kubeconfig, err := readClusterKubeconfig(meta, csAPI, clusterInfo, admin, targetEnv, endpointType, execArgs)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error downloading the cluster config [%s]: %s", name, err)
+	}
+
+	d.Set("kubeconfig", string(kubeconfig.config))
+	d.Set("host", kubeconfig.host)
+	d.Set("token", kubeconfig.token)
+	d.Set("ca_certificate", kubeconfig.caCertificate)
+	d.Set("admin_key", kubeconfig.adminKey)
+	d.Set("admin_certificate", kubeconfig.adminCertificate)
+	return nil
+}
+
+// readClusterKubeconfig is getClusterKubeconfig retrying the intermittent
+// failures of newly created clusters.
+func readClusterKubeconfig(meta interface{}, csAPI v2.Clusters, clusterInfo *v2.ClusterInfo, admin bool, targetEnv v2.ClusterTargetHeader, endpointType string, execArgs []string) (clusterKubeconfig, error) {
+	var kubeconfig clusterKubeconfig
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		var err error
+		kubeconfig, err = getClusterKubeconfig(meta, csAPI, clusterInfo, admin, targetEnv, endpointType, execArgs)
+		if err != nil {
+			return clusterConfigRetryError(err)
+		}
+		return nil
+	})
+	if conns.IsResourceTimeoutError(err) {
+		kubeconfig, err = getClusterKubeconfig(meta, csAPI, clusterInfo, admin, targetEnv, endpointType, execArgs)
+	}
+	return kubeconfig, err
+}
+
+// getClusterKubeconfig downloads the same archive as GetClusterConfigDetail into
+// memory, and returns its kubeconfig with the certificate files it refers to
+// inlined. The user of the kubeconfig runs the exec credential plugin if
+// execArgs is set.
+func getClusterKubeconfig(meta interface{}, csAPI v2.Clusters, clusterInfo *v2.ClusterInfo, admin bool, targetEnv v2.ClusterTargetHeader, endpointType string, execArgs []string) (clusterKubeconfig, error) {
+	var kubeconfig clusterKubeconfig
+	if clusterInfo.Provider == "satellite" {
+		endpointType = "link"
+		admin = true
+	}
+	archive, err := downloadClusterConfigArchive(meta, clusterInfo.ID, admin, targetEnv, endpointType)
+	if err != nil {
+		return kubeconfig, err
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return kubeconfig, fmt.Errorf("Error reading the cluster config archive: %s", err)
+	}
+	files := make(map[string][]byte, len(zipReader.File))
+	var configName string
+	for _, f := range zipReader.File {
+		rc, err := f.Open()
+		if err != nil {
+			return kubeconfig, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return kubeconfig, err
+		}
+		fileName := path.Base(f.Name)
+		files[fileName] = content
+		switch {
+		case strings.HasSuffix(fileName, ".yaml") || strings.HasSuffix(fileName, ".yml"):
+			configName = fileName
+		case fileName == "admin-key.pem":
+			kubeconfig.adminKey = string(content)
+		case fileName == "admin.pem":
+			kubeconfig.adminCertificate = string(content)
+		case strings.HasPrefix(fileName, "ca") && strings.HasSuffix(fileName, ".pem"):
+			kubeconfig.caCertificate = string(content)
+		}
+	}
+	if configName == "" {
+		return kubeconfig, fmt.Errorf("Unable to locate kube config in zip archive")
+	}
+
+	config := files[configName]
+	if clusterInfo.Type == "openshift" && clusterInfo.Provider != "satellite" {
+		// GetClusterConfigDetail logs in to OpenShift to add a token to the config
+		// with a method of the client that is not part of the Clusters interface.
+		fetcher, ok := csAPI.(interface {
+			FetchOCTokenForKubeConfig(kubecfg []byte, cMeta *v2.ClusterInfo, skipSSLVerification bool, endpointType string) ([]byte, string, error)
+		})
+		if !ok {
+			return kubeconfig, fmt.Errorf("Unable to log in to OpenShift cluster (%s)", clusterInfo.ID)
+		}
+		config, kubeconfig.host, err = fetcher.FetchOCTokenForKubeConfig(config, clusterInfo, clusterInfo.IsStagingSatelliteCluster(), endpointType)
+		if err != nil {
+			return kubeconfig, err
+		}
+		kubeconfig.caCertificate = ""
+	}
+
+	var configMap map[string]interface{}
+	if err := yaml.Unmarshal(config, &configMap); err != nil {
+		return kubeconfig, fmt.Errorf("Error parsing the cluster config: %s", err)
+	}
+	clusters, _ := configMap["clusters"].([]interface{})
+	users, _ := configMap["users"].([]interface{})
+	for _, c := range clusters {
+		cluster := kubeconfigEntry(c, "cluster")
+		inlineKubeconfigFiles(cluster, files, "certificate-authority")
+		if server, ok := cluster["server"].(string); ok && kubeconfig.host == "" {
+			kubeconfig.host = server
+		}
+	}
+	for _, u := range users {
+		user := kubeconfigEntry(u, "user")
+		inlineKubeconfigFiles(user, files, "client-certificate", "client-key")
+		if kubeconfig.token == "" {
+			kubeconfig.token = kubeconfigUserToken(user)
+		}
+		if len(execArgs) > 0 {
+			u.(map[string]interface{})["user"] = map[string]interface{}{
+				"exec": map[string]interface{}{
+					"apiVersion":      clusterConfigExecAPIVersion,
+					"command":         clusterConfigExecCommand,
+					"args":            execArgs,
+					"interactiveMode": "Never",
+				},
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(configMap); err != nil {
+		return kubeconfig, err
+	}
+	encoder.Close()
+	kubeconfig.config = buf.Bytes()
+	return kubeconfig, nil
+}
+
+// downloadClusterConfigArchive downloads the kubeconfig archive of a cluster
+// into memory. The ApplyRBACAndGetKubeconfig operation of the SDK discards the
+// response body, so the request is sent with the service of the client.
+func downloadClusterConfigArchive(meta interface{}, name string, admin bool, targetEnv v2.ClusterTargetHeader, endpointType string) ([]byte, error) {
+	kubeClient, err := meta.(conns.ClientSession).SatelliteClientSession()
+	if err != nil {
+		return nil, err
+	}
+	bmxSess, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"cluster": name,
+		"format":  "zip",
+	}
+	if admin {
+		body["admin"] = true
+	}
+	if endpointType != "" {
+		body["endpointType"] = endpointType
+	}
+
+	builder := core.NewRequestBuilder(core.POST)
+	if _, err := builder.ResolveRequestURL(kubeClient.Service.Options.URL, "/v2/applyRBACAndGetKubeconfig", nil); err != nil {
+		return nil, err
+	}
+	builder.AddHeader("Content-Type", "application/json")
+	if bmxSess.Config.IAMRefreshToken != "" {
+		builder.AddHeader("X-Auth-Refresh-Token", bmxSess.Config.IAMRefreshToken)
+	}
+	if targetEnv.ResourceGroup != "" {
+		builder.AddHeader("X-Auth-Resource-Group", targetEnv.ResourceGroup)
+	}
+	if _, err := builder.SetBodyContentJSON(body); err != nil {
+		return nil, err
+	}
+	request, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var result io.ReadCloser
+	if _, err := kubeClient.Service.Request(request, &result); err != nil {
+		return nil, err
+	}
+	defer result.Close()
+	return io.ReadAll(result)
+}
+
+// kubeconfigEntry returns the cluster or user of a named entry of a kubeconfig.
+func kubeconfigEntry(namedEntry interface{}, key string) map[string]interface{} {
+	if m, ok := namedEntry.(map[string]interface{}); ok {
+		if entry, ok := m[key].(map[string]interface{}); ok {
+			return entry
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// inlineKubeconfigFiles replaces the file paths of the keys of a cluster or
+// user with the base64 encoded content of the files, in the keys suffixed
+// with -data.
+func inlineKubeconfigFiles(entry map[string]interface{}, files map[string][]byte, keys ...string) {
+	for _, key := range keys {
+		filePath, ok := entry[key].(string)
+		if !ok {
+			continue
+		}
+		if content, ok := files[path.Base(filePath)]; ok {
+			entry[key+"-data"] = base64.StdEncoding.EncodeToString(content)
+			delete(entry, key)
+		}
+	}
+}
+
+func kubeconfigUserToken(user map[string]interface{}) string {
+	if token, ok := user["token"].(string); ok {
+		return token
+	}
+	if authProvider, ok := user["auth-provider"].(map[string]interface{}); ok {
+		if config, ok := authProvider["config"].(map[string]interface{}); ok {
+			if token, ok := config["id-token"].(string); ok {
+				return token
+			}
+		}
+	}
+	return ""
+}
+
+const (
+	clusterConfigExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+	clusterConfigExecCommand    = "sh"
+
+	// clusterConfigExecScript exchanges the API key in IC_API_KEY or IBMCLOUD_API_KEY
+	// for an IAM token of the kube client, which the API server of a cluster accepts,
+	// and prints it as an ExecCredential. The plugin is run by the Kubernetes client
+	// outside of Terraform, so it can't use the credentials of the provider. Any
+	// failure is reported on standard error, which the Kubernetes client shows,
+	// rather than returning an empty token.
+	clusterConfigExecScript = `set -eu
+apikey="${IC_API_KEY:-${IBMCLOUD_API_KEY:-}}"
+if [ -z "$apikey" ]; then
+  echo "IC_API_KEY or IBMCLOUD_API_KEY must be set to request an IAM token for the cluster" >&2
+  exit 1
+fi
+response=$(curl -sS -X POST -H "Authorization: Basic a3ViZTprdWJl" -H "Accept: application/json" \
+  -d grant_type=urn:ibm:params:oauth:grant-type:apikey -d response_type=cloud_iam \
+  --data-urlencode "apikey=$apikey" %s/identity/token)
+token=$(printf '%%s' "$response" | sed -n 's/.*"id_token" *: *"\([A-Za-z0-9._-]*\)".*/\1/p')
+if [ -z "$token" ]; then
+  echo "Error requesting an IAM token for the cluster: $response" >&2
+  exit 1
+fi
+printf '{"apiVersion":"client.authentication.k8s.io/v1beta1","kind":"ExecCredential","status":{"token":"%%s"}}\n' "$token"
+`
+)
+
+// clusterConfigExecArgs returns the arguments of the exec credential plugin of
+// a cluster. OpenShift clusters do not accept IAM tokens.
+func clusterConfigExecArgs(meta interface{}, clusterInfo *v2.ClusterInfo) ([]string, error) {
+	if clusterInfo.Type == "openshift" {
+		return nil, fmt.Errorf("[ERROR] exec_credential is not supported for OpenShift cluster (%s)", clusterInfo.ID)
+	}
+	bmxSess, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return nil, err
+	}
+	iamEndpoint, err := bmxSess.Config.EndpointLocator.IAMEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	return []string{"-c", fmt.Sprintf(clusterConfigExecScript, iamEndpoint)}, nil
+}
+
+func flattenClusterConfigExec(args []string) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"api_version": clusterConfigExecAPIVersion,
+			"command":     clusterConfigExecCommand,
+			"args":        args,
+		},
+	}
+}
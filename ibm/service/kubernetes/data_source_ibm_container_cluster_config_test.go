@@ -85,6 +85,33 @@ func TestAccIBMContainer_ClusterConfigCalicoDataSourceBasic(t *testing.T) {
 	})
 }
 
+func TestAccIBMContainer_ClusterConfigDataSourceInMemory(t *testing.T) {
+	clusterName := fmt.Sprintf("tf-cluster-config-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerClusterDataSourceInMemoryConfig(clusterName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "config_file_path"),
+					resource.TestMatchResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "kubeconfig", regexp.MustCompile("certificate-authority-data")),
+					resource.TestMatchResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "host", regexp.MustCompile("^https://")),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "token"),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "ca_certificate"),
+					resource.TestCheckResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "exec.0.command", "sh"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckIBMContainerClusterDataSourceConfig(clustername string) string {
 	return fmt.Sprintf(`
 resource "ibm_container_cluster" "testacc_cluster" {
@@ -141,3 +168,26 @@ data "ibm_container_cluster_config" "testacc_ds_cluster" {
   endpoint_type   = "private"
 }`, clustername, acc.Datacenter, acc.MachineType, acc.PublicVlanID, acc.PrivateVlanID)
 }
+
+func testAccCheckIBMContainerClusterDataSourceInMemoryConfig(clustername string) string {
+	return fmt.Sprintf(`
+	resource "ibm_container_vpc_cluster" "testacc_cluster" {
+		name              = "%[1]s"
+		vpc_id            = "%[2]s"
+		flavor            = "bx2.4x16"
+		worker_count      = 1
+		resource_group_id = "%[3]s"
+		zones {
+			subnet_id = "%[4]s"
+			name      = "us-south-1"
+		}
+		wait_till = "Normal"
+	}
+
+data "ibm_container_cluster_config" "testacc_ds_cluster" {
+  cluster_name_id   = ibm_container_vpc_cluster.testacc_cluster.id
+  resource_group_id = "%[3]s"
+  in_memory         = true
+  exec_credential   = true
+}`, clustername, acc.IksClusterVpcID, acc.IksClusterResourceGroupID, acc.IksClusterSubnetID)
+}
@@ -0,0 +1,154 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+const clusterCredentialsEphemeralResourceName = "ibm_container_cluster_credentials"
+
+var (
+	_ ephemeral.EphemeralResource              = &IBMContainerClusterCredentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &IBMContainerClusterCredentialsEphemeralResource{}
+)
+
+// IBMContainerClusterCredentialsEphemeralResource returns the credentials to access a
+// cluster without writing a kubeconfig to disk or storing them in the plan or state.
+type IBMContainerClusterCredentialsEphemeralResource struct {
+	meta conns.ClientSession
+}
+
+type ibmContainerClusterCredentialsEphemeralResourceModel struct {
+	ClusterNameID    types.String `tfsdk:"cluster_name_id"`
+	ResourceGroupID  types.String `tfsdk:"resource_group_id"`
+	Admin            types.Bool   `tfsdk:"admin"`
+	EndpointType     types.String `tfsdk:"endpoint_type"`
+	Host             types.String `tfsdk:"host"`
+	Token            types.String `tfsdk:"token"`
+	AdminKey         types.String `tfsdk:"admin_key"`
+	AdminCertificate types.String `tfsdk:"admin_certificate"`
+	CaCertificate    types.String `tfsdk:"ca_certificate"`
+}
+
+func NewIBMContainerClusterCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &IBMContainerClusterCredentialsEphemeralResource{}
+}
+
+func (r *IBMContainerClusterCredentialsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_cluster_credentials"
+}
+
+func (r *IBMContainerClusterCredentialsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Returns the credentials of a cluster without persisting them in the plan, the state or a kubeconfig file.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_name_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The name or ID of the cluster.",
+			},
+			"resource_group_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the resource group of the cluster.",
+			},
+			"admin": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Return the admin certificate and key instead of a token of the provider user.",
+			},
+			"endpoint_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "The kind of server URL to return, such as private, link or vpe.",
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL of the Kubernetes API server.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token to authenticate with the cluster.",
+			},
+			"admin_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The admin key of the cluster, set when admin is true.",
+			},
+			"admin_certificate": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The admin certificate of the cluster, set when admin is true.",
+			},
+			"ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The certificate authority certificate of the cluster.",
+			},
+		},
+	}
+}
+
+func (r *IBMContainerClusterCredentialsEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	meta, err := conns.FrameworkClientSession(req.ProviderData)
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", clusterCredentialsEphemeralResourceName), "configure")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+	r.meta = meta
+}
+
+func (r *IBMContainerClusterCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ibmContainerClusterCredentialsEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.meta == nil {
+		err := conns.ErrFrameworkProviderNotConfigured
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", clusterCredentialsEphemeralResourceName), "open")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+
+	csClient, err := r.meta.VpcContainerAPI()
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", clusterCredentialsEphemeralResourceName), "open")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+
+	name := data.ClusterNameID.ValueString()
+	targetEnv := v2.ClusterTargetHeader{
+		ResourceGroup: data.ResourceGroupID.ValueString(),
+	}
+	clusterInfo, err := csClient.Clusters().GetCluster(name, targetEnv)
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error retrieving cluster (%s): %s", name, err.Error()), fmt.Sprintf("(Ephemeral) %s", clusterCredentialsEphemeralResourceName), "open")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+	kubeconfig, err := readClusterKubeconfig(r.meta, csClient.Clusters(), clusterInfo, data.Admin.ValueBool(), targetEnv, data.EndpointType.ValueString(), nil)
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error downloading the cluster config [%s]: %s", name, err.Error()), fmt.Sprintf("(Ephemeral) %s", clusterCredentialsEphemeralResourceName), "open")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+
+	data.Host = types.StringValue(kubeconfig.host)
+	data.Token = types.StringValue(kubeconfig.token)
+	data.AdminKey = types.StringValue(kubeconfig.adminKey)
+	data.AdminCertificate = types.StringValue(kubeconfig.adminCertificate)
+	data.CaCertificate = types.StringValue(kubeconfig.caCertificate)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
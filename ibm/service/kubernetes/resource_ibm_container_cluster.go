@@ -249,6 +249,10 @@ func ResourceIBMContainerCluster() *schema.Resource {
 				Description: "Updates all the woker nodes if sets to true",
 			},
 
+			"update_strategy": workerUpdateStrategySchema(),
+
+			"updated_workers": updatedWorkersSchema(),
+
 			"machine_type": {
 				Type:             schema.TypeString,
 				DiffSuppressFunc: flex.ApplyOnce,
@@ -978,7 +982,21 @@ func resourceIBMContainerClusterUpdate(d *schema.ResourceData, meta interface{})
 		// "update_all_workers" deafult is false, enable to true when all worker nodes to be updated
 		// with major and minor updates.
 		updateAllWorkers := d.Get("update_all_workers").(bool)
-		if updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version") {
+		strategy, hasStrategy := expandWorkerUpdateStrategy(d)
+		if hasStrategy && (updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version")) {
+			updater := &classicWorkerUpdater{
+				client:    csClient,
+				clientV2:  csClientV2,
+				clusterID: clusterID,
+				target:    targetEnv,
+				targetV2:  targetEnvV2,
+				timeout:   d.Timeout(schema.TimeoutUpdate),
+			}
+			if err := updateWorkers(d, strategy, updater); err != nil {
+				d.Set("patch_version", nil)
+				return fmt.Errorf("[ERROR] Error updating the workers of cluster (%s): %s", clusterID, err)
+			}
+		} else if updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version") {
 			workerFields, err := csClientV2.Workers().ListAllWorkers(clusterID, false, targetEnvV2)
 			if err != nil {
 				return fmt.Errorf("[ERROR] Error retrieving workers for cluster: %s", err)
@@ -185,6 +185,10 @@ func ResourceIBMContainerVpcCluster() *schema.Resource {
 				Description: "Wait for worker node to update during kube version update.",
 			},
 
+			"update_strategy": workerUpdateStrategySchema(),
+
+			"updated_workers": updatedWorkersSchema(),
+
 			"service_subnet": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -811,7 +815,19 @@ func resourceIBMContainerVpcClusterUpdate(d *schema.ResourceData, meta interface
 		workersInfo := make(map[string]int)
 
 		updateAllWorkers := d.Get("update_all_workers").(bool)
-		if updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version") {
+		strategy, hasStrategy := expandWorkerUpdateStrategy(d)
+		if hasStrategy && (updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version")) {
+			updater := &vpcWorkerUpdater{
+				client:    csClient,
+				clusterID: clusterID,
+				target:    targetEnv,
+				timeout:   d.Timeout(schema.TimeoutUpdate),
+			}
+			if err := updateWorkers(d, strategy, updater); err != nil {
+				d.Set("patch_version", nil)
+				return fmt.Errorf("[ERROR] Error updating the workers of cluster (%s): %s", clusterID, err)
+			}
+		} else if updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version") {
 
 			// patchVersion := d.Get("patch_version").(string)
 			workers, err := csClient.Workers().ListWorkers(clusterID, false, targetEnv)
@@ -233,6 +233,39 @@ func TestAccIBMContainerVPCClusterUpdateDisableOutboundTrafficProtection(t *test
 	})
 }
 
+func TestAccIBMContainerVpcClusterUpdateStrategy(t *testing.T) {
+	name := fmt.Sprintf("tf-vpc-cluster-%d", acctest.RandIntRange(10, 100))
+	var conf *v2.ClusterInfo
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMContainerVpcClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerVpcClusterUpdateStrategy(name, "1.29", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMContainerVpcExists("ibm_container_vpc_cluster.cluster", conf),
+					resource.TestCheckResourceAttr(
+						"ibm_container_vpc_cluster.cluster", "update_strategy.0.max_unavailable", "50%"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_vpc_cluster.cluster", "update_strategy.0.zone_by_zone", "true"),
+				),
+			},
+			{
+				Config: testAccCheckIBMContainerVpcClusterUpdateStrategy(name, "1.30", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMContainerVpcExists("ibm_container_vpc_cluster.cluster", conf),
+					resource.TestCheckResourceAttr(
+						"ibm_container_vpc_cluster.cluster", "kube_version", "1.30"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_vpc_cluster.cluster", "updated_workers.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckIBMContainerVpcClusterDestroy(s *terraform.State) error {
 	csClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).VpcContainerAPI()
 	if err != nil {
@@ -524,6 +557,50 @@ resource "ibm_container_vpc_cluster" "cluster" {
 }`, name, disable_outbound_traffic_protection)
 }
 
+func testAccCheckIBMContainerVpcClusterUpdateStrategy(name, kubeVersion string, updateAllWorkers bool) string {
+	return fmt.Sprintf(`
+data "ibm_resource_group" "resource_group" {
+	is_default = "true"
+}
+resource "ibm_is_vpc" "vpc" {
+	name = "%[1]s"
+}
+resource "ibm_is_subnet" "subnet1" {
+	name                     = "%[1]s-1"
+	vpc                      = ibm_is_vpc.vpc.id
+	zone                     = "us-south-1"
+	total_ipv4_address_count = 256
+}
+resource "ibm_is_subnet" "subnet2" {
+	name                     = "%[1]s-2"
+	vpc                      = ibm_is_vpc.vpc.id
+	zone                     = "us-south-2"
+	total_ipv4_address_count = 256
+}
+resource "ibm_container_vpc_cluster" "cluster" {
+	name               = "%[1]s"
+	vpc_id             = ibm_is_vpc.vpc.id
+	flavor             = "cx2.2x4"
+	worker_count       = 2
+	kube_version       = "%[2]s"
+	update_all_workers = %[3]t
+	wait_till          = "OneWorkerNodeReady"
+	resource_group_id  = data.ibm_resource_group.resource_group.id
+	zones {
+		subnet_id = ibm_is_subnet.subnet1.id
+		name      = "us-south-1"
+	}
+	zones {
+		subnet_id = ibm_is_subnet.subnet2.id
+		name      = "us-south-2"
+	}
+	update_strategy {
+		max_unavailable = "50%%"
+		zone_by_zone    = true
+	}
+}`, name, kubeVersion, updateAllWorkers)
+}
+
 // preveously you have to create securitygroups and use them instead
 func testAccCheckIBMContainerVpcClusterSecurityGroups(name string) string {
 	return fmt.Sprintf(`
@@ -35,6 +35,7 @@ func ResourceIBMContainerVpcWorkerPool() *schema.Resource {
 		Importer: &schema.ResourceImporter{},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(90 * time.Minute),
 			Delete: schema.DefaultTimeout(90 * time.Minute),
 		},
 
@@ -162,6 +163,10 @@ func ResourceIBMContainerVpcWorkerPool() *schema.Resource {
 				Description: "The operating system of the workers in the worker pool.",
 			},
 
+			"update_strategy": workerUpdateStrategySchema(),
+
+			"updated_workers": updatedWorkersSchema(),
+
 			"secondary_storage": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -541,6 +546,22 @@ func resourceIBMContainerVpcWorkerPoolUpdate(d *schema.ResourceData, meta interf
 		if err != nil {
 			return fmt.Errorf("[ERROR] Error updating the operating_system %s: %s", operatingSystem, err)
 		}
+
+		if strategy, ok := expandWorkerUpdateStrategy(d); ok {
+			updater := &vpcWorkerUpdater{
+				client:    ClusterClient,
+				clusterID: clusterNameOrID,
+				poolID:    workerPoolName,
+				target:    Env,
+				timeout:   d.Timeout(schema.TimeoutUpdate),
+			}
+			if err := updateWorkers(d, strategy, updater); err != nil {
+				// Keep the previous operating_system so that the next apply resumes the update
+				oldOperatingSystem, _ := d.GetChange("operating_system")
+				d.Set("operating_system", oldOperatingSystem)
+				return fmt.Errorf("[ERROR] Error updating the workers of worker pool (%s) of cluster (%s): %s", workerPoolName, clusterNameOrID, err)
+			}
+		}
 	}
 
 	return resourceIBMContainerVpcWorkerPoolRead(d, meta)
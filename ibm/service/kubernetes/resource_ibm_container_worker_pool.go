@@ -80,6 +80,10 @@ func ResourceIBMContainerWorkerPool() *schema.Resource {
 				Description: "The operating system of the workers in the worker pool.",
 			},
 
+			"update_strategy": workerUpdateStrategySchema(),
+
+			"updated_workers": updatedWorkersSchema(),
+
 			"hardware": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -459,6 +463,28 @@ func resourceIBMContainerWorkerPoolUpdate(d *schema.ResourceData, meta interface
 		if err != nil {
 			return fmt.Errorf("[ERROR] Error updating the operating_system %s: %s", operatingSystem, err)
 		}
+
+		if strategy, ok := expandWorkerUpdateStrategy(d); ok {
+			v1Env, err := getWorkerPoolTargetHeader(d, meta)
+			if err != nil {
+				return err
+			}
+			updater := &classicWorkerUpdater{
+				client:    csClient,
+				clientV2:  ClusterClient,
+				clusterID: clusterNameorID,
+				poolID:    workerPoolNameorID,
+				target:    v1Env,
+				targetV2:  Env,
+				timeout:   d.Timeout(schema.TimeoutUpdate),
+			}
+			if err := updateWorkers(d, strategy, updater); err != nil {
+				// Keep the previous operating_system so that the next apply resumes the update
+				oldOperatingSystem, _ := d.GetChange("operating_system")
+				d.Set("operating_system", oldOperatingSystem)
+				return fmt.Errorf("[ERROR] Error updating the workers of worker pool (%s) of cluster (%s): %s", workerPoolNameorID, clusterNameorID, err)
+			}
+		}
 	}
 
 	return resourceIBMContainerWorkerPoolRead(d, meta)
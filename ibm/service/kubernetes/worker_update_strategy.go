@@ -0,0 +1,559 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	workerReadinessChecking = "checking"
+	workerReadinessReady    = "ready"
+)
+
+// workerUpdateStrategySchema is the update_strategy of a cluster or a worker
+// pool, which updates the workers in batches instead of one by one.
+func workerUpdateStrategySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Updates the workers in batches, waiting for the workers of a batch to be normal and for the readiness check between batches",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_unavailable": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "1",
+					ValidateFunc: validateWorkerUpdateMaxUnavailable,
+					Description:  "The maximum number of workers updated at a time, as a count such as 2 or a percentage of the workers such as 25%",
+				},
+				"zone_by_zone": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Updates the workers of a zone before the workers of the next zone",
+				},
+				"readiness_check": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "The URL checked after each batch, before the next batch is updated",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"url": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+								Description:  "The URL to send a GET request to",
+							},
+							"expected_status": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      http.StatusOK,
+								ValidateFunc: validation.IntBetween(100, 599),
+								Description:  "The HTTP status code of a ready response",
+							},
+							"timeout": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      10,
+								ValidateFunc: validation.IntAtLeast(1),
+								Description:  "The time in minutes to wait for a ready response",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// updatedWorkersSchema tracks the workers updated by an update_strategy, so
+// that a failed update resumes without updating them again.
+func updatedWorkersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+		Description: "The IDs of the workers updated by an update_strategy that did not complete",
+	}
+}
+
+func validateWorkerUpdateMaxUnavailable(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := maxUnavailableWorkers(v.(string), 1); err != nil {
+		errors = append(errors, fmt.Errorf("%q %s", k, err))
+	}
+	return
+}
+
+// maxUnavailableWorkers returns the number of workers out of total that
+// maxUnavailable allows to update at a time. A percentage is rounded down, but
+// at least one worker is updated at a time.
+func maxUnavailableWorkers(maxUnavailable string, total int) (int, error) {
+	if p, ok := strings.CutSuffix(maxUnavailable, "%"); ok {
+		percent, err := strconv.Atoi(p)
+		if err != nil || percent < 1 || percent > 100 {
+			return 0, fmt.Errorf("must be a percentage between 1%% and 100%%, got %s", maxUnavailable)
+		}
+		return max(total*percent/100, 1), nil
+	}
+	count, err := strconv.Atoi(maxUnavailable)
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("must be a positive count or a percentage, got %s", maxUnavailable)
+	}
+	return count, nil
+}
+
+type workerUpdateStrategy struct {
+	maxUnavailable        string
+	zoneByZone            bool
+	readinessURL          string
+	readinessStatus       int
+	readinessCheckTimeout time.Duration
+}
+
+func expandWorkerUpdateStrategy(d *schema.ResourceData) (workerUpdateStrategy, bool) {
+	l := d.Get("update_strategy").([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return workerUpdateStrategy{}, false
+	}
+	m := l[0].(map[string]interface{})
+	strategy := workerUpdateStrategy{
+		maxUnavailable: m["max_unavailable"].(string),
+		zoneByZone:     m["zone_by_zone"].(bool),
+	}
+	if checks := m["readiness_check"].([]interface{}); len(checks) > 0 && checks[0] != nil {
+		check := checks[0].(map[string]interface{})
+		strategy.readinessURL = check["url"].(string)
+		strategy.readinessStatus = check["expected_status"].(int)
+		strategy.readinessCheckTimeout = time.Duration(check["timeout"].(int)) * time.Minute
+	}
+	return strategy, true
+}
+
+// waitForReadiness polls the readiness check URL until it returns the expected status.
+func (strategy workerUpdateStrategy) waitForReadiness() error {
+	if strategy.readinessURL == "" {
+		return nil
+	}
+	log.Printf("[INFO] Waiting for %s to return %d", strategy.readinessURL, strategy.readinessStatus)
+	client := &http.Client{Timeout: 30 * time.Second}
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{workerReadinessChecking},
+		Target:  []string{workerReadinessReady},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.Get(strategy.readinessURL)
+			if err != nil {
+				log.Printf("[DEBUG] Readiness check %s failed: %s", strategy.readinessURL, err)
+				return err, workerReadinessChecking, nil
+			}
+			resp.Body.Close()
+			if resp.StatusCode != strategy.readinessStatus {
+				return resp, workerReadinessChecking, nil
+			}
+			return resp, workerReadinessReady, nil
+		},
+		Timeout:    strategy.readinessCheckTimeout,
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("[ERROR] Error waiting for readiness check %s: %s", strategy.readinessURL, err)
+	}
+	return nil
+}
+
+// updatableWorker is a worker of the cluster or worker pool being updated.
+type updatableWorker struct {
+	ID   string
+	Pool string
+	Zone string
+	// Outdated is set when the worker runs another version or operating
+	// system than its worker pool.
+	Outdated bool
+}
+
+// workerUpdateBatches splits the outdated workers into batches of at most size
+// workers. With zoneByZone, the workers of a batch are in the same zone and
+// the zones are updated in order.
+func workerUpdateBatches(workers []updatableWorker, size int, zoneByZone bool) [][]updatableWorker {
+	groups := [][]updatableWorker{workers}
+	if zoneByZone {
+		zones := map[string][]updatableWorker{}
+		var names []string
+		for _, w := range workers {
+			if _, ok := zones[w.Zone]; !ok {
+				names = append(names, w.Zone)
+			}
+			zones[w.Zone] = append(zones[w.Zone], w)
+		}
+		sort.Strings(names)
+		groups = groups[:0]
+		for _, zone := range names {
+			groups = append(groups, zones[zone])
+		}
+	}
+	var batches [][]updatableWorker
+	for _, group := range groups {
+		for len(group) > size {
+			batches = append(batches, group[:size])
+			group = group[size:]
+		}
+		if len(group) > 0 {
+			batches = append(batches, group)
+		}
+	}
+	return batches
+}
+
+// workerUpdater updates the workers of a cluster or of a worker pool.
+type workerUpdater interface {
+	// Workers lists the workers to update.
+	Workers() ([]updatableWorker, error)
+	// Update starts the update of a worker.
+	Update(worker updatableWorker) error
+	// Wait waits for the workers of a batch to be updated and normal. record
+	// is called with the IDs of the updated workers as soon as they are known.
+	Wait(batch []updatableWorker, record func(ids ...string)) error
+	// WaitNormal waits for workers to be normal.
+	WaitNormal(ids []string) error
+}
+
+// updateWorkers updates the outdated workers in batches with the strategy. The
+// IDs of the updated workers are kept in updated_workers until all the
+// workers are updated, so that the next apply after a failure waits for them
+// instead of updating them again.
+func updateWorkers(d *schema.ResourceData, strategy workerUpdateStrategy, updater workerUpdater) error {
+	workers, err := updater.Workers()
+	if err != nil {
+		return err
+	}
+
+	updated := map[string]bool{}
+	for _, id := range d.Get("updated_workers").(*schema.Set).List() {
+		updated[id.(string)] = true
+	}
+	record := func(ids ...string) {
+		for _, id := range ids {
+			updated[id] = true
+		}
+		l := make([]string, 0, len(updated))
+		for id := range updated {
+			l = append(l, id)
+		}
+		d.Set("updated_workers", l)
+	}
+
+	var resumed []string
+	var outdated []updatableWorker
+	for _, w := range workers {
+		switch {
+		case updated[w.ID]:
+			resumed = append(resumed, w.ID)
+		case w.Outdated:
+			outdated = append(outdated, w)
+		}
+	}
+
+	if len(resumed) > 0 {
+		log.Printf("[INFO] Waiting for the workers updated by a previous apply: %v", resumed)
+		if err := updater.WaitNormal(resumed); err != nil {
+			return err
+		}
+		if err := strategy.waitForReadiness(); err != nil {
+			return err
+		}
+	}
+
+	size, err := maxUnavailableWorkers(strategy.maxUnavailable, len(workers))
+	if err != nil {
+		return err
+	}
+	batches := workerUpdateBatches(outdated, size, strategy.zoneByZone)
+	for i, batch := range batches {
+		log.Printf("[INFO] Updating batch %d of %d: %v", i+1, len(batches), batch)
+		for _, w := range batch {
+			if err := updater.Update(w); err != nil {
+				return err
+			}
+		}
+		if err := updater.Wait(batch, record); err != nil {
+			return err
+		}
+		if err := strategy.waitForReadiness(); err != nil {
+			return err
+		}
+	}
+
+	d.Set("updated_workers", nil)
+	return nil
+}
+
+// vpcWorkerUpdater replaces the workers of a VPC cluster, or of one of its
+// worker pools if poolID is set.
+type vpcWorkerUpdater struct {
+	client    v2.ContainerServiceAPI
+	clusterID string
+	poolID    string
+	target    v2.ClusterTargetHeader
+	timeout   time.Duration
+	// known holds the IDs of the workers seen so far, to tell the workers
+	// replacing a batch from the others.
+	known map[string]bool
+}
+
+func (u *vpcWorkerUpdater) list() ([]v2.Worker, error) {
+	if u.poolID != "" {
+		return u.client.Workers().ListByWorkerPool(u.clusterID, u.poolID, false, u.target)
+	}
+	return u.client.Workers().ListWorkers(u.clusterID, false, u.target)
+}
+
+func (u *vpcWorkerUpdater) Workers() ([]updatableWorker, error) {
+	workers, err := u.list()
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error retrieving workers for cluster (%s): %s", u.clusterID, err)
+	}
+	operatingSystems := map[string]string{}
+	u.known = map[string]bool{}
+	updatable := make([]updatableWorker, 0, len(workers))
+	for _, w := range workers {
+		u.known[w.ID] = true
+		os, ok := operatingSystems[w.PoolID]
+		if !ok {
+			workerPool, err := u.client.WorkerPools().GetWorkerPool(u.clusterID, w.PoolID, u.target)
+			if err != nil {
+				return nil, fmt.Errorf("[ERROR] Error retrieving worker pool: %s", err)
+			}
+			os = workerPool.OperatingSystem
+			operatingSystems[w.PoolID] = os
+		}
+		updatable = append(updatable, updatableWorker{
+			ID:   w.ID,
+			Pool: w.PoolID,
+			Zone: w.Location,
+			// A worker without an actual version is still provisioning.
+			Outdated: w.KubeVersion.Actual != "" && (w.KubeVersion.Actual != w.KubeVersion.Target || w.LifeCycle.ActualOperatingSystem != os),
+		})
+	}
+	return updatable, nil
+}
+
+func (u *vpcWorkerUpdater) Update(worker updatableWorker) error {
+	_, err := u.client.Workers().ReplaceWokerNode(u.clusterID, worker.ID, u.target)
+	// As API returns http response 204 NO CONTENT, error raised will be exempted.
+	if err != nil && !strings.Contains(err.Error(), "EmptyResponseBody") {
+		return fmt.Errorf("[ERROR] Error replacing the worker node (%s) from the cluster: %s", worker.ID, err)
+	}
+	return nil
+}
+
+func (u *vpcWorkerUpdater) Wait(batch []updatableWorker, record func(ids ...string)) error {
+	for _, w := range batch {
+		deleteStateConf := &resource.StateChangeConf{
+			Pending: []string{workerDeletePending},
+			Target:  []string{workerDeleteState},
+			Refresh: func() (interface{}, string, error) {
+				worker, err := u.client.Workers().Get(u.clusterID, w.ID, u.target)
+				if err != nil {
+					return worker, workerDeletePending, nil
+				}
+				if worker.LifeCycle.ActualState == "deleted" {
+					return worker, workerDeleteState, nil
+				}
+				return worker, workerDeletePending, nil
+			},
+			Timeout:      u.timeout,
+			Delay:        10 * time.Second,
+			MinTimeout:   5 * time.Second,
+			PollInterval: 5 * time.Second,
+		}
+		if _, err := deleteStateConf.WaitForState(); err != nil {
+			return fmt.Errorf("[ERROR] Worker node - %s is failed to replace: %s", w.ID, err)
+		}
+	}
+
+	slots := workerReplacementSlots(batch)
+	var replacements []string
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"creating"},
+		Target:  []string{"created"},
+		Refresh: func() (interface{}, string, error) {
+			workers, err := u.list()
+			if err != nil {
+				return workers, "", fmt.Errorf("[ERROR] Error in retriving the list of worker nodes: %s", err)
+			}
+			if ids := matchWorkerReplacements(slots, u.known, workers); len(ids) > 0 {
+				replacements = append(replacements, ids...)
+				record(ids...)
+			}
+			if len(replacements) >= len(batch) {
+				return workers, "created", nil
+			}
+			return workers, "creating", nil
+		},
+		Timeout:      u.timeout,
+		Delay:        10 * time.Second,
+		MinTimeout:   5 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("[ERROR] Failed to spawn new worker nodes: %s", err)
+	}
+	return u.WaitNormal(replacements)
+}
+
+// workerReplacementSlots counts the workers of a batch by worker pool and zone,
+// which are the worker pool and zone of the workers replacing them.
+func workerReplacementSlots(batch []updatableWorker) map[string]int {
+	slots := map[string]int{}
+	for _, w := range batch {
+		slots[w.Pool+"/"+w.Zone]++
+	}
+	return slots
+}
+
+// matchWorkerReplacements returns the IDs of the new workers that take one of
+// the free slots of their worker pool and zone. The other new workers, such as
+// the ones added by the cluster autoscaler in another pool or zone, are only
+// marked as known.
+func matchWorkerReplacements(slots map[string]int, known map[string]bool, workers []v2.Worker) []string {
+	var ids []string
+	for _, w := range workers {
+		if known[w.ID] {
+			continue
+		}
+		known[w.ID] = true
+		key := w.PoolID + "/" + w.Location
+		if slots[key] == 0 {
+			log.Printf("[DEBUG] Ignoring new worker %s, it does not replace a worker of the batch", w.ID)
+			continue
+		}
+		log.Printf("[DEBUG] Found new replaced worker %s", w.ID)
+		slots[key]--
+		ids = append(ids, w.ID)
+	}
+	return ids
+}
+
+func (u *vpcWorkerUpdater) WaitNormal(ids []string) error {
+	for _, id := range ids {
+		log.Printf("Waiting for worker (%s) version to be updated.", id)
+		stateConf := &resource.StateChangeConf{
+			Pending:                   []string{"retry", versionUpdating},
+			Target:                    []string{workerNormal},
+			Refresh:                   vpcClusterWorkersVersionRefreshFunc(u.client.Workers(), id, u.clusterID, u.target),
+			Timeout:                   u.timeout,
+			Delay:                     10 * time.Second,
+			MinTimeout:                10 * time.Second,
+			ContinuousTargetOccurence: 3,
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("[ERROR] Error waiting for worker (%s) of cluster (%s) to be updated: %s", id, u.clusterID, err)
+		}
+	}
+	return nil
+}
+
+// classicWorkerUpdater updates the workers of a classic cluster in place, or
+// the workers of one of its worker pools if poolID is set.
+type classicWorkerUpdater struct {
+	client    v1.ContainerServiceAPI
+	clientV2  v2.ContainerServiceAPI
+	clusterID string
+	poolID    string
+	target    v1.ClusterTargetHeader
+	targetV2  v2.ClusterTargetHeader
+	timeout   time.Duration
+}
+
+func (u *classicWorkerUpdater) Workers() ([]updatableWorker, error) {
+	workers, err := u.clientV2.Workers().ListAllWorkers(u.clusterID, false, u.targetV2)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error retrieving workers for cluster (%s): %s", u.clusterID, err)
+	}
+	operatingSystems := map[string]string{}
+	var updatable []updatableWorker
+	for _, w := range workers {
+		if u.poolID != "" && w.PoolID != u.poolID && w.PoolName != u.poolID {
+			continue
+		}
+		os, ok := operatingSystems[w.PoolID]
+		if !ok {
+			workerPool, err := u.client.WorkerPools().GetWorkerPool(u.clusterID, w.PoolID, u.target)
+			if err != nil {
+				return nil, fmt.Errorf("[ERROR] Error retrieving worker pool: %s", err)
+			}
+			os = workerPool.OperatingSystem
+			operatingSystems[w.PoolID] = os
+		}
+		updatable = append(updatable, updatableWorker{
+			ID:       w.ID,
+			Pool:     w.PoolID,
+			Zone:     w.Location,
+			Outdated: w.KubeVersion.Actual != "" && (w.KubeVersion.Actual != w.KubeVersion.Target || w.LifeCycle.ActualOperatingSystem != os),
+		})
+	}
+	return updatable, nil
+}
+
+func (u *classicWorkerUpdater) Update(worker updatableWorker) error {
+	params := v1.WorkerUpdateParam{
+		Action: "update",
+	}
+	if err := u.client.Workers().Update(u.clusterID, worker.ID, params, u.target); err != nil {
+		return fmt.Errorf("[ERROR] Error updating worker %s: %s", worker.ID, err)
+	}
+	return nil
+}
+
+func (u *classicWorkerUpdater) Wait(batch []updatableWorker, record func(ids ...string)) error {
+	ids := make([]string, 0, len(batch))
+	for _, w := range batch {
+		ids = append(ids, w.ID)
+	}
+	record(ids...)
+	return u.WaitNormal(ids)
+}
+
+func (u *classicWorkerUpdater) WaitNormal(ids []string) error {
+	for _, id := range ids {
+		log.Printf("Waiting for worker (%s) to be updated.", id)
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{"retry", workerProvisioning},
+			Target:  []string{workerNormal},
+			Refresh: func() (interface{}, string, error) {
+				worker, err := u.client.Workers().Get(id, u.target)
+				if err != nil {
+					return nil, "retry", fmt.Errorf("[ERROR] Error retrieving worker (%s): %s", id, err)
+				}
+				if strings.Contains(worker.KubeVersion, "pending") || worker.State != workerNormal || worker.Status != workerReadyState {
+					return worker, workerProvisioning, nil
+				}
+				return worker, workerNormal, nil
+			},
+			Timeout:                   u.timeout,
+			Delay:                     10 * time.Second,
+			MinTimeout:                10 * time.Second,
+			ContinuousTargetOccurence: 3,
+		}
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("[ERROR] Error waiting for worker (%s) of cluster (%s) to be updated: %s", id, u.clusterID, err)
+		}
+	}
+	return nil
+}
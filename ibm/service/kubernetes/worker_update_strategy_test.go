@@ -0,0 +1,253 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMaxUnavailableWorkers(t *testing.T) {
+	for _, tc := range []struct {
+		maxUnavailable string
+		total          int
+		expected       int
+		err            bool
+	}{
+		{"1", 6, 1, false},
+		{"4", 6, 4, false},
+		{"10", 6, 10, false},
+		{"50%", 6, 3, false},
+		{"25%", 6, 1, false},
+		{"100%", 6, 6, false},
+		// a percentage is rounded down, but at least one worker is updated
+		{"10%", 6, 1, false},
+		{"1%", 0, 1, false},
+		{"0", 6, 0, true},
+		{"-1", 6, 0, true},
+		{"0%", 6, 0, true},
+		{"101%", 6, 0, true},
+		{"half", 6, 0, true},
+		{"%", 6, 0, true},
+	} {
+		count, err := maxUnavailableWorkers(tc.maxUnavailable, tc.total)
+		if (err != nil) != tc.err {
+			t.Errorf("maxUnavailableWorkers(%q, %d) returned error %v, expected error %t", tc.maxUnavailable, tc.total, err, tc.err)
+			continue
+		}
+		if count != tc.expected {
+			t.Errorf("maxUnavailableWorkers(%q, %d) = %d, expected %d", tc.maxUnavailable, tc.total, count, tc.expected)
+		}
+	}
+}
+
+func TestWorkerUpdateBatches(t *testing.T) {
+	workers := []updatableWorker{
+		{ID: "w1", Zone: "us-south-2"},
+		{ID: "w2", Zone: "us-south-1"},
+		{ID: "w3", Zone: "us-south-2"},
+		{ID: "w4", Zone: "us-south-1"},
+		{ID: "w5", Zone: "us-south-3"},
+	}
+	for name, tc := range map[string]struct {
+		size       int
+		zoneByZone bool
+		expected   [][]string
+	}{
+		"one by one":            {size: 1, expected: [][]string{{"w1"}, {"w2"}, {"w3"}, {"w4"}, {"w5"}}},
+		"batches of two":        {size: 2, expected: [][]string{{"w1", "w2"}, {"w3", "w4"}, {"w5"}}},
+		"all at once":           {size: 10, expected: [][]string{{"w1", "w2", "w3", "w4", "w5"}}},
+		"zone by zone":          {size: 10, zoneByZone: true, expected: [][]string{{"w2", "w4"}, {"w1", "w3"}, {"w5"}}},
+		"zone by zone, one":     {size: 1, zoneByZone: true, expected: [][]string{{"w2"}, {"w4"}, {"w1"}, {"w3"}, {"w5"}}},
+		"zone by zone, smaller": {size: 2, zoneByZone: true, expected: [][]string{{"w2", "w4"}, {"w1", "w3"}, {"w5"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var batches [][]string
+			for _, batch := range workerUpdateBatches(workers, tc.size, tc.zoneByZone) {
+				batches = append(batches, workerIDs(batch))
+			}
+			if !reflect.DeepEqual(batches, tc.expected) {
+				t.Errorf("workerUpdateBatches() = %v, expected %v", batches, tc.expected)
+			}
+		})
+	}
+
+	if batches := workerUpdateBatches(nil, 2, true); len(batches) != 0 {
+		t.Errorf("workerUpdateBatches() without workers = %v, expected no batch", batches)
+	}
+}
+
+// fakeWorkerUpdater updates the workers in memory and records the calls.
+type fakeWorkerUpdater struct {
+	workers []updatableWorker
+	// failUpdate makes the update of the worker with this ID fail.
+	failUpdate string
+
+	updated    []string
+	batches    [][]string
+	waitNormal []string
+}
+
+func (u *fakeWorkerUpdater) Workers() ([]updatableWorker, error) {
+	return u.workers, nil
+}
+
+func (u *fakeWorkerUpdater) Update(worker updatableWorker) error {
+	if worker.ID == u.failUpdate {
+		return fmt.Errorf("update of %s failed", worker.ID)
+	}
+	u.updated = append(u.updated, worker.ID)
+	return nil
+}
+
+func (u *fakeWorkerUpdater) Wait(batch []updatableWorker, record func(ids ...string)) error {
+	ids := workerIDs(batch)
+	u.batches = append(u.batches, ids)
+	record(ids...)
+	return nil
+}
+
+func (u *fakeWorkerUpdater) WaitNormal(ids []string) error {
+	u.waitNormal = append(u.waitNormal, ids...)
+	return nil
+}
+
+func TestUpdateWorkers(t *testing.T) {
+	workers := []updatableWorker{
+		{ID: "w1", Zone: "us-south-1", Outdated: true},
+		{ID: "w2", Zone: "us-south-2", Outdated: true},
+		{ID: "w3", Zone: "us-south-1", Outdated: false},
+		{ID: "w4", Zone: "us-south-2", Outdated: true},
+	}
+	for name, tc := range map[string]struct {
+		strategy          workerUpdateStrategy
+		previouslyUpdated []string
+		failUpdate        string
+
+		err            bool
+		updated        []string
+		batches        [][]string
+		waitNormal     []string
+		updatedWorkers []string
+	}{
+		"outdated workers one by one": {
+			strategy: workerUpdateStrategy{maxUnavailable: "1"},
+			updated:  []string{"w1", "w2", "w4"},
+			batches:  [][]string{{"w1"}, {"w2"}, {"w4"}},
+		},
+		"outdated workers zone by zone": {
+			strategy: workerUpdateStrategy{maxUnavailable: "50%", zoneByZone: true},
+			updated:  []string{"w1", "w2", "w4"},
+			batches:  [][]string{{"w1"}, {"w2", "w4"}},
+		},
+		// the workers updated by a failed apply are waited for instead of
+		// being updated again
+		"resume": {
+			strategy:          workerUpdateStrategy{maxUnavailable: "2"},
+			previouslyUpdated: []string{"w1", "w2"},
+			updated:           []string{"w4"},
+			batches:           [][]string{{"w4"}},
+			waitNormal:        []string{"w1", "w2"},
+		},
+		// the replacements of a VPC batch are not in the worker list of the
+		// failed apply, and are not waited for again
+		"resume with replaced workers": {
+			strategy:          workerUpdateStrategy{maxUnavailable: "1"},
+			previouslyUpdated: []string{"w1", "w0"},
+			updated:           []string{"w2", "w4"},
+			batches:           [][]string{{"w2"}, {"w4"}},
+			waitNormal:        []string{"w1"},
+		},
+		// a failure keeps the updated workers for the next apply
+		"failure": {
+			strategy:       workerUpdateStrategy{maxUnavailable: "1"},
+			failUpdate:     "w4",
+			err:            true,
+			updated:        []string{"w1", "w2"},
+			batches:        [][]string{{"w1"}, {"w2"}},
+			updatedWorkers: []string{"w1", "w2"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+				"update_strategy": workerUpdateStrategySchema(),
+				"updated_workers": updatedWorkersSchema(),
+			}, map[string]interface{}{})
+			if err := d.Set("updated_workers", tc.previouslyUpdated); err != nil {
+				t.Fatal(err)
+			}
+			updater := &fakeWorkerUpdater{workers: workers, failUpdate: tc.failUpdate}
+
+			err := updateWorkers(d, tc.strategy, updater)
+			if (err != nil) != tc.err {
+				t.Fatalf("updateWorkers() returned error %v, expected error %t", err, tc.err)
+			}
+			if !reflect.DeepEqual(updater.updated, tc.updated) {
+				t.Errorf("updated %v, expected %v", updater.updated, tc.updated)
+			}
+			if !reflect.DeepEqual(updater.batches, tc.batches) {
+				t.Errorf("waited for batches %v, expected %v", updater.batches, tc.batches)
+			}
+			if !reflect.DeepEqual(updater.waitNormal, tc.waitNormal) {
+				t.Errorf("waited for resumed workers %v, expected %v", updater.waitNormal, tc.waitNormal)
+			}
+			var updatedWorkers []string
+			for _, id := range d.Get("updated_workers").(*schema.Set).List() {
+				updatedWorkers = append(updatedWorkers, id.(string))
+			}
+			sort.Strings(updatedWorkers)
+			if !reflect.DeepEqual(updatedWorkers, tc.updatedWorkers) {
+				t.Errorf("updated_workers = %v, expected %v", updatedWorkers, tc.updatedWorkers)
+			}
+		})
+	}
+}
+
+func TestMatchWorkerReplacements(t *testing.T) {
+	batch := []updatableWorker{
+		{ID: "w1", Pool: "default", Zone: "us-south-1"},
+		{ID: "w2", Pool: "default", Zone: "us-south-2"},
+	}
+	slots := workerReplacementSlots(batch)
+	known := map[string]bool{"w1": true, "w2": true, "w3": true}
+
+	// the autoscaler adds a worker to another pool while the batch is replaced
+	ids := matchWorkerReplacements(slots, known, []v2.Worker{
+		{ID: "w3", PoolID: "default", Location: "us-south-1"},
+		{ID: "w4", PoolID: "default", Location: "us-south-1"},
+		{ID: "a1", PoolID: "autoscaled", Location: "us-south-1"},
+	})
+	if !reflect.DeepEqual(ids, []string{"w4"}) {
+		t.Errorf("matchWorkerReplacements() = %v, expected [w4]", ids)
+	}
+
+	// a second new worker in a zone whose worker is already replaced is not a replacement
+	ids = matchWorkerReplacements(slots, known, []v2.Worker{
+		{ID: "w4", PoolID: "default", Location: "us-south-1"},
+		{ID: "w5", PoolID: "default", Location: "us-south-1"},
+		{ID: "w6", PoolID: "default", Location: "us-south-2"},
+		{ID: "a1", PoolID: "autoscaled", Location: "us-south-1"},
+	})
+	if !reflect.DeepEqual(ids, []string{"w6"}) {
+		t.Errorf("matchWorkerReplacements() = %v, expected [w6]", ids)
+	}
+	for _, id := range []string{"w5", "a1"} {
+		if !known[id] {
+			t.Errorf("worker %s is not known after it was listed", id)
+		}
+	}
+}
+
+func workerIDs(workers []updatableWorker) []string {
+	ids := make([]string, 0, len(workers))
+	for _, w := range workers {
+		ids = append(ids, w.ID)
+	}
+	return ids
+}
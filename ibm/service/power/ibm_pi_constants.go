@@ -34,6 +34,7 @@ const (
 	Arg_IBMiCSS                             = "pi_ibmi_css"
 	Arg_IBMiPHA                             = "pi_ibmi_pha"
 	Arg_IBMiRDSUsers                        = "pi_ibmi_rds_users"
+	Arg_ImageCOS                            = "pi_image_cos"
 	Arg_ImageID                             = "pi_image_id"
 	Arg_ImageImportDetails                  = "pi_image_import_details"
 	Arg_ImageName                           = "pi_image_name"
@@ -47,6 +48,7 @@ const (
 	Arg_LicenseRepositoryCapacity           = "pi_license_repository_capacity"
 	Arg_Memory                              = "pi_memory"
 	Arg_Name                                = "pi_name"
+	Arg_NamePrefix                          = "pi_name_prefix"
 	Arg_Network                             = "pi_network"
 	Arg_NetworkAddressGroupID               = "pi_network_address_group_id"
 	Arg_NetworkAddressGroupMemberID         = "pi_network_address_group_member_id"
@@ -72,6 +74,7 @@ const (
 	Arg_ReplicationScheme                   = "pi_replication_scheme"
 	Arg_ReplicationSites                    = "pi_replication_sites"
 	Arg_ResourceGroupID                     = "pi_resource_group_id"
+	Arg_Resources                           = "pi_resources"
 	Arg_SAP                                 = "sap"
 	Arg_SAPDeploymentType                   = "pi_sap_deployment_type"
 	Arg_SAPProfileID                        = "pi_sap_profile_id"
@@ -85,6 +88,7 @@ const (
 	Arg_SharedProcessorPoolReservedCores    = "pi_shared_processor_pool_reserved_cores"
 	Arg_SnapshotID                          = "pi_snapshot_id"
 	Arg_SnapShotName                        = "pi_snap_shot_name"
+	Arg_SourceCloudInstanceID               = "pi_source_cloud_instance_id"
 	Arg_SourcePorts                         = "pi_source_ports"
 	Arg_SPPPlacementGroupID                 = "pi_spp_placement_group_id"
 	Arg_SPPPlacementGroupName               = "pi_spp_placement_group_name"
@@ -96,7 +100,9 @@ const (
 	Arg_StorageType                         = "pi_storage_type"
 	Arg_SysType                             = "pi_sys_type"
 	Arg_Target                              = "pi_target"
+	Arg_Targets                             = "pi_targets"
 	Arg_TargetStorageTier                   = "pi_target_storage_tier"
+	Arg_TargetZone                          = "pi_target_zone"
 	Arg_Type                                = "pi_type"
 	Arg_UserData                            = "pi_user_data"
 	Arg_UserTags                            = "pi_user_tags"
@@ -120,6 +126,7 @@ const (
 	// Attributes
 	Attr_Access                             = "access"
 	Attr_AccessConfig                       = "access_config"
+	Attr_AccessKey                          = "access_key"
 	Attr_Action                             = "action"
 	Attr_AllocatedCores                     = "allocated_cores"
 	Attr_Architecture                       = "architecture"
@@ -134,6 +141,8 @@ const (
 	Attr_AvailableMemory                    = "available_memory"
 	Attr_Bootable                           = "bootable"
 	Attr_BootVolumeID                       = "boot_volume_id"
+	Attr_BucketName                         = "bucket_name"
+	Attr_BucketRegion                       = "bucket_region"
 	Attr_Capabilities                       = "capabilities"
 	Attr_CapabilityDetails                  = "capability_details"
 	Attr_Capacity                           = "capacity"
@@ -217,8 +226,12 @@ const (
 	Attr_IBMiRDSUsers                       = "ibmi_rds_users"
 	Attr_ICMPType                           = "icmp_type"
 	Attr_ID                                 = "id"
+	Attr_IDMapping                          = "id_mapping"
+	Attr_ImageFileName                      = "image_file_name"
 	Attr_ImageID                            = "image_id"
+	Attr_ImageIDs                           = "image_ids"
 	Attr_ImageInfo                          = "image_info"
+	Attr_ImageName                          = "image_name"
 	Attr_Images                             = "images"
 	Attr_ImageType                          = "image_type"
 	Attr_InputVolumes                       = "input_volumes"
@@ -321,6 +334,7 @@ const (
 	Attr_RemoteCopyRelationshipNames        = "remote_copy_relationship_names"
 	Attr_RemoteCopyRelationships            = "remote_copy_relationships"
 	Attr_RemotePool                         = "remote_pool"
+	Attr_Replicas                           = "replicas"
 	Attr_ReplicationEnabled                 = "replication_enabled"
 	Attr_ReplicationPoolMap                 = "replication_pool_map"
 	Attr_ReplicationSites                   = "replication_sites"
@@ -334,6 +348,7 @@ const (
 	Attr_Rules                              = "rules"
 	Attr_SAPS                               = "saps"
 	Attr_Secondaries                        = "secondaries"
+	Attr_SecretKey                          = "secret_key"
 	Attr_ServerName                         = "server_name"
 	Attr_Servers                            = "servers"
 	Attr_Shareable                          = "shreable"
@@ -345,6 +360,8 @@ const (
 	Attr_Size                               = "size"
 	Attr_SnapshotID                         = "snapshot_id"
 	Attr_SourceChecksum                     = "source_checksum"
+	Attr_SourceID                           = "source_id"
+	Attr_SourceName                         = "source_name"
 	Attr_SourcePort                         = "source_port"
 	Attr_SourceVolumeID                     = "source_volume_id"
 	Attr_SourceVolumeName                   = "source_volume_name"
@@ -355,6 +372,7 @@ const (
 	Attr_SPPPlacementGroupPolicy            = "policy"
 	Attr_SPPPlacementGroups                 = "spp_placement_groups"
 	Attr_SSHKey                             = "ssh_key"
+	Attr_SSHKeys                            = "ssh_keys"
 	Attr_StartTime                          = "start_time"
 	Attr_State                              = "state"
 	Attr_Status                             = "status"
@@ -375,7 +393,9 @@ const (
 	Attr_SysType                            = "sys_type"
 	Attr_Systype                            = "systype"
 	Attr_Target                             = "target"
+	Attr_TargetID                           = "target_id"
 	Attr_TargetLocations                    = "target_locations"
+	Attr_TargetName                         = "target_name"
 	Attr_TargetVolumeName                   = "target_volume_name"
 	Attr_TaskID                             = "task_id"
 	Attr_TCPFlags                           = "tcp_flags"
@@ -427,6 +447,7 @@ const (
 	Attr_WorkspaceStatus                    = "pi_workspace_status"
 	Attr_WorkspaceType                      = "pi_workspace_type"
 	Attr_WWN                                = "wwn"
+	Attr_Zone                               = "zone"
 
 	// Duplicate Attributes, will be removed as refactoring take course.
 	PICloudConnectionClassicGreSource      = "gre_source_address"
@@ -0,0 +1,262 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+)
+
+func ResourceIBMPIImageReplication() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMPIImageReplicationCreate,
+		ReadContext:   resourceIBMPIImageReplicationRead,
+		DeleteContext: resourceIBMPIImageReplicationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Arguments
+			Arg_CloudInstanceID: {
+				Description:  "The GUID of the workspace that holds the source image.",
+				ForceNew:     true,
+				Required:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			Arg_ImageCOS: piImageCOSSchema("Cloud Object Storage bucket the source image is exported to and the target workspaces import it from.", true),
+			Arg_ImageID: {
+				Description:  "The ID or name of the source image, for example the image_id of an ibm_pi_capture.",
+				ForceNew:     true,
+				Required:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			Arg_Targets: {
+				Description: "The workspaces the image is imported into.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						Attr_CloudInstanceID: {
+							Description:  "The GUID of the target workspace.",
+							Required:     true,
+							Type:         schema.TypeString,
+							ValidateFunc: validation.NoZeroValues,
+						},
+						Attr_ImageName: {
+							Description: "The name of the image in the target workspace. Defaults to the name of the source image.",
+							Optional:    true,
+							Type:        schema.TypeString,
+						},
+						Attr_StorageType: {
+							Description: "The storage type of the image in the target workspace. Defaults to the storage type of the source image.",
+							Optional:    true,
+							Type:        schema.TypeString,
+						},
+						Attr_Zone: {
+							Description: "The zone of the target workspace. Defaults to the provider's zone.",
+							Optional:    true,
+							Type:        schema.TypeString,
+						},
+					},
+				},
+				ForceNew: true,
+				MinItems: 1,
+				Required: true,
+				Type:     schema.TypeList,
+			},
+
+			// Attributes
+			Attr_ImageFileName: {
+				Computed:    true,
+				Description: "The name of the exported image file in the Cloud Object Storage bucket.",
+				Type:        schema.TypeString,
+			},
+			Attr_ImageIDs: {
+				Computed:    true,
+				Description: "Map of target workspace GUIDs to the ID of the replicated image.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeMap,
+			},
+			Attr_Replicas: {
+				Computed:    true,
+				Description: "The replicated images.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						Attr_CloudInstanceID: {
+							Computed:    true,
+							Description: "The GUID of the target workspace.",
+							Type:        schema.TypeString,
+						},
+						Attr_ImageID: {
+							Computed:    true,
+							Description: "The ID of the image in the target workspace.",
+							Type:        schema.TypeString,
+						},
+						Attr_ImageName: {
+							Computed:    true,
+							Description: "The name of the image in the target workspace.",
+							Type:        schema.TypeString,
+						},
+						Attr_State: {
+							Computed:    true,
+							Description: "The state of the image in the target workspace.",
+							Type:        schema.TypeString,
+						},
+						Attr_Zone: {
+							Computed:    true,
+							Description: "The zone of the target workspace.",
+							Type:        schema.TypeString,
+						},
+					},
+				},
+				Type: schema.TypeList,
+			},
+		},
+	}
+}
+
+func resourceIBMPIImageReplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := meta.(conns.ClientSession).IBMPISession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cloudInstanceID := d.Get(Arg_CloudInstanceID).(string)
+	cos := d.Get(Arg_ImageCOS + ".0").(map[string]interface{})
+	image, err := instance.NewIBMPIImageClient(ctx, sess, cloudInstanceID).Get(d.Get(Arg_ImageID).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fileName, err := piExportImageToCOS(ctx, sess, cloudInstanceID, *image.ImageID, *image.Name, cos, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.Errorf("error exporting image %s: %s", *image.Name, err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s", cloudInstanceID, *image.ImageID))
+	d.Set(Attr_ImageFileName, fileName)
+
+	targets := d.Get(Arg_Targets).([]interface{})
+	replicas := make([]map[string]interface{}, len(targets))
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		target := t.(map[string]interface{})
+		replica := map[string]interface{}{
+			Attr_CloudInstanceID: target[Attr_CloudInstanceID].(string),
+			Attr_ImageID:         "",
+			Attr_ImageName:       *image.Name,
+			Attr_Zone:            target[Attr_Zone].(string),
+		}
+		if name := target[Attr_ImageName].(string); name != "" {
+			replica[Attr_ImageName] = name
+		}
+		storageType := target[Attr_StorageType].(string)
+		if storageType == "" && image.StorageType != nil {
+			storageType = *image.StorageType
+		}
+		replicas[i] = replica
+
+		wg.Add(1)
+		go func(i int, storageType string) {
+			defer wg.Done()
+			targetSess, err := piSessionForZone(sess, replicas[i][Attr_Zone].(string))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			imageID, err := piImportImageFromCOS(ctx, targetSess, replicas[i][Attr_CloudInstanceID].(string), fileName, replicas[i][Attr_ImageName].(string), storageType, cos, d.Timeout(schema.TimeoutCreate))
+			replicas[i][Attr_ImageID] = imageID
+			errs[i] = err
+		}(i, storageType)
+	}
+	wg.Wait()
+
+	// Replicas that were imported are kept in state even if another target failed, so
+	// that they are removed when the tainted resource is destroyed.
+	imported := []map[string]interface{}{}
+	failures := []string{}
+	for i, replica := range replicas {
+		if replica[Attr_ImageID].(string) != "" {
+			imported = append(imported, replica)
+		}
+		if errs[i] != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", replica[Attr_CloudInstanceID], errs[i]))
+		}
+	}
+	d.Set(Attr_Replicas, imported)
+	if len(failures) > 0 {
+		return diag.Errorf("error replicating image %s: %s", *image.Name, strings.Join(failures, "; "))
+	}
+
+	return resourceIBMPIImageReplicationRead(ctx, d, meta)
+}
+
+func resourceIBMPIImageReplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := meta.(conns.ClientSession).IBMPISession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	replicas := []map[string]interface{}{}
+	imageIDs := map[string]interface{}{}
+	for _, r := range d.Get(Attr_Replicas).([]interface{}) {
+		replica := r.(map[string]interface{})
+		targetSess, err := piSessionForZone(sess, replica[Attr_Zone].(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		cloudInstanceID := replica[Attr_CloudInstanceID].(string)
+		image, err := instance.NewIBMPIImageClient(ctx, targetSess, cloudInstanceID).Get(replica[Attr_ImageID].(string))
+		if err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), NotFound) {
+				log.Printf("[DEBUG] replicated image %s no longer exists in workspace %s", replica[Attr_ImageID], cloudInstanceID)
+				continue
+			}
+			return diag.FromErr(err)
+		}
+		replica[Attr_State] = image.State
+		replicas = append(replicas, replica)
+		imageIDs[cloudInstanceID] = replica[Attr_ImageID]
+	}
+	d.Set(Attr_Replicas, replicas)
+	d.Set(Attr_ImageIDs, imageIDs)
+
+	return nil
+}
+
+func resourceIBMPIImageReplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := meta.(conns.ClientSession).IBMPISession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, r := range d.Get(Attr_Replicas).([]interface{}) {
+		replica := r.(map[string]interface{})
+		targetSess, err := piSessionForZone(sess, replica[Attr_Zone].(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = instance.NewIBMPIImageClient(ctx, targetSess, replica[Attr_CloudInstanceID].(string)).Delete(replica[Attr_ImageID].(string))
+		if err != nil && !strings.Contains(strings.ToLower(err.Error()), NotFound) {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
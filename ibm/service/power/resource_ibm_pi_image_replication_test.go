@@ -0,0 +1,64 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMPIImageReplicationBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-pi-image-replication-%d", acctest.RandIntRange(10, 100))
+	replicationRes := "ibm_pi_image_replication.power_image_replication"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccIBMPIWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMPIImageReplicationConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(replicationRes, "id"),
+					resource.TestCheckResourceAttrSet(replicationRes, "image_file_name"),
+					resource.TestCheckResourceAttr(replicationRes, "replicas.#", "1"),
+					resource.TestCheckResourceAttr(replicationRes, "replicas.0.state", "active"),
+					resource.TestCheckResourceAttrSet(replicationRes, "replicas.0.image_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMPIImageReplicationConfig(name string) string {
+	return fmt.Sprintf(`
+		data "ibm_pi_image" "power_image" {
+			pi_cloud_instance_id = "%[1]s"
+			pi_image_name        = "%[2]s"
+		}
+
+		resource "ibm_pi_workspace" "powervs_service_instance" {
+			pi_name              = "%[3]s"
+			pi_datacenter        = "dal12"
+			pi_resource_group_id = "%[4]s"
+		}
+
+		resource "ibm_pi_image_replication" "power_image_replication" {
+			pi_cloud_instance_id = "%[1]s"
+			pi_image_id          = data.ibm_pi_image.power_image.id
+			pi_image_cos {
+				access_key    = "%[5]s"
+				bucket_name   = "%[6]s"
+				bucket_region = "%[7]s"
+				secret_key    = "%[8]s"
+			}
+			pi_targets {
+				cloud_instance_id = ibm_pi_workspace.powervs_service_instance.id
+				image_name        = "%[3]s"
+			}
+		}`, acc.Pi_cloud_instance_id, acc.Pi_image, name, acc.Pi_resource_group_id, acc.Pi_image_bucket_access_key, acc.Pi_image_bucket_name, acc.Pi_image_bucket_region, acc.Pi_image_bucket_secret_key)
+}
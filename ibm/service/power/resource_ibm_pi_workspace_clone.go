@@ -0,0 +1,851 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/ibmpisession"
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+)
+
+// Objects are cloned in this order so that later objects can reference earlier ones,
+// and deleted in the reverse order.
+var piWorkspaceCloneKinds = []string{Attr_Networks, Attr_SSHKeys, Attr_PlacementGroups, Attr_Images, Attr_NetworkAddressGroups, Attr_NetworkSecurityGroups}
+
+func ResourceIBMPIWorkspaceClone() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMPIWorkspaceCloneCreate,
+		ReadContext:   resourceIBMPIWorkspaceCloneRead,
+		DeleteContext: resourceIBMPIWorkspaceCloneDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Arguments
+			Arg_CloudInstanceID: {
+				Description:  "The GUID of the target workspace the objects are cloned into.",
+				ForceNew:     true,
+				Required:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			Arg_ImageCOS: piImageCOSSchema("Cloud Object Storage bucket used to move imported images from the source to the target workspace.", false),
+			Arg_NamePrefix: {
+				Description: "Prefix added to the name of every object created in the target workspace.",
+				ForceNew:    true,
+				Optional:    true,
+				Type:        schema.TypeString,
+			},
+			Arg_Resources: {
+				Description: "The kinds of objects to clone. Supported values are networks, ssh_keys, placement_groups, images and network_security_groups. Defaults to all of them.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.ValidateAllowedStringValues([]string{Attr_Images, Attr_Networks, Attr_NetworkSecurityGroups, Attr_PlacementGroups, Attr_SSHKeys}),
+				},
+				ForceNew: true,
+				Optional: true,
+				Set:      schema.HashString,
+				Type:     schema.TypeSet,
+			},
+			Arg_SourceCloudInstanceID: {
+				Description:  "The GUID of the source workspace, read through the provider's region and zone.",
+				ForceNew:     true,
+				Required:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			Arg_TargetZone: {
+				Description: "The zone of the target workspace. Defaults to the provider's zone.",
+				ForceNew:    true,
+				Optional:    true,
+				Type:        schema.TypeString,
+			},
+
+			// Attributes
+			Attr_IDMapping: {
+				Computed:    true,
+				Description: "Map of source object IDs to the matching target object IDs.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeMap,
+			},
+			Attr_Images:                piWorkspaceCloneMappingSchema("images"),
+			Attr_NetworkAddressGroups:  piWorkspaceCloneMappingSchema("network address groups referenced by network security group rules"),
+			Attr_NetworkSecurityGroups: piWorkspaceCloneMappingSchema("network security groups"),
+			Attr_Networks:              piWorkspaceCloneMappingSchema("networks"),
+			Attr_PlacementGroups:       piWorkspaceCloneMappingSchema("placement groups"),
+			Attr_SSHKeys:               piWorkspaceCloneMappingSchema("SSH keys"),
+		},
+	}
+}
+
+func piWorkspaceCloneMappingSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Computed:    true,
+		Description: fmt.Sprintf("The cloned %s.", kind),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				Attr_Created: {
+					Computed:    true,
+					Description: "Whether the object was created by this resource; false when an object with the same name already existed in the target workspace.",
+					Type:        schema.TypeBool,
+				},
+				Attr_SourceID: {
+					Computed:    true,
+					Description: "The ID of the object in the source workspace.",
+					Type:        schema.TypeString,
+				},
+				Attr_SourceName: {
+					Computed:    true,
+					Description: "The name of the object in the source workspace.",
+					Type:        schema.TypeString,
+				},
+				Attr_TargetID: {
+					Computed:    true,
+					Description: "The ID of the object in the target workspace.",
+					Type:        schema.TypeString,
+				},
+				Attr_TargetName: {
+					Computed:    true,
+					Description: "The name of the object in the target workspace.",
+					Type:        schema.TypeString,
+				},
+			},
+		},
+		Type: schema.TypeList,
+	}
+}
+
+func resourceIBMPIWorkspaceCloneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := meta.(conns.ClientSession).IBMPISession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	targetSess, err := piSessionForZone(sess, d.Get(Arg_TargetZone).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cloudInstanceID := d.Get(Arg_CloudInstanceID).(string)
+	sourceCloudInstanceID := d.Get(Arg_SourceCloudInstanceID).(string)
+	if cloudInstanceID == sourceCloudInstanceID {
+		return diag.Errorf("%s and %s must reference different workspaces", Arg_CloudInstanceID, Arg_SourceCloudInstanceID)
+	}
+
+	c := &piWorkspaceCloner{
+		ctx:      ctx,
+		mapping:  map[string][]map[string]interface{}{},
+		prefix:   d.Get(Arg_NamePrefix).(string),
+		source:   sess,
+		sourceID: sourceCloudInstanceID,
+		target:   targetSess,
+		targetID: cloudInstanceID,
+		timeout:  d.Timeout(schema.TimeoutCreate),
+	}
+	if v, ok := d.GetOk(Arg_ImageCOS); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		c.cos = v.([]interface{})[0].(map[string]interface{})
+	}
+
+	kinds := map[string]bool{}
+	if v, ok := d.GetOk(Arg_Resources); ok && v.(*schema.Set).Len() > 0 {
+		for _, kind := range flex.FlattenSet(v.(*schema.Set)) {
+			kinds[kind] = true
+		}
+	} else {
+		for _, kind := range piWorkspaceCloneKinds {
+			kinds[kind] = true
+		}
+	}
+	// Rules can only be recreated once the address groups they reference exist.
+	kinds[Attr_NetworkAddressGroups] = kinds[Attr_NetworkSecurityGroups]
+
+	if kinds[Attr_Images] && c.cos == nil {
+		imported, err := c.importedImages()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(imported) > 0 {
+			return diag.Errorf("%s is required to clone the imported images %s", Arg_ImageCOS, strings.Join(imported, ", "))
+		}
+	}
+
+	// The ID is set up front so that objects created before a failure are tracked
+	// in state and removed when the tainted resource is destroyed.
+	d.SetId(fmt.Sprintf("%s/%s", cloudInstanceID, sourceCloudInstanceID))
+
+	clone := map[string]func() error{
+		Attr_Images:                c.cloneImages,
+		Attr_NetworkAddressGroups:  c.cloneNetworkAddressGroups,
+		Attr_NetworkSecurityGroups: c.cloneNetworkSecurityGroups,
+		Attr_Networks:              c.cloneNetworks,
+		Attr_PlacementGroups:       c.clonePlacementGroups,
+		Attr_SSHKeys:               c.cloneSSHKeys,
+	}
+	for _, kind := range piWorkspaceCloneKinds {
+		if !kinds[kind] {
+			continue
+		}
+		err := clone[kind]()
+		c.save(d)
+		if err != nil {
+			return diag.Errorf("error cloning %s from workspace %s to workspace %s: %s", kind, sourceCloudInstanceID, cloudInstanceID, err)
+		}
+	}
+
+	return resourceIBMPIWorkspaceCloneRead(ctx, d, meta)
+}
+
+func resourceIBMPIWorkspaceCloneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := meta.(conns.ClientSession).IBMPISession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	targetSess, err := piSessionForZone(sess, d.Get(Arg_TargetZone).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ops := piWorkspaceCloneTargetOps(ctx, targetSess, d.Get(Arg_CloudInstanceID).(string), d.Timeout(schema.TimeoutDelete))
+	idMapping := map[string]interface{}{}
+	for _, kind := range piWorkspaceCloneKinds {
+		entries := []map[string]interface{}{}
+		for _, e := range d.Get(kind).([]interface{}) {
+			entry := e.(map[string]interface{})
+			targetID := entry[Attr_TargetID].(string)
+			if err := ops[kind].get(targetID); err != nil {
+				if strings.Contains(strings.ToLower(err.Error()), NotFound) {
+					log.Printf("[DEBUG] cloned %s %s no longer exists in the target workspace", kind, targetID)
+					continue
+				}
+				return diag.FromErr(err)
+			}
+			entries = append(entries, entry)
+			idMapping[entry[Attr_SourceID].(string)] = targetID
+		}
+		d.Set(kind, entries)
+	}
+	d.Set(Attr_IDMapping, idMapping)
+
+	return nil
+}
+
+func resourceIBMPIWorkspaceCloneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := meta.(conns.ClientSession).IBMPISession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	targetSess, err := piSessionForZone(sess, d.Get(Arg_TargetZone).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ops := piWorkspaceCloneTargetOps(ctx, targetSess, d.Get(Arg_CloudInstanceID).(string), d.Timeout(schema.TimeoutDelete))
+	for i := len(piWorkspaceCloneKinds) - 1; i >= 0; i-- {
+		kind := piWorkspaceCloneKinds[i]
+		for _, e := range d.Get(kind).([]interface{}) {
+			entry := e.(map[string]interface{})
+			if !entry[Attr_Created].(bool) {
+				continue
+			}
+			if err := ops[kind].delete(entry[Attr_TargetID].(string)); err != nil {
+				if strings.Contains(strings.ToLower(err.Error()), NotFound) {
+					continue
+				}
+				return diag.Errorf("error deleting cloned %s %s: %s", kind, entry[Attr_TargetName], err)
+			}
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+type piWorkspaceCloneTargetOp struct {
+	get    func(id string) error
+	delete func(id string) error
+}
+
+func piWorkspaceCloneTargetOps(ctx context.Context, sess *ibmpisession.IBMPISession, cloudInstanceID string, timeout time.Duration) map[string]piWorkspaceCloneTargetOp {
+	imageC := instance.NewIBMPIImageClient(ctx, sess, cloudInstanceID)
+	keyC := instance.NewIBMPIKeyClient(ctx, sess, cloudInstanceID)
+	nagC := instance.NewIBMPINetworkAddressGroupClient(ctx, sess, cloudInstanceID)
+	networkC := instance.NewIBMPINetworkClient(ctx, sess, cloudInstanceID)
+	nsgC := instance.NewIBMIPINetworkSecurityGroupClient(ctx, sess, cloudInstanceID)
+	placementGroupC := instance.NewIBMPIPlacementGroupClient(ctx, sess, cloudInstanceID)
+
+	return map[string]piWorkspaceCloneTargetOp{
+		Attr_Images: {
+			get:    func(id string) error { _, err := imageC.Get(id); return err },
+			delete: imageC.Delete,
+		},
+		Attr_NetworkAddressGroups: {
+			get: func(id string) error { _, err := nagC.Get(id); return err },
+			delete: func(id string) error {
+				if err := nagC.Delete(id); err != nil {
+					return err
+				}
+				_, err := isWaitForIBMPINetworkAddressGroupDeleted(ctx, nagC, id, timeout)
+				return err
+			},
+		},
+		Attr_NetworkSecurityGroups: {
+			get: func(id string) error { _, err := nsgC.Get(id); return err },
+			delete: func(id string) error {
+				if err := nsgC.Delete(id); err != nil {
+					return err
+				}
+				_, err := isWaitForIBMPINetworkSecurityGroupDeleted(ctx, nsgC, id, timeout)
+				return err
+			},
+		},
+		Attr_Networks: {
+			get: func(id string) error { _, err := networkC.Get(id); return err },
+			delete: func(id string) error {
+				if err := networkC.Delete(id); err != nil {
+					return err
+				}
+				_, err := isWaitForIBMPINetworkDeleted(ctx, networkC, id, timeout)
+				return err
+			},
+		},
+		Attr_PlacementGroups: {
+			get:    func(id string) error { _, err := placementGroupC.Get(id); return err },
+			delete: placementGroupC.Delete,
+		},
+		Attr_SSHKeys: {
+			get:    func(id string) error { _, err := keyC.Get(id); return err },
+			delete: keyC.Delete,
+		},
+	}
+}
+
+// piWorkspaceCloner copies the objects of one workspace into another, reusing objects
+// that already exist in the target workspace under the same name.
+type piWorkspaceCloner struct {
+	ctx      context.Context
+	cos      map[string]interface{}
+	mapping  map[string][]map[string]interface{}
+	prefix   string
+	source   *ibmpisession.IBMPISession
+	sourceID string
+	target   *ibmpisession.IBMPISession
+	targetID string
+	timeout  time.Duration
+}
+
+func (c *piWorkspaceCloner) record(kind, sourceID, sourceName, targetID, targetName string, created bool) {
+	c.mapping[kind] = append(c.mapping[kind], map[string]interface{}{
+		Attr_Created:    created,
+		Attr_SourceID:   sourceID,
+		Attr_SourceName: sourceName,
+		Attr_TargetID:   targetID,
+		Attr_TargetName: targetName,
+	})
+}
+
+func (c *piWorkspaceCloner) targetIDFor(kind, sourceID string) (string, bool) {
+	for _, entry := range c.mapping[kind] {
+		if entry[Attr_SourceID] == sourceID {
+			return entry[Attr_TargetID].(string), true
+		}
+	}
+	return "", false
+}
+
+func (c *piWorkspaceCloner) save(d *schema.ResourceData) {
+	idMapping := map[string]interface{}{}
+	for _, kind := range piWorkspaceCloneKinds {
+		d.Set(kind, c.mapping[kind])
+		for _, entry := range c.mapping[kind] {
+			idMapping[entry[Attr_SourceID].(string)] = entry[Attr_TargetID]
+		}
+	}
+	d.Set(Attr_IDMapping, idMapping)
+}
+
+func (c *piWorkspaceCloner) cloneNetworks() error {
+	sourceC := instance.NewIBMPINetworkClient(c.ctx, c.source, c.sourceID)
+	targetC := instance.NewIBMPINetworkClient(c.ctx, c.target, c.targetID)
+
+	networks, err := sourceC.GetAll()
+	if err != nil {
+		return err
+	}
+	existing, err := targetC.GetAll()
+	if err != nil {
+		return err
+	}
+	existingIDs := map[string]string{}
+	for _, network := range existing.Networks {
+		existingIDs[*network.Name] = *network.NetworkID
+	}
+
+	for _, ref := range networks.Networks {
+		name := c.prefix + *ref.Name
+		if id, ok := existingIDs[name]; ok {
+			c.record(Attr_Networks, *ref.NetworkID, *ref.Name, id, name, false)
+			continue
+		}
+
+		network, err := sourceC.Get(*ref.NetworkID)
+		if err != nil {
+			return err
+		}
+		body := &models.NetworkCreate{
+			AccessConfig: network.AccessConfig,
+			DNSServers:   network.DNSServers,
+			Mtu:          network.Mtu,
+			Name:         name,
+			Type:         network.Type,
+		}
+		if network.Mtu == nil {
+			body.Jumbo = network.Jumbo
+		}
+		if *network.Type != PubVlan {
+			if network.Cidr != nil {
+				body.Cidr = *network.Cidr
+			}
+			body.Gateway = network.Gateway
+			body.IPAddressRanges = network.IPAddressRanges
+		}
+
+		created, err := targetC.Create(body)
+		if err != nil {
+			return err
+		}
+		c.record(Attr_Networks, *ref.NetworkID, *ref.Name, *created.NetworkID, name, true)
+		if _, err := isWaitForIBMPINetworkAvailable(c.ctx, targetC, *created.NetworkID, c.timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *piWorkspaceCloner) cloneSSHKeys() error {
+	sourceC := instance.NewIBMPIKeyClient(c.ctx, c.source, c.sourceID)
+	targetC := instance.NewIBMPIKeyClient(c.ctx, c.target, c.targetID)
+
+	keys, err := sourceC.GetAll()
+	if err != nil {
+		return err
+	}
+	existing, err := targetC.GetAll()
+	if err != nil {
+		return err
+	}
+	existingNames := map[string]bool{}
+	for _, key := range existing.SSHKeys {
+		existingNames[*key.Name] = true
+	}
+
+	// SSH keys are identified by their name, which is also their ID.
+	for _, key := range keys.SSHKeys {
+		name := c.prefix + *key.Name
+		if existingNames[name] {
+			c.record(Attr_SSHKeys, *key.Name, *key.Name, name, name, false)
+			continue
+		}
+		created, err := targetC.Create(&models.SSHKey{
+			Name:   &name,
+			SSHKey: key.SSHKey,
+		})
+		if err != nil {
+			return err
+		}
+		c.record(Attr_SSHKeys, *key.Name, *key.Name, *created.Name, name, true)
+	}
+	return nil
+}
+
+func (c *piWorkspaceCloner) clonePlacementGroups() error {
+	sourceC := instance.NewIBMPIPlacementGroupClient(c.ctx, c.source, c.sourceID)
+	targetC := instance.NewIBMPIPlacementGroupClient(c.ctx, c.target, c.targetID)
+
+	groups, err := sourceC.GetAll()
+	if err != nil {
+		return err
+	}
+	existing, err := targetC.GetAll()
+	if err != nil {
+		return err
+	}
+	existingIDs := map[string]string{}
+	for _, group := range existing.PlacementGroups {
+		existingIDs[*group.Name] = *group.ID
+	}
+
+	for _, group := range groups.PlacementGroups {
+		name := c.prefix + *group.Name
+		if id, ok := existingIDs[name]; ok {
+			c.record(Attr_PlacementGroups, *group.ID, *group.Name, id, name, false)
+			continue
+		}
+		created, err := targetC.Create(&models.PlacementGroupCreate{
+			Name:   &name,
+			Policy: group.Policy,
+		})
+		if err != nil {
+			return err
+		}
+		c.record(Attr_PlacementGroups, *group.ID, *group.Name, *created.ID, name, true)
+	}
+	return nil
+}
+
+// stockImageIDs returns the IDs of the stock catalog images available to the target
+// workspace, keyed by image name.
+func (c *piWorkspaceCloner) stockImageIDs() (map[string]string, error) {
+	stock, err := instance.NewIBMPIImageClient(c.ctx, c.target, c.targetID).GetAllStockImages(true, true)
+	if err != nil {
+		return nil, err
+	}
+	ids := map[string]string{}
+	for _, image := range stock.Images {
+		ids[*image.Name] = *image.ImageID
+	}
+	return ids, nil
+}
+
+// importedImages returns the names of the source images that are not part of the stock
+// catalog and therefore have to be moved through Cloud Object Storage.
+func (c *piWorkspaceCloner) importedImages() ([]string, error) {
+	images, err := instance.NewIBMPIImageClient(c.ctx, c.source, c.sourceID).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	stock, err := c.stockImageIDs()
+	if err != nil {
+		return nil, err
+	}
+	imported := []string{}
+	for _, image := range images.Images {
+		if _, ok := stock[*image.Name]; !ok {
+			imported = append(imported, *image.Name)
+		}
+	}
+	return imported, nil
+}
+
+func (c *piWorkspaceCloner) cloneImages() error {
+	sourceC := instance.NewIBMPIImageClient(c.ctx, c.source, c.sourceID)
+	targetC := instance.NewIBMPIImageClient(c.ctx, c.target, c.targetID)
+
+	images, err := sourceC.GetAll()
+	if err != nil {
+		return err
+	}
+	existing, err := targetC.GetAll()
+	if err != nil {
+		return err
+	}
+	existingIDs := map[string]string{}
+	for _, image := range existing.Images {
+		existingIDs[*image.Name] = *image.ImageID
+	}
+	stock, err := c.stockImageIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images.Images {
+		name := c.prefix + *image.Name
+		if id, ok := existingIDs[name]; ok {
+			c.record(Attr_Images, *image.ImageID, *image.Name, id, name, false)
+			continue
+		}
+
+		if stockID, ok := stock[*image.Name]; ok {
+			source := "root-project"
+			created, err := targetC.Create(&models.CreateImage{
+				ImageID:   stockID,
+				ImageName: name,
+				Source:    &source,
+			})
+			if err != nil {
+				return err
+			}
+			c.record(Attr_Images, *image.ImageID, *image.Name, *created.ImageID, name, true)
+			if _, err := isWaitForIBMPIImageAvailable(c.ctx, targetC, *created.ImageID, c.timeout); err != nil {
+				return err
+			}
+			continue
+		}
+
+		id, err := c.copyImageThroughCOS(image)
+		if err != nil {
+			return err
+		}
+		c.record(Attr_Images, *image.ImageID, *image.Name, id, name, true)
+	}
+	return nil
+}
+
+// copyImageThroughCOS exports an imported image from the source workspace to the
+// configured bucket and imports it into the target workspace, returning the new image ID.
+func (c *piWorkspaceCloner) copyImageThroughCOS(image *models.ImageReference) (string, error) {
+	if c.cos == nil {
+		return "", fmt.Errorf("%s is required to clone the imported image %s", Arg_ImageCOS, *image.Name)
+	}
+	fileName, err := piExportImageToCOS(c.ctx, c.source, c.sourceID, *image.ImageID, *image.Name, c.cos, c.timeout)
+	if err != nil {
+		return "", err
+	}
+	storageType := ""
+	if image.StorageType != nil {
+		storageType = *image.StorageType
+	}
+	return piImportImageFromCOS(c.ctx, c.target, c.targetID, fileName, c.prefix+*image.Name, storageType, c.cos, c.timeout)
+}
+
+func (c *piWorkspaceCloner) cloneNetworkAddressGroups() error {
+	sourceC := instance.NewIBMPINetworkAddressGroupClient(c.ctx, c.source, c.sourceID)
+	targetC := instance.NewIBMPINetworkAddressGroupClient(c.ctx, c.target, c.targetID)
+
+	groups, err := sourceC.GetAll()
+	if err != nil {
+		return err
+	}
+	existing, err := targetC.GetAll()
+	if err != nil {
+		return err
+	}
+	existingIDs := map[string]string{}
+	for _, group := range existing.NetworkAddressGroups {
+		existingIDs[*group.Name] = *group.ID
+	}
+
+	for _, group := range groups.NetworkAddressGroups {
+		name := c.prefix + *group.Name
+		if id, ok := existingIDs[name]; ok {
+			c.record(Attr_NetworkAddressGroups, *group.ID, *group.Name, id, name, false)
+			continue
+		}
+		created, err := targetC.Create(&models.NetworkAddressGroupCreate{
+			Name: &name,
+		})
+		if err != nil {
+			return err
+		}
+		c.record(Attr_NetworkAddressGroups, *group.ID, *group.Name, *created.ID, name, true)
+
+		for _, member := range group.Members {
+			added, err := targetC.AddMember(*created.ID, &models.NetworkAddressGroupAddMember{
+				Cidr: member.Cidr,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := isWaitForIBMPINetworkAddressGroupMemberAdd(c.ctx, targetC, *created.ID, *added.ID, c.timeout); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *piWorkspaceCloner) cloneNetworkSecurityGroups() error {
+	sourceC := instance.NewIBMIPINetworkSecurityGroupClient(c.ctx, c.source, c.sourceID)
+	targetC := instance.NewIBMIPINetworkSecurityGroupClient(c.ctx, c.target, c.targetID)
+
+	groups, err := sourceC.GetAll()
+	if err != nil {
+		return err
+	}
+	existing, err := targetC.GetAll()
+	if err != nil {
+		return err
+	}
+	existingIDs := map[string]string{}
+	for _, group := range existing.NetworkSecurityGroups {
+		existingIDs[*group.Name] = *group.ID
+	}
+
+	// All groups are created before any rule, since rules can reference other groups.
+	created := []*models.NetworkSecurityGroup{}
+	for _, group := range groups.NetworkSecurityGroups {
+		name := c.prefix + *group.Name
+		if id, ok := existingIDs[name]; ok {
+			c.record(Attr_NetworkSecurityGroups, *group.ID, *group.Name, id, name, false)
+			continue
+		}
+		nsg, err := targetC.Create(&models.NetworkSecurityGroupCreate{
+			Name: &name,
+		})
+		if err != nil {
+			return err
+		}
+		c.record(Attr_NetworkSecurityGroups, *group.ID, *group.Name, *nsg.ID, name, true)
+		created = append(created, group)
+	}
+
+	for _, group := range created {
+		targetID, _ := c.targetIDFor(Attr_NetworkSecurityGroups, *group.ID)
+		for _, rule := range group.Rules {
+			if rule.Remote == nil {
+				continue
+			}
+			remote := &models.NetworkSecurityGroupRuleRemote{Type: rule.Remote.Type}
+			switch rule.Remote.Type {
+			case NAG, NSG:
+				kind := Attr_NetworkSecurityGroups
+				if rule.Remote.Type == NAG {
+					kind = Attr_NetworkAddressGroups
+				}
+				id, ok := c.targetIDFor(kind, rule.Remote.ID)
+				if !ok {
+					return fmt.Errorf("rule %s of network security group %s references %s %s, which was not cloned", *rule.ID, *group.Name, rule.Remote.Type, rule.Remote.ID)
+				}
+				remote.ID = id
+			default:
+				remote.ID = rule.Remote.ID
+			}
+
+			added, err := targetC.AddRule(targetID, &models.NetworkSecurityGroupAddRule{
+				Action:           rule.Action,
+				DestinationPorts: rule.DestinationPort,
+				Protocol:         rule.Protocol,
+				Remote:           remote,
+				SourcePorts:      rule.SourcePort,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := isWaitForIBMPINetworkSecurityGroupRuleAdd(c.ctx, targetC, targetID, *added.ID, c.timeout); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// piSessionForZone returns a session for a workspace in the given zone. The provider
+// session is bound to a single zone, so a workspace in another zone gets its own
+// session that reuses the provider's credentials.
+func piSessionForZone(sess *ibmpisession.IBMPISession, zone string) (*ibmpisession.IBMPISession, error) {
+	if zone == "" || sess.Options == nil || zone == sess.Options.Zone {
+		return sess, nil
+	}
+	return ibmpisession.NewIBMPISession(&ibmpisession.IBMPIOptions{
+		Authenticator: sess.Options.Authenticator,
+		Debug:         sess.Options.Debug,
+		UserAccount:   sess.Options.UserAccount,
+		Zone:          zone,
+	})
+}
+
+func piImageCOSSchema(description string, required bool) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				Attr_AccessKey: {
+					Description:  "Cloud Object Storage HMAC access key.",
+					Required:     true,
+					Sensitive:    true,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Attr_BucketName: {
+					Description:  "Cloud Object Storage bucket name.",
+					Required:     true,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Attr_BucketRegion: {
+					Description:  "Cloud Object Storage bucket region.",
+					Required:     true,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Attr_SecretKey: {
+					Description:  "Cloud Object Storage HMAC secret key.",
+					Required:     true,
+					Sensitive:    true,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+		},
+		ForceNew: true,
+		MaxItems: 1,
+		Optional: !required,
+		Required: required,
+		Type:     schema.TypeList,
+	}
+}
+
+// piExportImageToCOS exports an image to the bucket described by cos and returns the
+// name of the exported file.
+func piExportImageToCOS(ctx context.Context, sess *ibmpisession.IBMPISession, cloudInstanceID, imageID, imageName string, cos map[string]interface{}, timeout time.Duration) (string, error) {
+	accessKey := cos[Attr_AccessKey].(string)
+	bucketName := cos[Attr_BucketName].(string)
+
+	job, err := instance.NewIBMPIImageClient(ctx, sess, cloudInstanceID).ExportImage(imageID, &models.ExportImage{
+		AccessKey:  &accessKey,
+		BucketName: &bucketName,
+		Region:     cos[Attr_BucketRegion].(string),
+		SecretKey:  cos[Attr_SecretKey].(string),
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := waitForIBMPIJobCompleted(ctx, instance.NewIBMPIJobClient(ctx, sess, cloudInstanceID), *job.ID, timeout); err != nil {
+		return "", err
+	}
+
+	// Exported images are written to the bucket as <image name>.ova.gz.
+	return imageName + ".ova.gz", nil
+}
+
+// piImportImageFromCOS imports an image file from the bucket described by cos and waits
+// for the image to be active, returning its ID.
+func piImportImageFromCOS(ctx context.Context, sess *ibmpisession.IBMPISession, cloudInstanceID, fileName, imageName, storageType string, cos map[string]interface{}, timeout time.Duration) (string, error) {
+	accessKey := cos[Attr_AccessKey].(string)
+	bucketAccess := "private"
+	bucketName := cos[Attr_BucketName].(string)
+	region := cos[Attr_BucketRegion].(string)
+
+	client := instance.NewIBMPIImageClient(ctx, sess, cloudInstanceID)
+	job, err := client.CreateCosImage(&models.CreateCosImageImportJob{
+		AccessKey:     accessKey,
+		BucketAccess:  &bucketAccess,
+		BucketName:    &bucketName,
+		ImageFilename: &fileName,
+		ImageName:     &imageName,
+		Region:        &region,
+		SecretKey:     cos[Attr_SecretKey].(string),
+		StorageType:   storageType,
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := waitForIBMPIJobCompleted(ctx, instance.NewIBMPIJobClient(ctx, sess, cloudInstanceID), *job.ID, timeout); err != nil {
+		return "", err
+	}
+
+	// Once the job is completed find by name
+	image, err := client.Get(imageName)
+	if err != nil {
+		return "", err
+	}
+	if _, err := isWaitForIBMPIImageAvailable(ctx, client, *image.ImageID, timeout); err != nil {
+		return *image.ImageID, err
+	}
+	return *image.ImageID, nil
+}
@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMPIWorkspaceCloneBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-pi-workspace-clone-%d", acctest.RandIntRange(10, 100))
+	cloneRes := "ibm_pi_workspace_clone.clone"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccIBMPIWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMPIWorkspaceCloneConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(cloneRes, "id"),
+					resource.TestCheckResourceAttrSet(cloneRes, "networks.#"),
+					resource.TestCheckResourceAttrSet(cloneRes, "placement_groups.#"),
+					testAccCheckIBMPIWorkspaceCloneNetworksExist(cloneRes),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMPIWorkspaceCloneNetworksExist(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		sess, err := acc.TestAccProvider.Meta().(conns.ClientSession).IBMPISession()
+		if err != nil {
+			return err
+		}
+		cloudInstanceID := rs.Primary.Attributes["pi_cloud_instance_id"]
+		client := instance.NewIBMPINetworkClient(context.Background(), sess, cloudInstanceID)
+		count, _ := strconv.Atoi(rs.Primary.Attributes["networks.#"])
+		for i := 0; i < count; i++ {
+			targetID := rs.Primary.Attributes[fmt.Sprintf("networks.%d.target_id", i)]
+			if _, err := client.Get(targetID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMPIWorkspaceCloneConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "ibm_pi_workspace" "powervs_service_instance" {
+			pi_name              = "%[1]s"
+			pi_datacenter        = "dal12"
+			pi_resource_group_id = "%[2]s"
+		}
+
+		resource "ibm_pi_workspace_clone" "clone" {
+			pi_cloud_instance_id        = ibm_pi_workspace.powervs_service_instance.id
+			pi_source_cloud_instance_id = "%[3]s"
+			pi_name_prefix              = "%[1]s-"
+			pi_resources                = ["networks", "placement_groups"]
+		}`, name, acc.Pi_resource_group_id, acc.Pi_cloud_instance_id)
+}
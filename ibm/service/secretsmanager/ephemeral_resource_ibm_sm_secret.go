@@ -0,0 +1,269 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/IBM/secrets-manager-go-sdk/v2/secretsmanagerv2"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+const SecretEphemeralResourceName = "ibm_sm_secret"
+
+var (
+	_ ephemeral.EphemeralResource              = &IbmSmSecretEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &IbmSmSecretEphemeralResource{}
+)
+
+// IbmSmSecretEphemeralResource reads the payload of a secret without storing it in the
+// plan or state.
+type IbmSmSecretEphemeralResource struct {
+	meta conns.ClientSession
+}
+
+type ibmSmSecretEphemeralResourceModel struct {
+	InstanceID      types.String `tfsdk:"instance_id"`
+	Region          types.String `tfsdk:"region"`
+	EndpointType    types.String `tfsdk:"endpoint_type"`
+	SecretID        types.String `tfsdk:"secret_id"`
+	Name            types.String `tfsdk:"name"`
+	SecretGroupName types.String `tfsdk:"secret_group_name"`
+	SecretType      types.String `tfsdk:"secret_type"`
+	Crn             types.String `tfsdk:"crn"`
+	ExpirationDate  types.String `tfsdk:"expiration_date"`
+	Payload         types.String `tfsdk:"payload"`
+	Data            types.Map    `tfsdk:"data"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	ApiKey          types.String `tfsdk:"api_key"`
+}
+
+func NewIbmSmSecretEphemeralResource() ephemeral.EphemeralResource {
+	return &IbmSmSecretEphemeralResource{}
+}
+
+func (r *IbmSmSecretEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sm_secret"
+}
+
+func (r *IbmSmSecretEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads an arbitrary, key-value, user credentials or IAM credentials secret without persisting it in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Secrets Manager instance.",
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The region of the Secrets Manager instance.",
+			},
+			"endpoint_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "public or private.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("public", "private"),
+				},
+			},
+			"secret_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the secret.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("secret_id"), path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The human-readable name of the secret.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("secret_group_name"), path.MatchRoot("secret_type")),
+				},
+			},
+			"secret_group_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the secret group. Used together with name.",
+			},
+			"secret_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The secret type. Used together with name.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(ArbitrarySecretType, KvSecretType, UsernamePasswordSecretType, IAMCredentialsSecretType),
+				},
+			},
+			"crn": schema.StringAttribute{
+				Computed:    true,
+				Description: "A CRN that uniquely identifies an IBM Cloud resource.",
+			},
+			"expiration_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date a secret is expired. The date format follows RFC 3339.",
+			},
+			"payload": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The payload of an arbitrary secret.",
+			},
+			"data": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The data of a key-value secret. Values that are not strings are JSON encoded.",
+			},
+			"username": schema.StringAttribute{
+				Computed:    true,
+				Description: "The username of a user credentials secret.",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The password of a user credentials secret.",
+			},
+			"api_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API key of an IAM credentials secret.",
+			},
+		},
+	}
+}
+
+func (r *IbmSmSecretEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	meta, err := conns.FrameworkClientSession(req.ProviderData)
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", SecretEphemeralResourceName), "configure")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+	r.meta = meta
+}
+
+func (r *IbmSmSecretEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ibmSmSecretEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.meta == nil {
+		err := conns.ErrFrameworkProviderNotConfigured
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", SecretEphemeralResourceName), "open")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+
+	secretsManagerClient, err := r.meta.SecretsManagerV2()
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", SecretEphemeralResourceName), "open")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+	region := regionOrDefault(secretsManagerClient, data.Region.ValueString())
+	endpointType := endpointTypeOrDefault(secretsManagerClient, data.EndpointType.ValueString())
+	secretsManagerClient = getClientWithInstanceEndpoint(secretsManagerClient, data.InstanceID.ValueString(), region, endpointType)
+
+	var secretIntf secretsmanagerv2.SecretIntf
+	if secretID := data.SecretID.ValueString(); secretID != "" {
+		getSecretOptions := &secretsmanagerv2.GetSecretOptions{}
+		getSecretOptions.SetID(secretID)
+
+		secretIntf, _, err = secretsManagerClient.GetSecretWithContext(ctx, getSecretOptions)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("GetSecretWithContext failed: %s", err.Error()), fmt.Sprintf("(Ephemeral) %s", SecretEphemeralResourceName), "open")
+			resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+			return
+		}
+	} else {
+		getSecretByNameOptions := &secretsmanagerv2.GetSecretByNameTypeOptions{}
+		getSecretByNameOptions.SetName(data.Name.ValueString())
+		getSecretByNameOptions.SetSecretType(data.SecretType.ValueString())
+		getSecretByNameOptions.SetSecretGroupName(data.SecretGroupName.ValueString())
+
+		secretIntf, _, err = secretsManagerClient.GetSecretByNameTypeWithContext(ctx, getSecretByNameOptions)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("GetSecretByNameTypeWithContext failed: %s", err.Error()), fmt.Sprintf("(Ephemeral) %s", SecretEphemeralResourceName), "open")
+			resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+			return
+		}
+	}
+
+	data.Region = types.StringValue(region)
+	data.Payload = types.StringNull()
+	data.Data = types.MapNull(types.StringType)
+	data.Username = types.StringNull()
+	data.Password = types.StringNull()
+	data.ApiKey = types.StringNull()
+
+	var id, name, secretType, crn *string
+	var expirationDate *strfmt.DateTime
+	switch secret := secretIntf.(type) {
+	case *secretsmanagerv2.ArbitrarySecret:
+		id, name, secretType, crn, expirationDate = secret.ID, secret.Name, secret.SecretType, secret.Crn, secret.ExpirationDate
+		data.Payload = types.StringPointerValue(secret.Payload)
+	case *secretsmanagerv2.KVSecret:
+		id, name, secretType, crn = secret.ID, secret.Name, secret.SecretType, secret.Crn
+		values, err := flattenKvSecretData(secret.Data)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error reading the data of secret %s", *secret.ID), fmt.Sprintf("(Ephemeral) %s", SecretEphemeralResourceName), "open")
+			resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+			return
+		}
+		mapValue, diags := types.MapValueFrom(ctx, types.StringType, values)
+		resp.Diagnostics.Append(diags...)
+		data.Data = mapValue
+	case *secretsmanagerv2.UsernamePasswordSecret:
+		id, name, secretType, crn, expirationDate = secret.ID, secret.Name, secret.SecretType, secret.Crn, secret.ExpirationDate
+		data.Username = types.StringPointerValue(secret.Username)
+		data.Password = types.StringPointerValue(secret.Password)
+	case *secretsmanagerv2.IAMCredentialsSecret:
+		id, name, secretType, crn, expirationDate = secret.ID, secret.Name, secret.SecretType, secret.Crn, secret.ExpirationDate
+		data.ApiKey = types.StringPointerValue(secret.ApiKey)
+	default:
+		err = fmt.Errorf("unsupported secret type %T, expected one of %s, %s, %s or %s", secretIntf, ArbitrarySecretType, KvSecretType, UsernamePasswordSecretType, IAMCredentialsSecretType)
+		tfErr := flex.TerraformErrorf(err, err.Error(), fmt.Sprintf("(Ephemeral) %s", SecretEphemeralResourceName), "open")
+		resp.Diagnostics.Append(tfErr.GetFrameworkDiag())
+		return
+	}
+	log.Printf("[DEBUG] Opened ephemeral secret %s", flex.StringValue(id))
+
+	data.SecretID = types.StringPointerValue(id)
+	data.Name = types.StringPointerValue(name)
+	data.SecretType = types.StringPointerValue(secretType)
+	data.Crn = types.StringPointerValue(crn)
+	data.ExpirationDate = types.StringValue(DateTimeToRFC3339(expirationDate))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// flattenKvSecretData converts the data of a key-value secret to a map of strings.
+func flattenKvSecretData(data map[string]interface{}) (map[string]string, error) {
+	values := make(map[string]string, len(data))
+	for k, v := range data {
+		if s, ok := v.(string); ok {
+			values[k] = s
+			continue
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		values[k] = string(encoded)
+	}
+	return values, nil
+}
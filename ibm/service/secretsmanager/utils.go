@@ -56,32 +56,38 @@ const (
 )
 
 func getRegion(originalClient *secretsmanagerv2.SecretsManagerV2, d *schema.ResourceData) string {
-	_, ok := d.GetOk("region")
-	if ok {
-		return d.Get("region").(string)
-	} else {
-		// extract region from base URL (provider config)
-		// base url is like that : "https://<private.>secrets-manager.<region>.<rest of domain>"
-		baseUrl := originalClient.Service.GetServiceURL()
-		u := strings.Replace(baseUrl, "private.", "", 1)
-		return strings.Split(u, ".")[1]
+	region, _ := d.Get("region").(string)
+	return regionOrDefault(originalClient, region)
+}
+
+// regionOrDefault returns region, or the region of the provider's secrets manager endpoint if it is empty
+func regionOrDefault(originalClient *secretsmanagerv2.SecretsManagerV2, region string) string {
+	if region != "" {
+		return region
 	}
+	// extract region from base URL (provider config)
+	// base url is like that : "https://<private.>secrets-manager.<region>.<rest of domain>"
+	baseUrl := originalClient.Service.GetServiceURL()
+	u := strings.Replace(baseUrl, "private.", "", 1)
+	return strings.Split(u, ".")[1]
 }
 
 // Clone the base secrets manager client and set the API endpoint per the instance
 func getEndpointType(originalClient *secretsmanagerv2.SecretsManagerV2, d *schema.ResourceData) string {
-	_, ok := d.GetOk("endpoint_type")
-	if ok {
-		return d.Get("endpoint_type").(string)
-	} else {
-		baseUrl := originalClient.Service.GetServiceURL()
+	endpointType, _ := d.Get("endpoint_type").(string)
+	return endpointTypeOrDefault(originalClient, endpointType)
+}
 
-		if strings.Contains(baseUrl, "private.") {
-			return "private"
-		} else {
-			return "public"
-		}
+// endpointTypeOrDefault returns endpointType, or the type of the provider's secrets manager endpoint if it is empty
+func endpointTypeOrDefault(originalClient *secretsmanagerv2.SecretsManagerV2, endpointType string) string {
+	if endpointType != "" {
+		return endpointType
+	}
+	baseUrl := originalClient.Service.GetServiceURL()
+	if strings.Contains(baseUrl, "private.") {
+		return "private"
 	}
+	return "public"
 }
 
 // Clone the base secrets manager client and set the API endpoint per the instance
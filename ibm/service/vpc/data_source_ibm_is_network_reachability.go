@@ -0,0 +1,1018 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+)
+
+const (
+	isNetworkReachabilitySource                  = "source"
+	isNetworkReachabilityDestination             = "destination"
+	isNetworkReachabilityProtocol                = "protocol"
+	isNetworkReachabilityPort                    = "port"
+	isNetworkReachabilityICMPType                = "icmp_type"
+	isNetworkReachabilityICMPCode                = "icmp_code"
+	isNetworkReachabilityAllowed                 = "allowed"
+	isNetworkReachabilityReason                  = "reason"
+	isNetworkReachabilityRuleChain               = "rule_chain"
+	isNetworkReachabilityInstance                = "instance"
+	isNetworkReachabilityVirtualNetworkInterface = "virtual_network_interface"
+	isNetworkReachabilityReservedIP              = "reserved_ip"
+	isNetworkReachabilitySubnet                  = "subnet"
+	isNetworkReachabilityCIDR                    = "cidr"
+	isNetworkReachabilityAddress                 = "address"
+	isNetworkReachabilityVPC                     = "vpc"
+
+	isNetworkReachabilityComponentSecurityGroup = "security_group"
+	isNetworkReachabilityComponentNetworkACL    = "network_acl"
+	isNetworkReachabilityComponentRoutingTable  = "routing_table"
+	isNetworkReachabilityComponentPublicGateway = "public_gateway"
+	isNetworkReachabilityComponentFloatingIP    = "floating_ip"
+
+	isNetworkReachabilityInbound  = "inbound"
+	isNetworkReachabilityOutbound = "outbound"
+
+	// The ephemeral ports the replies to a connection are sent to. They are
+	// only known once the connection is opened, so a network ACL rule has to
+	// allow all of them.
+	isNetworkReachabilityEphemeralPortMin = 1024
+	isNetworkReachabilityEphemeralPortMax = 65535
+)
+
+func DataSourceIBMISNetworkReachability() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMISNetworkReachabilityRead,
+
+		Schema: map[string]*schema.Schema{
+			isNetworkReachabilitySource: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        dataSourceIBMISNetworkReachabilityEndpoint(),
+				Description: "The endpoint the traffic is sent from",
+			},
+			isNetworkReachabilityDestination: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        dataSourceIBMISNetworkReachabilityEndpoint(),
+				Description: "The endpoint the traffic is sent to",
+			},
+			isNetworkReachabilityProtocol: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{isSecurityGroupRuleProtocolAll, isSecurityGroupRuleProtocolICMP, isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP}),
+				Description:  "The protocol of the traffic: all, icmp, tcp or udp",
+			},
+			isNetworkReachabilityPort: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validate.ValidatePortRange(1, 65535),
+				Description:  "The destination port of the traffic. Required for tcp and udp",
+			},
+			isNetworkReachabilityICMPType: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedRangeInt(0, 254),
+				Description:  "The ICMP type of the traffic. Only rules that allow all ICMP types match if not set",
+			},
+			isNetworkReachabilityICMPCode: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedRangeInt(0, 255),
+				Description:  "The ICMP code of the traffic. Requires icmp_type",
+			},
+			isNetworkReachabilityAllowed: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the traffic is allowed from the source to the destination",
+			},
+			isNetworkReachabilityReason: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Why the traffic is allowed or denied",
+			},
+			isNetworkReachabilityRuleChain: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rules that were evaluated, in the order the traffic meets them. The last one denies the traffic if it is not allowed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"component": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The kind of component: security_group, network_acl, routing_table, public_gateway or floating_ip",
+						},
+						"direction": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The direction the component applies to: inbound or outbound",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the security group, network ACL, routing table, public gateway or floating IP",
+						},
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the security group, network ACL, routing table, public gateway or floating IP",
+						},
+						"rule_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the rule or route that matched the traffic, if any",
+						},
+						"rule_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the rule or route that matched the traffic, if any",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The decision of the component: allow or deny",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "How the component handled the traffic",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMISNetworkReachabilityEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			isNetworkReachabilityInstance: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of an instance. Its primary network attachment or primary network interface is used",
+			},
+			isNetworkReachabilityVirtualNetworkInterface: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of a virtual network interface",
+			},
+			isNetworkReachabilityReservedIP: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of a reserved IP. Requires subnet",
+			},
+			isNetworkReachabilitySubnet: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the subnet of the reserved IP",
+			},
+			isNetworkReachabilityCIDR: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An IP address or a CIDR block, in the VPC of the other endpoint or outside of it",
+			},
+			isNetworkReachabilityAddress: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address or CIDR block of the endpoint",
+			},
+			isNetworkReachabilityVPC: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the VPC of the endpoint, if it is in a VPC",
+			},
+		},
+	}
+}
+
+// networkReachabilityEndpoint is an endpoint of the traffic with the network
+// policies that apply to it.
+type networkReachabilityEndpoint struct {
+	name    string
+	network *net.IPNet
+	vpcID   string
+	// subnet is nil for endpoints outside of the VPC.
+	subnet *vpcv1.Subnet
+	// hasSecurityGroups is false for endpoints that are not a network
+	// interface, whose traffic isn't filtered by security groups.
+	hasSecurityGroups bool
+	securityGroups    []*vpcv1.SecurityGroup
+	floatingIPs       []vpcv1.FloatingIPReference
+	networkACL        *vpcv1.NetworkACL
+	routingTable      *vpcv1.RoutingTable
+	routes            []vpcv1.Route
+}
+
+// networkReachabilityTraffic is the traffic to evaluate. A nil port is an
+// ephemeral port and a nil ICMP type or code is any type or code.
+type networkReachabilityTraffic struct {
+	protocol   string
+	sourcePort *int64
+	port       *int64
+	icmpType   *int64
+	icmpCode   *int64
+}
+
+// reply returns the traffic that answers t.
+func (t networkReachabilityTraffic) reply() networkReachabilityTraffic {
+	reply := networkReachabilityTraffic{
+		protocol:   t.protocol,
+		sourcePort: t.port,
+		port:       t.sourcePort,
+	}
+	// echo replies answer echo requests, other ICMP messages are not answered
+	// with a known type.
+	if t.icmpType != nil && *t.icmpType == 8 {
+		reply.icmpType = new(int64)
+	}
+	return reply
+}
+
+// networkReachabilityStep is a component of the rule chain.
+type networkReachabilityStep struct {
+	component    string
+	direction    string
+	resourceID   string
+	resourceName string
+	ruleID       string
+	ruleName     string
+	action       string
+	description  string
+}
+
+func dataSourceIBMISNetworkReachabilityRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	traffic := networkReachabilityTraffic{protocol: d.Get(isNetworkReachabilityProtocol).(string)}
+	port, portOk := d.GetOk(isNetworkReachabilityPort)
+	icmpType, icmpTypeOk := d.GetOk(isNetworkReachabilityICMPType)
+	icmpCode, icmpCodeOk := d.GetOk(isNetworkReachabilityICMPCode)
+	switch traffic.protocol {
+	case isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP:
+		if !portOk {
+			return diag.FromErr(fmt.Errorf("[ERROR] port must be set for %s traffic", traffic.protocol))
+		}
+		if icmpTypeOk || icmpCodeOk {
+			return diag.FromErr(fmt.Errorf("[ERROR] icmp_type and icmp_code can only be set for icmp traffic"))
+		}
+		traffic.port = core.Int64Ptr(int64(port.(int)))
+	case isSecurityGroupRuleProtocolICMP:
+		if portOk {
+			return diag.FromErr(fmt.Errorf("[ERROR] port can only be set for tcp and udp traffic"))
+		}
+		if icmpCodeOk && !icmpTypeOk {
+			return diag.FromErr(fmt.Errorf("[ERROR] icmp_code requires icmp_type"))
+		}
+		// icmp_type 0 (echo reply) is the zero value, so it is read from the
+		// configuration instead of with GetOk.
+		if v := d.GetRawConfig().GetAttr(isNetworkReachabilityICMPType); !v.IsNull() {
+			traffic.icmpType = core.Int64Ptr(int64(icmpType.(int)))
+		}
+		if v := d.GetRawConfig().GetAttr(isNetworkReachabilityICMPCode); !v.IsNull() {
+			traffic.icmpCode = core.Int64Ptr(int64(icmpCode.(int)))
+		}
+	default:
+		if portOk || icmpTypeOk || icmpCodeOk {
+			return diag.FromErr(fmt.Errorf("[ERROR] port, icmp_type and icmp_code can't be set for traffic of protocol %s", traffic.protocol))
+		}
+	}
+
+	reader := &networkReachabilityReader{
+		sess:           sess,
+		securityGroups: map[string]*vpcv1.SecurityGroup{},
+	}
+	source, err := reader.endpoint(d.Get(isNetworkReachabilitySource).([]interface{}), "source")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	destination, err := reader.endpoint(d.Get(isNetworkReachabilityDestination).([]interface{}), "destination")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// CIDR endpoints are placed in the subnet of the VPC of the other endpoint
+	// that contains them.
+	switch {
+	case source.vpcID == "" && destination.vpcID == "":
+		return diag.FromErr(fmt.Errorf("[ERROR] at least one of source and destination must be an instance, a virtual network interface or a reserved IP"))
+	case source.vpcID == "":
+		err = reader.place(source, destination.vpcID)
+	case destination.vpcID == "":
+		err = reader.place(destination, source.vpcID)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	allowed, reason, steps := evaluateNetworkReachability(source, destination, traffic)
+	log.Printf("[DEBUG] Traffic from %s to %s: %s", source.name, destination.name, reason)
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", source.network, destination.network, traffic.protocol))
+	for key, endpoint := range map[string]*networkReachabilityEndpoint{isNetworkReachabilitySource: source, isNetworkReachabilityDestination: destination} {
+		e := d.Get(key).([]interface{})[0].(map[string]interface{})
+		e[isNetworkReachabilityAddress] = endpoint.network.String()
+		e[isNetworkReachabilityVPC] = endpoint.vpcID
+		if err = d.Set(key, []interface{}{e}); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error setting %s: %s", key, err))
+		}
+	}
+	if err = d.Set(isNetworkReachabilityAllowed, allowed); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting allowed: %s", err))
+	}
+	if err = d.Set(isNetworkReachabilityReason, reason); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting reason: %s", err))
+	}
+	ruleChain := make([]map[string]interface{}, 0, len(steps))
+	for _, step := range steps {
+		ruleChain = append(ruleChain, map[string]interface{}{
+			"component":     step.component,
+			"direction":     step.direction,
+			"resource_id":   step.resourceID,
+			"resource_name": step.resourceName,
+			"rule_id":       step.ruleID,
+			"rule_name":     step.ruleName,
+			"action":        step.action,
+			"description":   step.description,
+		})
+	}
+	if err = d.Set(isNetworkReachabilityRuleChain, ruleChain); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting rule_chain: %s", err))
+	}
+	return nil
+}
+
+// networkReachabilityReader reads the network policies of the endpoints. The
+// security groups are read once even if both endpoints are in them.
+type networkReachabilityReader struct {
+	sess           *vpcv1.VpcV1
+	securityGroups map[string]*vpcv1.SecurityGroup
+}
+
+func (r *networkReachabilityReader) endpoint(v []interface{}, name string) (*networkReachabilityEndpoint, error) {
+	if len(v) == 0 || v[0] == nil {
+		return nil, fmt.Errorf("[ERROR] one of instance, virtual_network_interface, reserved_ip or cidr must be set for the %s", name)
+	}
+	endpoint := v[0].(map[string]interface{})
+	instance := endpoint[isNetworkReachabilityInstance].(string)
+	vni := endpoint[isNetworkReachabilityVirtualNetworkInterface].(string)
+	reservedIP := endpoint[isNetworkReachabilityReservedIP].(string)
+	subnet := endpoint[isNetworkReachabilitySubnet].(string)
+	cidr := endpoint[isNetworkReachabilityCIDR].(string)
+
+	set := 0
+	for _, v := range []string{instance, vni, reservedIP, cidr} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("[ERROR] exactly one of instance, virtual_network_interface, reserved_ip or cidr must be set for the %s", name)
+	}
+	if (reservedIP != "") != (subnet != "") {
+		return nil, fmt.Errorf("[ERROR] subnet must be set with reserved_ip, and only with reserved_ip, for the %s", name)
+	}
+
+	switch {
+	case instance != "":
+		return r.instance(instance)
+	case vni != "":
+		return r.virtualNetworkInterface(vni, "")
+	case reservedIP != "":
+		return r.reservedIP(subnet, reservedIP)
+	}
+	network, err := parseNetworkReachabilityCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Invalid cidr provided for the %s (%s): must be an IP address or a CIDR block", name, cidr)
+	}
+	return &networkReachabilityEndpoint{name: cidr, network: network}, nil
+}
+
+func (r *networkReachabilityReader) instance(id string) (*networkReachabilityEndpoint, error) {
+	instance, response, err := r.sess.GetInstance(&vpcv1.GetInstanceOptions{ID: &id})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting instance (%s): %s\n%s", id, err, response)
+	}
+	if instance.PrimaryNetworkAttachment != nil && instance.PrimaryNetworkAttachment.VirtualNetworkInterface != nil {
+		return r.virtualNetworkInterface(*instance.PrimaryNetworkAttachment.VirtualNetworkInterface.ID, "")
+	}
+	if instance.PrimaryNetworkInterface == nil {
+		return nil, fmt.Errorf("[ERROR] instance (%s) has no primary network interface", id)
+	}
+	return r.networkInterface(id, *instance.PrimaryNetworkInterface.ID, "")
+}
+
+func (r *networkReachabilityReader) networkInterface(instanceID, id, address string) (*networkReachabilityEndpoint, error) {
+	nic, response, err := r.sess.GetInstanceNetworkInterface(&vpcv1.GetInstanceNetworkInterfaceOptions{
+		InstanceID: &instanceID,
+		ID:         &id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting network interface (%s) of instance (%s): %s\n%s", id, instanceID, err, response)
+	}
+	if address == "" && nic.PrimaryIP != nil && nic.PrimaryIP.Address != nil {
+		address = *nic.PrimaryIP.Address
+	}
+	endpoint, err := r.networkEndpoint(*nic.Name, address, *nic.Subnet.ID, nic.SecurityGroups)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.floatingIPs = nic.FloatingIps
+	return endpoint, nil
+}
+
+func (r *networkReachabilityReader) virtualNetworkInterface(id, address string) (*networkReachabilityEndpoint, error) {
+	vni, response, err := r.sess.GetVirtualNetworkInterface(&vpcv1.GetVirtualNetworkInterfaceOptions{ID: &id})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting virtual network interface (%s): %s\n%s", id, err, response)
+	}
+	if address == "" && vni.PrimaryIP != nil && vni.PrimaryIP.Address != nil {
+		address = *vni.PrimaryIP.Address
+	}
+	endpoint, err := r.networkEndpoint(*vni.Name, address, *vni.Subnet.ID, vni.SecurityGroups)
+	if err != nil {
+		return nil, err
+	}
+	floatingIPs, response, err := r.sess.ListNetworkInterfaceFloatingIps(&vpcv1.ListNetworkInterfaceFloatingIpsOptions{VirtualNetworkInterfaceID: &id})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error listing floating IPs of virtual network interface (%s): %s\n%s", id, err, response)
+	}
+	endpoint.floatingIPs = floatingIPs.FloatingIps
+	return endpoint, nil
+}
+
+func (r *networkReachabilityReader) reservedIP(subnetID, id string) (*networkReachabilityEndpoint, error) {
+	reservedIP, response, err := r.sess.GetSubnetReservedIP(&vpcv1.GetSubnetReservedIPOptions{
+		SubnetID: &subnetID,
+		ID:       &id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting reserved IP (%s) of subnet (%s): %s\n%s", id, subnetID, err, response)
+	}
+	if target, ok := reservedIP.Target.(*vpcv1.ReservedIPTarget); ok && target != nil && target.ResourceType != nil && target.ID != nil {
+		switch *target.ResourceType {
+		case "virtual_network_interface":
+			return r.virtualNetworkInterface(*target.ID, *reservedIP.Address)
+		case "network_interface":
+			// The instance of a network interface is only in its href:
+			// .../instances/<instance>/network_interfaces/<network interface>
+			if parts := strings.Split(*target.Href, "/"); len(parts) > 3 && parts[len(parts)-4] == "instances" {
+				return r.networkInterface(parts[len(parts)-3], *target.ID, *reservedIP.Address)
+			}
+		case "endpoint_gateway":
+			gateway, response, err := r.sess.GetEndpointGateway(&vpcv1.GetEndpointGatewayOptions{ID: target.ID})
+			if err != nil {
+				return nil, fmt.Errorf("[ERROR] Error getting endpoint gateway (%s): %s\n%s", *target.ID, err, response)
+			}
+			return r.networkEndpoint(*gateway.Name, *reservedIP.Address, subnetID, gateway.SecurityGroups)
+		}
+	}
+	// The traffic of a reserved IP that isn't bound to a network interface
+	// isn't filtered by security groups.
+	endpoint, err := r.networkEndpoint(*reservedIP.Name, *reservedIP.Address, subnetID, nil)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.hasSecurityGroups = false
+	return endpoint, nil
+}
+
+// networkEndpoint returns the endpoint of a network interface with its
+// security groups and the network policies of its subnet.
+func (r *networkReachabilityReader) networkEndpoint(name, address, subnetID string, securityGroups []vpcv1.SecurityGroupReference) (*networkReachabilityEndpoint, error) {
+	network, err := parseNetworkReachabilityCIDR(address)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error parsing the address of %s (%s): %s", name, address, err)
+	}
+	endpoint := &networkReachabilityEndpoint{
+		name:              name,
+		network:           network,
+		hasSecurityGroups: true,
+	}
+	for _, ref := range securityGroups {
+		sg, ok := r.securityGroups[*ref.ID]
+		if !ok {
+			var response *core.DetailedResponse
+			sg, response, err = r.sess.GetSecurityGroup(&vpcv1.GetSecurityGroupOptions{ID: ref.ID})
+			if err != nil {
+				return nil, fmt.Errorf("[ERROR] Error getting security group (%s): %s\n%s", *ref.ID, err, response)
+			}
+			r.securityGroups[*ref.ID] = sg
+		}
+		endpoint.securityGroups = append(endpoint.securityGroups, sg)
+	}
+	subnet, response, err := r.sess.GetSubnet(&vpcv1.GetSubnetOptions{ID: &subnetID})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting subnet (%s): %s\n%s", subnetID, err, response)
+	}
+	if err = r.subnet(endpoint, subnet); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// subnet reads the network ACL and the routes of the routing table of the
+// subnet of endpoint.
+func (r *networkReachabilityReader) subnet(endpoint *networkReachabilityEndpoint, subnet *vpcv1.Subnet) error {
+	endpoint.subnet = subnet
+	endpoint.vpcID = *subnet.VPC.ID
+
+	networkACL, response, err := r.sess.GetNetworkACL(&vpcv1.GetNetworkACLOptions{ID: subnet.NetworkACL.ID})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error getting network ACL (%s): %s\n%s", *subnet.NetworkACL.ID, err, response)
+	}
+	endpoint.networkACL = networkACL
+
+	routingTable, response, err := r.sess.GetVPCRoutingTable(&vpcv1.GetVPCRoutingTableOptions{
+		VPCID: subnet.VPC.ID,
+		ID:    subnet.RoutingTable.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error getting routing table (%s): %s\n%s", *subnet.RoutingTable.ID, err, response)
+	}
+	endpoint.routingTable = routingTable
+	start := ""
+	for {
+		options := &vpcv1.ListVPCRoutingTableRoutesOptions{
+			VPCID:          subnet.VPC.ID,
+			RoutingTableID: subnet.RoutingTable.ID,
+		}
+		if start != "" {
+			options.Start = &start
+		}
+		routes, response, err := r.sess.ListVPCRoutingTableRoutes(options)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error listing routes of routing table (%s): %s\n%s", *subnet.RoutingTable.ID, err, response)
+		}
+		endpoint.routes = append(endpoint.routes, routes.Routes...)
+		start = flex.GetNext(routes.Next)
+		if start == "" {
+			break
+		}
+	}
+	return nil
+}
+
+// place puts a CIDR endpoint in the subnet of the VPC that contains it. The
+// endpoint is outside of the VPC if no subnet contains it.
+func (r *networkReachabilityReader) place(endpoint *networkReachabilityEndpoint, vpcID string) error {
+	start := ""
+	for {
+		options := &vpcv1.ListSubnetsOptions{VPCID: &vpcID}
+		if start != "" {
+			options.Start = &start
+		}
+		subnets, response, err := r.sess.ListSubnets(options)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error listing subnets of VPC (%s): %s\n%s", vpcID, err, response)
+		}
+		for i := range subnets.Subnets {
+			subnet := &subnets.Subnets[i]
+			if subnet.Ipv4CIDRBlock != nil && networkReachabilityContains(*subnet.Ipv4CIDRBlock, endpoint.network) {
+				return r.subnet(endpoint, subnet)
+			}
+		}
+		start = flex.GetNext(subnets.Next)
+		if start == "" {
+			return nil
+		}
+	}
+}
+
+// evaluateNetworkReachability evaluates the network policies the traffic meets
+// from the source to the destination, and the network ACLs the replies meet on
+// their way back, since network ACLs are stateless. It returns whether the
+// traffic is allowed, why and the components that were evaluated.
+func evaluateNetworkReachability(source, destination *networkReachabilityEndpoint, traffic networkReachabilityTraffic) (bool, string, []networkReachabilityStep) {
+	// Network ACLs only filter the traffic that enters or leaves a subnet.
+	sameSubnet := source.subnet != nil && destination.subnet != nil && *source.subnet.ID == *destination.subnet.ID
+	reply := traffic.reply()
+
+	var evaluations []func() ([]networkReachabilityStep, bool)
+	if source.hasSecurityGroups {
+		evaluations = append(evaluations, func() ([]networkReachabilityStep, bool) {
+			return evaluateNetworkReachabilitySecurityGroups(source, destination, isNetworkReachabilityOutbound, traffic)
+		})
+	}
+	if source.subnet != nil && !sameSubnet {
+		evaluations = append(evaluations, func() ([]networkReachabilityStep, bool) {
+			return evaluateNetworkReachabilityNetworkACL(source.networkACL, isNetworkReachabilityOutbound, source.network, destination.network, traffic)
+		})
+	}
+	if !sameSubnet {
+		evaluations = append(evaluations, func() ([]networkReachabilityStep, bool) {
+			return evaluateNetworkReachabilityRouting(source, destination)
+		})
+	}
+	if destination.subnet != nil && !sameSubnet {
+		evaluations = append(evaluations, func() ([]networkReachabilityStep, bool) {
+			return evaluateNetworkReachabilityNetworkACL(destination.networkACL, isNetworkReachabilityInbound, source.network, destination.network, traffic)
+		})
+	}
+	if destination.hasSecurityGroups {
+		evaluations = append(evaluations, func() ([]networkReachabilityStep, bool) {
+			return evaluateNetworkReachabilitySecurityGroups(destination, source, isNetworkReachabilityInbound, traffic)
+		})
+	}
+	if destination.subnet != nil && !sameSubnet {
+		evaluations = append(evaluations, func() ([]networkReachabilityStep, bool) {
+			return evaluateNetworkReachabilityNetworkACL(destination.networkACL, isNetworkReachabilityOutbound, destination.network, source.network, reply)
+		})
+	}
+	if source.subnet != nil && !sameSubnet {
+		evaluations = append(evaluations, func() ([]networkReachabilityStep, bool) {
+			return evaluateNetworkReachabilityNetworkACL(source.networkACL, isNetworkReachabilityInbound, destination.network, source.network, reply)
+		})
+	}
+
+	steps := []networkReachabilityStep{}
+	for _, evaluate := range evaluations {
+		evaluated, allowed := evaluate()
+		steps = append(steps, evaluated...)
+		if !allowed {
+			return false, evaluated[len(evaluated)-1].description, steps
+		}
+	}
+	return true, fmt.Sprintf("%s traffic from %s to %s is allowed", traffic.protocol, source.network, destination.network), steps
+}
+
+func evaluateNetworkReachabilitySecurityGroups(endpoint, peer *networkReachabilityEndpoint, direction string, traffic networkReachabilityTraffic) ([]networkReachabilityStep, bool) {
+	ids := make([]string, 0, len(endpoint.securityGroups))
+	names := make([]string, 0, len(endpoint.securityGroups))
+	for _, sg := range endpoint.securityGroups {
+		for _, item := range sg.Rules {
+			id, rule := flattenIBMISSecurityGroupRule(item)
+			if rule == nil || rule[isSecurityGroupRuleDirection] != direction || !networkReachabilitySecurityGroupRuleMatches(rule, endpoint, peer, traffic) {
+				continue
+			}
+			return []networkReachabilityStep{{
+				component:    isNetworkReachabilityComponentSecurityGroup,
+				direction:    direction,
+				resourceID:   *sg.ID,
+				resourceName: *sg.Name,
+				ruleID:       id,
+				action:       "allow",
+				description:  fmt.Sprintf("rule %s of security group %s allows the %s traffic of %s", id, *sg.Name, direction, endpoint.name),
+			}}, true
+		}
+		ids = append(ids, *sg.ID)
+		names = append(names, *sg.Name)
+	}
+	return []networkReachabilityStep{{
+		component:    isNetworkReachabilityComponentSecurityGroup,
+		direction:    direction,
+		resourceID:   strings.Join(ids, ","),
+		resourceName: strings.Join(names, ","),
+		action:       "deny",
+		description:  fmt.Sprintf("no %s rule of the security groups of %s allows the traffic", direction, endpoint.name),
+	}}, false
+}
+
+func networkReachabilitySecurityGroupRuleMatches(rule map[string]interface{}, endpoint, peer *networkReachabilityEndpoint, traffic networkReachabilityTraffic) bool {
+	num := func(key string) *int64 {
+		if v, ok := rule[key].(int); ok {
+			return core.Int64Ptr(int64(v))
+		}
+		return nil
+	}
+	protocol := rule[isSecurityGroupRuleProtocol].(string)
+	if !networkReachabilityProtocolMatches(protocol, traffic.protocol) {
+		return false
+	}
+	switch protocol {
+	case isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP:
+		portMin, _ := rule[isSecurityGroupRulePortMin].(int)
+		portMax, _ := rule[isSecurityGroupRulePortMax].(int)
+		portMin, portMax = ibmISSecurityGroupRulePorts(portMin, portMax)
+		if !networkReachabilityPortMatches(int64(portMin), int64(portMax), traffic.port) {
+			return false
+		}
+	case isSecurityGroupRuleProtocolICMP:
+		if !networkReachabilityICMPMatches(num(isSecurityGroupRuleType), num(isSecurityGroupRuleCode), traffic) {
+			return false
+		}
+	}
+	if remote, _ := rule[isSecurityGroupRuleRemote].(string); remote != "" {
+		address, cidr, id, _ := inferRemoteSecurityGroup(remote)
+		if id != "" {
+			if !peer.inSecurityGroup(id) {
+				return false
+			}
+		} else if !networkReachabilityContains(address+cidr, peer.network) {
+			return false
+		}
+	}
+	if local, _ := rule[isSecurityGroupRuleLocal].(string); local != "" && !networkReachabilityContains(local, endpoint.network) {
+		return false
+	}
+	return true
+}
+
+func (e *networkReachabilityEndpoint) inSecurityGroup(id string) bool {
+	for _, sg := range e.securityGroups {
+		if *sg.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// networkReachabilityACLRule is a network ACL rule of any protocol.
+type networkReachabilityACLRule struct {
+	id, name, action, direction, source, destination, protocol string
+
+	portMin, portMax, sourcePortMin, sourcePortMax int64
+	icmpType, icmpCode                             *int64
+}
+
+func flattenNetworkReachabilityACLRule(item vpcv1.NetworkACLRuleItemIntf) *networkReachabilityACLRule {
+	switch item := item.(type) {
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAll:
+		return &networkReachabilityACLRule{
+			id: *item.ID, name: *item.Name, action: *item.Action, direction: *item.Direction,
+			source: *item.Source, destination: *item.Destination, protocol: *item.Protocol,
+		}
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmp:
+		return &networkReachabilityACLRule{
+			id: *item.ID, name: *item.Name, action: *item.Action, direction: *item.Direction,
+			source: *item.Source, destination: *item.Destination, protocol: *item.Protocol,
+			icmpType: item.Type, icmpCode: item.Code,
+		}
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolTcpudp:
+		return &networkReachabilityACLRule{
+			id: *item.ID, name: *item.Name, action: *item.Action, direction: *item.Direction,
+			source: *item.Source, destination: *item.Destination, protocol: *item.Protocol,
+			portMin: *item.DestinationPortMin, portMax: *item.DestinationPortMax,
+			sourcePortMin: *item.SourcePortMin, sourcePortMax: *item.SourcePortMax,
+		}
+	}
+	return nil
+}
+
+// evaluateNetworkReachabilityNetworkACL evaluates the rules of a network ACL in
+// order. The first rule that matches the traffic decides, and traffic that no
+// rule matches is denied.
+func evaluateNetworkReachabilityNetworkACL(acl *vpcv1.NetworkACL, direction string, from, to *net.IPNet, traffic networkReachabilityTraffic) ([]networkReachabilityStep, bool) {
+	for _, item := range acl.Rules {
+		rule := flattenNetworkReachabilityACLRule(item)
+		if rule == nil || rule.direction != direction || !networkReachabilityContains(rule.source, from) || !networkReachabilityContains(rule.destination, to) || !networkReachabilityProtocolMatches(rule.protocol, traffic.protocol) {
+			continue
+		}
+		switch rule.protocol {
+		case isNetworkACLRuleTCP, isNetworkACLRuleUDP:
+			if !networkReachabilityPortMatches(rule.portMin, rule.portMax, traffic.port) || !networkReachabilityPortMatches(rule.sourcePortMin, rule.sourcePortMax, traffic.sourcePort) {
+				continue
+			}
+		case isNetworkACLRuleICMP:
+			if !networkReachabilityICMPMatches(rule.icmpType, rule.icmpCode, traffic) {
+				continue
+			}
+		}
+		verb := "allows"
+		if rule.action != "allow" {
+			verb = "denies"
+		}
+		return []networkReachabilityStep{{
+			component:    isNetworkReachabilityComponentNetworkACL,
+			direction:    direction,
+			resourceID:   *acl.ID,
+			resourceName: *acl.Name,
+			ruleID:       rule.id,
+			ruleName:     rule.name,
+			action:       rule.action,
+			description:  fmt.Sprintf("rule %s of network ACL %s %s the %s %s traffic from %s to %s", rule.name, *acl.Name, verb, direction, traffic.protocol, from, to),
+		}}, rule.action == "allow"
+	}
+	return []networkReachabilityStep{{
+		component:    isNetworkReachabilityComponentNetworkACL,
+		direction:    direction,
+		resourceID:   *acl.ID,
+		resourceName: *acl.Name,
+		action:       "deny",
+		description:  fmt.Sprintf("no %s rule of network ACL %s matches the %s traffic from %s to %s", direction, *acl.Name, traffic.protocol, from, to),
+	}}, false
+}
+
+// evaluateNetworkReachabilityRouting evaluates how the traffic leaves the
+// subnet of the source: the most specific route of its routing table in its
+// zone, then the public gateway or the floating IP that connects the VPC to a
+// public destination.
+func evaluateNetworkReachabilityRouting(source, destination *networkReachabilityEndpoint) ([]networkReachabilityStep, bool) {
+	if source.subnet == nil {
+		// Traffic from the internet only reaches the floating IP of a
+		// network interface.
+		if !networkReachabilityPublic(source.network) {
+			return nil, true
+		}
+		if len(destination.floatingIPs) > 0 {
+			fip := destination.floatingIPs[0]
+			return []networkReachabilityStep{{
+				component:    isNetworkReachabilityComponentFloatingIP,
+				direction:    isNetworkReachabilityInbound,
+				resourceID:   *fip.ID,
+				resourceName: *fip.Name,
+				action:       "allow",
+				description:  fmt.Sprintf("floating IP %s (%s) of %s receives the traffic from the internet", *fip.Name, *fip.Address, destination.name),
+			}}, true
+		}
+		return []networkReachabilityStep{{
+			component:   isNetworkReachabilityComponentFloatingIP,
+			direction:   isNetworkReachabilityInbound,
+			action:      "deny",
+			description: fmt.Sprintf("%s has no floating IP to receive the traffic from the internet", destination.name),
+		}}, false
+	}
+
+	var route *vpcv1.Route
+	routeOnes := -1
+	for i := range source.routes {
+		r := &source.routes[i]
+		if r.Zone != nil && r.Zone.Name != nil && *r.Zone.Name != *source.subnet.Zone.Name {
+			continue
+		}
+		if r.Destination == nil || !networkReachabilityContains(*r.Destination, destination.network) {
+			continue
+		}
+		_, network, err := net.ParseCIDR(*r.Destination)
+		if err != nil {
+			continue
+		}
+		// The most specific route wins, then the route with the highest
+		// priority, which is the lowest value.
+		ones, _ := network.Mask.Size()
+		if ones > routeOnes || (ones == routeOnes && r.Priority != nil && route.Priority != nil && *r.Priority < *route.Priority) {
+			route, routeOnes = r, ones
+		}
+	}
+	table := source.routingTable
+	if route != nil {
+		step := networkReachabilityStep{
+			component:    isNetworkReachabilityComponentRoutingTable,
+			direction:    isNetworkReachabilityOutbound,
+			resourceID:   *table.ID,
+			resourceName: *table.Name,
+			ruleID:       *route.ID,
+			ruleName:     *route.Name,
+			action:       "allow",
+		}
+		if *route.Action == "drop" {
+			step.action = "deny"
+			step.description = fmt.Sprintf("route %s (%s) of routing table %s drops the traffic", *route.Name, *route.Destination, *table.Name)
+			return []networkReachabilityStep{step}, false
+		}
+		nextHop := ""
+		if hop, ok := route.NextHop.(*vpcv1.RouteNextHop); ok && hop != nil {
+			if hop.Address != nil {
+				nextHop = *hop.Address
+			} else if hop.Name != nil {
+				nextHop = *hop.Name
+			}
+		}
+		step.description = fmt.Sprintf("route %s (%s) of routing table %s routes the traffic with action %s", *route.Name, *route.Destination, *table.Name, *route.Action)
+		if nextHop != "" {
+			step.description += " to " + nextHop
+		}
+		return []networkReachabilityStep{step}, true
+	}
+
+	if destination.subnet != nil && destination.vpcID == source.vpcID {
+		return nil, true
+	}
+	// A private network outside the VPC, such as another VPC or an
+	// on-premises network, is only reached through a route, for example to a
+	// transit gateway or a VPN gateway.
+	if !networkReachabilityPublic(destination.network) {
+		return []networkReachabilityStep{{
+			component:    isNetworkReachabilityComponentRoutingTable,
+			direction:    isNetworkReachabilityOutbound,
+			resourceID:   *table.ID,
+			resourceName: *table.Name,
+			action:       "deny",
+			description:  fmt.Sprintf("no route of routing table %s matches %s, which is outside the VPC of %s", *table.Name, destination.network, source.name),
+		}}, false
+	}
+	if len(source.floatingIPs) > 0 {
+		fip := source.floatingIPs[0]
+		return []networkReachabilityStep{{
+			component:    isNetworkReachabilityComponentFloatingIP,
+			direction:    isNetworkReachabilityOutbound,
+			resourceID:   *fip.ID,
+			resourceName: *fip.Name,
+			action:       "allow",
+			description:  fmt.Sprintf("floating IP %s (%s) of %s sends the traffic to the internet", *fip.Name, *fip.Address, source.name),
+		}}, true
+	}
+	if pgw := source.subnet.PublicGateway; pgw != nil {
+		return []networkReachabilityStep{{
+			component:    isNetworkReachabilityComponentPublicGateway,
+			direction:    isNetworkReachabilityOutbound,
+			resourceID:   *pgw.ID,
+			resourceName: *pgw.Name,
+			action:       "allow",
+			description:  fmt.Sprintf("public gateway %s of subnet %s sends the traffic to the internet", *pgw.Name, *source.subnet.Name),
+		}}, true
+	}
+	return []networkReachabilityStep{{
+		component:    isNetworkReachabilityComponentRoutingTable,
+		direction:    isNetworkReachabilityOutbound,
+		resourceID:   *table.ID,
+		resourceName: *table.Name,
+		action:       "deny",
+		description:  fmt.Sprintf("subnet %s has no public gateway and %s has no floating IP to reach %s", *source.subnet.Name, source.name, destination.network),
+	}}, false
+}
+
+// networkReachabilityProtocolMatches returns whether a rule of protocol rule
+// matches traffic of protocol traffic. Traffic of all protocols only matches
+// the rules of all protocols.
+func networkReachabilityProtocolMatches(rule, traffic string) bool {
+	return rule == isSecurityGroupRuleProtocolAll || rule == traffic
+}
+
+// networkReachabilityPortMatches returns whether port is in a port range. An
+// ephemeral port only matches the ranges that include all ephemeral ports.
+func networkReachabilityPortMatches(portMin, portMax int64, port *int64) bool {
+	if port == nil {
+		return portMin <= isNetworkReachabilityEphemeralPortMin && portMax >= isNetworkReachabilityEphemeralPortMax
+	}
+	return portMin <= *port && *port <= portMax
+}
+
+func networkReachabilityICMPMatches(icmpType, icmpCode *int64, traffic networkReachabilityTraffic) bool {
+	if icmpType == nil {
+		return true
+	}
+	if traffic.icmpType == nil || *traffic.icmpType != *icmpType {
+		return false
+	}
+	return icmpCode == nil || (traffic.icmpCode != nil && *traffic.icmpCode == *icmpCode)
+}
+
+// parseNetworkReachabilityCIDR parses an IPv4 address or CIDR block.
+func parseNetworkReachabilityCIDR(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		ip, network, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		if ip.To4() == nil {
+			return nil, fmt.Errorf("%s is not an IPv4 CIDR block", s)
+		}
+		return network, nil
+	}
+	ip := net.ParseIP(s).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("%s is not an IPv4 address", s)
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(32, 32)}, nil
+}
+
+// networkReachabilityContains returns whether the address or CIDR block cidr
+// contains all the addresses of network.
+func networkReachabilityContains(cidr string, network *net.IPNet) bool {
+	outer, err := parseNetworkReachabilityCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	outerOnes, _ := outer.Mask.Size()
+	ones, _ := network.Mask.Size()
+	return outerOnes <= ones && outer.Contains(network.IP)
+}
+
+// networkReachabilityPrivateNetworks are the networks that are reached without
+// a public gateway or a floating IP: the private networks, the shared address
+// space and the IBM Cloud service networks.
+var networkReachabilityPrivateNetworks = []string{
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"161.26.0.0/16",
+	"166.8.0.0/14",
+}
+
+func networkReachabilityPublic(network *net.IPNet) bool {
+	for _, private := range networkReachabilityPrivateNetworks {
+		if networkReachabilityContains(private, network) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMISNetworkReachabilityDataSource_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tf-subnet-%d", acctest.RandIntRange(10, 100))
+	sgname := fmt.Sprintf("tf-sg-%d", acctest.RandIntRange(10, 100))
+	vniname := fmt.Sprintf("tf-vni-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISNetworkReachabilityDataSourceConfig(vpcname, subnetname, sgname, vniname),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_is_network_reachability.ssh", "allowed", "true"),
+					resource.TestCheckResourceAttrSet("data.ibm_is_network_reachability.ssh", "source.0.address"),
+					resource.TestCheckResourceAttrPair("data.ibm_is_network_reachability.ssh", "destination.0.vpc", "ibm_is_vpc.testacc_vpc", "id"),
+					resource.TestCheckResourceAttr("data.ibm_is_network_reachability.http", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.ibm_is_network_reachability.http", "rule_chain.0.component", "security_group"),
+					resource.TestCheckResourceAttr("data.ibm_is_network_reachability.http", "rule_chain.0.action", "allow"),
+					resource.TestCheckResourceAttr("data.ibm_is_network_reachability.http", "rule_chain.1.component", "security_group"),
+					resource.TestCheckResourceAttr("data.ibm_is_network_reachability.http", "rule_chain.1.direction", "inbound"),
+					resource.TestCheckResourceAttr("data.ibm_is_network_reachability.http", "rule_chain.1.action", "deny"),
+					resource.TestCheckResourceAttr("data.ibm_is_network_reachability.internet", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.ibm_is_network_reachability.internet", "rule_chain.1.component", "routing_table"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISNetworkReachabilityDataSourceConfig(vpcname, subnetname, sgname, vniname string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	}
+	resource "ibm_is_subnet" "testacc_subnet" {
+		name                     = "%s"
+		vpc                      = ibm_is_vpc.testacc_vpc.id
+		zone                     = "%s"
+		total_ipv4_address_count = 16
+	}
+	resource "ibm_is_security_group" "testacc_sg" {
+		name = "%s"
+		vpc  = ibm_is_vpc.testacc_vpc.id
+		rule {
+			direction = "inbound"
+			protocol  = "tcp"
+			port_min  = 22
+			port_max  = 22
+		}
+		rule {
+			direction = "outbound"
+		}
+	}
+	resource "ibm_is_virtual_network_interface" "testacc_vni" {
+		count           = 2
+		name            = "%s-${count.index}"
+		subnet          = ibm_is_subnet.testacc_subnet.id
+		security_groups = [ibm_is_security_group.testacc_sg.id]
+	}
+	data "ibm_is_network_reachability" "ssh" {
+		source {
+			virtual_network_interface = ibm_is_virtual_network_interface.testacc_vni[0].id
+		}
+		destination {
+			virtual_network_interface = ibm_is_virtual_network_interface.testacc_vni[1].id
+		}
+		protocol = "tcp"
+		port     = 22
+	}
+	data "ibm_is_network_reachability" "http" {
+		source {
+			virtual_network_interface = ibm_is_virtual_network_interface.testacc_vni[0].id
+		}
+		destination {
+			virtual_network_interface = ibm_is_virtual_network_interface.testacc_vni[1].id
+		}
+		protocol = "tcp"
+		port     = 80
+	}
+	data "ibm_is_network_reachability" "internet" {
+		source {
+			virtual_network_interface = ibm_is_virtual_network_interface.testacc_vni[0].id
+		}
+		destination {
+			cidr = "8.8.8.8"
+		}
+		protocol = "udp"
+		port     = 53
+	}
+	`, vpcname, subnetname, acc.ISZoneName, sgname, vniname)
+}
@@ -0,0 +1,494 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+)
+
+func testReachabilityACLRule(id, action, direction, protocol, source, destination string, portMin, portMax int64) vpcv1.NetworkACLRuleItemIntf {
+	switch protocol {
+	case isNetworkACLRuleTCP, isNetworkACLRuleUDP:
+		return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolTcpudp{
+			ID: &id, Name: &id, Action: &action, Direction: &direction,
+			Source: &source, Destination: &destination, Protocol: &protocol,
+			DestinationPortMin: &portMin, DestinationPortMax: &portMax,
+			SourcePortMin: core.Int64Ptr(1), SourcePortMax: core.Int64Ptr(65535),
+		}
+	case isNetworkACLRuleICMP:
+		return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmp{
+			ID: &id, Name: &id, Action: &action, Direction: &direction,
+			Source: &source, Destination: &destination, Protocol: &protocol,
+			Type: core.Int64Ptr(portMin),
+		}
+	}
+	return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAll{
+		ID: &id, Name: &id, Action: &action, Direction: &direction,
+		Source: &source, Destination: &destination, Protocol: &protocol,
+	}
+}
+
+// testReachabilitySGRule returns a security group rule. The remote is a CIDR
+// block, or the ID of a security group when it doesn't contain a slash.
+func testReachabilitySGRule(id, direction, protocol, remote string, portMin, portMax int64) vpcv1.SecurityGroupRuleIntf {
+	r := &vpcv1.SecurityGroupRuleRemote{CIDRBlock: &remote}
+	if !strings.Contains(remote, "/") {
+		r = &vpcv1.SecurityGroupRuleRemote{ID: &remote}
+	}
+	switch protocol {
+	case isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP:
+		return &vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp{
+			ID: &id, Direction: &direction, IPVersion: core.StringPtr("ipv4"), Protocol: &protocol,
+			PortMin: &portMin, PortMax: &portMax, Remote: r,
+		}
+	case isSecurityGroupRuleProtocolICMP:
+		return &vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp{
+			ID: &id, Direction: &direction, IPVersion: core.StringPtr("ipv4"), Protocol: &protocol,
+			Type: &portMin, Remote: r,
+		}
+	}
+	return &vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll{
+		ID: &id, Direction: &direction, IPVersion: core.StringPtr("ipv4"), Protocol: &protocol, Remote: r,
+	}
+}
+
+func testReachabilityRoute(id, destination, action, zone string, priority int64) vpcv1.Route {
+	return vpcv1.Route{
+		ID: &id, Name: &id, Destination: &destination, Action: &action, Priority: &priority,
+		Zone:    &vpcv1.ZoneReference{Name: &zone},
+		NextHop: &vpcv1.RouteNextHop{Address: core.StringPtr("10.240.0.10")},
+	}
+}
+
+func testReachabilityNetwork(cidr string) *net.IPNet {
+	network, err := parseNetworkReachabilityCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// testReachabilityFixture is a VPC with a web subnet, which has a public
+// gateway, and a database subnet, which has none. web and web2 are in the web
+// subnet, db is in the database subnet and internet is a public address.
+type testReachabilityFixture struct {
+	web, web2, db, internet *networkReachabilityEndpoint
+}
+
+func newTestReachabilityFixture() testReachabilityFixture {
+	webSG := &vpcv1.SecurityGroup{
+		ID:   core.StringPtr("sg-web"),
+		Name: core.StringPtr("web"),
+		Rules: []vpcv1.SecurityGroupRuleIntf{
+			testReachabilitySGRule("r-web-https", isNetworkReachabilityInbound, "tcp", "0.0.0.0/0", 443, 443),
+			testReachabilitySGRule("r-web-ssh", isNetworkReachabilityInbound, "tcp", "10.240.1.0/24", 22, 22),
+			testReachabilitySGRule("r-web-ping", isNetworkReachabilityInbound, "icmp", "0.0.0.0/0", 8, 0),
+			testReachabilitySGRule("r-web-out", isNetworkReachabilityOutbound, "all", "0.0.0.0/0", 0, 0),
+		},
+	}
+	dbSG := &vpcv1.SecurityGroup{
+		ID:   core.StringPtr("sg-db"),
+		Name: core.StringPtr("db"),
+		Rules: []vpcv1.SecurityGroupRuleIntf{
+			testReachabilitySGRule("r-db-pg", isNetworkReachabilityInbound, "tcp", "sg-web", 5432, 5432),
+			testReachabilitySGRule("r-db-out", isNetworkReachabilityOutbound, "all", "0.0.0.0/0", 0, 0),
+		},
+	}
+	webACL := &vpcv1.NetworkACL{
+		ID:   core.StringPtr("acl-web"),
+		Name: core.StringPtr("web"),
+		Rules: []vpcv1.NetworkACLRuleItemIntf{
+			testReachabilityACLRule("allow-in", "allow", isNetworkReachabilityInbound, "all", "0.0.0.0/0", "0.0.0.0/0", 0, 0),
+			testReachabilityACLRule("allow-out", "allow", isNetworkReachabilityOutbound, "all", "0.0.0.0/0", "0.0.0.0/0", 0, 0),
+		},
+	}
+	dbACL := &vpcv1.NetworkACL{
+		ID:   core.StringPtr("acl-db"),
+		Name: core.StringPtr("db"),
+		Rules: []vpcv1.NetworkACLRuleItemIntf{
+			testReachabilityACLRule("deny-ssh", "deny", isNetworkReachabilityInbound, "tcp", "0.0.0.0/0", "0.0.0.0/0", 22, 22),
+			testReachabilityACLRule("allow-vpc", "allow", isNetworkReachabilityInbound, "tcp", "10.240.0.0/16", "0.0.0.0/0", 1, 65535),
+			testReachabilityACLRule("allow-ping", "allow", isNetworkReachabilityInbound, "icmp", "10.240.0.0/16", "0.0.0.0/0", 8, 0),
+			testReachabilityACLRule("allow-out", "allow", isNetworkReachabilityOutbound, "all", "0.0.0.0/0", "0.0.0.0/0", 0, 0),
+		},
+	}
+	zone := &vpcv1.ZoneReference{Name: core.StringPtr("us-south-1")}
+	webSubnet := &vpcv1.Subnet{
+		ID:            core.StringPtr("subnet-web"),
+		Name:          core.StringPtr("web"),
+		Zone:          zone,
+		PublicGateway: &vpcv1.PublicGatewayReference{ID: core.StringPtr("pgw-1"), Name: core.StringPtr("pgw")},
+	}
+	dbSubnet := &vpcv1.Subnet{
+		ID:   core.StringPtr("subnet-db"),
+		Name: core.StringPtr("db"),
+		Zone: zone,
+	}
+	table := &vpcv1.RoutingTable{ID: core.StringPtr("rt-1"), Name: core.StringPtr("default")}
+
+	return testReachabilityFixture{
+		web: &networkReachabilityEndpoint{
+			name: "web", network: testReachabilityNetwork("10.240.0.4"), vpcID: "vpc-1", subnet: webSubnet,
+			hasSecurityGroups: true, securityGroups: []*vpcv1.SecurityGroup{webSG},
+			networkACL: webACL, routingTable: table,
+		},
+		web2: &networkReachabilityEndpoint{
+			name: "web2", network: testReachabilityNetwork("10.240.0.5"), vpcID: "vpc-1", subnet: webSubnet,
+			hasSecurityGroups: true, securityGroups: []*vpcv1.SecurityGroup{webSG},
+			networkACL: webACL, routingTable: table,
+		},
+		db: &networkReachabilityEndpoint{
+			name: "db", network: testReachabilityNetwork("10.240.1.5"), vpcID: "vpc-1", subnet: dbSubnet,
+			hasSecurityGroups: true, securityGroups: []*vpcv1.SecurityGroup{dbSG},
+			networkACL: dbACL, routingTable: table,
+		},
+		internet: &networkReachabilityEndpoint{
+			name: "203.0.113.7", network: testReachabilityNetwork("203.0.113.7"),
+		},
+	}
+}
+
+func testReachabilityTCP(port int64) networkReachabilityTraffic {
+	return networkReachabilityTraffic{protocol: "tcp", port: &port}
+}
+
+func TestNetworkReachabilityContains(t *testing.T) {
+	for _, tc := range []struct {
+		cidr     string
+		network  string
+		contains bool
+	}{
+		{"10.0.0.0/8", "10.240.0.4", true},
+		{"10.240.0.4", "10.240.0.4", true},
+		{"10.240.0.4", "10.240.0.5", false},
+		{"0.0.0.0/0", "203.0.113.7", true},
+		{"0.0.0.0/0", "0.0.0.0/0", true},
+		{"10.240.0.0/16", "10.240.1.0/24", true},
+		// a smaller block doesn't contain all the addresses of a larger one
+		{"10.240.0.0/24", "10.240.0.0/16", false},
+		{"192.168.0.0/16", "10.240.0.4", false},
+		// the host bits of a CIDR block are ignored
+		{"10.240.0.7/24", "10.240.0.4", true},
+		{"not-a-cidr", "10.240.0.4", false},
+		{"2001:db8::/32", "10.240.0.4", false},
+	} {
+		if contains := networkReachabilityContains(tc.cidr, testReachabilityNetwork(tc.network)); contains != tc.contains {
+			t.Errorf("networkReachabilityContains(%q, %s) = %t, expected %t", tc.cidr, tc.network, contains, tc.contains)
+		}
+	}
+}
+
+func TestEvaluateNetworkReachabilitySecurityGroups(t *testing.T) {
+	f := newTestReachabilityFixture()
+	echo := networkReachabilityTraffic{protocol: "icmp", icmpType: core.Int64Ptr(8), icmpCode: core.Int64Ptr(0)}
+	unreachable := networkReachabilityTraffic{protocol: "icmp", icmpType: core.Int64Ptr(3)}
+
+	for name, tc := range map[string]struct {
+		endpoint, peer *networkReachabilityEndpoint
+		direction      string
+		traffic        networkReachabilityTraffic
+		allowed        bool
+		ruleID         string
+	}{
+		"remote security group":         {f.db, f.web, isNetworkReachabilityInbound, testReachabilityTCP(5432), true, "r-db-pg"},
+		"peer outside the remote group": {f.db, f.internet, isNetworkReachabilityInbound, testReachabilityTCP(5432), false, ""},
+		"port without a rule":           {f.db, f.web, isNetworkReachabilityInbound, testReachabilityTCP(22), false, ""},
+		"remote CIDR block":             {f.web, f.db, isNetworkReachabilityInbound, testReachabilityTCP(22), true, "r-web-ssh"},
+		"peer outside the remote block": {f.web, f.internet, isNetworkReachabilityInbound, testReachabilityTCP(22), false, ""},
+		"ICMP type":                     {f.web, f.internet, isNetworkReachabilityInbound, echo, true, "r-web-ping"},
+		"other ICMP type":               {f.web, f.internet, isNetworkReachabilityInbound, unreachable, false, ""},
+		"all protocols":                 {f.web, f.internet, isNetworkReachabilityOutbound, networkReachabilityTraffic{protocol: "udp", port: core.Int64Ptr(53)}, true, "r-web-out"},
+		"ephemeral port":                {f.web, f.internet, isNetworkReachabilityInbound, networkReachabilityTraffic{protocol: "tcp"}, false, ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			steps, allowed := evaluateNetworkReachabilitySecurityGroups(tc.endpoint, tc.peer, tc.direction, tc.traffic)
+			if allowed != tc.allowed {
+				t.Fatalf("evaluateNetworkReachabilitySecurityGroups() allowed = %t, expected %t: %+v", allowed, tc.allowed, steps)
+			}
+			if len(steps) != 1 {
+				t.Fatalf("evaluateNetworkReachabilitySecurityGroups() returned %d steps, expected 1", len(steps))
+			}
+			step := steps[0]
+			if step.component != isNetworkReachabilityComponentSecurityGroup || step.direction != tc.direction || step.ruleID != tc.ruleID {
+				t.Errorf("unexpected step %+v", step)
+			}
+			if !tc.allowed && (step.action != "deny" || step.resourceID != *tc.endpoint.securityGroups[0].ID) {
+				t.Errorf("unexpected denying step %+v", step)
+			}
+		})
+	}
+}
+
+func TestEvaluateNetworkReachabilityNetworkACL(t *testing.T) {
+	f := newTestReachabilityFixture()
+	acl := f.db.networkACL
+	web, internet, db := f.web.network, f.internet.network, f.db.network
+
+	for name, tc := range map[string]struct {
+		direction string
+		from, to  *net.IPNet
+		traffic   networkReachabilityTraffic
+		allowed   bool
+		ruleID    string
+	}{
+		"first matching rule denies": {isNetworkReachabilityInbound, web, db, testReachabilityTCP(22), false, "deny-ssh"},
+		"later rule allows":          {isNetworkReachabilityInbound, web, db, testReachabilityTCP(5432), true, "allow-vpc"},
+		"source outside the rule":    {isNetworkReachabilityInbound, internet, db, testReachabilityTCP(5432), false, ""},
+		"protocol without a rule":    {isNetworkReachabilityInbound, web, db, networkReachabilityTraffic{protocol: "udp", port: core.Int64Ptr(53)}, false, ""},
+		"ICMP type":                  {isNetworkReachabilityInbound, web, db, networkReachabilityTraffic{protocol: "icmp", icmpType: core.Int64Ptr(8)}, true, "allow-ping"},
+		"other ICMP type":            {isNetworkReachabilityInbound, web, db, networkReachabilityTraffic{protocol: "icmp", icmpType: core.Int64Ptr(3)}, false, ""},
+		"outbound reply":             {isNetworkReachabilityOutbound, db, web, testReachabilityTCP(5432).reply(), true, "allow-out"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			steps, allowed := evaluateNetworkReachabilityNetworkACL(acl, tc.direction, tc.from, tc.to, tc.traffic)
+			if allowed != tc.allowed {
+				t.Fatalf("evaluateNetworkReachabilityNetworkACL() allowed = %t, expected %t: %+v", allowed, tc.allowed, steps)
+			}
+			if len(steps) != 1 {
+				t.Fatalf("evaluateNetworkReachabilityNetworkACL() returned %d steps, expected 1", len(steps))
+			}
+			step := steps[0]
+			if step.component != isNetworkReachabilityComponentNetworkACL || step.resourceID != "acl-db" || step.direction != tc.direction || step.ruleID != tc.ruleID {
+				t.Errorf("unexpected step %+v", step)
+			}
+		})
+	}
+}
+
+func TestEvaluateNetworkReachabilityRouting(t *testing.T) {
+	for name, tc := range map[string]struct {
+		// setup picks the source and the destination from the fixture and
+		// changes it for the case.
+		setup     func(f testReachabilityFixture) (source, destination *networkReachabilityEndpoint)
+		allowed   bool
+		component string
+		ruleID    string
+	}{
+		"subnets of the VPC": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.web, f.db
+			},
+			allowed: true,
+		},
+		"public gateway": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.web, f.internet
+			},
+			allowed:   true,
+			component: isNetworkReachabilityComponentPublicGateway,
+		},
+		"floating IP before the public gateway": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				f.web.floatingIPs = []vpcv1.FloatingIPReference{{ID: core.StringPtr("fip-1"), Name: core.StringPtr("fip"), Address: core.StringPtr("198.51.100.1")}}
+				return f.web, f.internet
+			},
+			allowed:   true,
+			component: isNetworkReachabilityComponentFloatingIP,
+		},
+		"no way to the internet": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.db, f.internet
+			},
+			allowed:   false,
+			component: isNetworkReachabilityComponentRoutingTable,
+		},
+		"private network outside the VPC without a route": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.db, &networkReachabilityEndpoint{name: "on-premises", network: testReachabilityNetwork("192.168.5.9")}
+			},
+			allowed:   false,
+			component: isNetworkReachabilityComponentRoutingTable,
+		},
+		"another VPC without a route": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.db, &networkReachabilityEndpoint{
+					name: "other", network: testReachabilityNetwork("10.250.0.4"), vpcID: "vpc-2",
+					subnet: &vpcv1.Subnet{ID: core.StringPtr("subnet-other"), Name: core.StringPtr("other"), Zone: f.db.subnet.Zone},
+				}
+			},
+			allowed:   false,
+			component: isNetworkReachabilityComponentRoutingTable,
+		},
+		"another VPC through a transit gateway route": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				f.db.routes = []vpcv1.Route{testReachabilityRoute("r-tgw", "10.250.0.0/16", "deliver", "us-south-1", 2)}
+				return f.db, &networkReachabilityEndpoint{
+					name: "other", network: testReachabilityNetwork("10.250.0.4"), vpcID: "vpc-2",
+					subnet: &vpcv1.Subnet{ID: core.StringPtr("subnet-other"), Name: core.StringPtr("other"), Zone: f.db.subnet.Zone},
+				}
+			},
+			allowed:   true,
+			component: isNetworkReachabilityComponentRoutingTable,
+			ruleID:    "r-tgw",
+		},
+		"most specific route": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				f.db.routes = []vpcv1.Route{
+					testReachabilityRoute("r-wide", "192.168.0.0/16", "deliver", "us-south-1", 2),
+					testReachabilityRoute("r-narrow", "192.168.5.0/24", "deliver", "us-south-1", 2),
+					testReachabilityRoute("r-other-zone", "192.168.5.0/26", "drop", "us-south-2", 2),
+				}
+				return f.db, &networkReachabilityEndpoint{name: "on-premises", network: testReachabilityNetwork("192.168.5.9")}
+			},
+			allowed:   true,
+			component: isNetworkReachabilityComponentRoutingTable,
+			ruleID:    "r-narrow",
+		},
+		"route with the highest priority": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				f.db.routes = []vpcv1.Route{
+					testReachabilityRoute("r-low", "192.168.5.0/24", "deliver", "us-south-1", 4),
+					testReachabilityRoute("r-high", "192.168.5.0/24", "deliver", "us-south-1", 1),
+				}
+				return f.db, &networkReachabilityEndpoint{name: "on-premises", network: testReachabilityNetwork("192.168.5.9")}
+			},
+			allowed:   true,
+			component: isNetworkReachabilityComponentRoutingTable,
+			ruleID:    "r-high",
+		},
+		"drop route": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				f.web.routes = []vpcv1.Route{testReachabilityRoute("r-drop", "203.0.113.0/24", "drop", "us-south-1", 2)}
+				return f.web, f.internet
+			},
+			allowed:   false,
+			component: isNetworkReachabilityComponentRoutingTable,
+			ruleID:    "r-drop",
+		},
+		"floating IP from the internet": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				f.web.floatingIPs = []vpcv1.FloatingIPReference{{ID: core.StringPtr("fip-1"), Name: core.StringPtr("fip"), Address: core.StringPtr("198.51.100.1")}}
+				return f.internet, f.web
+			},
+			allowed:   true,
+			component: isNetworkReachabilityComponentFloatingIP,
+		},
+		"no floating IP from the internet": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.internet, f.web
+			},
+			allowed:   false,
+			component: isNetworkReachabilityComponentFloatingIP,
+		},
+		"private network outside the VPC to the VPC": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return &networkReachabilityEndpoint{name: "on-premises", network: testReachabilityNetwork("192.168.5.9")}, f.db
+			},
+			allowed: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			source, destination := tc.setup(newTestReachabilityFixture())
+			steps, allowed := evaluateNetworkReachabilityRouting(source, destination)
+			if allowed != tc.allowed {
+				t.Fatalf("evaluateNetworkReachabilityRouting() allowed = %t, expected %t: %+v", allowed, tc.allowed, steps)
+			}
+			if tc.component == "" {
+				if len(steps) != 0 {
+					t.Errorf("evaluateNetworkReachabilityRouting() returned steps %+v, expected none", steps)
+				}
+				return
+			}
+			if len(steps) != 1 {
+				t.Fatalf("evaluateNetworkReachabilityRouting() returned %d steps, expected 1", len(steps))
+			}
+			if steps[0].component != tc.component || steps[0].ruleID != tc.ruleID {
+				t.Errorf("unexpected step %+v", steps[0])
+			}
+		})
+	}
+}
+
+func TestEvaluateNetworkReachability(t *testing.T) {
+	sg, acl, fip := isNetworkReachabilityComponentSecurityGroup, isNetworkReachabilityComponentNetworkACL, isNetworkReachabilityComponentFloatingIP
+
+	for name, tc := range map[string]struct {
+		setup      func(f testReachabilityFixture) (source, destination *networkReachabilityEndpoint)
+		traffic    networkReachabilityTraffic
+		allowed    bool
+		reason     string
+		components []string
+	}{
+		// the replies meet the network ACLs on their way back
+		"across subnets": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.web, f.db
+			},
+			traffic:    testReachabilityTCP(5432),
+			allowed:    true,
+			reason:     "tcp traffic from 10.240.0.4/32 to 10.240.1.5/32 is allowed",
+			components: []string{sg, acl, acl, sg, acl, acl},
+		},
+		"denied by a network ACL": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.web, f.db
+			},
+			traffic:    testReachabilityTCP(22),
+			allowed:    false,
+			reason:     "rule deny-ssh of network ACL db denies the inbound tcp traffic",
+			components: []string{sg, acl, acl},
+		},
+		"denied by a security group": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.db, f.web
+			},
+			traffic:    testReachabilityTCP(8080),
+			allowed:    false,
+			reason:     "no inbound rule of the security groups of web allows the traffic",
+			components: []string{sg, acl, acl, sg},
+		},
+		// network ACLs and routes don't apply within a subnet
+		"same subnet": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				f.web.networkACL.Rules = nil
+				return f.web, f.web2
+			},
+			traffic:    testReachabilityTCP(443),
+			allowed:    true,
+			components: []string{sg, sg},
+		},
+		"from the internet": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				f.web.floatingIPs = []vpcv1.FloatingIPReference{{ID: core.StringPtr("fip-1"), Name: core.StringPtr("fip"), Address: core.StringPtr("198.51.100.1")}}
+				return f.internet, f.web
+			},
+			traffic:    testReachabilityTCP(443),
+			allowed:    true,
+			components: []string{fip, acl, sg, acl},
+		},
+		"from the internet without a floating IP": {
+			setup: func(f testReachabilityFixture) (*networkReachabilityEndpoint, *networkReachabilityEndpoint) {
+				return f.internet, f.web
+			},
+			traffic:    testReachabilityTCP(443),
+			allowed:    false,
+			reason:     "web has no floating IP to receive the traffic from the internet",
+			components: []string{fip},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			source, destination := tc.setup(newTestReachabilityFixture())
+			allowed, reason, steps := evaluateNetworkReachability(source, destination, tc.traffic)
+			if allowed != tc.allowed {
+				t.Fatalf("evaluateNetworkReachability() allowed = %t, expected %t: %s", allowed, tc.allowed, reason)
+			}
+			if tc.reason != "" && !strings.Contains(reason, tc.reason) {
+				t.Errorf("evaluateNetworkReachability() reason = %q, expected it to contain %q", reason, tc.reason)
+			}
+			components := make([]string, 0, len(steps))
+			for _, step := range steps {
+				components = append(components, step.component)
+			}
+			if !reflect.DeepEqual(components, tc.components) {
+				t.Errorf("evaluateNetworkReachability() evaluated %v, expected %v", components, tc.components)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+const (
+	isVPCTopologyVPC   = "vpc"
+	isVPCTopologyNodes = "nodes"
+	isVPCTopologyEdges = "edges"
+	isVPCTopologyJSON  = "json"
+	isVPCTopologyDOT   = "dot"
+)
+
+func DataSourceIBMISVPCTopology() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMISVPCTopologyRead,
+
+		Schema: map[string]*schema.Schema{
+			isVPCTopologyVPC: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the VPC",
+			},
+			isVPCTopologyNodes: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The resources of the VPC",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the resource",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the resource",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the resource",
+						},
+						"crn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The CRN of the resource, if it has one",
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The zone of the resource, if it is zonal",
+						},
+					},
+				},
+			},
+			isVPCTopologyEdges: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The relations between the resources of the VPC",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"from": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the dependent resource",
+						},
+						"to": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the resource it depends on",
+						},
+						"relation": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The relation between the resources",
+						},
+					},
+				},
+			},
+			isVPCTopologyJSON: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The topology as a JSON object with its nodes and edges",
+			},
+			isVPCTopologyDOT: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The topology as a Graphviz DOT digraph",
+			},
+		},
+	}
+}
+
+func dataSourceIBMISVPCTopologyRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vpcID := d.Get(isVPCTopologyVPC).(string)
+	vpc, response, err := sess.GetVPCWithContext(context, &vpcv1.GetVPCOptions{ID: &vpcID})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting VPC (%s): %s\n%s", vpcID, err, response))
+	}
+
+	topology, err := collectVPCTopology(context, sess, vpc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(vpcID)
+	nodes := []map[string]interface{}{}
+	for _, node := range topology.Nodes() {
+		nodes = append(nodes, map[string]interface{}{
+			"id":   node.ID,
+			"type": node.Type,
+			"name": node.Name,
+			"crn":  node.CRN,
+			"zone": node.Zone,
+		})
+	}
+	if err = d.Set(isVPCTopologyNodes, nodes); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting nodes: %s", err))
+	}
+	edges := []map[string]interface{}{}
+	for _, edge := range topology.Edges() {
+		edges = append(edges, map[string]interface{}{
+			"from":     edge.From,
+			"to":       edge.To,
+			"relation": edge.Relation,
+		})
+	}
+	if err = d.Set(isVPCTopologyEdges, edges); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting edges: %s", err))
+	}
+	topologyJSON, err := json.Marshal(topology)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error marshalling the topology of VPC (%s): %s", vpcID, err))
+	}
+	if err = d.Set(isVPCTopologyJSON, string(topologyJSON)); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting json: %s", err))
+	}
+	if err = d.Set(isVPCTopologyDOT, topology.DOT(*vpc.Name)); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting dot: %s", err))
+	}
+	return nil
+}
+
+// collectVPCTopology reads the resources of a VPC and returns them as a graph.
+func collectVPCTopology(context context.Context, sess *vpcv1.VpcV1, vpc *vpcv1.VPC) (*vpcTopology, error) {
+	vpcID := *vpc.ID
+	topology := newVPCTopology()
+	topology.addNode(vpcTopologyNode{ID: vpcID, Type: vpcTopologyVPC, Name: *vpc.Name, CRN: *vpc.CRN})
+
+	// subnets, with their public gateways
+	subnets := map[string]bool{}
+	start := ""
+	for {
+		options := &vpcv1.ListSubnetsOptions{VPCID: &vpcID}
+		if start != "" {
+			options.Start = &start
+		}
+		result, response, err := sess.ListSubnetsWithContext(context, options)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing subnets of VPC (%s): %s\n%s", vpcID, err, response)
+		}
+		for _, subnet := range result.Subnets {
+			subnets[*subnet.ID] = true
+			topology.addNode(vpcTopologyNode{ID: *subnet.ID, Type: vpcTopologySubnet, Name: *subnet.Name, CRN: *subnet.CRN, Zone: *subnet.Zone.Name})
+			topology.addEdge(*subnet.ID, vpcID, "in")
+			if subnet.RoutingTable != nil {
+				topology.addEdge(*subnet.ID, *subnet.RoutingTable.ID, "routing_table")
+			}
+			if pgw := subnet.PublicGateway; pgw != nil {
+				topology.addNode(vpcTopologyNode{ID: *pgw.ID, Type: vpcTopologyPublicGateway, Name: *pgw.Name, CRN: *pgw.CRN, Zone: *subnet.Zone.Name})
+				topology.addEdge(*subnet.ID, *pgw.ID, "public_gateway")
+				topology.addEdge(*pgw.ID, vpcID, "in")
+			}
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	// routing tables
+	start = ""
+	for {
+		options := &vpcv1.ListVPCRoutingTablesOptions{VPCID: &vpcID}
+		if start != "" {
+			options.Start = &start
+		}
+		result, response, err := sess.ListVPCRoutingTablesWithContext(context, options)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing routing tables of VPC (%s): %s\n%s", vpcID, err, response)
+		}
+		for _, table := range result.RoutingTables {
+			topology.addNode(vpcTopologyNode{ID: *table.ID, Type: vpcTopologyRoutingTable, Name: *table.Name, CRN: *table.CRN})
+			topology.addEdge(*table.ID, vpcID, "in")
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	// virtual network interfaces can't be listed by VPC
+	start = ""
+	for {
+		options := &vpcv1.ListVirtualNetworkInterfacesOptions{}
+		if start != "" {
+			options.Start = &start
+		}
+		result, response, err := sess.ListVirtualNetworkInterfacesWithContext(context, options)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing virtual network interfaces: %s\n%s", err, response)
+		}
+		for _, vni := range result.VirtualNetworkInterfaces {
+			if vni.VPC == nil || *vni.VPC.ID != vpcID {
+				continue
+			}
+			topology.addNode(vpcTopologyNode{ID: *vni.ID, Type: vpcTopologyVirtualNetworkInterface, Name: *vni.Name, CRN: *vni.CRN, Zone: *vni.Zone.Name})
+			topology.addEdge(*vni.ID, *vni.Subnet.ID, "in")
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	// instances
+	start = ""
+	for {
+		options := &vpcv1.ListInstancesOptions{VPCID: &vpcID}
+		if start != "" {
+			options.Start = &start
+		}
+		result, response, err := sess.ListInstancesWithContext(context, options)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing instances of VPC (%s): %s\n%s", vpcID, err, response)
+		}
+		for _, instance := range result.Instances {
+			topology.addNode(vpcTopologyNode{ID: *instance.ID, Type: vpcTopologyInstance, Name: *instance.Name, CRN: *instance.CRN, Zone: *instance.Zone.Name})
+			for _, attachment := range instance.NetworkAttachments {
+				if attachment.VirtualNetworkInterface != nil {
+					topology.addEdge(*instance.ID, *attachment.VirtualNetworkInterface.ID, "network_attachment")
+				}
+			}
+			for _, nic := range instance.NetworkInterfaces {
+				topology.addEdge(*instance.ID, *nic.Subnet.ID, "network_interface")
+			}
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	// bare metal servers
+	start = ""
+	for {
+		options := &vpcv1.ListBareMetalServersOptions{VPCID: &vpcID}
+		if start != "" {
+			options.Start = &start
+		}
+		result, response, err := sess.ListBareMetalServersWithContext(context, options)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing bare metal servers of VPC (%s): %s\n%s", vpcID, err, response)
+		}
+		for _, server := range result.BareMetalServers {
+			topology.addNode(vpcTopologyNode{ID: *server.ID, Type: vpcTopologyBareMetalServer, Name: *server.Name, CRN: *server.CRN, Zone: *server.Zone.Name})
+			for _, attachment := range server.NetworkAttachments {
+				if attachment.VirtualNetworkInterface != nil {
+					topology.addEdge(*server.ID, *attachment.VirtualNetworkInterface.ID, "network_attachment")
+				}
+			}
+			for _, nic := range server.NetworkInterfaces {
+				topology.addEdge(*server.ID, *nic.Subnet.ID, "network_interface")
+			}
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	// load balancers can't be listed by VPC, they are in the VPC of their
+	// subnets
+	start = ""
+	for {
+		options := &vpcv1.ListLoadBalancersOptions{}
+		if start != "" {
+			options.Start = &start
+		}
+		result, response, err := sess.ListLoadBalancersWithContext(context, options)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing load balancers: %s\n%s", err, response)
+		}
+		for _, lb := range result.LoadBalancers {
+			inVPC := false
+			for _, subnet := range lb.Subnets {
+				inVPC = inVPC || subnets[*subnet.ID]
+			}
+			if !inVPC {
+				continue
+			}
+			topology.addNode(vpcTopologyNode{ID: *lb.ID, Type: vpcTopologyLoadBalancer, Name: *lb.Name, CRN: *lb.CRN})
+			for _, subnet := range lb.Subnets {
+				topology.addEdge(*lb.ID, *subnet.ID, "in")
+			}
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	// VPN gateways can't be listed by VPC
+	start = ""
+	for {
+		options := &vpcv1.ListVPNGatewaysOptions{}
+		if start != "" {
+			options.Start = &start
+		}
+		result, response, err := sess.ListVPNGatewaysWithContext(context, options)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing VPN gateways: %s\n%s", err, response)
+		}
+		for _, item := range result.VPNGateways {
+			gateway, ok := item.(*vpcv1.VPNGateway)
+			if !ok || gateway.Subnet == nil || !subnets[*gateway.Subnet.ID] {
+				continue
+			}
+			topology.addNode(vpcTopologyNode{ID: *gateway.ID, Type: vpcTopologyVPNGateway, Name: *gateway.Name, CRN: *gateway.CRN})
+			topology.addEdge(*gateway.ID, *gateway.Subnet.ID, "in")
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	// endpoint gateways, in the subnets of their reserved IPs
+	start = ""
+	for {
+		options := &vpcv1.ListEndpointGatewaysOptions{VPCID: &vpcID}
+		if start != "" {
+			options.Start = &start
+		}
+		result, response, err := sess.ListEndpointGatewaysWithContext(context, options)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing endpoint gateways of VPC (%s): %s\n%s", vpcID, err, response)
+		}
+		for _, gateway := range result.EndpointGateways {
+			topology.addNode(vpcTopologyNode{ID: *gateway.ID, Type: vpcTopologyEndpointGateway, Name: *gateway.Name, CRN: *gateway.CRN})
+			inSubnet := false
+			for _, ip := range gateway.Ips {
+				if subnetID := vpcTopologyReservedIPSubnet(ip); subnetID != "" {
+					topology.addEdge(*gateway.ID, subnetID, "in")
+					inSubnet = true
+				}
+			}
+			if !inSubnet {
+				topology.addEdge(*gateway.ID, vpcID, "in")
+			}
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	return topology, nil
+}
+
+// vpcTopologyReservedIPSubnet returns the ID of the subnet of a reserved IP
+// from its href: .../subnets/<subnet>/reserved_ips/<reserved IP>
+func vpcTopologyReservedIPSubnet(ip vpcv1.ReservedIPReference) string {
+	if ip.Href == nil {
+		return ""
+	}
+	parts := strings.Split(*ip.Href, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "subnets" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMISVPCTopologyDataSource_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tf-subnet-%d", acctest.RandIntRange(10, 100))
+	vniname := fmt.Sprintf("tf-vni-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISVPCTopologyDataSourceConfig(vpcname, subnetname, vniname),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_is_vpc_topology.example", "nodes.0.type", "vpc"),
+					resource.TestCheckResourceAttr("data.ibm_is_vpc_topology.example", "nodes.0.name", vpcname),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ibm_is_vpc_topology.example", "nodes.*", map[string]string{
+						"type": "subnet",
+						"name": subnetname,
+						"zone": acc.ISZoneName,
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ibm_is_vpc_topology.example", "nodes.*", map[string]string{
+						"type": "virtual_network_interface",
+						"name": vniname,
+					}),
+					resource.TestCheckTypeSetElemAttrPair("data.ibm_is_vpc_topology.example", "edges.*.from", "ibm_is_virtual_network_interface.testacc_vni", "id"),
+					resource.TestCheckTypeSetElemAttrPair("data.ibm_is_vpc_topology.example", "edges.*.to", "ibm_is_subnet.testacc_subnet", "id"),
+					resource.TestMatchResourceAttr("data.ibm_is_vpc_topology.example", "json", regexp.MustCompile(`"relation":"in"`)),
+					resource.TestMatchResourceAttr("data.ibm_is_vpc_topology.example", "dot", regexp.MustCompile(`^digraph "`+vpcname+`" \{`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISVPCTopologyDataSourceConfig(vpcname, subnetname, vniname string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	}
+	resource "ibm_is_subnet" "testacc_subnet" {
+		name                     = "%s"
+		vpc                      = ibm_is_vpc.testacc_vpc.id
+		zone                     = "%s"
+		total_ipv4_address_count = 16
+	}
+	resource "ibm_is_virtual_network_interface" "testacc_vni" {
+		name   = "%s"
+		subnet = ibm_is_subnet.testacc_subnet.id
+	}
+	data "ibm_is_vpc_topology" "example" {
+		vpc        = ibm_is_vpc.testacc_vpc.id
+		depends_on = [ibm_is_virtual_network_interface.testacc_vni]
+	}
+	`, vpcname, subnetname, acc.ISZoneName, vniname)
+}
@@ -0,0 +1,335 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceIBMIsVPNGatewayConnectionPeerConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMIsVPNGatewayConnectionPeerConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"vpn_gateway": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The VPN gateway identifier.",
+			},
+			"vpn_gateway_connection": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The VPN gateway connection identifier.",
+			},
+			"device_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"device_type", "template"},
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{vpnPeerConfigStrongSwan, vpnPeerConfigLibreswan, vpnPeerConfigCiscoIOS, vpnPeerConfigJuniperSRX, vpnPeerConfigFortiGate}),
+				Description:  "The type of the peer device to render the configuration for.",
+			},
+			"template": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"device_type", "template"},
+				Description:  "A Go template to render the configuration with, for a device without a built-in template.",
+			},
+			"interface": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The interface of the peer device facing the internet.",
+			},
+			"file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The file path to save the configuration to.",
+			},
+			"configuration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The configuration of the peer device.",
+			},
+		},
+	}
+}
+
+func dataSourceIBMIsVPNGatewayConnectionPeerConfigRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	gatewayID := d.Get("vpn_gateway").(string)
+	connectionID := d.Get("vpn_gateway_connection").(string)
+
+	config, err := getVPNPeerConfig(context, sess, gatewayID, connectionID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	device := d.Get("device_type").(string)
+	text := vpnPeerConfigTemplates[device]
+	if v, ok := d.GetOk("template"); ok {
+		text = v.(string)
+	}
+	config.Interface = vpnPeerConfigDefaultInterfaces[device]
+	if v, ok := d.GetOk("interface"); ok {
+		config.Interface = v.(string)
+	}
+	config.SetNames(device)
+	configuration, err := config.Render(text)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error rendering the peer configuration of VPN gateway connection (%s): %s", connectionID, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", gatewayID, connectionID))
+	if v, ok := d.GetOk("file_path"); ok {
+		fileName := v.(string)
+		// The configuration contains the preshared key.
+		if err = os.WriteFile(fileName, []byte(configuration), 0600); err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error saving the peer configuration of VPN gateway connection (%s): %s", connectionID, err))
+		}
+		log.Printf("[DEBUG] Peer configuration of VPN gateway connection %s was saved to %s", connectionID, fileName)
+	}
+	if err = d.Set("configuration", configuration); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting configuration: %s", err))
+	}
+	return nil
+}
+
+// getVPNPeerConfig reads a VPN gateway connection, its VPN gateway and its IKE
+// and IPsec policies.
+func getVPNPeerConfig(context context.Context, sess *vpcv1.VpcV1, gatewayID, connectionID string) (*vpnPeerConfig, error) {
+	connectionIntf, response, err := sess.GetVPNGatewayConnectionWithContext(context, sess.NewGetVPNGatewayConnectionOptions(gatewayID, connectionID))
+	if err != nil || connectionIntf == nil {
+		return nil, fmt.Errorf("[ERROR] Error getting VPN gateway connection (%s): %s\n%s", connectionID, err, response)
+	}
+
+	config := &vpnPeerConfig{}
+	var (
+		ikePolicy       *vpcv1.IkePolicyReference
+		ipsecPolicy     *vpcv1.IPsecPolicyReference
+		dpd             *vpcv1.VPNGatewayConnectionDpd
+		localIdentities []vpcv1.VPNGatewayConnectionIkeIdentityIntf
+		tunnels         []vpcv1.VPNGatewayConnectionStaticRouteModeTunnel
+		psk             *string
+	)
+	switch connection := connectionIntf.(type) {
+	case *vpcv1.VPNGatewayConnectionPolicyMode:
+		config.Name, config.Mode = *connection.Name, *connection.Mode
+		ikePolicy, ipsecPolicy, dpd, psk = connection.IkePolicy, connection.IpsecPolicy, connection.DeadPeerDetection, connection.Psk
+		if connection.Local != nil {
+			config.GatewayCIDRs = connection.Local.CIDRs
+			localIdentities = connection.Local.IkeIdentities
+		}
+		config.Peer, err = vpnPeerConfigPolicyModePeer(connection.Peer)
+	case *vpcv1.VPNGatewayConnectionRouteMode:
+		config.Name, config.Mode = *connection.Name, *connection.Mode
+		ikePolicy, ipsecPolicy, dpd, psk = connection.IkePolicy, connection.IpsecPolicy, connection.DeadPeerDetection, connection.Psk
+		if connection.Local != nil {
+			localIdentities = connection.Local.IkeIdentities
+		}
+		tunnels = connection.Tunnels
+		config.Peer, err = vpnPeerConfigStaticRouteModePeer(connection.Peer)
+	case *vpcv1.VPNGatewayConnectionRouteModeVPNGatewayConnectionStaticRouteMode:
+		config.Name, config.Mode = *connection.Name, *connection.Mode
+		ikePolicy, ipsecPolicy, dpd, psk = connection.IkePolicy, connection.IpsecPolicy, connection.DeadPeerDetection, connection.Psk
+		if connection.Local != nil {
+			localIdentities = connection.Local.IkeIdentities
+		}
+		tunnels = connection.Tunnels
+		config.Peer, err = vpnPeerConfigStaticRouteModePeer(connection.Peer)
+	default:
+		return nil, fmt.Errorf("[ERROR] Unrecognized VPN gateway connection type %T", connectionIntf)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if psk != nil {
+		config.PSK = *psk
+	}
+	if config.Mode != "policy" {
+		config.Peer.CIDRs = []string{"0.0.0.0/0"}
+		config.GatewayCIDRs = []string{"0.0.0.0/0"}
+	}
+	for _, peerCIDR := range config.Peer.CIDRs {
+		for _, gatewayCIDR := range config.GatewayCIDRs {
+			config.TrafficSelectors = append(config.TrafficSelectors, vpnPeerConfigTrafficSelector{
+				Number:      len(config.TrafficSelectors) + 1,
+				PeerCIDR:    peerCIDR,
+				GatewayCIDR: gatewayCIDR,
+			})
+		}
+	}
+
+	gatewayIntf, response, err := sess.GetVPNGatewayWithContext(context, sess.NewGetVPNGatewayOptions(gatewayID))
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting VPN gateway (%s): %s\n%s", gatewayID, err, response)
+	}
+	gateway := gatewayIntf.(*vpcv1.VPNGateway)
+
+	// A policy mode gateway has an active and a standby member, and the peer
+	// connects to the active one. A route mode gateway has two active members
+	// with a tunnel each.
+	var addresses []string
+	if config.Mode == "policy" {
+		for _, member := range gateway.Members {
+			if member.PublicIP != nil && member.Role != nil && *member.Role == vpcv1.VPNGatewayMemberRoleActiveConst {
+				addresses = append(addresses, *member.PublicIP.Address)
+				break
+			}
+		}
+	} else {
+		for _, tunnel := range tunnels {
+			if tunnel.PublicIP != nil {
+				addresses = append(addresses, *tunnel.PublicIP.Address)
+			}
+		}
+	}
+	if len(addresses) == 0 {
+		for _, member := range gateway.Members {
+			if member.PublicIP != nil {
+				addresses = append(addresses, *member.PublicIP.Address)
+			}
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("[ERROR] VPN gateway (%s) has no public IP address", gatewayID)
+	}
+	// The first local IKE identity applies to the member with the lower
+	// public IP address.
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(net.ParseIP(addresses[i]).To16(), net.ParseIP(addresses[j]).To16()) < 0
+	})
+	for i, address := range addresses {
+		tunnel := vpnPeerConfigTunnel{
+			Number:             i + 1,
+			GatewayAddress:     address,
+			GatewayIkeIdentity: address,
+		}
+		if i < len(localIdentities) {
+			if identity := vpnPeerConfigIkeIdentity(localIdentities[i]); identity != "" {
+				tunnel.GatewayIkeIdentity = identity
+			}
+		}
+		config.Tunnels = append(config.Tunnels, tunnel)
+	}
+
+	config.IKE = vpnPeerConfigDefaultIKE
+	if ikePolicy != nil {
+		policy, response, err := sess.GetIkePolicyWithContext(context, sess.NewGetIkePolicyOptions(*ikePolicy.ID))
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error getting IKE policy (%s): %s\n%s", *ikePolicy.ID, err, response)
+		}
+		config.IKE = vpnPeerConfigIKE{
+			Version:                 *policy.IkeVersion,
+			AuthenticationAlgorithm: *policy.AuthenticationAlgorithm,
+			EncryptionAlgorithm:     *policy.EncryptionAlgorithm,
+			DhGroup:                 *policy.DhGroup,
+			KeyLifetime:             *policy.KeyLifetime,
+		}
+	}
+	config.IPsec = vpnPeerConfigDefaultIPsec
+	if ipsecPolicy != nil {
+		policy, response, err := sess.GetIpsecPolicyWithContext(context, sess.NewGetIpsecPolicyOptions(*ipsecPolicy.ID))
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error getting IPsec policy (%s): %s\n%s", *ipsecPolicy.ID, err, response)
+		}
+		config.IPsec = vpnPeerConfigIPsec{
+			AuthenticationAlgorithm: *policy.AuthenticationAlgorithm,
+			EncryptionAlgorithm:     *policy.EncryptionAlgorithm,
+			KeyLifetime:             *policy.KeyLifetime,
+		}
+		if *policy.Pfs != vpcv1.IPsecPolicyPfsDisabledConst {
+			config.IPsec.PfsGroup, err = strconv.ParseInt(strings.TrimPrefix(*policy.Pfs, "group_"), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("[ERROR] Unrecognized PFS group %s of IPsec policy (%s)", *policy.Pfs, *ipsecPolicy.ID)
+			}
+		}
+	}
+	config.DPD = vpnPeerConfigDPD{Action: vpcv1.VPNGatewayConnectionDpdActionNoneConst}
+	if dpd != nil {
+		config.DPD = vpnPeerConfigDPD{
+			Action:   *dpd.Action,
+			Interval: *dpd.Interval,
+			Timeout:  *dpd.Timeout,
+		}
+	}
+	return config, nil
+}
+
+// vpnPeerConfigIkeIdentity returns the value of an IKE identity, or "" if it
+// has none.
+func vpnPeerConfigIkeIdentity(identityIntf vpcv1.VPNGatewayConnectionIkeIdentityIntf) string {
+	var value *string
+	switch identity := identityIntf.(type) {
+	case *vpcv1.VPNGatewayConnectionIkeIdentityVPNGatewayConnectionIkeIdentityFqdn:
+		value = identity.Value
+	case *vpcv1.VPNGatewayConnectionIkeIdentityVPNGatewayConnectionIkeIdentityHostname:
+		value = identity.Value
+	case *vpcv1.VPNGatewayConnectionIkeIdentityVPNGatewayConnectionIkeIdentityIPv4:
+		value = identity.Value
+	case *vpcv1.VPNGatewayConnectionIkeIdentityVPNGatewayConnectionIkeIdentityKeyID:
+		value = identity.Value
+	case *vpcv1.VPNGatewayConnectionIkeIdentity:
+		value = identity.Value
+	}
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// newVPNPeerConfigPeer returns the peer of a connection with the address or
+// the FQDN of the peer, defaulting its IKE identity to it.
+func newVPNPeerConfigPeer(address, fqdn *string, identity vpcv1.VPNGatewayConnectionIkeIdentityIntf, cidrs []string) vpnPeerConfigPeer {
+	peer := vpnPeerConfigPeer{CIDRs: cidrs}
+	if address != nil {
+		peer.Address = *address
+	} else if fqdn != nil {
+		peer.Address = *fqdn
+	}
+	peer.IkeIdentity = vpnPeerConfigIkeIdentity(identity)
+	if peer.IkeIdentity == "" {
+		peer.IkeIdentity = peer.Address
+	}
+	return peer
+}
+
+func vpnPeerConfigPolicyModePeer(peerIntf vpcv1.VPNGatewayConnectionPolicyModePeerIntf) (vpnPeerConfigPeer, error) {
+	switch peer := peerIntf.(type) {
+	case *vpcv1.VPNGatewayConnectionPolicyModePeerVPNGatewayConnectionPeerByAddress:
+		return newVPNPeerConfigPeer(peer.Address, nil, peer.IkeIdentity, peer.CIDRs), nil
+	case *vpcv1.VPNGatewayConnectionPolicyModePeerVPNGatewayConnectionPeerByFqdn:
+		return newVPNPeerConfigPeer(nil, peer.Fqdn, peer.IkeIdentity, peer.CIDRs), nil
+	case *vpcv1.VPNGatewayConnectionPolicyModePeer:
+		return newVPNPeerConfigPeer(peer.Address, peer.Fqdn, peer.IkeIdentity, peer.CIDRs), nil
+	}
+	return vpnPeerConfigPeer{}, fmt.Errorf("[ERROR] Unrecognized VPN gateway connection peer type %T", peerIntf)
+}
+
+func vpnPeerConfigStaticRouteModePeer(peerIntf vpcv1.VPNGatewayConnectionStaticRouteModePeerIntf) (vpnPeerConfigPeer, error) {
+	switch peer := peerIntf.(type) {
+	case *vpcv1.VPNGatewayConnectionStaticRouteModePeerVPNGatewayConnectionPeerByAddress:
+		return newVPNPeerConfigPeer(peer.Address, nil, peer.IkeIdentity, nil), nil
+	case *vpcv1.VPNGatewayConnectionStaticRouteModePeerVPNGatewayConnectionPeerByFqdn:
+		return newVPNPeerConfigPeer(nil, peer.Fqdn, peer.IkeIdentity, nil), nil
+	case *vpcv1.VPNGatewayConnectionStaticRouteModePeer:
+		return newVPNPeerConfigPeer(peer.Address, peer.Fqdn, peer.IkeIdentity, nil), nil
+	}
+	return vpnPeerConfigPeer{}, fmt.Errorf("[ERROR] Unrecognized VPN gateway connection peer type %T", peerIntf)
+}
@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMIsVPNGatewayConnectionPeerConfigDataSourceBasic(t *testing.T) {
+	vpcname := fmt.Sprintf("tfvpnuat-vpc-%d", acctest.RandIntRange(100, 200))
+	subnetname := fmt.Sprintf("tfvpnuat-subnet-%d", acctest.RandIntRange(100, 200))
+	vpngwname := fmt.Sprintf("tfvpnuat-vpngw-%d", acctest.RandIntRange(100, 200))
+	name := fmt.Sprintf("tfvpnuat-createname-%d", acctest.RandIntRange(100, 200))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMIsVPNGatewayConnectionPeerConfigDataSourceConfigBasic(vpcname, subnetname, vpngwname, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.strongswan", "configuration", regexp.MustCompile(`conn `+name+`-1`)),
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.strongswan", "configuration", regexp.MustCompile(`ike=aes256-sha256-modp2048!`)),
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.strongswan", "configuration", regexp.MustCompile(`PSK "VPNDemoPassword"`)),
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.cisco", "configuration", regexp.MustCompile(`crypto ikev2 proposal `+name)),
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.juniper", "configuration", regexp.MustCompile(`set security ike gateway `+name+`-1 address`)),
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.fortigate", "configuration", regexp.MustCompile(`set interface "port1"`)),
+					resource.TestCheckResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.custom", "configuration", "aes256-sha256-14 1.2.3.4"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMIsVPNGatewayConnectionPeerConfigDataSourceConfigBasic(vpc, subnet, vpngwname, name string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "example" {
+		name = "%s"
+	}
+	resource "ibm_is_subnet" "example" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.example.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	}
+	resource "ibm_is_vpn_gateway" "example" {
+		name   = "%s"
+		subnet = ibm_is_subnet.example.id
+		mode   = "policy"
+	}
+	resource "ibm_is_ike_policy" "example" {
+		name                     = "%s-ike"
+		authentication_algorithm = "sha256"
+		encryption_algorithm     = "aes256"
+		dh_group                 = 14
+		ike_version              = 2
+	}
+	resource "ibm_is_vpn_gateway_connection" "example" {
+		name          = "%s"
+		vpn_gateway   = ibm_is_vpn_gateway.example.id
+		peer_address  = "1.2.3.4"
+		peer_cidrs    = ["192.168.0.0/24"]
+		local_cidrs   = [ibm_is_subnet.example.ipv4_cidr_block]
+		preshared_key = "VPNDemoPassword"
+		ike_policy    = ibm_is_ike_policy.example.id
+	}
+	data "ibm_is_vpn_gateway_connection_peer_config" "strongswan" {
+		vpn_gateway            = ibm_is_vpn_gateway.example.id
+		vpn_gateway_connection = ibm_is_vpn_gateway_connection.example.gateway_connection
+		device_type            = "strongswan"
+	}
+	data "ibm_is_vpn_gateway_connection_peer_config" "cisco" {
+		vpn_gateway            = ibm_is_vpn_gateway.example.id
+		vpn_gateway_connection = ibm_is_vpn_gateway_connection.example.gateway_connection
+		device_type            = "cisco_ios"
+	}
+	data "ibm_is_vpn_gateway_connection_peer_config" "juniper" {
+		vpn_gateway            = ibm_is_vpn_gateway.example.id
+		vpn_gateway_connection = ibm_is_vpn_gateway_connection.example.gateway_connection
+		device_type            = "juniper_srx"
+	}
+	data "ibm_is_vpn_gateway_connection_peer_config" "fortigate" {
+		vpn_gateway            = ibm_is_vpn_gateway.example.id
+		vpn_gateway_connection = ibm_is_vpn_gateway_connection.example.gateway_connection
+		device_type            = "fortigate"
+		interface              = "port1"
+	}
+	data "ibm_is_vpn_gateway_connection_peer_config" "custom" {
+		vpn_gateway            = ibm_is_vpn_gateway.example.id
+		vpn_gateway_connection = ibm_is_vpn_gateway_connection.example.gateway_connection
+		template               = "{{ .IKE.EncryptionAlgorithm }}-{{ .IKE.AuthenticationAlgorithm }}-{{ .IKE.DhGroup }} {{ .Peer.Address }}"
+	}
+	`, vpc, subnet, acc.ISZoneName, acc.ISCIDR, vpngwname, name, name)
+}
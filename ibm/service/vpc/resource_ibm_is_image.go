@@ -5,13 +5,24 @@ package vpc
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/cos"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/aws/request"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3/s3manager"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -49,6 +60,17 @@ const (
 	isImageDeprecate      = "deprecate"
 	isImageObsolete       = "obsolete"
 	isImageUserDataFormat = "user_data_format"
+
+	isImageSourceFile                  = "source_file"
+	isImageSourceFileChecksum          = "source_file_checksum"
+	isImageSourceFileStaging           = "source_file_staging"
+	isImageStagingBucketCRN            = "bucket_crn"
+	isImageStagingBucketLocation       = "bucket_location"
+	isImageStagingEndpointType         = "endpoint_type"
+	isImageStagingKey                  = "key"
+	isImageStagingDeleteAfterImport    = "delete_after_import"
+	isImageSourceFileUploadPartSize    = 64 * 1024 * 1024
+	isImageSourceFileUploadConcurrency = 4
 )
 
 func ResourceIBMISImage() *schema.Resource {
@@ -76,6 +98,10 @@ func ResourceIBMISImage() *schema.Resource {
 				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
 					return flex.ResourceValidateAccessTags(diff, v)
 				}),
+			customdiff.Sequence(
+				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+					return imgSourceFileChecksumCustomizeDiff(diff)
+				}),
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -85,10 +111,74 @@ func ResourceIBMISImage() *schema.Resource {
 				Computed:         true,
 				DiffSuppressFunc: flex.ApplyOnce,
 				RequiredWith:     []string{isImageOperatingSystem},
-				ExactlyOneOf:     []string{isImageHref, isImageVolume},
+				ExactlyOneOf:     []string{isImageHref, isImageVolume, isImageSourceFile},
 				Description:      "Image Href value",
 			},
 
+			isImageSourceFile: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{isImageOperatingSystem, isImageSourceFileStaging},
+				ExactlyOneOf: []string{isImageHref, isImageVolume, isImageSourceFile},
+				Description:  "The path of a local qcow2 or vhd image file to upload to Cloud Object Storage and create the image from",
+			},
+
+			isImageSourceFileChecksum: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				RequiredWith: []string{isImageSourceFile},
+				Description:  "The SHA256 checksum of source_file. It is verified before the upload and against the checksum of the image",
+			},
+
+			isImageSourceFileStaging: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				RequiredWith: []string{isImageSourceFile},
+				Description:  "The Cloud Object Storage bucket source_file is uploaded to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isImageStagingBucketCRN: {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The CRN of the bucket",
+						},
+						isImageStagingBucketLocation: {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The location of the bucket",
+						},
+						isImageStagingEndpointType: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "public",
+							ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private", "direct"}),
+							Description:  "The type of the endpoint used to upload the file: public, private or direct",
+						},
+						isImageStagingKey: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The key of the object the file is uploaded to. Defaults to the name of the file",
+						},
+						isImageStagingDeleteAfterImport: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     false,
+							Description: "Whether to delete the object once the image is created",
+						},
+					},
+				},
+			},
+
 			isImageName: {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -148,12 +238,12 @@ func ResourceIBMISImage() *schema.Resource {
 			},
 
 			isImageOperatingSystem: {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				RequiredWith: []string{isImageHref},
-				Computed:     true,
-				Description:  "Image Operating system",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{isImageVolume},
+				Computed:      true,
+				Description:   "Image Operating system",
 			},
 
 			isImageEncryption: {
@@ -189,7 +279,7 @@ func ResourceIBMISImage() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ExactlyOneOf: []string{isImageHref, isImageVolume},
+				ExactlyOneOf: []string{isImageHref, isImageVolume, isImageSourceFile},
 				Description:  "Image volume id",
 			},
 
@@ -300,12 +390,18 @@ func resourceIBMISImageCreate(d *schema.ResourceData, meta interface{}) error {
 	name := d.Get(isImageName).(string)
 	operatingSystem := d.Get(isImageOperatingSystem).(string)
 	volume := d.Get(isImageVolume).(string)
+	sourceFile := d.Get(isImageSourceFile).(string)
 
 	if volume != "" {
 		err := imgCreateByVolume(d, meta, name, volume)
 		if err != nil {
 			return err
 		}
+	} else if sourceFile != "" {
+		err := imgCreateBySourceFile(d, meta, sourceFile, name, operatingSystem)
+		if err != nil {
+			return err
+		}
 	} else {
 		err := imgCreateByFile(d, meta, href, name, operatingSystem)
 		if err != nil {
@@ -393,6 +489,179 @@ func imgCreateByFile(d *schema.ResourceData, meta interface{}, href, name, opera
 	}
 	return nil
 }
+
+// imgCreateBySourceFile uploads a local image file to a staging bucket and
+// creates the image from the uploaded object.
+func imgCreateBySourceFile(d *schema.ResourceData, meta interface{}, sourceFile, name, operatingSystem string) error {
+	staging := d.Get(isImageSourceFileStaging).([]interface{})[0].(map[string]interface{})
+	bucketCRN := staging[isImageStagingBucketCRN].(string)
+	crnParts := strings.Split(bucketCRN, ":bucket:")
+	if len(crnParts) != 2 || crnParts[1] == "" {
+		return fmt.Errorf("[ERROR] Invalid bucket_crn provided (%s): must be the CRN of a Cloud Object Storage bucket", bucketCRN)
+	}
+	bucketName := crnParts[1]
+	instanceCRN := fmt.Sprintf("%s::", crnParts[0])
+	bucketLocation := staging[isImageStagingBucketLocation].(string)
+	endpointType := staging[isImageStagingEndpointType].(string)
+	key := staging[isImageStagingKey].(string)
+	if key == "" {
+		key = filepath.Base(sourceFile)
+	}
+
+	// The file may have changed since the plan computed its checksum.
+	checksum, err := imgSourceFileChecksum(sourceFile)
+	if err != nil {
+		return err
+	}
+	if expected := d.Get(isImageSourceFileChecksum).(string); expected != "" && !strings.EqualFold(expected, checksum) {
+		return fmt.Errorf("[ERROR] The SHA256 checksum of source_file (%s) is %s, expected %s", sourceFile, checksum, expected)
+	}
+	d.Set(isImageSourceFileChecksum, checksum)
+
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return err
+	}
+	s3Client, err := cos.GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	if err != nil {
+		return err
+	}
+	if err = imgUploadSourceFile(s3Client, sourceFile, bucketName, key); err != nil {
+		return err
+	}
+	if staging[isImageStagingDeleteAfterImport].(bool) {
+		defer func() {
+			_, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+				Bucket: aws.String(bucketName),
+				Key:    aws.String(key),
+			})
+			if err != nil {
+				log.Printf("[WARN] Error deleting the staging object (%s) of image %s from COS bucket (%s): %s", key, name, bucketName, err)
+			}
+		}()
+	}
+
+	href := fmt.Sprintf("cos://%s/%s/%s", bucketLocation, bucketName, key)
+	if err = imgCreateByFile(d, meta, href, name, operatingSystem); err != nil {
+		return err
+	}
+
+	// The checksum of the image is the checksum of the object it was created
+	// from, which catches an upload that was corrupted.
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	id := d.Id()
+	image, response, err := sess.GetImage(&vpcv1.GetImageOptions{ID: &id})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error Getting Image (%s): %s\n%s", id, err, response)
+	}
+	if image.File != nil && image.File.Checksums != nil && image.File.Checksums.Sha256 != nil && !strings.EqualFold(*image.File.Checksums.Sha256, checksum) {
+		return fmt.Errorf("[ERROR] The SHA256 checksum of image (%s) is %s, expected the checksum of source_file %s", id, *image.File.Checksums.Sha256, checksum)
+	}
+	return nil
+}
+
+// imgSourceFileChecksumCustomizeDiff plans the SHA256 checksum of source_file,
+// so that a change of the file content replaces the image. A configured
+// checksum must match the file.
+func imgSourceFileChecksumCustomizeDiff(diff *schema.ResourceDiff) error {
+	expected := diff.GetRawConfig().GetAttr(isImageSourceFileChecksum)
+	if !diff.NewValueKnown(isImageSourceFile) || !expected.IsKnown() {
+		return nil
+	}
+	sourceFile := diff.Get(isImageSourceFile).(string)
+	if sourceFile == "" {
+		return nil
+	}
+	checksum, err := imgSourceFileChecksum(sourceFile)
+	if err != nil {
+		// The file of an existing image may be gone, which doesn't change the
+		// image.
+		if diff.Id() != "" && !diff.HasChange(isImageSourceFile) {
+			log.Printf("[WARN] Error computing the checksum of source_file of image (%s): %s", diff.Id(), err)
+			return nil
+		}
+		return err
+	}
+
+	if !expected.IsNull() {
+		if !strings.EqualFold(expected.AsString(), checksum) {
+			return fmt.Errorf("[ERROR] The SHA256 checksum of source_file (%s) is %s, expected %s", sourceFile, checksum, expected.AsString())
+		}
+		return nil
+	}
+	if old, _ := diff.GetChange(isImageSourceFileChecksum); strings.EqualFold(old.(string), checksum) {
+		return nil
+	}
+	if err := diff.SetNew(isImageSourceFileChecksum, checksum); err != nil {
+		return err
+	}
+	if diff.Id() != "" {
+		return diff.ForceNew(isImageSourceFileChecksum)
+	}
+	return nil
+}
+
+func imgSourceFileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error opening image file (%s): %s", path, err)
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("[ERROR] Error reading image file (%s): %s", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// imgUploadSourceFile uploads an image file to a COS bucket in parts, and
+// checks that the object has the size of the file. Each part is sent with its
+// Content-MD5, which COS verifies, even when the client disables the MD5
+// validation.
+func imgUploadSourceFile(s3Client *s3.S3, path, bucketName, key string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error opening image file (%s): %s", path, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error reading image file (%s): %s", path, err)
+	}
+
+	log.Printf("[INFO] Uploading image file (%s) to COS bucket (%s) as %s", path, bucketName, key)
+	uploader := s3manager.NewUploaderWithClient(s3Client, func(u *s3manager.Uploader) {
+		u.PartSize = isImageSourceFileUploadPartSize
+		u.Concurrency = isImageSourceFileUploadConcurrency
+		u.RequestOptions = append(u.RequestOptions, func(r *request.Request) {
+			r.Config.S3DisableContentMD5Validation = aws.Bool(false)
+		})
+	})
+	_, err = uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+		Body:   file,
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error uploading image file (%s) to COS bucket (%s): %s", path, bucketName, err)
+	}
+
+	head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error getting object (%s) in COS bucket (%s): %s", key, bucketName, err)
+	}
+	if aws.Int64Value(head.ContentLength) != info.Size() {
+		return fmt.Errorf("[ERROR] The object (%s) in COS bucket (%s) has %d bytes, expected the %d bytes of image file (%s)", key, bucketName, aws.Int64Value(head.ContentLength), info.Size(), path)
+	}
+	return nil
+}
+
 func imgCreateByVolume(d *schema.ResourceData, meta interface{}, name, volume string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
@@ -40,6 +40,31 @@ func TestAccIBMISImage_basic(t *testing.T) {
 		},
 	})
 }
+func TestAccIBMISImage_sourceFile(t *testing.T) {
+	var image string
+	name := fmt.Sprintf("tfimg-name-%d", acctest.RandIntRange(10, 100))
+	key := fmt.Sprintf("tfimg-source-%d.qcow2", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheckImageSourceFile(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: checkImageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISImageSourceFileConfig(name, key),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISImageExists("ibm_is_image.isExampleImage", image),
+					resource.TestCheckResourceAttr(
+						"ibm_is_image.isExampleImage", "name", name),
+					resource.TestCheckResourceAttr(
+						"ibm_is_image.isExampleImage", "status", "available"),
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_image.isExampleImage", "source_file_checksum", "ibm_is_image.isExampleImage", "checksum"),
+				),
+			},
+		},
+	})
+}
 func TestAccIBMISImage_lifecycle(t *testing.T) {
 	var image string
 	name := fmt.Sprintf("tfimg-name-%d", acctest.RandIntRange(10, 100))
@@ -289,6 +314,21 @@ func testAccCheckIBMISImageConfig(name string) string {
 		}
 	`, acc.Image_cos_url, name, acc.Image_operating_system)
 }
+func testAccCheckIBMISImageSourceFileConfig(name, key string) string {
+	return fmt.Sprintf(`
+		resource "ibm_is_image" "isExampleImage" {
+			source_file = "%s"
+			name = "%s"
+			operating_system = "%s"
+			source_file_staging {
+				bucket_crn = "%s"
+				bucket_location = "%s"
+				key = "%s"
+				delete_after_import = true
+			}
+		}
+	`, acc.Image_source_file, name, acc.Image_operating_system, acc.IsCosBucketCRN, acc.RegionName, key)
+}
 func testAccCheckIBMISImageLifecycleConfig(name, deprecationAt, obsolescenceAt string) string {
 	return fmt.Sprintf(`
 		resource "ibm_is_image" "isExampleImage" {
@@ -0,0 +1,367 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	isLBPoolMembers  = "member"
+	isLBPoolMemberID = "id"
+)
+
+func ResourceIBMISLBPoolMembers() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMISLBPoolMembersCreate,
+		Read:     resourceIBMISLBPoolMembersRead,
+		Update:   resourceIBMISLBPoolMembersUpdate,
+		Delete:   resourceIBMISLBPoolMembersDelete,
+		Importer: &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			isLBID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Load balancer ID",
+			},
+
+			isLBPoolID: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressLBPoolIDDiff,
+				Description:      "Load balancer pool ID",
+			},
+
+			isLBPoolMembers: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         resourceIBMISLBPoolMemberHash,
+				Description: "The members of the load balancer pool. Members of the pool that are not listed are removed.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isLBPoolMemberPort: {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The port the member receives load balancer traffic on",
+						},
+						isLBPoolMemberTargetAddress: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The IP address of the member target",
+						},
+						isLBPoolMemberTargetID: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the virtual server instance of the member target",
+						},
+						isLBPoolMemberWeight: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validate.InvokeValidator("ibm_is_lb_pool_member", isLBPoolMemberWeight),
+							Description:  "The weight of the member. Only applies to pools with the weighted_round_robin algorithm",
+						},
+						isLBPoolMemberID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the member",
+						},
+						isLBPoolMemberHealth: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The health of the member",
+						},
+						isLBPoolMemberProvisioningStatus: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The provisioning status of the member",
+						},
+					},
+				},
+			},
+
+			flex.RelatedCRN: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The crn of the LB resource",
+			},
+		},
+	}
+}
+
+func resourceIBMISLBPoolMembersCreate(d *schema.ResourceData, meta interface{}) error {
+	lbID := d.Get(isLBID).(string)
+	lbPoolID, err := getPoolId(d.Get(isLBPoolID).(string))
+	if err != nil {
+		return err
+	}
+
+	err = lbpMembersReplace(meta, lbID, lbPoolID, d.Get(isLBPoolMembers).(*schema.Set).List(), lbpMembersWithWeight(d), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s/%s", lbID, lbPoolID))
+
+	return resourceIBMISLBPoolMembersRead(d, meta)
+}
+
+// lbpMembersReplace replaces the members of the pool in a single request and
+// waits once for the load balancer to apply it. withWeight holds the hashes of
+// the members that set their weight in the configuration.
+func lbpMembersReplace(meta interface{}, lbID, lbPoolID string, members []interface{}, withWeight map[int]bool, timeout time.Duration) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+
+	prototypes := make([]vpcv1.LoadBalancerPoolMemberPrototype, 0, len(members))
+	for _, m := range members {
+		member := m.(map[string]interface{})
+		port := int64(member[isLBPoolMemberPort].(int))
+		prototype := vpcv1.LoadBalancerPoolMemberPrototype{
+			Port: &port,
+		}
+		targetAddress := member[isLBPoolMemberTargetAddress].(string)
+		targetID := member[isLBPoolMemberTargetID].(string)
+		switch {
+		case targetAddress != "" && targetID != "":
+			return fmt.Errorf("[ERROR] Only one of target_address and target_id can be set for the member on port %d", port)
+		case targetAddress != "":
+			prototype.Target = &vpcv1.LoadBalancerPoolMemberTargetPrototypeIP{
+				Address: &targetAddress,
+			}
+		case targetID != "":
+			prototype.Target = &vpcv1.LoadBalancerPoolMemberTargetPrototype{
+				ID: &targetID,
+			}
+		default:
+			return fmt.Errorf("[ERROR] One of target_address and target_id must be set for the member on port %d", port)
+		}
+		// The weight can only be set for pools with the weighted_round_robin algorithm.
+		// A configured weight of 0 is sent as well, it drains the member.
+		if weight := int64(member[isLBPoolMemberWeight].(int)); weight != 0 || withWeight[resourceIBMISLBPoolMemberHash(member)] {
+			prototype.Weight = &weight
+		}
+		prototypes = append(prototypes, prototype)
+	}
+
+	isLBKey := "load_balancer_key_" + lbID
+	conns.IbmMutexKV.Lock(isLBKey)
+	defer conns.IbmMutexKV.Unlock(isLBKey)
+
+	_, err = isWaitForLBPoolActive(sess, lbID, lbPoolID, timeout)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error checking for load balancer pool (%s) is active: %s", lbPoolID, err)
+	}
+
+	_, err = isWaitForLBAvailable(sess, lbID, timeout)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error checking for load balancer (%s) is active: %s", lbID, err)
+	}
+
+	options := &vpcv1.ReplaceLoadBalancerPoolMembersOptions{
+		LoadBalancerID: &lbID,
+		PoolID:         &lbPoolID,
+		Members:        prototypes,
+	}
+	_, response, err := sess.ReplaceLoadBalancerPoolMembers(options)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error Replacing Load Balancer Pool Members: %s\n%s", err, response)
+	}
+	log.Printf("[INFO] Replaced the members of load balancer pool (%s) with %d members", lbPoolID, len(prototypes))
+
+	_, err = isWaitForLBPoolActive(sess, lbID, lbPoolID, timeout)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error checking for load balancer pool (%s) is active: %s", lbPoolID, err)
+	}
+
+	_, err = isWaitForLBAvailable(sess, lbID, timeout)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error checking for load balancer (%s) is active: %s", lbID, err)
+	}
+
+	return nil
+}
+
+func resourceIBMISLBPoolMembersRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+
+	parts, err := flex.IdParts(d.Id())
+	if err != nil {
+		return err
+	}
+	if len(parts) < 2 {
+		return fmt.Errorf("[ERROR] The id should contain loadbalancer Id and loadbalancer pool Id")
+	}
+	lbID := parts[0]
+	lbPoolID := parts[1]
+
+	listLoadBalancerPoolMembersOptions := &vpcv1.ListLoadBalancerPoolMembersOptions{
+		LoadBalancerID: &lbID,
+		PoolID:         &lbPoolID,
+	}
+	collection, response, err := sess.ListLoadBalancerPoolMembers(listLoadBalancerPoolMembersOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error Listing Load Balancer Pool Members: %s\n%s", err, response)
+	}
+
+	members := make([]interface{}, 0, len(collection.Members))
+	for _, lbPoolMem := range collection.Members {
+		member := map[string]interface{}{
+			isLBPoolMemberPort:               int(*lbPoolMem.Port),
+			isLBPoolMemberID:                 *lbPoolMem.ID,
+			isLBPoolMemberHealth:             *lbPoolMem.Health,
+			isLBPoolMemberProvisioningStatus: *lbPoolMem.ProvisioningStatus,
+		}
+		if target, ok := lbPoolMem.Target.(*vpcv1.LoadBalancerPoolMemberTarget); ok {
+			if target.ID != nil {
+				member[isLBPoolMemberTargetID] = *target.ID
+			} else if target.Address != nil {
+				member[isLBPoolMemberTargetAddress] = *target.Address
+			}
+		}
+		if lbPoolMem.Weight != nil {
+			member[isLBPoolMemberWeight] = int(*lbPoolMem.Weight)
+		}
+		members = append(members, member)
+	}
+	d.Set(isLBID, lbID)
+	d.Set(isLBPoolID, lbPoolID)
+	if err := d.Set(isLBPoolMembers, schema.NewSet(resourceIBMISLBPoolMemberHash, members)); err != nil {
+		return fmt.Errorf("[ERROR] Error setting member: %s", err)
+	}
+
+	getLoadBalancerOptions := &vpcv1.GetLoadBalancerOptions{
+		ID: &lbID,
+	}
+	lb, response, err := sess.GetLoadBalancer(getLoadBalancerOptions)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error Getting Load Balancer : %s\n%s", err, response)
+	}
+	d.Set(flex.RelatedCRN, *lb.CRN)
+	return nil
+}
+
+func resourceIBMISLBPoolMembersUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange(isLBPoolMembers) {
+		parts, err := flex.IdParts(d.Id())
+		if err != nil {
+			return err
+		}
+		err = lbpMembersReplace(meta, parts[0], parts[1], d.Get(isLBPoolMembers).(*schema.Set).List(), lbpMembersWithWeight(d), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+	}
+	return resourceIBMISLBPoolMembersRead(d, meta)
+}
+
+func resourceIBMISLBPoolMembersDelete(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+
+	parts, err := flex.IdParts(d.Id())
+	if err != nil {
+		return err
+	}
+	lbID := parts[0]
+	lbPoolID := parts[1]
+
+	getlbpOptions := &vpcv1.GetLoadBalancerPoolOptions{
+		LoadBalancerID: &lbID,
+		ID:             &lbPoolID,
+	}
+	_, response, err := sess.GetLoadBalancerPool(getlbpOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error Getting Load Balancer Pool: %s\n%s", err, response)
+	}
+
+	err = lbpMembersReplace(meta, lbID, lbPoolID, nil, nil, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// suppressLBPoolIDDiff suppresses the diff between a pool ID and the ID of
+// ibm_is_lb_pool, which also contains the load balancer ID.
+func suppressLBPoolIDDiff(k, o, n string, d *schema.ResourceData) bool {
+	if o == "" {
+		return false
+	}
+	poolID, err := getPoolId(n)
+	return err == nil && poolID == o
+}
+
+// lbpMembersWithWeight returns the hashes of the members that set a weight in
+// the configuration. The weight of a set element can't be told apart from its
+// zero value with GetOkExists, so it is looked up in the raw configuration.
+func lbpMembersWithWeight(d *schema.ResourceData) map[int]bool {
+	withWeight := map[int]bool{}
+	members := d.GetRawConfig().GetAttr(isLBPoolMembers)
+	if members.IsNull() || !members.IsKnown() {
+		return withWeight
+	}
+	for it := members.ElementIterator(); it.Next(); {
+		_, member := it.Element()
+		if member.IsNull() || !member.IsKnown() || member.GetAttr(isLBPoolMemberWeight).IsNull() {
+			continue
+		}
+		key := map[string]interface{}{}
+		for _, attr := range []string{isLBPoolMemberTargetID, isLBPoolMemberTargetAddress} {
+			if v := member.GetAttr(attr); v.IsKnown() && !v.IsNull() {
+				key[attr] = v.AsString()
+			}
+		}
+		if v := member.GetAttr(isLBPoolMemberPort); v.IsKnown() && !v.IsNull() {
+			port, _ := v.AsBigFloat().Int64()
+			key[isLBPoolMemberPort] = int(port)
+		}
+		withWeight[resourceIBMISLBPoolMemberHash(key)] = true
+	}
+	return withWeight
+}
+
+// resourceIBMISLBPoolMemberHash hashes a member by its target and port, so
+// that a change of its weight updates the member in place.
+func resourceIBMISLBPoolMemberHash(v interface{}) int {
+	member := v.(map[string]interface{})
+	target, _ := member[isLBPoolMemberTargetID].(string)
+	if target == "" {
+		target, _ = member[isLBPoolMemberTargetAddress].(string)
+	}
+	port, _ := member[isLBPoolMemberPort].(int)
+	return schema.HashString(fmt.Sprintf("%s:%d", target, port))
+}
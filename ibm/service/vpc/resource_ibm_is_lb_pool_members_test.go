@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMISLBPoolMembers_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tflbpms-vpc-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tflbpms-name-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tfcreate%d", acctest.RandIntRange(10, 100))
+	poolName := fmt.Sprintf("tflbpools%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMISLBPoolMembersDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISLBPoolMembersConfig(vpcname, subnetname, acc.ISZoneName, acc.ISCIDR, name, poolName, []string{"192.168.0.1", "192.168.0.2", "192.168.0.3"}, 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_lb_pool_members.testacc_lb_members", "member.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"ibm_is_lb_pool_members.testacc_lb_members", "member.*", map[string]string{
+							"target_address": "192.168.0.2",
+							"port":           "8080",
+							"weight":         "60",
+						}),
+				),
+			},
+			{
+				Config: testAccCheckIBMISLBPoolMembersConfig(vpcname, subnetname, acc.ISZoneName, acc.ISCIDR, name, poolName, []string{"192.168.0.1", "192.168.0.4"}, 80),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_lb_pool_members.testacc_lb_members", "member.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"ibm_is_lb_pool_members.testacc_lb_members", "member.*", map[string]string{
+							"target_address": "192.168.0.4",
+							"weight":         "80",
+						}),
+				),
+			},
+			{
+				ResourceName:      "ibm_is_lb_pool_members.testacc_lb_members",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISLBPoolMembersDestroy(s *terraform.State) error {
+	sess, _ := acc.TestAccProvider.Meta().(conns.ClientSession).VpcV1API()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_is_lb_pool_members" {
+			continue
+		}
+		parts, err := flex.IdParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		listLoadBalancerPoolMembersOptions := &vpcv1.ListLoadBalancerPoolMembersOptions{
+			LoadBalancerID: &parts[0],
+			PoolID:         &parts[1],
+		}
+		members, _, err := sess.ListLoadBalancerPoolMembers(listLoadBalancerPoolMembersOptions)
+		if err == nil && len(members.Members) > 0 {
+			return fmt.Errorf("LB Pool still has %d members: %s", len(members.Members), rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMISLBPoolMembersConfig(vpcname, subnetname, zone, cidr, name, poolName string, addresses []string, weight int) string {
+	members := ""
+	for _, address := range addresses {
+		members += fmt.Sprintf(`
+		member {
+			port           = 8080
+			target_address = "%s"
+			weight         = %d
+		}`, address, weight)
+	}
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	}
+
+	resource "ibm_is_subnet" "testacc_subnet" {
+		name = "%s"
+		vpc = "${ibm_is_vpc.testacc_vpc.id}"
+		zone = "%s"
+		ipv4_cidr_block = "%s"
+	}
+	resource "ibm_is_lb" "testacc_LB" {
+		name = "%s"
+		subnets = ["${ibm_is_subnet.testacc_subnet.id}"]
+	}
+	resource "ibm_is_lb_pool" "testacc_lb_pool" {
+		name = "%s"
+		lb = "${ibm_is_lb.testacc_LB.id}"
+		algorithm = "weighted_round_robin"
+		protocol = "http"
+		health_delay= 45
+		health_retries = 5
+		health_timeout = 30
+		health_type = "tcp"
+	}
+	resource "ibm_is_lb_pool_members" "testacc_lb_members" {
+		lb   = ibm_is_lb.testacc_LB.id
+		pool = ibm_is_lb_pool.testacc_lb_pool.id
+		%s
+	}`, vpcname, subnetname, zone, cidr, name, poolName, members)
+}
@@ -27,6 +27,8 @@ const (
 	isSecurityGroupTags          = "tags"
 	isSecurityGroupAccessTags    = "access_tags"
 	isSecurityGroupCRN           = "crn"
+
+	isSecurityGroupAuthoritativeRules = "authoritative_rules"
 )
 
 func ResourceIBMISSecurityGroup() *schema.Resource {
@@ -49,6 +51,10 @@ func ResourceIBMISSecurityGroup() *schema.Resource {
 				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
 					return flex.ResourceValidateAccessTags(diff, v)
 				}),
+			customdiff.Sequence(
+				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+					return resourceIBMISSecurityGroupAuthoritativeRulesCustomizeDiff(diff)
+				}),
 		),
 
 		Timeouts: &schema.ResourceTimeout{
@@ -105,6 +111,22 @@ func ResourceIBMISSecurityGroup() *schema.Resource {
 				},
 			},
 
+			isSecurityGroupRulesRule: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Set:         resourceIBMISSecurityGroupRuleHash,
+				Description: "The rules of the security group. If set, rules of the security group that are not listed are removed. Removing all rule blocks leaves the rules in place unless authoritative_rules is set.",
+				Elem:        resourceIBMISSecurityGroupRulesRule(),
+			},
+
+			isSecurityGroupAuthoritativeRules: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the rule blocks are authoritative even when there are none, in which case all rules of the security group are removed",
+			},
+
 			isSecurityGroupResourceGroup: {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -205,6 +227,12 @@ func resourceIBMISSecurityGroupCreate(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("[ERROR] Error while creating Security Group %s\n%s", err, response)
 	}
 	d.SetId(*sg.ID)
+	if rules, ok := d.GetOk(isSecurityGroupRulesRule); ok {
+		err = applyIBMISSecurityGroupRules(sess, *sg.ID, rules.(*schema.Set).List())
+		if err != nil {
+			return err
+		}
+	}
 	v := os.Getenv("IC_ENV_TAGS")
 	if _, ok := d.GetOk(isSecurityGroupTags); ok || v != "" {
 		oldList, newList := d.GetChange(isSecurityGroupTags)
@@ -377,6 +405,15 @@ func resourceIBMISSecurityGroupRead(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 	d.Set(isSecurityGroupRules, rules)
+	ruleSet := make([]interface{}, 0, len(group.Rules))
+	for _, rule := range group.Rules {
+		if _, r := flattenIBMISSecurityGroupRule(rule); r != nil {
+			ruleSet = append(ruleSet, r)
+		}
+	}
+	if err := d.Set(isSecurityGroupRulesRule, schema.NewSet(resourceIBMISSecurityGroupRuleHash, ruleSet)); err != nil {
+		return fmt.Errorf("[ERROR] Error setting rule: %s", err)
+	}
 	d.SetId(*group.ID)
 	if group.ResourceGroup != nil {
 		d.Set(isSecurityGroupResourceGroup, group.ResourceGroup.ID)
@@ -392,6 +429,24 @@ func resourceIBMISSecurityGroupRead(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
+// resourceIBMISSecurityGroupAuthoritativeRulesCustomizeDiff plans the removal
+// of all rules when the rules are authoritative and no rule block is set.
+// Without authoritative_rules, rule is left to its computed value, so that the
+// rules can be managed by ibm_is_security_group_rule.
+func resourceIBMISSecurityGroupAuthoritativeRulesCustomizeDiff(diff *schema.ResourceDiff) error {
+	if !diff.Get(isSecurityGroupAuthoritativeRules).(bool) {
+		return nil
+	}
+	rules := diff.GetRawConfig().GetAttr(isSecurityGroupRulesRule)
+	if !rules.IsKnown() || (!rules.IsNull() && rules.LengthInt() > 0) {
+		return nil
+	}
+	if old, _ := diff.GetChange(isSecurityGroupRulesRule); old.(*schema.Set).Len() == 0 {
+		return nil
+	}
+	return diff.SetNew(isSecurityGroupRulesRule, []interface{}{})
+}
+
 func resourceIBMISSecurityGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
@@ -417,6 +472,12 @@ func resourceIBMISSecurityGroupUpdate(d *schema.ResourceData, meta interface{})
 				"Error on update of Security Group (%s) access tags: %s", d.Id(), err)
 		}
 	}
+	if d.HasChange(isSecurityGroupRulesRule) {
+		err := applyIBMISSecurityGroupRules(sess, id, d.Get(isSecurityGroupRulesRule).(*schema.Set).List())
+		if err != nil {
+			return err
+		}
+	}
 	if d.HasChange(isSecurityGroupName) {
 		name = d.Get(isSecurityGroupName).(string)
 		hasChanged = true
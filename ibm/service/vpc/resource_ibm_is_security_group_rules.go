@@ -0,0 +1,470 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	isSecurityGroupRulesSecurityGroup = "security_group"
+	isSecurityGroupRulesRule          = "rule"
+	isSecurityGroupRuleProtocolAll    = "all"
+
+	// The remote and local of a rule default to any address
+	isSecurityGroupRuleAnyCIDR = "0.0.0.0/0"
+)
+
+func ResourceIBMISSecurityGroupRules() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMISSecurityGroupRulesCreate,
+		Read:     resourceIBMISSecurityGroupRulesRead,
+		Update:   resourceIBMISSecurityGroupRulesUpdate,
+		Delete:   resourceIBMISSecurityGroupRulesDelete,
+		Importer: &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			isSecurityGroupRulesSecurityGroup: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The security group identifier",
+			},
+
+			isSecurityGroupRulesRule: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         resourceIBMISSecurityGroupRuleHash,
+				Description: "The rules of the security group. Rules of the security group that are not listed are removed.",
+				Elem:        resourceIBMISSecurityGroupRulesRule(),
+			},
+		},
+	}
+}
+
+// resourceIBMISSecurityGroupRulesRule is a rule of the authoritative rule
+// sets of ibm_is_security_group and ibm_is_security_group_rules.
+func resourceIBMISSecurityGroupRulesRule() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			isSecurityGroupRuleDirection: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Direction of traffic to enforce, either inbound or outbound",
+				ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleDirection),
+			},
+			isSecurityGroupRuleIPVersion: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      isSecurityGroupRuleIPVersionDefault,
+				Description:  "IP version: ipv4",
+				ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleIPVersion),
+			},
+			isSecurityGroupRuleProtocol: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      isSecurityGroupRuleProtocolAll,
+				Description:  "The protocol to enforce: all, icmp, tcp or udp",
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{isSecurityGroupRuleProtocolAll, isSecurityGroupRuleProtocolICMP, isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP}),
+			},
+			isSecurityGroupRuleRemote: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Security group id: an IP address, a CIDR block, or a single security group identifier. Defaults to 0.0.0.0/0",
+			},
+			isSecurityGroupRuleLocal: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Security group local ip: an IP address, a CIDR block. Defaults to 0.0.0.0/0",
+			},
+			isSecurityGroupRulePortMin: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The inclusive lower bound of the TCP or UDP port range. Defaults to 1",
+				ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRulePortMin),
+			},
+			isSecurityGroupRulePortMax: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The inclusive upper bound of the TCP or UDP port range. Defaults to 65535",
+				ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRulePortMax),
+			},
+			isSecurityGroupRuleType: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The ICMP traffic type to allow. All types are allowed if not set",
+				ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleType),
+			},
+			isSecurityGroupRuleCode: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The ICMP traffic code to allow. Requires type",
+				ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleCode),
+			},
+		},
+	}
+}
+
+func resourceIBMISSecurityGroupRulesCreate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	secgrpID := d.Get(isSecurityGroupRulesSecurityGroup).(string)
+	err = applyIBMISSecurityGroupRules(sess, secgrpID, d.Get(isSecurityGroupRulesRule).(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	d.SetId(secgrpID)
+	return resourceIBMISSecurityGroupRulesRead(d, meta)
+}
+
+func resourceIBMISSecurityGroupRulesRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	secgrpID := d.Id()
+	getSecurityGroupOptions := &vpcv1.GetSecurityGroupOptions{
+		ID: &secgrpID,
+	}
+	group, response, err := sess.GetSecurityGroup(getSecurityGroupOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error getting Security Group : %s\n%s", err, response)
+	}
+	d.Set(isSecurityGroupRulesSecurityGroup, secgrpID)
+	rules := make([]interface{}, 0, len(group.Rules))
+	for _, rule := range group.Rules {
+		if _, r := flattenIBMISSecurityGroupRule(rule); r != nil {
+			rules = append(rules, r)
+		}
+	}
+	if err := d.Set(isSecurityGroupRulesRule, schema.NewSet(resourceIBMISSecurityGroupRuleHash, rules)); err != nil {
+		return fmt.Errorf("[ERROR] Error setting rule: %s", err)
+	}
+	return nil
+}
+
+func resourceIBMISSecurityGroupRulesUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	if d.HasChange(isSecurityGroupRulesRule) {
+		err = applyIBMISSecurityGroupRules(sess, d.Id(), d.Get(isSecurityGroupRulesRule).(*schema.Set).List())
+		if err != nil {
+			return err
+		}
+	}
+	return resourceIBMISSecurityGroupRulesRead(d, meta)
+}
+
+func resourceIBMISSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	secgrpID := d.Id()
+	getSecurityGroupOptions := &vpcv1.GetSecurityGroupOptions{
+		ID: &secgrpID,
+	}
+	_, response, err := sess.GetSecurityGroup(getSecurityGroupOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error Getting Security Group (%s): %s\n%s", secgrpID, err, response)
+	}
+	err = applyIBMISSecurityGroupRules(sess, secgrpID, nil)
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// applyIBMISSecurityGroupRules creates the rules that the security group
+// lacks and deletes the rules of the security group that are not in rules.
+// The rules are listed and changed while holding the lock of the rules of the
+// group, which ibm_is_security_group_rule holds too.
+func applyIBMISSecurityGroupRules(sess *vpcv1.VpcV1, secgrpID string, rules []interface{}) error {
+	isSecurityGroupRuleKey := "security_group_rule_key_" + secgrpID
+	conns.IbmMutexKV.Lock(isSecurityGroupRuleKey)
+	defer conns.IbmMutexKV.Unlock(isSecurityGroupRuleKey)
+
+	listSecurityGroupRulesOptions := &vpcv1.ListSecurityGroupRulesOptions{
+		SecurityGroupID: &secgrpID,
+	}
+	current, response, err := sess.ListSecurityGroupRules(listSecurityGroupRulesOptions)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing Security Group Rules of Security Group (%s): %s\n%s", secgrpID, err, response)
+	}
+
+	wanted := make(map[string]map[string]interface{}, len(rules))
+	for _, r := range rules {
+		rule := r.(map[string]interface{})
+		wanted[ibmISSecurityGroupRuleKey(rule)] = rule
+	}
+	var unwanted []string
+	for _, r := range current.Rules {
+		id, rule := flattenIBMISSecurityGroupRule(r)
+		if rule == nil {
+			continue
+		}
+		key := ibmISSecurityGroupRuleKey(rule)
+		if _, ok := wanted[key]; ok {
+			delete(wanted, key)
+		} else {
+			unwanted = append(unwanted, id)
+		}
+	}
+
+	// The missing rules are created before the unwanted rules are deleted so
+	// that a changed rule doesn't interrupt the traffic it allows.
+	keys := make([]string, 0, len(wanted))
+	for key := range wanted {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		prototype, err := expandIBMISSecurityGroupRule(wanted[key])
+		if err != nil {
+			return err
+		}
+		options := &vpcv1.CreateSecurityGroupRuleOptions{
+			SecurityGroupID:            &secgrpID,
+			SecurityGroupRulePrototype: prototype,
+		}
+		_, response, err := sess.CreateSecurityGroupRule(options)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error while creating Security Group Rule %s\n%s", err, response)
+		}
+	}
+	for _, ruleID := range unwanted {
+		log.Printf("[DEBUG] Deleting Security Group Rule (%s) of Security Group (%s)", ruleID, secgrpID)
+		deleteSecurityGroupRuleOptions := &vpcv1.DeleteSecurityGroupRuleOptions{
+			SecurityGroupID: &secgrpID,
+			ID:              &ruleID,
+		}
+		response, err := sess.DeleteSecurityGroupRule(deleteSecurityGroupRuleOptions)
+		if err != nil && (response == nil || response.StatusCode != 404) {
+			return fmt.Errorf("[ERROR] Error Deleting Security Group Rule (%s): %s\n%s", ruleID, err, response)
+		}
+	}
+	return nil
+}
+
+// resourceIBMISSecurityGroupRuleHash hashes a rule by the traffic it allows, so
+// that arguments left to their defaults don't change the hash.
+func resourceIBMISSecurityGroupRuleHash(v interface{}) int {
+	return schema.HashString(ibmISSecurityGroupRuleKey(v.(map[string]interface{})))
+}
+
+// ibmISSecurityGroupRuleKey returns the traffic a rule allows as a string, with
+// the defaults of the arguments that are not set filled in.
+func ibmISSecurityGroupRuleKey(rule map[string]interface{}) string {
+	str := func(key, defaultValue string) string {
+		if v, ok := rule[key].(string); ok && v != "" {
+			return v
+		}
+		return defaultValue
+	}
+	num := func(key string) int {
+		v, _ := rule[key].(int)
+		return v
+	}
+
+	protocol := str(isSecurityGroupRuleProtocol, isSecurityGroupRuleProtocolAll)
+	fields := []string{
+		str(isSecurityGroupRuleDirection, ""),
+		str(isSecurityGroupRuleIPVersion, isSecurityGroupRuleIPVersionDefault),
+		protocol,
+		str(isSecurityGroupRuleRemote, isSecurityGroupRuleAnyCIDR),
+		str(isSecurityGroupRuleLocal, isSecurityGroupRuleAnyCIDR),
+	}
+	switch protocol {
+	case isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP:
+		portMin, portMax := ibmISSecurityGroupRulePorts(num(isSecurityGroupRulePortMin), num(isSecurityGroupRulePortMax))
+		fields = append(fields, strconv.Itoa(portMin), strconv.Itoa(portMax))
+	case isSecurityGroupRuleProtocolICMP:
+		if icmpType := num(isSecurityGroupRuleType); icmpType != 0 {
+			fields = append(fields, strconv.Itoa(icmpType))
+			if code := num(isSecurityGroupRuleCode); code != 0 {
+				fields = append(fields, strconv.Itoa(code))
+			}
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// ibmISSecurityGroupRulePorts fills in the port range of a TCP or UDP rule the
+// way ibm_is_security_group_rule does.
+func ibmISSecurityGroupRulePorts(portMin, portMax int) (int, int) {
+	switch {
+	case portMin == 0 && portMax == 0:
+		return 1, 65535
+	case portMin == 0:
+		return portMax, portMax
+	case portMax == 0:
+		return portMin, portMin
+	}
+	return portMin, portMax
+}
+
+func expandIBMISSecurityGroupRule(rule map[string]interface{}) (*vpcv1.SecurityGroupRulePrototype, error) {
+	direction := rule[isSecurityGroupRuleDirection].(string)
+	ipVersion := rule[isSecurityGroupRuleIPVersion].(string)
+	protocol := rule[isSecurityGroupRuleProtocol].(string)
+	prototype := &vpcv1.SecurityGroupRulePrototype{
+		Direction: &direction,
+		IPVersion: &ipVersion,
+		Protocol:  &protocol,
+	}
+	if remote, _ := rule[isSecurityGroupRuleRemote].(string); remote != "" {
+		address, cidr, id, err := inferRemoteSecurityGroup(remote)
+		if err != nil {
+			return nil, err
+		}
+		prototype.Remote = &vpcv1.SecurityGroupRuleRemotePrototype{}
+		if address != "" {
+			prototype.Remote.(*vpcv1.SecurityGroupRuleRemotePrototype).Address = &address
+		} else if cidr != "" {
+			prototype.Remote.(*vpcv1.SecurityGroupRuleRemotePrototype).CIDRBlock = &cidr
+		} else {
+			prototype.Remote.(*vpcv1.SecurityGroupRuleRemotePrototype).ID = &id
+		}
+	}
+	if local, _ := rule[isSecurityGroupRuleLocal].(string); local != "" {
+		address, cidr, err := inferLocalSecurityGroup(local)
+		if err != nil {
+			return nil, err
+		}
+		if address == "" && cidr == "" {
+			return nil, fmt.Errorf("[ERROR] Invalid local provided (%s): must be an IP address or a CIDR block", local)
+		}
+		prototype.Local = &vpcv1.SecurityGroupRuleLocalPrototype{}
+		if address != "" {
+			prototype.Local.(*vpcv1.SecurityGroupRuleLocalPrototype).Address = &address
+		} else {
+			prototype.Local.(*vpcv1.SecurityGroupRuleLocalPrototype).CIDRBlock = &cidr
+		}
+	}
+
+	portMin, _ := rule[isSecurityGroupRulePortMin].(int)
+	portMax, _ := rule[isSecurityGroupRulePortMax].(int)
+	icmpType, _ := rule[isSecurityGroupRuleType].(int)
+	code, _ := rule[isSecurityGroupRuleCode].(int)
+	switch protocol {
+	case isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP:
+		if icmpType != 0 || code != 0 {
+			return nil, fmt.Errorf("[ERROR] type and code can only be set for icmp rules")
+		}
+		portMin, portMax = ibmISSecurityGroupRulePorts(portMin, portMax)
+		prototype.PortMin = core.Int64Ptr(int64(portMin))
+		prototype.PortMax = core.Int64Ptr(int64(portMax))
+	case isSecurityGroupRuleProtocolICMP:
+		if portMin != 0 || portMax != 0 {
+			return nil, fmt.Errorf("[ERROR] port_min and port_max can only be set for tcp and udp rules")
+		}
+		if code != 0 && icmpType == 0 {
+			return nil, fmt.Errorf("icmp code requires icmp type")
+		}
+		if icmpType != 0 {
+			prototype.Type = core.Int64Ptr(int64(icmpType))
+		}
+		if code != 0 {
+			prototype.Code = core.Int64Ptr(int64(code))
+		}
+	default:
+		if portMin != 0 || portMax != 0 || icmpType != 0 || code != 0 {
+			return nil, fmt.Errorf("[ERROR] port_min, port_max, type and code can't be set for rules of protocol %s", protocol)
+		}
+	}
+	return prototype, nil
+}
+
+// flattenIBMISSecurityGroupRule returns the ID and the arguments of a rule, or
+// a nil map for a rule of an unknown protocol.
+func flattenIBMISSecurityGroupRule(rule vpcv1.SecurityGroupRuleIntf) (string, map[string]interface{}) {
+	var id string
+	var remote vpcv1.SecurityGroupRuleRemoteIntf
+	var local vpcv1.SecurityGroupRuleLocalIntf
+	r := map[string]interface{}{}
+	switch rule := rule.(type) {
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll:
+		id, remote, local = *rule.ID, rule.Remote, rule.Local
+		r[isSecurityGroupRuleDirection] = *rule.Direction
+		r[isSecurityGroupRuleIPVersion] = *rule.IPVersion
+		r[isSecurityGroupRuleProtocol] = *rule.Protocol
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp:
+		id, remote, local = *rule.ID, rule.Remote, rule.Local
+		r[isSecurityGroupRuleDirection] = *rule.Direction
+		r[isSecurityGroupRuleIPVersion] = *rule.IPVersion
+		r[isSecurityGroupRuleProtocol] = *rule.Protocol
+		if rule.Type != nil {
+			r[isSecurityGroupRuleType] = int(*rule.Type)
+		}
+		if rule.Code != nil {
+			r[isSecurityGroupRuleCode] = int(*rule.Code)
+		}
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp:
+		id, remote, local = *rule.ID, rule.Remote, rule.Local
+		r[isSecurityGroupRuleDirection] = *rule.Direction
+		r[isSecurityGroupRuleIPVersion] = *rule.IPVersion
+		r[isSecurityGroupRuleProtocol] = *rule.Protocol
+		if rule.PortMin != nil {
+			r[isSecurityGroupRulePortMin] = int(*rule.PortMin)
+		}
+		if rule.PortMax != nil {
+			r[isSecurityGroupRulePortMax] = int(*rule.PortMax)
+		}
+	default:
+		return "", nil
+	}
+	if remote, ok := remote.(*vpcv1.SecurityGroupRuleRemote); ok && remote != nil {
+		if remote.ID != nil {
+			r[isSecurityGroupRuleRemote] = *remote.ID
+		} else if remote.Address != nil {
+			r[isSecurityGroupRuleRemote] = *remote.Address
+		} else if remote.CIDRBlock != nil {
+			r[isSecurityGroupRuleRemote] = *remote.CIDRBlock
+		}
+	}
+	if local, ok := local.(*vpcv1.SecurityGroupRuleLocal); ok && local != nil {
+		if local.Address != nil {
+			r[isSecurityGroupRuleLocal] = *local.Address
+		} else if local.CIDRBlock != nil {
+			r[isSecurityGroupRuleLocal] = *local.CIDRBlock
+		}
+	}
+	return id, r
+}
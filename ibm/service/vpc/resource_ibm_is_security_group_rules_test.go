@@ -0,0 +1,144 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMISSecurityGroupRules_basic(t *testing.T) {
+	var securityGroupID string
+
+	vpcname := fmt.Sprintf("tfsgrules-vpc-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tfsgrules-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMISSecurityGroupRulesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISSecurityGroupRulesConfig(vpcname, name),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						securityGroupID = s.RootModule().Resources["ibm_is_security_group_rules.testacc_rules"].Primary.ID
+						return nil
+					},
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group_rules.testacc_rules", "rule.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"ibm_is_security_group_rules.testacc_rules", "rule.*", map[string]string{
+							"direction": "inbound",
+							"protocol":  "icmp",
+							"type":      "8",
+						}),
+				),
+			},
+			{
+				// a rule that is added outside of Terraform is drift
+				PreConfig: func() {
+					testAccIBMISSecurityGroupRulesAddRule(t, securityGroupID)
+				},
+				Config:             testAccCheckIBMISSecurityGroupRulesConfig(vpcname, name),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// and is removed by the next apply
+				Config: testAccCheckIBMISSecurityGroupRulesConfig(vpcname, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group_rules.testacc_rules", "rule.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "ibm_is_security_group_rules.testacc_rules",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIBMISSecurityGroupRulesAddRule(t *testing.T, securityGroupID string) {
+	sess, err := acc.TestAccProvider.Meta().(conns.ClientSession).VpcV1API()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = sess.CreateSecurityGroupRule(&vpcv1.CreateSecurityGroupRuleOptions{
+		SecurityGroupID: &securityGroupID,
+		SecurityGroupRulePrototype: &vpcv1.SecurityGroupRulePrototype{
+			Direction: core.StringPtr("inbound"),
+			Protocol:  core.StringPtr("tcp"),
+			PortMin:   core.Int64Ptr(3389),
+			PortMax:   core.Int64Ptr(3389),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testAccCheckIBMISSecurityGroupRulesDestroy(s *terraform.State) error {
+	sess, _ := acc.TestAccProvider.Meta().(conns.ClientSession).VpcV1API()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_is_security_group_rules" {
+			continue
+		}
+
+		listSecurityGroupRulesOptions := &vpcv1.ListSecurityGroupRulesOptions{
+			SecurityGroupID: &rs.Primary.ID,
+		}
+		rules, _, err := sess.ListSecurityGroupRules(listSecurityGroupRulesOptions)
+		if err == nil && len(rules.Rules) > 0 {
+			return fmt.Errorf("security group %s still has %d rules", rs.Primary.ID, len(rules.Rules))
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMISSecurityGroupRulesConfig(vpcname, name string) string {
+	return fmt.Sprintf(`
+resource "ibm_is_vpc" "testacc_vpc" {
+	name = "%s"
+}
+
+resource "ibm_is_security_group" "testacc_security_group" {
+	name = "%s"
+	vpc  = ibm_is_vpc.testacc_vpc.id
+}
+
+resource "ibm_is_security_group_rules" "testacc_rules" {
+	security_group = ibm_is_security_group.testacc_security_group.id
+
+	rule {
+		direction = "inbound"
+		protocol  = "tcp"
+		port_min  = 22
+		port_max  = 22
+		remote    = "10.0.0.0/8"
+	}
+
+	rule {
+		direction = "inbound"
+		protocol  = "icmp"
+		type      = 8
+	}
+
+	rule {
+		direction = "outbound"
+	}
+}`, vpcname, name)
+}
@@ -0,0 +1,176 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIBMISSecurityGroupRuleHash(t *testing.T) {
+	for name, tc := range map[string]struct {
+		a, b  map[string]interface{}
+		equal bool
+	}{
+		"defaults": {
+			a:     map[string]interface{}{"direction": "inbound"},
+			b:     map[string]interface{}{"direction": "inbound", "ip_version": "ipv4", "protocol": "all", "remote": "0.0.0.0/0", "local": "0.0.0.0/0"},
+			equal: true,
+		},
+		"empty remote and local": {
+			a:     map[string]interface{}{"direction": "inbound", "remote": "", "local": ""},
+			b:     map[string]interface{}{"direction": "inbound", "remote": "0.0.0.0/0", "local": "0.0.0.0/0"},
+			equal: true,
+		},
+		"default port range": {
+			a:     map[string]interface{}{"direction": "inbound", "protocol": "tcp"},
+			b:     map[string]interface{}{"direction": "inbound", "protocol": "tcp", "port_min": 1, "port_max": 65535},
+			equal: true,
+		},
+		"single port from port_min": {
+			a:     map[string]interface{}{"direction": "inbound", "protocol": "udp", "port_min": 53},
+			b:     map[string]interface{}{"direction": "inbound", "protocol": "udp", "port_min": 53, "port_max": 53},
+			equal: true,
+		},
+		"single port from port_max": {
+			a:     map[string]interface{}{"direction": "inbound", "protocol": "tcp", "port_max": 22},
+			b:     map[string]interface{}{"direction": "inbound", "protocol": "tcp", "port_min": 22, "port_max": 22},
+			equal: true,
+		},
+		// the ports and ICMP fields of other protocols are ignored
+		"ports of ICMP": {
+			a:     map[string]interface{}{"direction": "inbound", "protocol": "icmp", "port_min": 22, "port_max": 22},
+			b:     map[string]interface{}{"direction": "inbound", "protocol": "icmp"},
+			equal: true,
+		},
+		"ICMP code without type": {
+			a:     map[string]interface{}{"direction": "inbound", "protocol": "icmp", "code": 0},
+			b:     map[string]interface{}{"direction": "inbound", "protocol": "icmp", "type": 0, "code": 4},
+			equal: true,
+		},
+		"type of all": {
+			a:     map[string]interface{}{"direction": "outbound", "protocol": "all", "type": 8},
+			b:     map[string]interface{}{"direction": "outbound"},
+			equal: true,
+		},
+		"direction": {
+			a: map[string]interface{}{"direction": "inbound"},
+			b: map[string]interface{}{"direction": "outbound"},
+		},
+		"protocol": {
+			a: map[string]interface{}{"direction": "inbound", "protocol": "tcp"},
+			b: map[string]interface{}{"direction": "inbound", "protocol": "udp"},
+		},
+		"port range": {
+			a: map[string]interface{}{"direction": "inbound", "protocol": "tcp", "port_min": 22, "port_max": 22},
+			b: map[string]interface{}{"direction": "inbound", "protocol": "tcp", "port_min": 22, "port_max": 23},
+		},
+		"remote": {
+			a: map[string]interface{}{"direction": "inbound", "remote": "10.0.0.0/8"},
+			b: map[string]interface{}{"direction": "inbound"},
+		},
+		"local": {
+			a: map[string]interface{}{"direction": "inbound", "local": "10.240.0.4"},
+			b: map[string]interface{}{"direction": "inbound"},
+		},
+		"ICMP type": {
+			a: map[string]interface{}{"direction": "inbound", "protocol": "icmp", "type": 8},
+			b: map[string]interface{}{"direction": "inbound", "protocol": "icmp"},
+		},
+		"ICMP code": {
+			a: map[string]interface{}{"direction": "inbound", "protocol": "icmp", "type": 3, "code": 1},
+			b: map[string]interface{}{"direction": "inbound", "protocol": "icmp", "type": 3},
+		},
+	} {
+		a, b := resourceIBMISSecurityGroupRuleHash(tc.a), resourceIBMISSecurityGroupRuleHash(tc.b)
+		if (a == b) != tc.equal {
+			t.Errorf("%s: hashes of %v (%s) and %v (%s) equal = %t, expected %t", name, tc.a, ibmISSecurityGroupRuleKey(tc.a), tc.b, ibmISSecurityGroupRuleKey(tc.b), a == b, tc.equal)
+		}
+	}
+}
+
+func TestIBMISSecurityGroupAuthoritativeRules(t *testing.T) {
+	group := ResourceIBMISSecurityGroup().Schema
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			isSecurityGroupRulesRule:          group[isSecurityGroupRulesRule],
+			isSecurityGroupAuthoritativeRules: group[isSecurityGroupAuthoritativeRules],
+		},
+		CustomizeDiff: func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+			return resourceIBMISSecurityGroupAuthoritativeRulesCustomizeDiff(diff)
+		},
+		Create: func(*schema.ResourceData, interface{}) error { return nil },
+		Read:   func(*schema.ResourceData, interface{}) error { return nil },
+		Update: func(*schema.ResourceData, interface{}) error { return nil },
+		Delete: func(*schema.ResourceData, interface{}) error { return nil },
+	}
+	ty := r.CoreConfigSchema().ImpliedType()
+	ruleTy := ty.AttributeType(isSecurityGroupRulesRule).ElementType()
+	rule := func(direction string) cty.Value {
+		attrs := map[string]cty.Value{}
+		for name, attrTy := range ruleTy.AttributeTypes() {
+			attrs[name] = cty.NullVal(attrTy)
+		}
+		attrs[isSecurityGroupRuleDirection] = cty.StringVal(direction)
+		attrs[isSecurityGroupRuleIPVersion] = cty.StringVal(isSecurityGroupRuleIPVersionDefault)
+		attrs[isSecurityGroupRuleProtocol] = cty.StringVal(isSecurityGroupRuleProtocolAll)
+		return cty.ObjectVal(attrs)
+	}
+	dynamicValue := func(id cty.Value, authoritative bool, rules ...cty.Value) *tfprotov5.DynamicValue {
+		set := cty.SetValEmpty(ruleTy)
+		if len(rules) > 0 {
+			set = cty.SetVal(rules)
+		}
+		b, err := msgpack.Marshal(cty.ObjectVal(map[string]cty.Value{
+			"id":                              id,
+			isSecurityGroupRulesRule:          set,
+			isSecurityGroupAuthoritativeRules: cty.BoolVal(authoritative),
+		}), ty)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &tfprotov5.DynamicValue{MsgPack: b}
+	}
+	server := schema.NewGRPCProviderServer(&schema.Provider{ResourcesMap: map[string]*schema.Resource{"ibm_is_security_group": r}})
+
+	for name, tc := range map[string]struct {
+		authoritative bool
+		config        []cty.Value
+		rules         int
+	}{
+		"rules left in place":    {rules: 2},
+		"all rules removed":      {authoritative: true, rules: 0},
+		"rule blocks":            {config: []cty.Value{rule("inbound")}, rules: 1},
+		"authoritative blocks":   {authoritative: true, config: []cty.Value{rule("inbound")}, rules: 1},
+		"drift with rule blocks": {config: []cty.Value{rule("outbound")}, rules: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			prior := dynamicValue(cty.StringVal("sg-1"), tc.authoritative, rule("inbound"), rule("outbound"))
+			resp, err := server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+				TypeName:         "ibm_is_security_group",
+				PriorState:       prior,
+				ProposedNewState: dynamicValue(cty.StringVal("sg-1"), tc.authoritative, tc.config...),
+				Config:           dynamicValue(cty.NullVal(cty.String), tc.authoritative, tc.config...),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("PlanResourceChange() returned diagnostic %s: %s", d.Summary, d.Detail)
+			}
+			planned, err := msgpack.Unmarshal(resp.PlannedState.MsgPack, ty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rules := planned.GetAttr(isSecurityGroupRulesRule).LengthInt(); rules != tc.rules {
+				t.Errorf("planned %d rules, expected %d", rules, tc.rules)
+			}
+		})
+	}
+}
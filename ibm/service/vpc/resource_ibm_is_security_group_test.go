@@ -227,3 +227,66 @@ resource "ibm_is_security_group" "testacc_security_group" {
 }`, vpcname, name)
 
 }
+
+func TestAccIBMISSecurityGroup_rules(t *testing.T) {
+	var securityGroup string
+
+	vpcname := fmt.Sprintf("tfsg-vpc-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tfsg-rules-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMISSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISsecurityGroupRulesConfig(vpcname, name, 22),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISSecurityGroupExists("ibm_is_security_group.testacc_security_group", securityGroup),
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group.testacc_security_group", "rule.#", "2"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group.testacc_security_group", "rules.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISsecurityGroupRulesConfig(vpcname, name, 443),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group.testacc_security_group", "rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"ibm_is_security_group.testacc_security_group", "rule.*", map[string]string{
+							"direction": "inbound",
+							"protocol":  "tcp",
+							"port_min":  "443",
+							"port_max":  "443",
+						}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISsecurityGroupRulesConfig(vpcname, name string, port int) string {
+	return fmt.Sprintf(`
+resource "ibm_is_vpc" "testacc_vpc" {
+	name = "%s"
+}
+
+resource "ibm_is_security_group" "testacc_security_group" {
+	name = "%s"
+	vpc  = ibm_is_vpc.testacc_vpc.id
+
+	rule {
+		direction = "inbound"
+		protocol  = "tcp"
+		port_min  = %d
+		port_max  = %d
+		remote    = "10.0.0.0/8"
+	}
+
+	rule {
+		direction = "outbound"
+	}
+}`, vpcname, name, port, port)
+}
@@ -6,10 +6,12 @@ package vpc_test
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/unittest"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -962,3 +964,75 @@ func testAccCheckIBMISVPCNoSgAclRulesConfig(vpcname string) string {
 `, vpcname)
 
 }
+
+func TestMockIBMISVPCBasic(t *testing.T) {
+	server := unittest.NewMockServer(t)
+	vpcs := mockIBMISVPCAPI(server)
+	tags := server.GlobalTagging()
+
+	server.UnitTest(t,
+		resource.TestStep{
+			Config: testAccCheckIBMISVPCConfig("mock-vpc"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ibm_is_vpc.testacc_vpc", "name", "mock-vpc"),
+				resource.TestCheckResourceAttr("ibm_is_vpc.testacc_vpc", "status", "available"),
+				resource.TestCheckResourceAttr("ibm_is_vpc.testacc_vpc", "tags.#", "2"),
+				resource.TestCheckResourceAttr("ibm_is_vpc.testacc_vpc", "default_security_group_name", "dsgn"),
+				func(s *terraform.State) error {
+					if vpcs.Len() != 1 {
+						return fmt.Errorf("expected 1 VPC on the mock server, got %d", vpcs.Len())
+					}
+					crn := s.RootModule().Resources["ibm_is_vpc.testacc_vpc"].Primary.Attributes["crn"]
+					if got := tags.Get(crn, "user"); len(got) != 2 {
+						return fmt.Errorf("expected 2 user tags on %s, got %v", crn, got)
+					}
+					return nil
+				},
+			),
+		},
+		resource.TestStep{
+			Config: testAccCheckIBMISVPCConfigUpdate("mock-vpc"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ibm_is_vpc.testacc_vpc", "tags.#", "1"),
+			),
+		},
+		resource.TestStep{
+			ResourceName:            "ibm_is_vpc.testacc_vpc",
+			ImportState:             true,
+			ImportStateVerify:       true,
+			ImportStateVerifyIgnore: []string{"address_prefix_management", "no_sg_acl_rules"},
+		},
+	)
+}
+
+// mockIBMISVPCAPI serves the VPC endpoints used by ibm_is_vpc from memory.
+func mockIBMISVPCAPI(server *unittest.MockServer) *unittest.MockCollection {
+	server.HandleJSON(http.MethodGet, "/v1/subnets", http.StatusOK, map[string]interface{}{"subnets": []interface{}{}, "limit": 50, "total_count": 0})
+	server.HandleJSON(http.MethodGet, "/v1/security_groups", http.StatusOK, map[string]interface{}{"security_groups": []interface{}{}, "limit": 50, "total_count": 0})
+	server.Handle(http.MethodPatch, "/v1/security_groups/{id}", mockIBMISVPCRename)
+	server.Handle(http.MethodPatch, "/v1/network_acls/{id}", mockIBMISVPCRename)
+	server.Handle(http.MethodPatch, "/v1/vpcs/{vpc_id}/routing_tables/{id}", mockIBMISVPCRename)
+
+	vpcs := server.Collection("/v1/vpcs", "vpcs")
+	vpcs.OnCreate = func(vpc map[string]interface{}) {
+		id := vpc["id"].(string)
+		vpc["crn"] = fmt.Sprintf("crn:v1:bluemix:public:is:us-south:a/%s::vpc:%s", unittest.MockAccountID, id)
+		vpc["href"] = server.URL + "/v1/vpcs/" + id
+		vpc["status"] = "available"
+		vpc["health_state"] = "ok"
+		vpc["health_reasons"] = []interface{}{}
+		vpc["cse_source_ips"] = []interface{}{}
+		vpc["created_at"] = "2024-01-01T00:00:00Z"
+		vpc["resource_group"] = map[string]interface{}{"id": "mock-resource-group", "name": "Default"}
+		vpc["default_network_acl"] = map[string]interface{}{"id": id + "-acl", "name": "dnwacln", "crn": vpc["crn"].(string) + "-acl"}
+		vpc["default_security_group"] = map[string]interface{}{"id": id + "-sg", "name": "dsgn", "crn": vpc["crn"].(string) + "-sg"}
+		vpc["default_routing_table"] = map[string]interface{}{"id": id + "-rt", "name": "drtn", "resource_type": "routing_table"}
+	}
+	return vpcs
+}
+
+func mockIBMISVPCRename(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body := unittest.ReadJSON(r)
+	body["id"] = params["id"]
+	unittest.WriteJSON(w, http.StatusOK, body)
+}
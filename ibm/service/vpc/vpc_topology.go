@@ -0,0 +1,219 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The types of the nodes of a VPC topology, in the order they are listed.
+const (
+	vpcTopologyVPC                     = "vpc"
+	vpcTopologyRoutingTable            = "routing_table"
+	vpcTopologyPublicGateway           = "public_gateway"
+	vpcTopologySubnet                  = "subnet"
+	vpcTopologyVirtualNetworkInterface = "virtual_network_interface"
+	vpcTopologyInstance                = "instance"
+	vpcTopologyBareMetalServer         = "bare_metal_server"
+	vpcTopologyLoadBalancer            = "load_balancer"
+	vpcTopologyVPNGateway              = "vpn_gateway"
+	vpcTopologyEndpointGateway         = "endpoint_gateway"
+)
+
+var vpcTopologyTypeOrder = map[string]int{
+	vpcTopologyVPC:                     0,
+	vpcTopologyRoutingTable:            1,
+	vpcTopologyPublicGateway:           2,
+	vpcTopologySubnet:                  3,
+	vpcTopologyVirtualNetworkInterface: 4,
+	vpcTopologyInstance:                5,
+	vpcTopologyBareMetalServer:         6,
+	vpcTopologyLoadBalancer:            7,
+	vpcTopologyVPNGateway:              8,
+	vpcTopologyEndpointGateway:         9,
+}
+
+// vpcTopologyShapes are the Graphviz shapes of the types of nodes.
+var vpcTopologyShapes = map[string]string{
+	vpcTopologyVPC:                     "doubleoctagon",
+	vpcTopologyRoutingTable:            "note",
+	vpcTopologyPublicGateway:           "invhouse",
+	vpcTopologySubnet:                  "box",
+	vpcTopologyVirtualNetworkInterface: "ellipse",
+	vpcTopologyInstance:                "component",
+	vpcTopologyBareMetalServer:         "box3d",
+	vpcTopologyLoadBalancer:            "diamond",
+	vpcTopologyVPNGateway:              "hexagon",
+	vpcTopologyEndpointGateway:         "cds",
+}
+
+// vpcTopologyNode is a resource of a VPC topology.
+type vpcTopologyNode struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+	CRN  string `json:"crn,omitempty"`
+	Zone string `json:"zone,omitempty"`
+}
+
+// vpcTopologyEdge is a relation between two resources of a VPC topology, such
+// as an instance attached to a virtual network interface or a subnet in a
+// VPC. Edges point from the dependent resource to the resource it depends on.
+type vpcTopologyEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+// vpcTopology is a graph of the resources of a VPC. Nodes and edges can be
+// added in any order and more than once. Edges to nodes that are not in the
+// graph are left out when it is exported, so a relation to a resource that
+// wasn't collected doesn't dangle.
+type vpcTopology struct {
+	nodes map[string]vpcTopologyNode
+	edges map[vpcTopologyEdge]bool
+}
+
+func newVPCTopology() *vpcTopology {
+	return &vpcTopology{
+		nodes: map[string]vpcTopologyNode{},
+		edges: map[vpcTopologyEdge]bool{},
+	}
+}
+
+// addNode adds a node, or replaces the fields that are set of a node with the
+// same ID.
+func (t *vpcTopology) addNode(node vpcTopologyNode) {
+	existing := t.nodes[node.ID]
+	if node.Type == "" {
+		node.Type = existing.Type
+	}
+	if node.Name == "" {
+		node.Name = existing.Name
+	}
+	if node.CRN == "" {
+		node.CRN = existing.CRN
+	}
+	if node.Zone == "" {
+		node.Zone = existing.Zone
+	}
+	t.nodes[node.ID] = node
+}
+
+func (t *vpcTopology) addEdge(from, to, relation string) {
+	if from == "" || to == "" {
+		return
+	}
+	t.edges[vpcTopologyEdge{From: from, To: to, Relation: relation}] = true
+}
+
+// Nodes returns the nodes sorted by type, name and ID.
+func (t *vpcTopology) Nodes() []vpcTopologyNode {
+	nodes := make([]vpcTopologyNode, 0, len(t.nodes))
+	for _, node := range t.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if a.Type != b.Type {
+			return vpcTopologyTypeOrder[a.Type] < vpcTopologyTypeOrder[b.Type]
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+	return nodes
+}
+
+// Edges returns the edges between nodes of the graph, sorted by their nodes
+// and relation.
+func (t *vpcTopology) Edges() []vpcTopologyEdge {
+	edges := make([]vpcTopologyEdge, 0, len(t.edges))
+	for edge := range t.edges {
+		_, from := t.nodes[edge.From]
+		_, to := t.nodes[edge.To]
+		if from && to {
+			edges = append(edges, edge)
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Relation < b.Relation
+	})
+	return edges
+}
+
+// MarshalJSON returns the graph as an object with its nodes and edges.
+func (t *vpcTopology) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Nodes []vpcTopologyNode `json:"nodes"`
+		Edges []vpcTopologyEdge `json:"edges"`
+	}{t.Nodes(), t.Edges()})
+}
+
+// DOT returns the graph in the Graphviz DOT language. The nodes of a zone are
+// grouped in a cluster.
+func (t *vpcTopology) DOT(name string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", vpcTopologyDOTQuote(name))
+	b.WriteString("  rankdir=\"LR\";\n")
+	b.WriteString("  node [fontname=\"Helvetica\"];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\", fontsize=\"10\"];\n")
+
+	zones := map[string][]vpcTopologyNode{}
+	var zoneNames []string
+	for _, node := range t.Nodes() {
+		if node.Zone == "" {
+			fmt.Fprintf(&b, "  %s;\n", vpcTopologyDOTNode(node))
+			continue
+		}
+		if _, ok := zones[node.Zone]; !ok {
+			zoneNames = append(zoneNames, node.Zone)
+		}
+		zones[node.Zone] = append(zones[node.Zone], node)
+	}
+	sort.Strings(zoneNames)
+	for _, zone := range zoneNames {
+		fmt.Fprintf(&b, "  subgraph %s {\n", vpcTopologyDOTQuote("cluster_"+zone))
+		fmt.Fprintf(&b, "    label=%s;\n", vpcTopologyDOTQuote(zone))
+		for _, node := range zones[zone] {
+			fmt.Fprintf(&b, "    %s;\n", vpcTopologyDOTNode(node))
+		}
+		b.WriteString("  }\n")
+	}
+	for _, edge := range t.Edges() {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", vpcTopologyDOTQuote(edge.From), vpcTopologyDOTQuote(edge.To), vpcTopologyDOTQuote(edge.Relation))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func vpcTopologyDOTNode(node vpcTopologyNode) string {
+	shape, ok := vpcTopologyShapes[node.Type]
+	if !ok {
+		shape = "ellipse"
+	}
+	// The label is the type and the name of the node on two lines.
+	label := `"` + vpcTopologyDOTEscape(node.Type) + `\n` + vpcTopologyDOTEscape(node.Name) + `"`
+	return fmt.Sprintf("%s [label=%s, shape=%s]", vpcTopologyDOTQuote(node.ID), label, vpcTopologyDOTQuote(shape))
+}
+
+// vpcTopologyDOTQuote returns s as a DOT quoted string.
+func vpcTopologyDOTQuote(s string) string {
+	return `"` + vpcTopologyDOTEscape(s) + `"`
+}
+
+func vpcTopologyDOTEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// testVPCTopology returns a VPC with a subnet in each of two zones, an
+// instance attached to the first subnet through a virtual network interface,
+// and a public gateway.
+func testVPCTopology() *vpcTopology {
+	t := newVPCTopology()
+	// the subnets are added after their relations, which must not matter
+	t.addEdge("subnet-2", "vpc-1", "in")
+	t.addEdge("subnet-1", "vpc-1", "in")
+	t.addEdge("subnet-1", "pgw-1", "uses")
+	t.addNode(vpcTopologyNode{ID: "vpc-1", Type: vpcTopologyVPC, Name: "prod", CRN: "crn:v1:vpc-1"})
+	t.addNode(vpcTopologyNode{ID: "subnet-2", Type: vpcTopologySubnet, Name: "app", Zone: "us-south-2"})
+	t.addNode(vpcTopologyNode{ID: "subnet-1", Type: vpcTopologySubnet, Name: "app", Zone: "us-south-1"})
+	t.addNode(vpcTopologyNode{ID: "pgw-1", Type: vpcTopologyPublicGateway, Name: "egress", Zone: "us-south-1"})
+	t.addNode(vpcTopologyNode{ID: "instance-1", Type: vpcTopologyInstance, Name: "web", Zone: "us-south-1"})
+	t.addNode(vpcTopologyNode{ID: "vni-1", Type: vpcTopologyVirtualNetworkInterface, Name: "web-eth0"})
+	t.addEdge("instance-1", "vni-1", "attached to")
+	t.addEdge("vni-1", "subnet-1", "in")
+	// the VNI is seen again from its subnet, with its zone and no name
+	t.addNode(vpcTopologyNode{ID: "vni-1", Zone: "us-south-1"})
+	t.addEdge("vni-1", "subnet-1", "in")
+	// the routing table of the VPC wasn't collected
+	t.addEdge("subnet-1", "rt-1", "routed by")
+	t.addEdge("subnet-1", "", "routed by")
+	return t
+}
+
+func TestVPCTopologyNodes(t *testing.T) {
+	expected := []vpcTopologyNode{
+		{ID: "vpc-1", Type: vpcTopologyVPC, Name: "prod", CRN: "crn:v1:vpc-1"},
+		{ID: "pgw-1", Type: vpcTopologyPublicGateway, Name: "egress", Zone: "us-south-1"},
+		{ID: "subnet-1", Type: vpcTopologySubnet, Name: "app", Zone: "us-south-1"},
+		{ID: "subnet-2", Type: vpcTopologySubnet, Name: "app", Zone: "us-south-2"},
+		{ID: "vni-1", Type: vpcTopologyVirtualNetworkInterface, Name: "web-eth0", Zone: "us-south-1"},
+		{ID: "instance-1", Type: vpcTopologyInstance, Name: "web", Zone: "us-south-1"},
+	}
+	if nodes := testVPCTopology().Nodes(); !reflect.DeepEqual(nodes, expected) {
+		t.Errorf("Nodes() = %+v, expected %+v", nodes, expected)
+	}
+}
+
+func TestVPCTopologyEdges(t *testing.T) {
+	expected := []vpcTopologyEdge{
+		{From: "instance-1", To: "vni-1", Relation: "attached to"},
+		{From: "subnet-1", To: "pgw-1", Relation: "uses"},
+		{From: "subnet-1", To: "vpc-1", Relation: "in"},
+		{From: "subnet-2", To: "vpc-1", Relation: "in"},
+		{From: "vni-1", To: "subnet-1", Relation: "in"},
+	}
+	if edges := testVPCTopology().Edges(); !reflect.DeepEqual(edges, expected) {
+		t.Errorf("Edges() = %+v, expected %+v", edges, expected)
+	}
+}
+
+func TestVPCTopologyMarshalJSON(t *testing.T) {
+	topology := newVPCTopology()
+	topology.addNode(vpcTopologyNode{ID: "vpc-1", Type: vpcTopologyVPC, Name: "prod"})
+	topology.addNode(vpcTopologyNode{ID: "subnet-1", Type: vpcTopologySubnet, Name: "app", Zone: "us-south-1"})
+	topology.addEdge("subnet-1", "vpc-1", "in")
+	topology.addEdge("subnet-1", "rt-1", "routed by")
+
+	b, err := json.Marshal(topology)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %s", err)
+	}
+	expected := `{"nodes":[` +
+		`{"id":"vpc-1","type":"vpc","name":"prod"},` +
+		`{"id":"subnet-1","type":"subnet","name":"app","zone":"us-south-1"}],` +
+		`"edges":[{"from":"subnet-1","to":"vpc-1","relation":"in"}]}`
+	if string(b) != expected {
+		t.Errorf("MarshalJSON() = %s, expected %s", b, expected)
+	}
+
+	b, err = json.Marshal(newVPCTopology())
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %s", err)
+	}
+	if string(b) != `{"nodes":[],"edges":[]}` {
+		t.Errorf("MarshalJSON() of an empty topology = %s", b)
+	}
+}
+
+func TestVPCTopologyDOT(t *testing.T) {
+	expected := `digraph "prod" {
+  rankdir="LR";
+  node [fontname="Helvetica"];
+  edge [fontname="Helvetica", fontsize="10"];
+  "vpc-1" [label="vpc\nprod", shape="doubleoctagon"];
+  subgraph "cluster_us-south-1" {
+    label="us-south-1";
+    "pgw-1" [label="public_gateway\negress", shape="invhouse"];
+    "subnet-1" [label="subnet\napp", shape="box"];
+    "vni-1" [label="virtual_network_interface\nweb-eth0", shape="ellipse"];
+    "instance-1" [label="instance\nweb", shape="component"];
+  }
+  subgraph "cluster_us-south-2" {
+    label="us-south-2";
+    "subnet-2" [label="subnet\napp", shape="box"];
+  }
+  "instance-1" -> "vni-1" [label="attached to"];
+  "subnet-1" -> "pgw-1" [label="uses"];
+  "subnet-1" -> "vpc-1" [label="in"];
+  "subnet-2" -> "vpc-1" [label="in"];
+  "vni-1" -> "subnet-1" [label="in"];
+}
+`
+	if dot := testVPCTopology().DOT("prod"); dot != expected {
+		t.Errorf("DOT() = %s, expected %s", dot, expected)
+	}
+}
+
+func TestVPCTopologyDOTEscape(t *testing.T) {
+	topology := newVPCTopology()
+	topology.addNode(vpcTopologyNode{ID: `id"1`, Type: "unknown", Name: "a \"quoted\"\nname \\ with a backslash"})
+
+	expected := `digraph "my \"vpc\"" {
+  rankdir="LR";
+  node [fontname="Helvetica"];
+  edge [fontname="Helvetica", fontsize="10"];
+  "id\"1" [label="unknown\na \"quoted\"\nname \\ with a backslash", shape="ellipse"];
+}
+`
+	if dot := topology.DOT(`my "vpc"`); dot != expected {
+		t.Errorf("DOT() = %s, expected %s", dot, expected)
+	}
+
+	for s, escaped := range map[string]string{
+		"plain":      "plain",
+		`a"b`:        `a\"b`,
+		`a\b`:        `a\\b`,
+		`a\"b`:       `a\\\"b`,
+		"line\nline": `line\nline`,
+	} {
+		if e := vpcTopologyDOTEscape(s); e != escaped {
+			t.Errorf("vpcTopologyDOTEscape(%q) = %q, expected %q", s, e, escaped)
+		}
+	}
+}
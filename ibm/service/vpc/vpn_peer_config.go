@@ -0,0 +1,626 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"text/template"
+)
+
+// The devices a peer configuration is rendered for.
+const (
+	vpnPeerConfigStrongSwan = "strongswan"
+	vpnPeerConfigLibreswan  = "libreswan"
+	vpnPeerConfigCiscoIOS   = "cisco_ios"
+	vpnPeerConfigJuniperSRX = "juniper_srx"
+	vpnPeerConfigFortiGate  = "fortigate"
+)
+
+// vpnPeerConfig is a VPN gateway connection as seen from the peer. It is the
+// data the peer configuration templates are executed with.
+type vpnPeerConfig struct {
+	Name string
+	// Mode is the mode of the connection, policy or route.
+	Mode string
+	PSK  string
+	// Interface is the interface of the peer device facing the internet.
+	Interface string
+	Peer      vpnPeerConfigPeer
+	// GatewayCIDRs are the CIDRs behind the VPN gateway. In route mode, the
+	// CIDRs of both sides are 0.0.0.0/0 and the routes select the traffic.
+	GatewayCIDRs     []string
+	Tunnels          []vpnPeerConfigTunnel
+	TrafficSelectors []vpnPeerConfigTrafficSelector
+	IKE              vpnPeerConfigIKE
+	IPsec            vpnPeerConfigIPsec
+	DPD              vpnPeerConfigDPD
+	Names            vpnPeerConfigNames
+}
+
+type vpnPeerConfigPeer struct {
+	// Address is the IP address or the FQDN of the peer.
+	Address     string
+	IkeIdentity string
+	CIDRs       []string
+}
+
+// vpnPeerConfigTunnel is a tunnel from the peer to a member of the VPN
+// gateway. Tunnels are numbered from 1.
+type vpnPeerConfigTunnel struct {
+	Number             int
+	GatewayAddress     string
+	GatewayIkeIdentity string
+}
+
+// vpnPeerConfigTrafficSelector is a pair of a peer CIDR and a gateway CIDR.
+// Traffic selectors are numbered from 1.
+type vpnPeerConfigTrafficSelector struct {
+	Number      int
+	PeerCIDR    string
+	GatewayCIDR string
+}
+
+type vpnPeerConfigIKE struct {
+	Version                 int64
+	AuthenticationAlgorithm string
+	EncryptionAlgorithm     string
+	DhGroup                 int64
+	KeyLifetime             int64
+}
+
+type vpnPeerConfigIPsec struct {
+	AuthenticationAlgorithm string
+	EncryptionAlgorithm     string
+	// PfsGroup is 0 when perfect forward secrecy is disabled.
+	PfsGroup    int64
+	KeyLifetime int64
+}
+
+type vpnPeerConfigDPD struct {
+	Action   string
+	Interval int64
+	Timeout  int64
+}
+
+// vpnPeerConfigNames are the algorithms of a connection in the syntax of a
+// device. An algorithm that isn't used, such as the ESP integrity of a GCM
+// cipher or a disabled PFS group, has an empty name.
+type vpnPeerConfigNames struct {
+	IKEEncryption string
+	IKEIntegrity  string
+	DhGroup       string
+	ESPEncryption string
+	ESPIntegrity  string
+	PfsGroup      string
+}
+
+// The algorithms of a connection without an IKE or IPsec policy. The VPN
+// gateway negotiates the algorithms of such a connection, and accepts these.
+var (
+	vpnPeerConfigDefaultIKE = vpnPeerConfigIKE{
+		Version:                 2,
+		AuthenticationAlgorithm: "sha256",
+		EncryptionAlgorithm:     "aes256",
+		DhGroup:                 14,
+		KeyLifetime:             28800,
+	}
+	vpnPeerConfigDefaultIPsec = vpnPeerConfigIPsec{
+		AuthenticationAlgorithm: "sha256",
+		EncryptionAlgorithm:     "aes256",
+		KeyLifetime:             3600,
+	}
+)
+
+// vpnPeerConfigSyntax maps the algorithms of the VPC API to the names a device
+// uses for them. Algorithms missing from a map keep their name.
+type vpnPeerConfigSyntax struct {
+	ikeEncryption map[string]string
+	ikeIntegrity  map[string]string
+	espEncryption map[string]string
+	espIntegrity  map[string]string
+	group         func(group int64) string
+}
+
+// vpnPeerConfigModpGroups are the names of the Diffie-Hellman groups in
+// strongSwan and libreswan.
+var vpnPeerConfigModpGroups = map[int64]string{
+	2:  "modp1024",
+	5:  "modp1536",
+	14: "modp2048",
+	15: "modp3072",
+	16: "modp4096",
+	17: "modp6144",
+	18: "modp8192",
+	19: "ecp256",
+	20: "ecp384",
+	21: "ecp521",
+	22: "modp1024s160",
+	23: "modp2048s224",
+	24: "modp2048s256",
+	31: "curve25519",
+}
+
+func vpnPeerConfigGroupNumber(group int64) string {
+	return fmt.Sprintf("%d", group)
+}
+
+var vpnPeerConfigSyntaxes = map[string]vpnPeerConfigSyntax{
+	vpnPeerConfigStrongSwan: {
+		ikeEncryption: map[string]string{"triple_des": "3des"},
+		espEncryption: map[string]string{"triple_des": "3des"},
+		group: func(group int64) string {
+			return vpnPeerConfigModpGroups[group]
+		},
+	},
+	vpnPeerConfigLibreswan: {
+		ikeEncryption: map[string]string{"triple_des": "3des"},
+		ikeIntegrity:  map[string]string{"sha256": "sha2_256", "sha384": "sha2_384", "sha512": "sha2_512"},
+		espEncryption: map[string]string{"triple_des": "3des", "aes128gcm16": "aes_gcm128", "aes192gcm16": "aes_gcm192", "aes256gcm16": "aes_gcm256"},
+		espIntegrity:  map[string]string{"sha256": "sha2_256", "sha384": "sha2_384", "sha512": "sha2_512"},
+		group: func(group int64) string {
+			if name := vpnPeerConfigModpGroups[group]; strings.HasPrefix(name, "modp") {
+				return name
+			}
+			return fmt.Sprintf("dh%d", group)
+		},
+	},
+	vpnPeerConfigCiscoIOS: {
+		ikeEncryption: map[string]string{"aes128": "aes-cbc-128", "aes192": "aes-cbc-192", "aes256": "aes-cbc-256", "triple_des": "3des"},
+		espEncryption: map[string]string{"aes128": "esp-aes", "aes192": "esp-aes 192", "aes256": "esp-aes 256", "triple_des": "esp-3des", "aes128gcm16": "esp-gcm", "aes192gcm16": "esp-gcm 192", "aes256gcm16": "esp-gcm 256"},
+		espIntegrity:  map[string]string{"md5": "esp-md5-hmac", "sha1": "esp-sha-hmac", "sha256": "esp-sha256-hmac", "sha384": "esp-sha384-hmac", "sha512": "esp-sha512-hmac"},
+		group:         vpnPeerConfigGroupNumber,
+	},
+	vpnPeerConfigJuniperSRX: {
+		ikeEncryption: map[string]string{"aes128": "aes-128-cbc", "aes192": "aes-192-cbc", "aes256": "aes-256-cbc", "triple_des": "3des-cbc"},
+		ikeIntegrity:  map[string]string{"sha256": "sha-256", "sha384": "sha-384", "sha512": "sha-512"},
+		espEncryption: map[string]string{"aes128": "aes-128-cbc", "aes192": "aes-192-cbc", "aes256": "aes-256-cbc", "triple_des": "3des-cbc", "aes128gcm16": "aes-128-gcm", "aes192gcm16": "aes-192-gcm", "aes256gcm16": "aes-256-gcm"},
+		espIntegrity:  map[string]string{"md5": "hmac-md5-96", "sha1": "hmac-sha1-96", "sha256": "hmac-sha-256-128", "sha384": "hmac-sha-384", "sha512": "hmac-sha-512"},
+		group: func(group int64) string {
+			return fmt.Sprintf("group%d", group)
+		},
+	},
+	vpnPeerConfigFortiGate: {
+		ikeEncryption: map[string]string{"triple_des": "3des"},
+		espEncryption: map[string]string{"triple_des": "3des", "aes128gcm16": "aes128gcm", "aes192gcm16": "aes192gcm", "aes256gcm16": "aes256gcm"},
+		group:         vpnPeerConfigGroupNumber,
+	},
+}
+
+// vpnPeerConfigCiscoIKEv1Syntax is the syntax of the ISAKMP policies Cisco IOS
+// configures IKEv1 with.
+var vpnPeerConfigCiscoIKEv1Syntax = map[string]map[string]string{
+	"encryption": {"aes128": "aes", "aes192": "aes 192", "aes256": "aes 256", "triple_des": "3des"},
+	"integrity":  {"sha1": "sha"},
+}
+
+// SetNames sets the names of the algorithms of the connection in the syntax
+// of device. The names of an unknown device, such as the one of a custom
+// template, are the names of the VPC API.
+func (c *vpnPeerConfig) SetNames(device string) {
+	syntax, ok := vpnPeerConfigSyntaxes[device]
+	if !ok {
+		syntax = vpnPeerConfigSyntax{group: vpnPeerConfigGroupNumber}
+	}
+	if device == vpnPeerConfigCiscoIOS && c.IKE.Version == 1 {
+		syntax.ikeEncryption = vpnPeerConfigCiscoIKEv1Syntax["encryption"]
+		syntax.ikeIntegrity = vpnPeerConfigCiscoIKEv1Syntax["integrity"]
+	}
+	name := func(names map[string]string, algorithm string) string {
+		if algorithm == "disabled" {
+			return ""
+		}
+		if name, ok := names[algorithm]; ok {
+			return name
+		}
+		return algorithm
+	}
+	c.Names = vpnPeerConfigNames{
+		IKEEncryption: name(syntax.ikeEncryption, c.IKE.EncryptionAlgorithm),
+		IKEIntegrity:  name(syntax.ikeIntegrity, c.IKE.AuthenticationAlgorithm),
+		DhGroup:       syntax.group(c.IKE.DhGroup),
+		ESPEncryption: name(syntax.espEncryption, c.IPsec.EncryptionAlgorithm),
+		ESPIntegrity:  name(syntax.espIntegrity, c.IPsec.AuthenticationAlgorithm),
+	}
+	if c.IPsec.PfsGroup != 0 {
+		c.Names.PfsGroup = syntax.group(c.IPsec.PfsGroup)
+	}
+}
+
+// Render executes the template text with the connection.
+func (c *vpnPeerConfig) Render(text string) (string, error) {
+	tmpl, err := template.New("peer_config").Funcs(vpnPeerConfigFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, c); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// vpnPeerConfigFuncs are the functions available to the templates.
+var vpnPeerConfigFuncs = template.FuncMap{
+	"join": strings.Join,
+	"isIP": func(s string) bool {
+		return net.ParseIP(s) != nil
+	},
+	// address returns the address of a CIDR.
+	"address": func(cidr string) (string, error) {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		return network.IP.String(), nil
+	},
+	// netmask returns the netmask of a CIDR, such as 255.255.255.0.
+	"netmask": func(cidr string) (string, error) {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		return net.IP(network.Mask).String(), nil
+	},
+	// wildcard returns the inverse of the netmask of a CIDR, such as 0.0.0.255.
+	"wildcard": func(cidr string) (string, error) {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		wildcard := make(net.IP, len(network.Mask))
+		for i, b := range network.Mask {
+			wildcard[i] = ^b
+		}
+		return wildcard.String(), nil
+	},
+	"clamp": func(min, max, value int64) int64 {
+		if value < min {
+			return min
+		}
+		if value > max {
+			return max
+		}
+		return value
+	},
+	"div": func(a, b int64) int64 {
+		if b == 0 {
+			return 0
+		}
+		return a / b
+	},
+	// truncate shortens a name to the length limit of a device.
+	"truncate": func(length int, s string) string {
+		if len(s) > length {
+			return s[:length]
+		}
+		return s
+	},
+}
+
+// vpnPeerConfigTemplates are the templates of the devices.
+var vpnPeerConfigTemplates = map[string]string{
+	vpnPeerConfigStrongSwan: vpnPeerConfigStrongSwanTemplate,
+	vpnPeerConfigLibreswan:  vpnPeerConfigLibreswanTemplate,
+	vpnPeerConfigCiscoIOS:   vpnPeerConfigCiscoIOSTemplate,
+	vpnPeerConfigJuniperSRX: vpnPeerConfigJuniperSRXTemplate,
+	vpnPeerConfigFortiGate:  vpnPeerConfigFortiGateTemplate,
+}
+
+// vpnPeerConfigDefaultInterfaces are the interfaces facing the internet used
+// when none is set.
+var vpnPeerConfigDefaultInterfaces = map[string]string{
+	vpnPeerConfigCiscoIOS:   "GigabitEthernet1",
+	vpnPeerConfigJuniperSRX: "ge-0/0/0.0",
+	vpnPeerConfigFortiGate:  "wan1",
+}
+
+const vpnPeerConfigStrongSwanTemplate = `# strongSwan configuration of the peer of the VPN gateway connection {{ .Name }}.
+
+# /etc/ipsec.conf
+{{- range .Tunnels }}
+
+conn {{ $.Name }}-{{ .Number }}
+    keyexchange=ikev{{ $.IKE.Version }}
+    authby=psk
+    left=%defaultroute
+    leftid={{ $.Peer.IkeIdentity }}
+    leftsubnet={{ join $.Peer.CIDRs "," }}
+    right={{ .GatewayAddress }}
+    rightid={{ .GatewayIkeIdentity }}
+    rightsubnet={{ join $.GatewayCIDRs "," }}
+    ike={{ $.Names.IKEEncryption }}-{{ $.Names.IKEIntegrity }}-{{ $.Names.DhGroup }}!
+    esp={{ $.Names.ESPEncryption }}{{ with $.Names.ESPIntegrity }}-{{ . }}{{ end }}{{ with $.Names.PfsGroup }}-{{ . }}{{ end }}!
+    ikelifetime={{ $.IKE.KeyLifetime }}s
+    lifetime={{ $.IPsec.KeyLifetime }}s
+{{- if ne $.DPD.Action "none" }}
+    dpddelay={{ $.DPD.Interval }}s
+    dpdtimeout={{ $.DPD.Timeout }}s
+    dpdaction={{ $.DPD.Action }}
+{{- end }}
+{{- if eq $.Mode "route" }}
+    mark={{ .Number }}
+{{- end }}
+    auto=start
+{{- end }}
+
+# /etc/ipsec.secrets
+{{- range .Tunnels }}
+{{ $.Peer.IkeIdentity }} {{ .GatewayIkeIdentity }} : PSK "{{ $.PSK }}"
+{{- end }}
+{{- if eq .Mode "route" }}
+
+# Create a VTI for each tunnel and route the VPC subnets through it, for example:
+{{- range .Tunnels }}
+#   ip link add vti{{ .Number }} type vti local <peer address> remote {{ .GatewayAddress }} key {{ .Number }}
+#   ip link set vti{{ .Number }} up
+#   sysctl -w net.ipv4.conf.vti{{ .Number }}.disable_policy=1
+{{- end }}
+# and set install_routes = no in the charon section of /etc/strongswan.conf.
+{{- end }}
+`
+
+const vpnPeerConfigLibreswanTemplate = `# libreswan configuration of the peer of the VPN gateway connection {{ .Name }}.
+
+# /etc/ipsec.d/{{ .Name }}.conf
+{{- range .Tunnels }}
+
+conn {{ $.Name }}-{{ .Number }}
+    ikev2={{ if eq $.IKE.Version 1 }}no{{ else }}insist{{ end }}
+    authby=secret
+    left=%defaultroute
+    leftid={{ $.Peer.IkeIdentity }}
+    leftsubnets={{ "{" }}{{ join $.Peer.CIDRs " " }}{{ "}" }}
+    right={{ .GatewayAddress }}
+    rightid={{ .GatewayIkeIdentity }}
+    rightsubnets={{ "{" }}{{ join $.GatewayCIDRs " " }}{{ "}" }}
+    ike={{ $.Names.IKEEncryption }}-{{ $.Names.IKEIntegrity }}-{{ $.Names.DhGroup }}
+    esp={{ $.Names.ESPEncryption }}{{ with $.Names.ESPIntegrity }}-{{ . }}{{ end }}{{ with $.Names.PfsGroup }}-{{ . }}{{ end }}
+    pfs={{ if $.Names.PfsGroup }}yes{{ else }}no{{ end }}
+    ikelifetime={{ $.IKE.KeyLifetime }}s
+    salifetime={{ $.IPsec.KeyLifetime }}s
+{{- if ne $.DPD.Action "none" }}
+    dpddelay={{ $.DPD.Interval }}
+    dpdtimeout={{ $.DPD.Timeout }}
+    dpdaction={{ $.DPD.Action }}
+{{- end }}
+{{- if eq $.Mode "route" }}
+    mark={{ .Number }}/0xffffffff
+    vti-interface=vti{{ .Number }}
+    vti-routing=no
+{{- end }}
+    auto=start
+{{- end }}
+
+# /etc/ipsec.d/{{ .Name }}.secrets
+{{- range .Tunnels }}
+{{ $.Peer.IkeIdentity }} {{ .GatewayIkeIdentity }} : PSK "{{ $.PSK }}"
+{{- end }}
+{{- if eq .Mode "route" }}
+
+# Route the VPC subnets through the VTI of each tunnel, for example:
+{{- range .Tunnels }}
+#   ip route add <VPC subnet> dev vti{{ .Number }}
+{{- end }}
+{{- end }}
+`
+
+const vpnPeerConfigCiscoIOSTemplate = `! Cisco IOS configuration of the peer of the VPN gateway connection {{ .Name }}.
+! {{ .Interface }} is the interface facing the internet.
+!
+{{- if eq .IKE.Version 1 }}
+crypto isakmp policy 10
+ encryption {{ .Names.IKEEncryption }}
+ hash {{ .Names.IKEIntegrity }}
+ authentication pre-share
+ group {{ .Names.DhGroup }}
+ lifetime {{ .IKE.KeyLifetime }}
+!
+{{- range .Tunnels }}
+crypto isakmp key {{ $.PSK }} address {{ .GatewayAddress }}
+{{- end }}
+{{- if ne .DPD.Action "none" }}
+crypto isakmp keepalive {{ clamp 10 3600 .DPD.Interval }} {{ clamp 2 60 .DPD.Interval }} periodic
+{{- end }}
+!
+{{- else }}
+crypto ikev2 proposal {{ .Name }}
+ encryption {{ .Names.IKEEncryption }}
+ integrity {{ .Names.IKEIntegrity }}
+ group {{ .Names.DhGroup }}
+!
+crypto ikev2 policy {{ .Name }}
+ proposal {{ .Name }}
+!
+crypto ikev2 keyring {{ .Name }}
+{{- range .Tunnels }}
+ peer {{ $.Name }}-{{ .Number }}
+  address {{ .GatewayAddress }}
+  pre-shared-key {{ $.PSK }}
+{{- end }}
+!
+{{- range .Tunnels }}
+crypto ikev2 profile {{ $.Name }}-{{ .Number }}
+{{- if isIP .GatewayIkeIdentity }}
+ match identity remote address {{ .GatewayIkeIdentity }} 255.255.255.255
+{{- else }}
+ match identity remote fqdn {{ .GatewayIkeIdentity }}
+{{- end }}
+{{- if isIP $.Peer.IkeIdentity }}
+ identity local address {{ $.Peer.IkeIdentity }}
+{{- else }}
+ identity local fqdn {{ $.Peer.IkeIdentity }}
+{{- end }}
+ authentication remote pre-share
+ authentication local pre-share
+ keyring local {{ $.Name }}
+ lifetime {{ $.IKE.KeyLifetime }}
+{{- if ne $.DPD.Action "none" }}
+ dpd {{ clamp 10 3600 $.DPD.Interval }} {{ clamp 2 60 $.DPD.Interval }} periodic
+{{- end }}
+!
+{{- end }}
+{{- end }}
+crypto ipsec transform-set {{ .Name }} {{ .Names.ESPEncryption }}{{ with .Names.ESPIntegrity }} {{ . }}{{ end }}
+ mode tunnel
+!
+{{- if eq .Mode "policy" }}
+ip access-list extended {{ .Name }}
+{{- range .TrafficSelectors }}
+ permit ip {{ address .PeerCIDR }} {{ wildcard .PeerCIDR }} {{ address .GatewayCIDR }} {{ wildcard .GatewayCIDR }}
+{{- end }}
+!
+{{- range .Tunnels }}
+crypto map {{ $.Name }} {{ .Number }}0 ipsec-isakmp
+ set peer {{ .GatewayAddress }}
+ set transform-set {{ $.Name }}
+{{- with $.Names.PfsGroup }}
+ set pfs group{{ . }}
+{{- end }}
+ set security-association lifetime seconds {{ $.IPsec.KeyLifetime }}
+{{- if ne $.IKE.Version 1 }}
+ set ikev2-profile {{ $.Name }}-{{ .Number }}
+{{- end }}
+ match address {{ $.Name }}
+!
+{{- end }}
+interface {{ .Interface }}
+ crypto map {{ .Name }}
+!
+{{- else }}
+{{- range .Tunnels }}
+crypto ipsec profile {{ $.Name }}-{{ .Number }}
+ set transform-set {{ $.Name }}
+{{- with $.Names.PfsGroup }}
+ set pfs group{{ . }}
+{{- end }}
+ set security-association lifetime seconds {{ $.IPsec.KeyLifetime }}
+{{- if ne $.IKE.Version 1 }}
+ set ikev2-profile {{ $.Name }}-{{ .Number }}
+{{- end }}
+!
+interface Tunnel{{ .Number }}
+ description {{ $.Name }} to {{ .GatewayAddress }}
+ ip unnumbered {{ $.Interface }}
+ tunnel source {{ $.Interface }}
+ tunnel mode ipsec ipv4
+ tunnel destination {{ .GatewayAddress }}
+ tunnel protection ipsec profile {{ $.Name }}-{{ .Number }}
+!
+{{- end }}
+! Route the VPC subnets through the tunnel interfaces, for example:
+{{- range .Tunnels }}
+!   ip route <VPC subnet address> <VPC subnet netmask> Tunnel{{ .Number }}
+{{- end }}
+{{- end }}
+`
+
+const vpnPeerConfigJuniperSRXTemplate = `# Juniper SRX configuration of the peer of the VPN gateway connection {{ .Name }}.
+# {{ .Interface }} is the interface facing the internet.
+set security ike proposal {{ .Name }} authentication-method pre-shared-keys
+set security ike proposal {{ .Name }} dh-group {{ .Names.DhGroup }}
+set security ike proposal {{ .Name }} authentication-algorithm {{ .Names.IKEIntegrity }}
+set security ike proposal {{ .Name }} encryption-algorithm {{ .Names.IKEEncryption }}
+set security ike proposal {{ .Name }} lifetime-seconds {{ .IKE.KeyLifetime }}
+{{- if eq .IKE.Version 1 }}
+set security ike policy {{ .Name }} mode main
+{{- end }}
+set security ike policy {{ .Name }} proposals {{ .Name }}
+set security ike policy {{ .Name }} pre-shared-key ascii-text "{{ .PSK }}"
+{{- range .Tunnels }}
+set security ike gateway {{ $.Name }}-{{ .Number }} ike-policy {{ $.Name }}
+set security ike gateway {{ $.Name }}-{{ .Number }} address {{ .GatewayAddress }}
+set security ike gateway {{ $.Name }}-{{ .Number }} external-interface {{ $.Interface }}
+set security ike gateway {{ $.Name }}-{{ .Number }} local-identity {{ if isIP $.Peer.IkeIdentity }}inet{{ else }}hostname{{ end }} {{ $.Peer.IkeIdentity }}
+set security ike gateway {{ $.Name }}-{{ .Number }} remote-identity {{ if isIP .GatewayIkeIdentity }}inet{{ else }}hostname{{ end }} {{ .GatewayIkeIdentity }}
+set security ike gateway {{ $.Name }}-{{ .Number }} version v{{ $.IKE.Version }}-only
+{{- if ne $.DPD.Action "none" }}
+set security ike gateway {{ $.Name }}-{{ .Number }} dead-peer-detection interval {{ clamp 2 60 $.DPD.Interval }}
+set security ike gateway {{ $.Name }}-{{ .Number }} dead-peer-detection threshold {{ clamp 1 5 (div $.DPD.Timeout $.DPD.Interval) }}
+{{- end }}
+{{- end }}
+set security ipsec proposal {{ .Name }} protocol esp
+{{- with .Names.ESPIntegrity }}
+set security ipsec proposal {{ $.Name }} authentication-algorithm {{ . }}
+{{- end }}
+set security ipsec proposal {{ .Name }} encryption-algorithm {{ .Names.ESPEncryption }}
+set security ipsec proposal {{ .Name }} lifetime-seconds {{ .IPsec.KeyLifetime }}
+set security ipsec policy {{ .Name }} proposals {{ .Name }}
+{{- with .Names.PfsGroup }}
+set security ipsec policy {{ $.Name }} perfect-forward-secrecy keys {{ . }}
+{{- end }}
+{{- range $tunnel := .Tunnels }}
+set interfaces st0 unit {{ .Number }} family inet
+set security ipsec vpn {{ $.Name }}-{{ .Number }} bind-interface st0.{{ .Number }}
+set security ipsec vpn {{ $.Name }}-{{ .Number }} ike gateway {{ $.Name }}-{{ .Number }}
+set security ipsec vpn {{ $.Name }}-{{ .Number }} ike ipsec-policy {{ $.Name }}
+{{- if eq $.Mode "policy" }}
+{{- range $.TrafficSelectors }}
+set security ipsec vpn {{ $.Name }}-{{ $tunnel.Number }} traffic-selector ts{{ .Number }} local-ip {{ .PeerCIDR }}
+set security ipsec vpn {{ $.Name }}-{{ $tunnel.Number }} traffic-selector ts{{ .Number }} remote-ip {{ .GatewayCIDR }}
+{{- end }}
+{{- end }}
+set security ipsec vpn {{ $.Name }}-{{ .Number }} establish-tunnels immediately
+{{- end }}
+# Add the st0 units to a security zone, allow the traffic with security policies
+{{- if eq .Mode "route" }}
+# and route the VPC subnets through the st0 units.
+{{- else }}
+# and route the VPC subnets through the st0 units:
+{{- range $tunnel := .Tunnels }}
+{{- range $.GatewayCIDRs }}
+set routing-options static route {{ . }} next-hop st0.{{ $tunnel.Number }}
+{{- end }}
+{{- end }}
+{{- end }}
+`
+
+const vpnPeerConfigFortiGateTemplate = `# FortiGate configuration of the peer of the VPN gateway connection {{ .Name }}.
+# {{ .Interface }} is the interface facing the internet.
+config vpn ipsec phase1-interface
+{{- range .Tunnels }}
+    edit "{{ truncate 13 $.Name }}-{{ .Number }}"
+        set interface "{{ $.Interface }}"
+        set ike-version {{ $.IKE.Version }}
+        set peertype any
+        set net-device disable
+        set proposal {{ $.Names.IKEEncryption }}-{{ $.Names.IKEIntegrity }}
+        set dhgrp {{ $.Names.DhGroup }}
+        set keylife {{ $.IKE.KeyLifetime }}
+        set localid "{{ $.Peer.IkeIdentity }}"
+        set remote-gw {{ .GatewayAddress }}
+        set psksecret "{{ $.PSK }}"
+{{- if eq $.DPD.Action "none" }}
+        set dpd disable
+{{- else }}
+        set dpd on-idle
+        set dpd-retryinterval {{ clamp 1 3600 $.DPD.Interval }}
+        set dpd-retrycount {{ clamp 1 10 (div $.DPD.Timeout $.DPD.Interval) }}
+{{- end }}
+    next
+{{- end }}
+end
+config vpn ipsec phase2-interface
+{{- range $tunnel := .Tunnels }}
+{{- range $.TrafficSelectors }}
+    edit "{{ truncate 13 $.Name }}-{{ $tunnel.Number }}-{{ .Number }}"
+        set phase1name "{{ truncate 13 $.Name }}-{{ $tunnel.Number }}"
+        set proposal {{ $.Names.ESPEncryption }}{{ with $.Names.ESPIntegrity }}-{{ . }}{{ end }}
+{{- if $.Names.PfsGroup }}
+        set pfs enable
+        set dhgrp {{ $.Names.PfsGroup }}
+{{- else }}
+        set pfs disable
+{{- end }}
+        set keylifeseconds {{ $.IPsec.KeyLifetime }}
+        set src-subnet {{ address .PeerCIDR }} {{ netmask .PeerCIDR }}
+        set dst-subnet {{ address .GatewayCIDR }} {{ netmask .GatewayCIDR }}
+        set auto-negotiate enable
+    next
+{{- end }}
+{{- end }}
+end
+# Allow the traffic with firewall policies and route the VPC subnets through the tunnel interfaces.
+`
@@ -0,0 +1,213 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"strings"
+	"testing"
+)
+
+// testVPNPeerConfig returns a policy mode connection with two tunnels, a
+// traffic selector, PFS and dead peer detection.
+func testVPNPeerConfig() vpnPeerConfig {
+	return vpnPeerConfig{
+		Name: "vpn-conn",
+		Mode: "policy",
+		PSK:  "secret",
+		Peer: vpnPeerConfigPeer{
+			Address:     "203.0.113.10",
+			IkeIdentity: "203.0.113.10",
+			CIDRs:       []string{"192.168.0.0/24"},
+		},
+		GatewayCIDRs: []string{"10.240.0.0/24"},
+		Tunnels: []vpnPeerConfigTunnel{
+			{Number: 1, GatewayAddress: "169.61.0.1", GatewayIkeIdentity: "169.61.0.1"},
+			{Number: 2, GatewayAddress: "169.61.0.2", GatewayIkeIdentity: "169.61.0.2"},
+		},
+		TrafficSelectors: []vpnPeerConfigTrafficSelector{
+			{Number: 1, PeerCIDR: "192.168.0.0/24", GatewayCIDR: "10.240.0.0/24"},
+		},
+		IKE: vpnPeerConfigIKE{
+			Version:                 2,
+			AuthenticationAlgorithm: "sha256",
+			EncryptionAlgorithm:     "aes256",
+			DhGroup:                 14,
+			KeyLifetime:             28800,
+		},
+		IPsec: vpnPeerConfigIPsec{
+			AuthenticationAlgorithm: "sha384",
+			EncryptionAlgorithm:     "aes256",
+			PfsGroup:                19,
+			KeyLifetime:             3600,
+		},
+		DPD: vpnPeerConfigDPD{
+			Action:   "restart",
+			Interval: 30,
+			Timeout:  120,
+		},
+	}
+}
+
+func TestVPNPeerConfigRender(t *testing.T) {
+	expected := map[string][]string{
+		vpnPeerConfigStrongSwan: {
+			"conn vpn-conn-1\n",
+			"conn vpn-conn-2\n",
+			"    keyexchange=ikev2\n",
+			"    leftsubnet=192.168.0.0/24\n",
+			"    right=169.61.0.2\n",
+			"    rightsubnet=10.240.0.0/24\n",
+			"    ike=aes256-sha256-modp2048!\n",
+			"    esp=aes256-sha384-ecp256!\n",
+			"    dpdaction=restart\n",
+			"203.0.113.10 169.61.0.1 : PSK \"secret\"\n",
+		},
+		vpnPeerConfigLibreswan: {
+			"# /etc/ipsec.d/vpn-conn.conf\n",
+			"conn vpn-conn-2\n",
+			"    ikev2=insist\n",
+			"    leftsubnets={192.168.0.0/24}\n",
+			"    rightsubnets={10.240.0.0/24}\n",
+			"    ike=aes256-sha2_256-modp2048\n",
+			"    esp=aes256-sha2_384-dh19\n",
+			"    pfs=yes\n",
+			"    salifetime=3600s\n",
+			"    dpddelay=30\n",
+			"203.0.113.10 169.61.0.2 : PSK \"secret\"\n",
+		},
+		vpnPeerConfigCiscoIOS: {
+			"crypto ikev2 proposal vpn-conn\n encryption aes-cbc-256\n integrity sha256\n group 14\n",
+			"  address 169.61.0.2\n  pre-shared-key secret\n",
+			" match identity remote address 169.61.0.1 255.255.255.255\n",
+			" identity local address 203.0.113.10\n",
+			" dpd 30 30 periodic\n",
+			"crypto ipsec transform-set vpn-conn esp-aes 256 esp-sha384-hmac\n",
+			" permit ip 192.168.0.0 0.0.0.255 10.240.0.0 0.0.0.255\n",
+			"crypto map vpn-conn 20 ipsec-isakmp\n set peer 169.61.0.2\n",
+			" set pfs group19\n",
+			" set ikev2-profile vpn-conn-1\n",
+			"interface GigabitEthernet1\n crypto map vpn-conn\n",
+		},
+		vpnPeerConfigJuniperSRX: {
+			"set security ike proposal vpn-conn dh-group group14\n",
+			"set security ike proposal vpn-conn authentication-algorithm sha-256\n",
+			"set security ike proposal vpn-conn encryption-algorithm aes-256-cbc\n",
+			"set security ike gateway vpn-conn-2 address 169.61.0.2\n",
+			"set security ike gateway vpn-conn-1 external-interface ge-0/0/0.0\n",
+			"set security ike gateway vpn-conn-1 local-identity inet 203.0.113.10\n",
+			"set security ike gateway vpn-conn-1 version v2-only\n",
+			"set security ike gateway vpn-conn-1 dead-peer-detection threshold 4\n",
+			"set security ipsec proposal vpn-conn authentication-algorithm hmac-sha-384\n",
+			"set security ipsec policy vpn-conn perfect-forward-secrecy keys group19\n",
+			"set security ipsec vpn vpn-conn-2 traffic-selector ts1 local-ip 192.168.0.0/24\n",
+			"set routing-options static route 10.240.0.0/24 next-hop st0.1\n",
+		},
+		vpnPeerConfigFortiGate: {
+			"    edit \"vpn-conn-1\"\n        set interface \"wan1\"\n        set ike-version 2\n",
+			"        set proposal aes256-sha256\n        set dhgrp 14\n",
+			"        set remote-gw 169.61.0.2\n",
+			"        set dpd-retryinterval 30\n        set dpd-retrycount 4\n",
+			"    edit \"vpn-conn-2-1\"\n        set phase1name \"vpn-conn-2\"\n        set proposal aes256-sha384\n        set pfs enable\n        set dhgrp 19\n",
+			"        set src-subnet 192.168.0.0 255.255.255.0\n        set dst-subnet 10.240.0.0 255.255.255.0\n",
+		},
+	}
+
+	for device, text := range vpnPeerConfigTemplates {
+		t.Run(device, func(t *testing.T) {
+			lines, ok := expected[device]
+			if !ok {
+				t.Fatalf("no expected output for the %s template", device)
+			}
+			c := testVPNPeerConfig()
+			c.Interface = vpnPeerConfigDefaultInterfaces[device]
+			c.SetNames(device)
+
+			config, err := c.Render(text)
+			if err != nil {
+				t.Fatalf("Render() returned error: %s", err)
+			}
+			if strings.Contains(config, "<no value>") {
+				t.Errorf("Render() output contains a missing value:\n%s", config)
+			}
+			for _, line := range lines {
+				if !strings.Contains(config, line) {
+					t.Errorf("Render() output does not contain %q:\n%s", line, config)
+				}
+			}
+		})
+	}
+}
+
+func TestVPNPeerConfigRenderRouteMode(t *testing.T) {
+	expected := map[string]string{
+		vpnPeerConfigStrongSwan: "    mark=2\n",
+		vpnPeerConfigLibreswan:  "    vti-interface=vti2\n",
+		vpnPeerConfigCiscoIOS:   "interface Tunnel2\n description vpn-conn to 169.61.0.2\n",
+		vpnPeerConfigJuniperSRX: "set security ipsec vpn vpn-conn-2 bind-interface st0.2\n",
+		vpnPeerConfigFortiGate:  "        set src-subnet 0.0.0.0 0.0.0.0\n",
+	}
+
+	for device, text := range vpnPeerConfigTemplates {
+		t.Run(device, func(t *testing.T) {
+			c := testVPNPeerConfig()
+			c.Mode = "route"
+			c.Peer.CIDRs = []string{"0.0.0.0/0"}
+			c.GatewayCIDRs = []string{"0.0.0.0/0"}
+			c.TrafficSelectors = []vpnPeerConfigTrafficSelector{{Number: 1, PeerCIDR: "0.0.0.0/0", GatewayCIDR: "0.0.0.0/0"}}
+			c.DPD.Action = "none"
+			c.IPsec.PfsGroup = 0
+			c.Interface = vpnPeerConfigDefaultInterfaces[device]
+			c.SetNames(device)
+
+			config, err := c.Render(text)
+			if err != nil {
+				t.Fatalf("Render() returned error: %s", err)
+			}
+			if strings.Contains(config, "<no value>") || strings.Contains(config, "dpd-retrycount") || strings.Contains(config, "dpdaction") {
+				t.Errorf("Render() output contains a missing value or a disabled setting:\n%s", config)
+			}
+			if !strings.Contains(config, expected[device]) {
+				t.Errorf("Render() output does not contain %q:\n%s", expected[device], config)
+			}
+		})
+	}
+}
+
+func TestVPNPeerConfigRenderCiscoIKEv1(t *testing.T) {
+	c := testVPNPeerConfig()
+	c.IKE.Version = 1
+	c.IKE.AuthenticationAlgorithm = "sha1"
+	c.Interface = vpnPeerConfigDefaultInterfaces[vpnPeerConfigCiscoIOS]
+	c.SetNames(vpnPeerConfigCiscoIOS)
+
+	config, err := c.Render(vpnPeerConfigCiscoIOSTemplate)
+	if err != nil {
+		t.Fatalf("Render() returned error: %s", err)
+	}
+	for _, line := range []string{
+		"crypto isakmp policy 10\n encryption aes 256\n hash sha\n authentication pre-share\n group 14\n",
+		"crypto isakmp key secret address 169.61.0.1\n",
+		"crypto isakmp keepalive 30 30 periodic\n",
+	} {
+		if !strings.Contains(config, line) {
+			t.Errorf("Render() output does not contain %q:\n%s", line, config)
+		}
+	}
+	if strings.Contains(config, "ikev2") {
+		t.Errorf("Render() output of an IKEv1 connection contains IKEv2 settings:\n%s", config)
+	}
+}
+
+func TestVPNPeerConfigRenderErrors(t *testing.T) {
+	c := testVPNPeerConfig()
+	for name, text := range map[string]string{
+		"parse error":   "{{ .Name ",
+		"unknown field": "{{ .Unknown }}",
+		"invalid CIDR":  `{{ address "10.0.0.1" }}`,
+	} {
+		if _, err := c.Render(text); err == nil {
+			t.Errorf("%s: Render(%q) returned no error", name, text)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package unittest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// MockCollection is an in-memory REST collection served by a MockServer. It implements
+// the create/list/get/update/delete conventions shared by most IBM Cloud APIs:
+//
+//	POST   {path}       creates an item from the JSON body
+//	GET    {path}       lists the items under the {listKey} property
+//	GET    {path}/{id}  returns an item
+//	PATCH  {path}/{id}  merges the JSON body into an item
+//	DELETE {path}/{id}  removes an item
+type MockCollection struct {
+	// OnCreate is called with every new item before it is stored, so that tests can
+	// fill in server generated properties such as status, crn or href.
+	OnCreate func(item map[string]interface{})
+	// OnGet is called with every item before it is returned.
+	OnGet func(item map[string]interface{})
+
+	path    string
+	listKey string
+
+	mu    sync.Mutex
+	seq   int
+	items map[string]map[string]interface{}
+}
+
+// Collection registers an in-memory collection on the mock server.
+func (m *MockServer) Collection(path, listKey string) *MockCollection {
+	c := &MockCollection{
+		path:    path,
+		listKey: listKey,
+		items:   map[string]map[string]interface{}{},
+	}
+	m.Handle(http.MethodPost, path, c.create)
+	m.Handle(http.MethodGet, path, c.list)
+	m.Handle(http.MethodGet, path+"/{id}", c.get)
+	m.Handle(http.MethodPatch, path+"/{id}", c.update)
+	m.Handle(http.MethodDelete, path+"/{id}", c.delete)
+	return c
+}
+
+// Put stores item, which must have a string "id" property.
+func (c *MockCollection) Put(item map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[item["id"].(string)] = item
+}
+
+// Get returns a copy of the stored item, or nil.
+func (c *MockCollection) Get(id string) map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if item, ok := c.items[id]; ok {
+		return copyItem(item)
+	}
+	return nil
+}
+
+// Len returns the number of stored items.
+func (c *MockCollection) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+func (c *MockCollection) create(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	item := ReadJSON(r)
+	c.mu.Lock()
+	c.seq++
+	if _, ok := item["id"]; !ok {
+		item["id"] = fmt.Sprintf("r006-mock-%04d", c.seq)
+	}
+	c.mu.Unlock()
+	if c.OnCreate != nil {
+		c.OnCreate(item)
+	}
+	c.Put(item)
+	WriteJSON(w, http.StatusCreated, c.view(item))
+}
+
+func (c *MockCollection) list(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	c.mu.Lock()
+	ids := make([]string, 0, len(c.items))
+	for id := range c.items {
+		ids = append(ids, id)
+	}
+	c.mu.Unlock()
+	sort.Strings(ids)
+
+	items := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if item := c.Get(id); item != nil {
+			items = append(items, c.view(item))
+		}
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		c.listKey:     items,
+		"limit":       len(items) + 1,
+		"total_count": len(items),
+	})
+}
+
+func (c *MockCollection) get(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	item := c.Get(params["id"])
+	if item == nil {
+		WriteError(w, http.StatusNotFound, "not_found", fmt.Sprintf("%s/%s not found", c.path, params["id"]))
+		return
+	}
+	WriteJSON(w, http.StatusOK, c.view(item))
+}
+
+func (c *MockCollection) update(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	item := c.Get(params["id"])
+	if item == nil {
+		WriteError(w, http.StatusNotFound, "not_found", fmt.Sprintf("%s/%s not found", c.path, params["id"]))
+		return
+	}
+	for k, v := range ReadJSON(r) {
+		item[k] = v
+	}
+	c.Put(item)
+	WriteJSON(w, http.StatusOK, c.view(item))
+}
+
+func (c *MockCollection) delete(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	c.mu.Lock()
+	_, ok := c.items[params["id"]]
+	delete(c.items, params["id"])
+	c.mu.Unlock()
+	if !ok {
+		WriteError(w, http.StatusNotFound, "not_found", fmt.Sprintf("%s/%s not found", c.path, params["id"]))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *MockCollection) view(item map[string]interface{}) map[string]interface{} {
+	item = copyItem(item)
+	if c.OnGet != nil {
+		c.OnGet(item)
+	}
+	return item
+}
+
+func copyItem(item map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		out[k] = v
+	}
+	return out
+}
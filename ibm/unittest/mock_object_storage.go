@@ -0,0 +1,297 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package unittest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockBucketSubresources are the bucket configuration sub-resources that are stored
+// verbatim by PUT and returned by GET.
+var mockBucketSubresources = map[string]string{
+	"lifecycle":   "NoSuchLifecycleConfiguration: The lifecycle configuration does not exist",
+	"protection":  "",
+	"versioning":  "",
+	"object-lock": "ObjectLockConfigurationNotFoundError: Object Lock configuration does not exist for this bucket",
+	"website":     "NoSuchWebsiteConfiguration: The specified bucket does not have a website configuration",
+	"replication": "ReplicationConfigurationNotFoundError: The replication configuration was not found",
+	"cors":        "NoSuchCORSConfiguration: The CORS configuration does not exist",
+}
+
+// MockBucket is a bucket held by MockObjectStorage.
+type MockBucket struct {
+	Name               string
+	LocationConstraint string
+	Created            time.Time
+	Config             map[string][]byte
+	Objects            map[string][]byte
+}
+
+// MockObjectStorage emulates the path-style S3 API of Cloud Object Storage on its own
+// listener, together with the bucket configuration API on the main mock server.
+type MockObjectStorage struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	buckets map[string]*MockBucket
+}
+
+// ObjectStorage starts the COS emulation. The S3 endpoint is exported as
+// IBMCLOUD_COS_ENDPOINT by Env.
+func (m *MockServer) ObjectStorage(t testing.TB) *MockObjectStorage {
+	cos := &MockObjectStorage{buckets: map[string]*MockBucket{}}
+	cos.Server = httptest.NewServer(http.HandlerFunc(cos.serveS3))
+	t.Cleanup(cos.Server.Close)
+
+	m.mu.Lock()
+	m.cosURL = cos.URL
+	m.mu.Unlock()
+
+	m.Handle(http.MethodGet, "/v1/b/{bucket}", cos.getBucketConfig)
+	m.Handle(http.MethodPatch, "/v1/b/{bucket}", cos.getBucketConfig)
+	return cos
+}
+
+// Bucket returns the named bucket, or nil.
+func (cos *MockObjectStorage) Bucket(name string) *MockBucket {
+	cos.mu.Lock()
+	defer cos.mu.Unlock()
+	return cos.buckets[name]
+}
+
+// PutObject stores an object in an existing bucket.
+func (cos *MockObjectStorage) PutObject(bucket, key string, body []byte) {
+	cos.mu.Lock()
+	defer cos.mu.Unlock()
+	if b, ok := cos.buckets[bucket]; ok {
+		b.Objects[key] = body
+	}
+}
+
+func (cos *MockObjectStorage) getBucketConfig(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	b := cos.Bucket(params["bucket"])
+	if b == nil {
+		WriteError(w, http.StatusNotFound, "bucket_not_found", "The specified bucket does not exist")
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"name":         b.Name,
+		"time_created": b.Created.Format(time.RFC3339),
+		"object_count": len(b.Objects),
+		"bytes_used":   0,
+	})
+}
+
+func (cos *MockObjectStorage) serveS3(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if path == "" {
+		cos.listBuckets(w, r)
+		return
+	}
+	bucket, key, _ := strings.Cut(path, "/")
+
+	cos.mu.Lock()
+	defer cos.mu.Unlock()
+	b, ok := cos.buckets[bucket]
+	if !ok && !(r.Method == http.MethodPut && key == "") {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+		return
+	}
+	if key != "" {
+		cos.serveObject(w, r, b, key)
+		return
+	}
+
+	query := r.URL.Query()
+	for sub, missing := range mockBucketSubresources {
+		if _, ok := query[sub]; !ok {
+			continue
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			b.Config[sub] = body
+			w.WriteHeader(http.StatusOK)
+		case http.MethodDelete:
+			delete(b.Config, sub)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			if body, ok := b.Config[sub]; ok {
+				w.Header().Set("Content-Type", "application/xml")
+				w.Write(body)
+			} else if missing != "" {
+				code, message, _ := strings.Cut(missing, ": ")
+				writeS3Error(w, http.StatusNotFound, code, message)
+			} else {
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(http.StatusOK)
+			}
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if ok {
+			writeS3Error(w, http.StatusConflict, "BucketAlreadyExists", "The requested bucket name is not available.")
+			return
+		}
+		var config struct {
+			LocationConstraint string
+		}
+		body, _ := io.ReadAll(r.Body)
+		xml.Unmarshal(body, &config)
+		cos.buckets[bucket] = &MockBucket{
+			Name:               bucket,
+			LocationConstraint: config.LocationConstraint,
+			Created:            time.Now().UTC(),
+			Config:             map[string][]byte{},
+			Objects:            map[string][]byte{},
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		if len(b.Objects) > 0 {
+			writeS3Error(w, http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty.")
+			return
+		}
+		delete(cos.buckets, bucket)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		cos.listObjects(w, r, b)
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	}
+}
+
+func (cos *MockObjectStorage) serveObject(w http.ResponseWriter, r *http.Request, b *MockBucket, key string) {
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		b.Objects[key] = body
+		w.Header().Set("ETag", mockETag(body))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		body, ok := b.Objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.Header().Set("ETag", mockETag(body))
+		w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+		w.Header().Set("Last-Modified", b.Created.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(body)
+		}
+	case http.MethodDelete:
+		delete(b.Objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	}
+}
+
+func (cos *MockObjectStorage) listBuckets(w http.ResponseWriter, r *http.Request) {
+	type bucket struct {
+		Name               string
+		CreationDate       string
+		LocationConstraint string `xml:",omitempty"`
+	}
+	result := struct {
+		XMLName xml.Name `xml:"ListAllMyBucketsResult"`
+		Owner   struct {
+			ID          string
+			DisplayName string
+		}
+		IsTruncated bool
+		Buckets     []bucket `xml:"Buckets>Bucket"`
+	}{}
+	result.Owner.ID = MockAccountID
+	result.Owner.DisplayName = MockAccountID
+
+	cos.mu.Lock()
+	for _, b := range cos.buckets {
+		result.Buckets = append(result.Buckets, bucket{
+			Name:               b.Name,
+			CreationDate:       b.Created.Format(time.RFC3339),
+			LocationConstraint: b.LocationConstraint,
+		})
+	}
+	cos.mu.Unlock()
+	sort.Slice(result.Buckets, func(i, j int) bool { return result.Buckets[i].Name < result.Buckets[j].Name })
+	writeXML(w, http.StatusOK, result)
+}
+
+func (cos *MockObjectStorage) listObjects(w http.ResponseWriter, r *http.Request, b *MockBucket) {
+	type object struct {
+		Key          string
+		LastModified string
+		ETag         string
+		Size         int
+		StorageClass string
+	}
+	prefix := r.URL.Query().Get("prefix")
+	result := struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		Name        string
+		Prefix      string
+		KeyCount    int
+		MaxKeys     int
+		IsTruncated bool
+		Contents    []object
+	}{Name: b.Name, Prefix: prefix, MaxKeys: 1000}
+
+	keys := make([]string, 0, len(b.Objects))
+	for key := range b.Objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		result.Contents = append(result.Contents, object{
+			Key:          key,
+			LastModified: b.Created.Format(time.RFC3339),
+			ETag:         mockETag(b.Objects[key]),
+			Size:         len(b.Objects[key]),
+			StorageClass: "STANDARD",
+		})
+	}
+	result.KeyCount = len(result.Contents)
+	writeXML(w, http.StatusOK, result)
+}
+
+func mockETag(body []byte) string {
+	sum := md5.Sum(body)
+	return fmt.Sprintf("%q", hex.EncodeToString(sum[:]))
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code, message string) {
+	writeXML(w, status, struct {
+		XMLName   xml.Name `xml:"Error"`
+		Code      string
+		Message   string
+		RequestId string
+	}{Code: code, Message: message, RequestId: "mock-request"})
+}
+
+func writeXML(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.Write([]byte(xml.Header))
+	xml.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,374 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package unittest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/provider"
+	"github.com/golang-jwt/jwt"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+//
+// Offline stand-in for the IBM Cloud APIs used by the resource/data source unit tests.
+//
+
+const (
+	MockAccountID = "0123456789abcdef0123456789abcdef"
+	MockUserID    = "IBMid-0000000MCK"
+	MockUserEmail = "mock.user@example.com"
+	MockRegion    = "us-south"
+	MockAPIKey    = "mock-api-key" // pragma: allowlist secret
+)
+
+// mockServiceEndpoints maps every endpoint override honoured by conns.Config and
+// bluemix-go to the base path the corresponding SDK client expects.
+var mockServiceEndpoints = map[string]string{
+	"IBMCLOUD_ACCOUNT_MANAGEMENT_API_ENDPOINT":     "",
+	"IBMCLOUD_API_GATEWAY_ENDPOINT":                "/controller",
+	"IBMCLOUD_APP_CONFIG_ENDPOINT":                 "",
+	"IBMCLOUD_APPID_MANAGEMENT_API_ENDPOINT":       "",
+	"IBMCLOUD_ATRACKER_API_ENDPOINT":               "",
+	"IBMCLOUD_CATALOG_MANAGEMENT_API_ENDPOINT":     "/api/v1-beta",
+	"IBMCLOUD_CERTIFICATE_MANAGER_API_ENDPOINT":    "",
+	"IBMCLOUD_CF_API_ENDPOINT":                     "",
+	"IBMCLOUD_CIS_API_ENDPOINT":                    "",
+	"IBMCLOUD_CLOUD_SHELL_API_ENDPOINT":            "",
+	"IBMCLOUD_CODE_ENGINE_API_ENDPOINT":            "/v2",
+	"IBMCLOUD_CONTEXT_BASED_RESTRICTIONS_ENDPOINT": "",
+	"IBMCLOUD_COS_CONFIG_ENDPOINT":                 "/v1",
+	"IBMCLOUD_COS_ENDPOINT":                        "",
+	"IBMCLOUD_CR_API_ENDPOINT":                     "",
+	"IBMCLOUD_CS_API_ENDPOINT":                     "",
+	"IBMCLOUD_CSE_ENDPOINT":                        "",
+	"IBMCLOUD_DATABASES_API_ENDPOINT":              "/v5/ibm",
+	"IBMCLOUD_DL_API_ENDPOINT":                     "/v1",
+	"IBMCLOUD_DL_PROVIDER_API_ENDPOINT":            "/provider/v2",
+	"IBMCLOUD_ENTERPRISE_API_ENDPOINT":             "",
+	"IBMCLOUD_EVENT_NOTIFICATIONS_API_ENDPOINT":    "/event-notifications",
+	"IBMCLOUD_FUNCTIONS_API_ENDPOINT":              "",
+	"IBMCLOUD_GS_API_ENDPOINT":                     "",
+	"IBMCLOUD_GT_API_ENDPOINT":                     "",
+	"IBMCLOUD_HPCS_API_ENDPOINT":                   "",
+	"IBMCLOUD_IAM_API_ENDPOINT":                    "",
+	"IBMCLOUD_IAMPAP_API_ENDPOINT":                 "",
+	"IBMCLOUD_ICD_API_ENDPOINT":                    "",
+	"IBMCLOUD_IS_NG_API_ENDPOINT":                  "/v1",
+	"IBMCLOUD_KP_API_ENDPOINT":                     "",
+	"IBMCLOUD_LOGS_API_ENDPOINT":                   "",
+	"IBMCLOUD_MCCP_API_ENDPOINT":                   "",
+	"IBMCLOUD_METRICS_ROUTING_API_ENDPOINT":        "/api/v3",
+	"IBMCLOUD_MQCLOUD_CONFIG_ENDPOINT":             "",
+	"IBMCLOUD_PARTNER_CENTER_SELL_API_ENDPOINT":    "/openapi/v1",
+	"IBMCLOUD_PI_API_ENDPOINT":                     "",
+	"IBMCLOUD_PRIVATE_DNS_API_ENDPOINT":            "/v1",
+	"IBMCLOUD_PROJECT_API_ENDPOINT":                "",
+	"IBMCLOUD_PUSH_API_ENDPOINT":                   "/imfpush/v1",
+	"IBMCLOUD_RESOURCE_CATALOG_API_ENDPOINT":       "",
+	"IBMCLOUD_RESOURCE_CONTROLLER_API_ENDPOINT":    "",
+	"IBMCLOUD_RESOURCE_MANAGEMENT_API_ENDPOINT":    "",
+	"IBMCLOUD_SAT_API_ENDPOINT":                    "",
+	"IBMCLOUD_SATELLITE_API_ENDPOINT":              "",
+	"IBMCLOUD_SATELLITE_LINK_API_ENDPOINT":         "",
+	"IBMCLOUD_SCC_API_ENDPOINT":                    "",
+	"IBMCLOUD_SCHEMATICS_API_ENDPOINT":             "",
+	"IBMCLOUD_TEKTON_PIPELINE_ENDPOINT":            "/pipeline/v2",
+	"IBMCLOUD_TG_API_ENDPOINT":                     "/v1",
+	"IBMCLOUD_TOOLCHAIN_ENDPOINT":                  "/toolchain/v2",
+	"IBMCLOUD_UAA_ENDPOINT":                        "",
+	"IBMCLOUD_USAGE_REPORTS_API_ENDPOINT":          "",
+	"IBMCLOUD_USER_MANAGEMENT_ENDPOINT":            "",
+}
+
+// MockHandlerFunc serves a request matched by a mock route. params holds the values
+// captured by the `{name}` segments of the route pattern.
+type MockHandlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+// MockRequest is a request received by the mock server.
+type MockRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+type mockRoute struct {
+	method   string
+	segments []string
+	handler  MockHandlerFunc
+}
+
+// MockServer is a local HTTP stand-in for the IBM Cloud APIs. Every SDK client built by
+// conns.Config can be pointed at it, and it serves a fake IAM token endpoint so that
+// provider configuration succeeds without an account.
+type MockServer struct {
+	*httptest.Server
+
+	t        testing.TB
+	mu       sync.Mutex
+	routes   []mockRoute
+	requests []MockRequest
+	cosURL   string
+}
+
+// NewMockServer starts a mock server that is closed when the test finishes.
+func NewMockServer(t testing.TB) *MockServer {
+	m := &MockServer{t: t}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	t.Cleanup(m.Server.Close)
+
+	m.Handle(http.MethodPost, "/identity/token", m.serveIAMToken)
+	m.Handle(http.MethodPost, "/oauth/token", m.serveIAMToken)
+	return m
+}
+
+// Handle registers a handler for method and pattern. Patterns are matched segment by
+// segment against the request path; `{name}` matches any single segment and `*` as the
+// last segment matches the remainder of the path. Routes registered later take precedence.
+func (m *MockServer) Handle(method, pattern string, handler MockHandlerFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes = append(m.routes, mockRoute{
+		method:   method,
+		segments: splitPath(pattern),
+		handler:  handler,
+	})
+}
+
+// HandleJSON registers a route that always responds with the given status and JSON body.
+func (m *MockServer) HandleJSON(method, pattern string, status int, body interface{}) {
+	m.Handle(method, pattern, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		WriteJSON(w, status, body)
+	})
+}
+
+// Requests returns the requests received so far, in order.
+func (m *MockServer) Requests() []MockRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockRequest(nil), m.requests...)
+}
+
+// RequestCount returns how many received requests matched method and pattern.
+func (m *MockServer) RequestCount(method, pattern string) int {
+	segments := splitPath(pattern)
+	count := 0
+	for _, req := range m.Requests() {
+		if req.Method == method && matchSegments(segments, splitPath(req.Path)) != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Env returns the environment that points the provider at the mock server.
+func (m *MockServer) Env() map[string]string {
+	env := map[string]string{
+		"IC_API_KEY":  MockAPIKey,
+		"IC_REGION":   MockRegion,
+		"MAX_RETRIES": "0",
+	}
+	for k, path := range mockServiceEndpoints {
+		env[k] = m.URL + path
+	}
+	m.mu.Lock()
+	if m.cosURL != "" {
+		env["IBMCLOUD_COS_ENDPOINT"] = m.cosURL
+	}
+	m.mu.Unlock()
+	return env
+}
+
+// SetEnv applies Env for the duration of the test.
+func (m *MockServer) SetEnv(t testing.TB) {
+	for k, v := range m.Env() {
+		t.Setenv(k, v)
+	}
+	// Endpoint files and schematics tags would bypass or alter the mocked requests.
+	for _, k := range []string{"IC_ENDPOINTS_FILE_PATH", "IBMCLOUD_ENDPOINTS_FILE_PATH", "IC_ENV_TAGS", "IC_IAM_TOKEN", "IBMCLOUD_IAM_TOKEN"} {
+		t.Setenv(k, "")
+	}
+}
+
+// ClientSession builds a conns.ClientSession whose clients all talk to the mock server.
+func (m *MockServer) ClientSession(t testing.TB) conns.ClientSession {
+	m.SetEnv(t)
+	config := conns.Config{
+		BluemixAPIKey:    MockAPIKey,
+		Region:           MockRegion,
+		BluemixTimeout:   30 * time.Second,
+		SoftLayerTimeout: 30 * time.Second,
+		RetryCount:       0,
+		RetryDelay:       time.Millisecond,
+		Visibility:       "public",
+	}
+	sess, err := config.ClientSession()
+	if err != nil {
+		t.Fatalf("Error configuring the mock client session: %s", err)
+	}
+	return sess.(conns.ClientSession)
+}
+
+// ProviderFactories returns protocol version 5 provider factories for resource.TestCase,
+// serving the muxed SDKv2 and framework provider.
+func (m *MockServer) ProviderFactories() map[string]func() (tfprotov5.ProviderServer, error) {
+	return map[string]func() (tfprotov5.ProviderServer, error){
+		"ibm": func() (tfprotov5.ProviderServer, error) {
+			serverFactory, err := provider.ProviderServer(context.Background())
+			if err != nil {
+				return nil, err
+			}
+			return serverFactory(), nil
+		},
+	}
+}
+
+// UnitTest runs the test steps with resource.UnitTest against the mock server. Like
+// acceptance tests they drive the Terraform CLI, so they only run when TF_MOCK is set.
+func (m *MockServer) UnitTest(t *testing.T, steps ...resource.TestStep) {
+	if os.Getenv("TF_MOCK") == "" {
+		t.Skip("Mock server tests skipped unless env 'TF_MOCK' set")
+	}
+	m.SetEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: m.ProviderFactories(),
+		Steps:                    steps,
+	})
+}
+
+// WriteJSON writes body as a JSON response with the given status.
+func WriteJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body != nil {
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+// WriteError writes an error response in the format used by the IBM Cloud platform APIs.
+func WriteError(w http.ResponseWriter, status int, code, message string) {
+	WriteJSON(w, status, map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{"code": code, "message": message},
+		},
+		"trace":       "mock-trace",
+		"status_code": status,
+	})
+}
+
+// ReadJSON decodes the request body into a generic map.
+func ReadJSON(r *http.Request) map[string]interface{} {
+	body := map[string]interface{}{}
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+	return body
+}
+
+func (m *MockServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+	m.mu.Lock()
+	m.requests = append(m.requests, MockRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Body:   body,
+	})
+	routes := append([]mockRoute(nil), m.routes...)
+	m.mu.Unlock()
+
+	path := splitPath(r.URL.Path)
+	for i := len(routes) - 1; i >= 0; i-- {
+		route := routes[i]
+		if route.method != r.Method {
+			continue
+		}
+		if params := matchSegments(route.segments, path); params != nil {
+			route.handler(w, r, params)
+			return
+		}
+	}
+
+	m.t.Logf("[WARN] Mock server has no route for %s %s", r.Method, r.URL.Path)
+	WriteError(w, http.StatusNotFound, "not_found", fmt.Sprintf("No mock route for %s %s", r.Method, r.URL.Path))
+}
+
+func (m *MockServer) serveIAMToken(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	now := time.Now()
+	token, err := MockAccessToken(now.Add(time.Hour))
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "token_error", err.Error())
+		return
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  token,
+		"refresh_token": "mock-refresh-token",
+		"token_type":    "Bearer",
+		"expires_in":    3600,
+		"expiration":    now.Add(time.Hour).Unix(),
+		"scope":         "ibm openid",
+	})
+}
+
+// MockAccessToken returns a signed IAM style access token for the mock account.
+func MockAccessToken(expiration time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"iam_id":     MockUserID,
+		"id":         MockUserID,
+		"sub":        MockUserEmail,
+		"email":      MockUserEmail,
+		"iss":        "https://iam.cloud.ibm.com/identity",
+		"grant_type": "urn:ibm:params:oauth:grant-type:apikey",
+		"account": map[string]interface{}{
+			"bss":   MockAccountID,
+			"valid": true,
+		},
+		"iat": time.Now().Unix(),
+		"exp": expiration.Unix(),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("mock-signing-key"))
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func matchSegments(pattern, path []string) map[string]string {
+	params := map[string]string{}
+	for i, segment := range pattern {
+		if segment == "*" && i == len(pattern)-1 {
+			params["*"] = strings.Join(path[i:], "/")
+			return params
+		}
+		if i >= len(path) {
+			return nil
+		}
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[segment[1:len(segment)-1]] = path[i]
+			continue
+		}
+		if segment != path[i] {
+			return nil
+		}
+	}
+	if len(pattern) != len(path) {
+		return nil
+	}
+	return params
+}
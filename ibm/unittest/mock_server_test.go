@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package unittest_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/IBM-Cloud/terraform-provider-ibm/ibm/unittest"
+)
+
+func TestMockServerRoutes(t *testing.T) {
+	server := NewMockServer(t)
+	server.Handle(http.MethodGet, "/v1/things/{id}", func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		WriteJSON(w, http.StatusOK, map[string]interface{}{"id": params["id"]})
+	})
+	server.HandleJSON(http.MethodGet, "/v1/files/*", http.StatusOK, map[string]interface{}{})
+
+	resp, err := http.Get(server.URL + "/v1/things/abc?version=2024-01-01")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/v1/files/a/b/c")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/v1/things")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	assert.Equal(t, 1, server.RequestCount(http.MethodGet, "/v1/things/{id}"))
+	assert.Equal(t, "version=2024-01-01", server.Requests()[0].Query)
+}
+
+func TestMockServerClientSession(t *testing.T) {
+	server := NewMockServer(t)
+	vpcs := server.Collection("/v1/vpcs", "vpcs")
+	vpcs.OnCreate = func(item map[string]interface{}) {
+		item["status"] = "available"
+	}
+	sess := server.ClientSession(t)
+
+	userDetails, err := sess.BluemixUserDetails()
+	assert.Nil(t, err)
+	assert.Equal(t, MockAccountID, userDetails.UserAccount)
+	assert.Equal(t, MockUserID, userDetails.UserID)
+
+	vpcClient, err := sess.VpcV1API()
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(vpcClient.GetServiceURL(), server.URL))
+
+	vpc, _, err := vpcClient.CreateVPC(&vpcv1.CreateVPCOptions{Name: PtrToString("mock-vpc")})
+	assert.Nil(t, err)
+	assert.Equal(t, "mock-vpc", *vpc.Name)
+	assert.Equal(t, 1, vpcs.Len())
+
+	vpc, _, err = vpcClient.GetVPC(&vpcv1.GetVPCOptions{ID: vpc.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, "available", *vpc.Status)
+
+	_, response, err := vpcClient.GetVPC(&vpcv1.GetVPCOptions{ID: PtrToString("missing")})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	assert.True(t, server.RequestCount(http.MethodPost, "/identity/token") > 0)
+}
+
+func PtrToString(s string) *string {
+	return &s
+}
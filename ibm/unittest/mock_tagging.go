@@ -0,0 +1,122 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package unittest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MockTags emulates the Global Tagging and Global Search APIs that back the tags and
+// access_tags attributes of the taggable resources.
+type MockTags struct {
+	mu   sync.Mutex
+	tags map[string]map[string]map[string]bool
+}
+
+// GlobalTagging registers the tag attach/detach/list and resource search endpoints.
+func (m *MockServer) GlobalTagging() *MockTags {
+	tags := &MockTags{tags: map[string]map[string]map[string]bool{}}
+	m.Handle(http.MethodPost, "/v3/tags/attach", tags.attach)
+	m.Handle(http.MethodPost, "/v3/tags/detach", tags.detach)
+	m.Handle(http.MethodGet, "/v3/tags", tags.list)
+	m.Handle(http.MethodPost, "/v3/resources/search", tags.search)
+	return tags
+}
+
+// Get returns the sorted tags of tagType ("user", "access" or "service") attached to crn.
+func (mt *MockTags) Get(crn, tagType string) []string {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+	names := []string{}
+	for name := range mt.tags[crn][normalizeTagType(tagType)] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (mt *MockTags) attach(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	mt.apply(w, r, true)
+}
+
+func (mt *MockTags) detach(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	mt.apply(w, r, false)
+}
+
+func (mt *MockTags) apply(w http.ResponseWriter, r *http.Request, attach bool) {
+	body := ReadJSON(r)
+	tagType := normalizeTagType(r.URL.Query().Get("tag_type"))
+	names := []string{}
+	if v, ok := body["tag_names"].([]interface{}); ok {
+		for _, name := range v {
+			names = append(names, name.(string))
+		}
+	}
+	if v, ok := body["tag_name"].(string); ok {
+		names = append(names, v)
+	}
+
+	results := []map[string]interface{}{}
+	resources, _ := body["resources"].([]interface{})
+	mt.mu.Lock()
+	for _, res := range resources {
+		crn, _ := res.(map[string]interface{})["resource_id"].(string)
+		if mt.tags[crn] == nil {
+			mt.tags[crn] = map[string]map[string]bool{}
+		}
+		if mt.tags[crn][tagType] == nil {
+			mt.tags[crn][tagType] = map[string]bool{}
+		}
+		for _, name := range names {
+			if attach {
+				mt.tags[crn][tagType][name] = true
+			} else {
+				delete(mt.tags[crn][tagType], name)
+			}
+		}
+		results = append(results, map[string]interface{}{"resource_id": crn, "is_error": false})
+	}
+	mt.mu.Unlock()
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+}
+
+func (mt *MockTags) list(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	items := []map[string]interface{}{}
+	for _, name := range mt.Get(r.URL.Query().Get("attached_to"), r.URL.Query().Get("tag_type")) {
+		items = append(items, map[string]interface{}{"name": name})
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"total_count": len(items),
+		"offset":      0,
+		"limit":       len(items),
+		"items":       items,
+	})
+}
+
+func (mt *MockTags) search(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	query, _ := ReadJSON(r)["query"].(string)
+	crn := strings.Trim(strings.TrimPrefix(query, "crn:"), "\"")
+	items := []map[string]interface{}{
+		{
+			"crn":          crn,
+			"tags":         mt.Get(crn, "user"),
+			"access_tags":  mt.Get(crn, "access"),
+			"service_tags": mt.Get(crn, "service"),
+		},
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items": items,
+		"limit": 10,
+	})
+}
+
+func normalizeTagType(tagType string) string {
+	if tagType == "" {
+		return "user"
+	}
+	return tagType
+}
@@ -450,25 +450,41 @@ func validateCIDRAddress() schema.SchemaValidateFunc {
 // validateOverlappingAddress...
 func validateOverlappingAddress() schema.SchemaValidateFunc {
 	return func(v interface{}, k string) (ws []string, errors []error) {
-		nonOverlappingCIDR := map[string]bool{
-			"127.0.0.0/8":    true,
-			"161.26.0.0/16":  true,
-			"166.8.0.0/14":   true,
-			"169.254.0.0/16": true,
-			"224.0.0.0/4":    true,
+		reservedCIDRs := []string{
+			"127.0.0.0/8",
+			"161.26.0.0/16",
+			"166.8.0.0/14",
+			"169.254.0.0/16",
+			"224.0.0.0/4",
 		}
 
 		address := v.(string)
-		_, found := nonOverlappingCIDR[address]
-		if found {
-			errors = append(errors, fmt.Errorf(
-				"%q the request is overlapping with reserved address ranges",
-				k))
+		for _, reserved := range reservedCIDRs {
+			// an address that isn't a CIDR block is left to the other validations
+			if overlaps, err := CIDRsOverlap(address, reserved); err == nil && overlaps {
+				errors = append(errors, fmt.Errorf(
+					"%q the request is overlapping with reserved address ranges",
+					k))
+				break
+			}
 		}
 		return
 	}
 }
 
+// CIDRsOverlap reports whether the two CIDR blocks share at least one address.
+func CIDRsOverlap(first, second string) (bool, error) {
+	_, firstNet, err := net.ParseCIDR(first)
+	if err != nil {
+		return false, err
+	}
+	_, secondNet, err := net.ParseCIDR(second)
+	if err != nil {
+		return false, err
+	}
+	return firstNet.Contains(secondNet.IP) || secondNet.Contains(firstNet.IP), nil
+}
+
 // ValidateRemoteIP...
 func ValidateRemoteIP(v interface{}, k string) (ws []string, errors []error) {
 	_, err1 := ValidateCIDR(v, k)
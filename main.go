@@ -1,16 +1,33 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/provider"
 	"github.com/IBM-Cloud/terraform-provider-ibm/version"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 func main() {
+	var debug bool
+	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.Parse()
+
 	log.Println("IBM Cloud Provider version", version.Version, version.VersionPrerelease, version.GitCommit)
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: provider.Provider,
-	})
+
+	serverFactory, err := provider.ProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf5server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+	err = tf5server.Serve("registry.terraform.io/IBM-Cloud/ibm", serverFactory, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }